@@ -0,0 +1,553 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	"com.biesnecker/tts-daemon/internal/client"
+	"com.biesnecker/tts-daemon/internal/config"
+	"com.biesnecker/tts-daemon/internal/player"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// requestTimeout bounds each RPC made by the inspector
+	requestTimeout = 30 * time.Second
+
+	// loadAheadRows is how close to the end of the loaded entries the cursor gets before the
+	// next page is requested
+	loadAheadRows = 10
+
+	// detailHeight is the number of lines of the detail pane, including its border
+	detailHeight = 10
+)
+
+var (
+	titleStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	detailStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("8")).Padding(0, 1)
+	labelStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	helpStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+)
+
+// inputMode is what the keyboard is currently used for
+type inputMode int
+
+const (
+	modeBrowse        inputMode = iota
+	modeSearch                  // Typing a search term
+	modeLanguage                // Typing a language code to filter by
+	modeConfirmDelete           // Waiting for y/n before deleting the selected entry
+)
+
+// Messages delivered to Update by the commands below
+type (
+	pageMsg struct {
+		entries   []*pb.CacheEntryInfo
+		nextToken string
+		reset     bool // The page starts a new listing (e.g. after the language filter changed)
+	}
+	deletedMsg  struct{ cacheKey string }
+	statusMsg   string
+	errMsg      struct{ err error }
+	playDoneMsg struct{}
+)
+
+// model is the inspector's state
+type model struct {
+	client    pb.TTSServiceClient
+	player    *player.Player
+	pageSize  int32
+	exportDir string
+
+	entries   []*pb.CacheEntryInfo // Loaded so far, in cache key order
+	visible   []*pb.CacheEntryInfo // The loaded entries matching the search term
+	nextToken string
+	loading   bool
+	language  string // Language filter ("" = all)
+	search    string
+
+	mode   inputMode
+	input  textinput.Model
+	table  table.Model
+	width  int
+	status string
+}
+
+func main() {
+	address := flag.String("address", "localhost:50051", "Address of the daemon")
+	pageSize := flag.Int("page-size", 200, "Entries requested per ListCacheEntries call")
+	language := flag.String("lang", "", "Only show entries for this language code")
+	exportDir := flag.String("export-dir", ".", "Directory exported audio is written to")
+	configPath := flag.String("config", "", "Config file to read audio settings from (default: ~/.config/tts-daemon/config.yaml)")
+	flag.Parse()
+
+	pool, err := client.NewClientPool([]string{*address}, client.PolicyPickFirst, nil)
+	if err != nil {
+		log.Fatalf("Failed to connect to daemon at %s: %v", *address, err)
+	}
+	defer pool.Close()
+
+	audioPlayer := player.NewPlayer(loadAudioConfig(*configPath))
+	defer audioPlayer.Close()
+
+	m := newModel(pool.Client(), audioPlayer, int32(*pageSize), *exportDir, *language)
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		log.Fatalf("Inspector failed: %v", err)
+	}
+}
+
+// loadAudioConfig reads the audio settings used for playback, falling back to the defaults
+func loadAudioConfig(path string) config.AudioConfig {
+	explicit := path != ""
+	if !explicit {
+		defaultPath, err := config.GetDefaultConfigPath()
+		if err != nil {
+			return config.Defaults().Audio
+		}
+		path = defaultPath
+	}
+
+	cfg, err := config.LoadAudio(path)
+	if err != nil {
+		if explicit || !errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Warning: using default audio settings: %v\n", err)
+		}
+		return config.Defaults().Audio
+	}
+	return cfg
+}
+
+func newModel(c pb.TTSServiceClient, p *player.Player, pageSize int32, exportDir, language string) model {
+	t := table.New(
+		table.WithColumns(columns(80)),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+	styles := table.DefaultStyles()
+	styles.Header = styles.Header.BorderStyle(lipgloss.NormalBorder()).BorderBottom(true).Bold(true)
+	styles.Selected = styles.Selected.Foreground(lipgloss.Color("0")).Background(lipgloss.Color("12"))
+	t.SetStyles(styles)
+
+	input := textinput.New()
+	input.CharLimit = 200
+
+	return model{
+		client:    c,
+		player:    p,
+		pageSize:  pageSize,
+		exportDir: exportDir,
+		language:  language,
+		input:     input,
+		table:     t,
+		loading:   true,
+	}
+}
+
+// columns returns the table's columns, giving the text snippet whatever width is left over
+func columns(width int) []table.Column {
+	fixed := []table.Column{
+		{Title: "Cache key", Width: 14},
+		{Title: "Language", Width: 9},
+		{Title: "Size", Width: 9},
+		{Title: "Last accessed", Width: 16},
+	}
+	used := 0
+	for _, c := range fixed {
+		used += c.Width + 2 // Cell padding
+	}
+	text := table.Column{Title: "Text", Width: max(20, width-used-2)}
+	return []table.Column{fixed[0], text, fixed[1], fixed[2], fixed[3]}
+}
+
+func (m model) Init() tea.Cmd {
+	return m.loadPage("", true)
+}
+
+// loadPage requests the page of entries starting after token
+func (m model) loadPage(token string, reset bool) tea.Cmd {
+	c, language, pageSize := m.client, m.language, m.pageSize
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		resp, err := c.ListCacheEntries(ctx, &pb.ListCacheEntriesRequest{
+			LanguageCode: language,
+			PageSize:     pageSize,
+			PageToken:    token,
+		})
+		if err != nil {
+			return errMsg{fmt.Errorf("ListCacheEntries failed: %w", err)}
+		}
+		return pageMsg{entries: resp.Entries, nextToken: resp.NextPageToken, reset: reset}
+	}
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.table.SetColumns(columns(msg.Width))
+		m.table.SetHeight(max(3, msg.Height-detailHeight-3)) // Title, status and help lines
+		return m, nil
+
+	case pageMsg:
+		m.loading = false
+		if msg.reset {
+			m.entries = nil
+			m.table.SetCursor(0)
+		}
+		m.entries = append(m.entries, msg.entries...)
+		m.nextToken = msg.nextToken
+		m.applySearch()
+		more := m.loadMoreIfNeeded()
+		return m, more
+
+	case deletedMsg:
+		for i, e := range m.entries {
+			if e.CacheKey == msg.cacheKey {
+				m.entries = append(m.entries[:i], m.entries[i+1:]...)
+				break
+			}
+		}
+		m.applySearch()
+		m.status = "Deleted " + msg.cacheKey
+		return m, nil
+
+	case statusMsg:
+		m.status = string(msg)
+		return m, nil
+
+	case errMsg:
+		m.loading = false
+		m.status = "Error: " + msg.err.Error()
+		return m, nil
+
+	case playDoneMsg:
+		m.status = "Playback finished"
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.mode {
+		case modeSearch, modeLanguage:
+			return m.updateInput(msg)
+		case modeConfirmDelete:
+			return m.updateConfirmDelete(msg)
+		}
+		return m.updateBrowse(msg)
+	}
+	return m, nil
+}
+
+// updateBrowse handles keys while moving through the table
+func (m model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "/":
+		return m.startInput(modeSearch, "Search: ", m.search), nil
+	case "L":
+		return m.startInput(modeLanguage, "Language (empty for all): ", m.language), nil
+	case "esc":
+		m.search = ""
+		m.applySearch()
+		return m, nil
+	}
+
+	entry := m.selected()
+	if entry != nil {
+		switch msg.String() {
+		case "D":
+			m.mode = modeConfirmDelete
+			m.status = fmt.Sprintf("Delete %s? (y/n)", entry.CacheKey)
+			return m, nil
+		case "P":
+			m.status = "Playing " + entry.CacheKey + "..."
+			return m, m.play(entry.CacheKey)
+		case "E":
+			return m, m.export(entry.CacheKey)
+		}
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	more := m.loadMoreIfNeeded()
+	return m, tea.Batch(cmd, more)
+}
+
+// startInput switches to typing a search term or language code
+func (m model) startInput(mode inputMode, prompt, value string) model {
+	m.mode = mode
+	m.input.Prompt = prompt
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	m.input.Focus()
+	m.table.Blur()
+	return m
+}
+
+// updateInput handles keys while typing a search term or language code. The search is applied
+// as it is typed; the language filter, which needs a new listing, when it is submitted.
+func (m model) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "esc":
+		mode := m.mode
+		m.mode = modeBrowse
+		m.input.Blur()
+		m.table.Focus()
+		if msg.String() == "esc" {
+			if mode == modeSearch {
+				m.search = ""
+				m.applySearch()
+			}
+			return m, nil
+		}
+		if mode == modeLanguage {
+			m.language = strings.TrimSpace(m.input.Value())
+			m.loading = true
+			return m, m.loadPage("", true)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.mode == modeSearch {
+		m.search = m.input.Value()
+		m.applySearch()
+	}
+	more := m.loadMoreIfNeeded()
+	return m, tea.Batch(cmd, more)
+}
+
+// updateConfirmDelete handles the answer to the delete prompt
+func (m model) updateConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.mode = modeBrowse
+	entry := m.selected()
+	if entry == nil || (msg.String() != "y" && msg.String() != "Y") {
+		m.status = "Delete cancelled"
+		return m, nil
+	}
+
+	c, key := m.client, entry.CacheKey
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		resp, err := c.DeleteCacheEntry(ctx, &pb.GetCacheEntryRequest{CacheKey: key})
+		if err != nil {
+			return errMsg{fmt.Errorf("DeleteCacheEntry failed: %w", err)}
+		}
+		if !resp.Success {
+			return errMsg{errors.New(resp.Message)}
+		}
+		return deletedMsg{cacheKey: key}
+	}
+}
+
+// fetchAudio returns the audio stored under cacheKey
+func fetchAudio(c pb.TTSServiceClient, cacheKey string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	resp, err := c.GetCacheEntry(ctx, &pb.GetCacheEntryRequest{CacheKey: cacheKey})
+	if err != nil {
+		return nil, fmt.Errorf("GetCacheEntry failed: %w", err)
+	}
+	if !resp.Found {
+		return nil, fmt.Errorf("%s is no longer cached", cacheKey)
+	}
+	return resp.AudioData, nil
+}
+
+// play plays the audio stored under cacheKey
+func (m model) play(cacheKey string) tea.Cmd {
+	c, p := m.client, m.player
+	return func() tea.Msg {
+		audioData, err := fetchAudio(c, cacheKey)
+		if err != nil {
+			return errMsg{err}
+		}
+		if err := p.Play(audioData); err != nil {
+			return errMsg{fmt.Errorf("playback failed: %w", err)}
+		}
+		return playDoneMsg{}
+	}
+}
+
+// export writes the audio stored under cacheKey to <cache key>.<format> in the export directory
+func (m model) export(cacheKey string) tea.Cmd {
+	c, dir := m.client, m.exportDir
+	return func() tea.Msg {
+		audioData, err := fetchAudio(c, cacheKey)
+		if err != nil {
+			return errMsg{err}
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return errMsg{fmt.Errorf("failed to create export directory: %w", err)}
+		}
+		path := filepath.Join(dir, cacheKey+audioExtension(audioData))
+		if err := os.WriteFile(path, audioData, 0644); err != nil {
+			return errMsg{fmt.Errorf("failed to write %s: %w", path, err)}
+		}
+		return statusMsg(fmt.Sprintf("Exported %s (%d bytes)", path, len(audioData)))
+	}
+}
+
+// audioExtension returns the file extension for the format of audioData
+func audioExtension(audioData []byte) string {
+	switch {
+	case bytes.HasPrefix(audioData, []byte("RIFF")):
+		return ".wav"
+	case bytes.HasPrefix(audioData, []byte("OggS")):
+		return ".ogg"
+	case bytes.HasPrefix(audioData, []byte("ID3")), len(audioData) > 1 && audioData[0] == 0xFF && audioData[1]&0xE0 == 0xE0:
+		return ".mp3"
+	default:
+		return ".opus" // Raw Opus frames
+	}
+}
+
+// loadMoreIfNeeded requests the next page when the cursor nears the end of the visible entries
+func (m *model) loadMoreIfNeeded() tea.Cmd {
+	if m.loading || m.nextToken == "" || m.table.Cursor() < len(m.visible)-loadAheadRows {
+		return nil
+	}
+	m.loading = true
+	return m.loadPage(m.nextToken, false)
+}
+
+// applySearch shows the loaded entries whose text or cache key contains the search term
+func (m *model) applySearch() {
+	term := strings.ToLower(m.search)
+	m.visible = nil
+	for _, e := range m.entries {
+		if term == "" || strings.Contains(strings.ToLower(e.Text), term) || strings.HasPrefix(e.CacheKey, term) {
+			m.visible = append(m.visible, e)
+		}
+	}
+
+	rows := make([]table.Row, len(m.visible))
+	for i, e := range m.visible {
+		rows[i] = table.Row{
+			e.CacheKey[:min(12, len(e.CacheKey))],
+			snippet(e.Text),
+			e.LanguageCode,
+			formatSize(e.AudioSize),
+			formatTime(e.LastAccessed),
+		}
+	}
+	m.table.SetRows(rows)
+	// The table leaves the cursor at -1 while it's empty
+	if cursor := m.table.Cursor(); cursor < 0 {
+		m.table.SetCursor(0)
+	} else if cursor >= len(rows) {
+		m.table.SetCursor(len(rows) - 1)
+	}
+}
+
+// selected returns the entry under the cursor, or nil if there are none
+func (m model) selected() *pb.CacheEntryInfo {
+	i := m.table.Cursor()
+	if i < 0 || i >= len(m.visible) {
+		return nil
+	}
+	return m.visible[i]
+}
+
+func (m model) View() string {
+	var b strings.Builder
+
+	title := fmt.Sprintf("TTS cache inspector — %d entries loaded", len(m.entries))
+	if m.nextToken != "" {
+		title += " (more available)"
+	}
+	if m.language != "" {
+		title += ", language " + m.language
+	}
+	if m.search != "" {
+		title += fmt.Sprintf(", %d matching %q", len(m.visible), m.search)
+	}
+	b.WriteString(titleStyle.Render(title) + "\n")
+	b.WriteString(m.table.View() + "\n")
+	b.WriteString(m.detailView() + "\n")
+
+	switch {
+	case m.mode == modeSearch || m.mode == modeLanguage:
+		b.WriteString(m.input.View())
+	case m.loading:
+		b.WriteString(statusStyle.Render("Loading..."))
+	default:
+		b.WriteString(statusStyle.Render(m.status))
+	}
+	b.WriteString("\n" + helpStyle.Render("↑/↓ move • / search • L language • P play • E export • D delete • esc clear search • q quit"))
+	return b.String()
+}
+
+// detailView renders the pane describing the selected entry
+func (m model) detailView() string {
+	width := max(40, m.width-2)
+	entry := m.selected()
+	if entry == nil {
+		return detailStyle.Width(width - 2).Height(detailHeight - 2).Render("No entries")
+	}
+
+	voice := entry.VoiceName
+	if voice == "" {
+		voice = "(not recorded)"
+	}
+	fields := []string{
+		labelStyle.Render("Cache key: ") + entry.CacheKey,
+		labelStyle.Render("Language:  ") + entry.LanguageCode + labelStyle.Render("   Voice: ") + voice,
+		labelStyle.Render("Created:   ") + formatTime(entry.CreatedAt) + labelStyle.Render("   Last accessed: ") + formatTime(entry.LastAccessed) +
+			labelStyle.Render("   Hits: ") + fmt.Sprint(entry.HitCount) + labelStyle.Render("   Size: ") + formatSize(entry.AudioSize),
+		"",
+		entry.Text,
+	}
+
+	// Long texts are cut off at the bottom of the pane
+	lines := strings.Split(lipgloss.NewStyle().Width(width-4).Render(strings.Join(fields, "\n")), "\n")
+	if len(lines) > detailHeight-2 {
+		lines = append(lines[:detailHeight-3], "…")
+	}
+	return detailStyle.Width(width - 2).Height(detailHeight - 2).Render(strings.Join(lines, "\n"))
+}
+
+// snippet returns the start of text on a single line
+func snippet(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > 80 {
+		return string(runes[:80]) + "…"
+	}
+	return text
+}
+
+// formatSize formats a size in bytes for the table
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+// formatTime formats a Unix timestamp for display
+func formatTime(unix int64) string {
+	if unix == 0 {
+		return "-"
+	}
+	return time.Unix(unix, 0).Format("2006-01-02 15:04")
+}
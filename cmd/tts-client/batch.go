@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	"com.biesnecker/tts-daemon/internal/player"
+)
+
+// runBatch implements the `batch` sub-command
+func runBatch(address string, args []string) {
+	fs := flag.NewFlagSet("batch", flag.ExitOnError)
+	language := fs.String("lang", "en-US", "Language code for all texts")
+	file := fs.String("file", "", "Read texts from a file, one per line (\"-\" for stdin)")
+	playMode := fs.Bool("play", false, "Play each item in order after fetching")
+	streaming := fs.Bool("streaming-bulk", false, "Stream results as they complete and play them as soon as they are available")
+	forceRefresh := fs.Bool("force", false, "Force refresh from Azure, bypassing cache")
+	adaptive := fs.Bool("adaptive", false, "Have the daemon fetch in batches sized to Azure's rate limits (for large batches)")
+	format := fs.String("format", "mp3", "Audio format to synthesize and cache (mp3, wav, opus, ogg-opus, ogg-opus-24k)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: client batch [options] [<text> ...]\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	texts := fs.Args()
+	if *file != "" {
+		lines, err := readLines(*file)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", *file, err)
+		}
+		texts = append(texts, lines...)
+	}
+	if len(texts) == 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	outputFormat, err := parseOutputFormat(*format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *adaptive && *streaming {
+		log.Fatal("--adaptive can't be combined with --streaming-bulk")
+	}
+
+	bulkReq := &pb.BulkTTSRequest{
+		Requests: make([]*pb.TTSRequest, len(texts)),
+		Adaptive: *adaptive,
+	}
+	for i, text := range texts {
+		bulkReq.Requests[i] = &pb.TTSRequest{
+			Text:         text,
+			LanguageCode: *language,
+			ForceRefresh: *forceRefresh,
+			OutputFormat: outputFormat,
+			Namespace:    namespace,
+			Tags:         tags,
+			TtlSeconds:   ttlSeconds,
+		}
+	}
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	if *streaming {
+		// Playback happens while the stream is open, so don't bound it by the fetch timeout
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		runStreamingBatch(ctx, client, bulkReq)
+		return
+	}
+
+	// Adaptive fetches deliberately hold back requests, so allow them more time
+	timeout := defaultTimeout
+	if *adaptive {
+		timeout *= 10
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.BulkFetchTTS(ctx, bulkReq)
+	if err != nil {
+		log.Fatalf("BulkFetchTTS failed: %v", err)
+	}
+
+	var audioPlayer *player.Player
+	if *playMode {
+		audioPlayer = newPlayer()
+		defer audioPlayer.Close()
+	}
+
+	for i, item := range resp.Responses {
+		logInfo("%d. %s (%s, %d bytes)\n", i+1, texts[i], sourceLabel(item.Cached), item.AudioSize)
+		if audioPlayer != nil {
+			if err := audioPlayer.Play(item.AudioData); err != nil {
+				log.Fatalf("Playback of item %d failed: %v", i+1, err)
+			}
+		}
+	}
+}
+
+// runStreamingBatch fetches items via StreamBulkFetchTTS and plays them in order as soon as
+// each next item has arrived, while later items are still being fetched
+func runStreamingBatch(ctx context.Context, client pb.TTSServiceClient, bulkReq *pb.BulkTTSRequest) {
+	stream, err := client.StreamBulkFetchTTS(ctx, bulkReq)
+	if err != nil {
+		log.Fatalf("StreamBulkFetchTTS failed: %v", err)
+	}
+
+	audioPlayer := newPlayer()
+	defer audioPlayer.Close()
+
+	pending := make(map[int32]*pb.BulkItemResult)
+	next := int32(0)
+	failed := 0
+
+	for {
+		result, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatalf("StreamBulkFetchTTS failed: %v", err)
+		}
+		pending[result.Index] = result
+
+		// Play every item that is now next in line
+		for {
+			item, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+
+			text := bulkReq.Requests[next].Text
+			if item.ErrorMessage != "" {
+				fmt.Fprintf(os.Stderr, "%d. %s: %s\n", next+1, text, item.ErrorMessage)
+				failed++
+			} else {
+				logInfo("%d. %s (%s, %d bytes)\n", next+1, text, sourceLabel(item.Response.Cached), item.Response.AudioSize)
+				if err := audioPlayer.Play(item.Response.AudioData); err != nil {
+					log.Fatalf("Playback of item %d failed: %v", next+1, err)
+				}
+			}
+			next++
+		}
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// sourceLabel describes where a response's audio came from
+func sourceLabel(cached bool) string {
+	if cached {
+		return "cached"
+	}
+	return "fetched"
+}
+
+// readLines reads non-empty lines from a file, or from stdin if path is "-"
+func readLines(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runCheckVoices implements the `check-voices` sub-command
+func runCheckVoices(address string, args []string) {
+	fs := flag.NewFlagSet("check-voices", flag.ExitOnError)
+	language := fs.String("lang", "", "Only check this language (default: all)")
+	jsonOutput := fs.Bool("json", false, "Print the inconsistencies as JSON")
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.CheckVoiceConsistency(ctx, &pb.ConsistencyRequest{LanguageCode: *language})
+	if err != nil {
+		log.Fatalf("CheckVoiceConsistency failed: %v", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(resp); err != nil {
+			log.Fatalf("Failed to encode inconsistencies: %v", err)
+		}
+		return
+	}
+
+	if len(resp.Inconsistencies) == 0 {
+		fmt.Println("All cached entries use the current voice for their language")
+		return
+	}
+	for _, i := range resp.Inconsistencies {
+		current := i.CurrentVoice
+		if current == "" {
+			current = "(no voice)"
+		}
+		fmt.Printf("%-8s current %s, cached %s (%d entries use another voice)\n",
+			i.Locale, current, strings.Join(i.CachedVoices, ", "), i.AffectedEntries)
+	}
+	fmt.Println("\nRun `resynthesize --lang <locale>` to re-synthesize a language with its current voice")
+}
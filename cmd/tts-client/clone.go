@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runClone implements the `clone` sub-command
+func runClone(address string, args []string) {
+	fs := flag.NewFlagSet("clone", flag.ExitOnError)
+	from := fs.String("from", "", "Address of the daemon to copy entries from (as seen by the destination daemon)")
+	to := fs.String("to", address, "Address of the daemon to copy entries into")
+	language := fs.String("lang", "", "Only copy entries for this language code")
+	since := fs.Duration("since", 0, "Only copy entries created within this long ago (e.g. 72h; default: all)")
+	batchSize := fs.Int("batch-size", 100, "Entries requested from the source per page")
+	fs.Parse(args)
+
+	if *from == "" {
+		fmt.Fprintf(os.Stderr, "Usage: client clone --from <address> [--to <address>] [options]\n\nOptions:\n")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+
+	req := &pb.CloneRequest{
+		SourceAddress:      *from,
+		LanguageCodeFilter: *language,
+		BatchSize:          int32(*batchSize),
+		Namespace:          namespace,
+	}
+	if *since > 0 {
+		req.SinceUnix = time.Now().Add(-*since).Unix()
+	}
+
+	client, pool := mustConnect(*to)
+	defer pool.Close()
+
+	// Cloning can take a while; run until done or interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	stream, err := client.Clone(ctx, req)
+	if err != nil {
+		log.Fatalf("Clone failed: %v", err)
+	}
+
+	var last *pb.CloneProgress
+	for {
+		progress, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Fatalf("Clone failed: %v", err)
+		}
+		last = progress
+		logInfo("copied=%d skipped=%d failed=%d (%s)\n",
+			progress.Copied, progress.Skipped, progress.Failed, progress.CurrentLanguage)
+	}
+
+	if last == nil {
+		last = &pb.CloneProgress{}
+	}
+	fmt.Printf("Copied %d entries, skipped %d already cached, %d failed\n", last.Copied, last.Skipped, last.Failed)
+	if last.Failed > 0 {
+		os.Exit(1)
+	}
+}
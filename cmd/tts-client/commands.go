@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	"com.biesnecker/tts-daemon/internal/client"
+)
+
+// command is a client sub-command invoked as `tts-client [options] <name> [args]`
+type command struct {
+	summary string
+	run     func(address string, args []string)
+}
+
+// commands maps sub-command names to their implementations
+var commands = map[string]command{
+	"batch":             {"Fetch (and optionally play) several texts at once", runBatch},
+	"check-voices":      {"Find languages with cached entries from a voice other than the current one", runCheckVoices},
+	"clone":             {"Copy cache entries from one daemon to another", runClone},
+	"compact":           {"Compact the cache database with VACUUM now", runCompact},
+	"corpus-stats":      {"Analyze the text stored in the cache database (offline)", runCorpusStats},
+	"dedup-stats":       {"Show how many Azure calls request deduplication has saved", runDedupStats},
+	"delete-pattern":    {"Delete cached entries whose text matches a LIKE pattern", runDeletePattern},
+	"delete-tag":        {"Delete cached entries with a tag", runDeleteTag},
+	"diagnose":          {"Run daemon self-diagnostics", runDiagnose},
+	"diff":              {"Show how two texts normalize and whether they share a cache key", runDiff},
+	"diff-voices":       {"Synthesize a text in several voices and save (or play) each for comparison", runDiffVoices},
+	"drain":             {"Stop the daemon from accepting connections and shut it down once requests finish", runDrain},
+	"enqueue":           {"Queue text for background synthesis and print the job ID", runEnqueue},
+	"fetch-fallback":    {"Fetch audio, falling back to a cached fallback text if synthesis is slow", runFetchFallback},
+	"heatmap":           {"Show at what times of day cache entries were last accessed", runHeatmap},
+	"job-status":        {"Show the status of a queued synthesis job", runJobStatus},
+	"list":              {"List cached entries, optionally by language or text prefix", runList},
+	"list-locales":      {"List the locales Azure has voices for and the cached entries for each", runListLocales},
+	"metrics":           {"Print the daemon's Prometheus metrics in text format", runMetrics},
+	"near-duplicates":   {"Find cached entries whose texts are nearly identical", runNearDuplicates},
+	"pause-synthesis":   {"Stop requests from reaching Azure, serving only cached audio", runPauseSynthesis},
+	"rate-limit-status": {"Show the capacity left in the Azure rate limiter", runRateLimitStatus},
+	"refresh-voices":    {"Reload the voices Azure offers", runRefreshVoices},
+	"reorder":           {"Change the priority of pending synthesis jobs", runReorder},
+	"resume-synthesis":  {"Let requests reach Azure again after pause-synthesis", runResumeSynthesis},
+	"resynthesize":      {"Re-synthesize every cached entry for a language", runResynthesize},
+	"save":              {"Fetch audio and have the daemon write it to a file", runSave},
+	"server":            {"Share one daemon connection between client invocations via a Unix socket", runMuxServer},
+	"stats":             {"Show the size of the cache, overall and for each language", runStats},
+	"stream":            {"Fetch audio in chunks and save (or play) it, for long texts", runStream},
+	"verify":            {"Check cache keys for collisions and mismatches with their text", runVerify},
+	"voice-history":     {"List detected changes to Azure's default voices", runVoiceHistory},
+	"watch":             {"Stream cache changes as they happen", runWatch},
+}
+
+// printCommands prints the list of available sub-commands to stderr
+func printCommands() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(os.Stderr, "\nCommands:\n")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-20s %s\n", name, commands[name].summary)
+	}
+}
+
+// mustConnect connects to the daemon and returns a client, exiting on failure
+func mustConnect(address string) (pb.TTSServiceClient, *client.ClientPool) {
+	pool, err := connect(address)
+	if err != nil {
+		log.Fatalf("Failed to connect to daemon at %s: %v", address, err)
+	}
+	return pool.Client(), pool
+}
+
+// parseInterspersed parses fs from args, allowing flags to follow positional arguments
+// (e.g. `delete-pattern '%text%' --dry-run`), and returns the positional arguments
+func parseInterspersed(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		fs.Parse(args)
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// shortKeyLength is how many characters of a cache key are shown in tables and event lists
+const shortKeyLength = 12
+
+// shortKey returns the first shortKeyLength characters of cacheKey, or all of it if it is
+// shorter (such as an empty key from an older daemon)
+func shortKey(cacheKey string) string {
+	if len(cacheKey) <= shortKeyLength {
+		return cacheKey
+	}
+	return cacheKey[:shortKeyLength]
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestShortKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"", ""},
+		{"abc", "abc"},
+		{"0123456789ab", "0123456789ab"},
+		{"0123456789abcdef", "0123456789ab"},
+	}
+	for _, tt := range tests {
+		if got := shortKey(tt.key); got != tt.want {
+			t.Errorf("shortKey(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
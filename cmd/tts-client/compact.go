@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runCompact implements the `compact` sub-command
+func runCompact(address string, args []string) {
+	fs := flag.NewFlagSet("compact", flag.ExitOnError)
+	timeout := fs.Duration("timeout", 10*time.Minute, "How long to wait for the compaction to finish")
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	resp, err := client.RunCompaction(ctx, &pb.CompactionRequest{})
+	if err != nil {
+		log.Fatalf("RunCompaction failed: %v", err)
+	}
+
+	fmt.Printf("Compacted the database from %d to %d bytes in %dms, freed %d bytes\n",
+		resp.SizeBeforeBytes, resp.SizeAfterBytes, resp.DurationMs, resp.SizeBeforeBytes-resp.SizeAfterBytes)
+}
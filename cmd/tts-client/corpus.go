@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"com.biesnecker/tts-daemon/internal/tts"
+)
+
+// runCorpusStats implements the `corpus-stats` sub-command; it reads the cache database
+// directly and does not need a running daemon
+func runCorpusStats(address string, args []string) {
+	fs := flag.NewFlagSet("corpus-stats", flag.ExitOnError)
+	dbPath := fs.String("db", defaultDatabasePath(), "Path to the cache database")
+	jsonOutput := fs.Bool("json", false, "Print the statistics as JSON")
+	top := fs.Int("top", 50, "Number of most frequent words to show per language")
+	fs.Parse(args)
+
+	stats, err := tts.AnalyzeCorpus(*dbPath, *top)
+	if err != nil {
+		log.Fatalf("Failed to analyze %s: %v", *dbPath, err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(stats); err != nil {
+			log.Fatalf("Failed to encode statistics: %v", err)
+		}
+		return
+	}
+
+	fmt.Printf("Texts:            %d\n", stats.TotalTexts)
+	fmt.Printf("Unique words:     %d\n", stats.UniqueWords)
+	fmt.Printf("Average length:   %.1f chars, %.1f words\n", stats.AvgChars, stats.AvgWords)
+	fmt.Printf("SSML:             %.1f%%\n", stats.SSMLPercent)
+	fmt.Printf("Contain numbers:  %.1f%%\n", stats.NumericPercent)
+	fmt.Printf("Zipf coefficient: %.3f\n", stats.ZipfCoefficient)
+
+	if len(stats.LengthHistogram) > 0 {
+		maxCount := 0
+		for _, b := range stats.LengthHistogram {
+			maxCount = max(maxCount, b.Count)
+		}
+		fmt.Printf("\nText length (chars):\n")
+		for _, b := range stats.LengthHistogram {
+			bar := strings.Repeat("#", (b.Count*40+maxCount-1)/maxCount)
+			fmt.Printf("  %4d-%-4d %6d %s\n", b.MinChars, b.MaxChars, b.Count, bar)
+		}
+	}
+
+	langs := make([]string, 0, len(stats.TopWords))
+	for lang := range stats.TopWords {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	for _, lang := range langs {
+		fmt.Printf("\nTop words (%s):\n", lang)
+		for i, wc := range stats.TopWords[lang] {
+			fmt.Printf("  %3d. %-24s %d\n", i+1, wc.Word, wc.Count)
+		}
+	}
+}
+
+// defaultDatabasePath returns the daemon's default cache database location
+func defaultDatabasePath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "cache.db"
+	}
+	return filepath.Join(homeDir, ".local", "share", "tts-daemon", "cache.db")
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runDedupStats implements the `dedup-stats` sub-command
+func runDedupStats(address string, args []string) {
+	fs := flag.NewFlagSet("dedup-stats", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Print the statistics as JSON")
+	recent := fs.Int("recent", 10, "Number of recent events to show")
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	stats, err := client.GetDedupStats(ctx, &pb.StatsRequest{})
+	if err != nil {
+		log.Fatalf("GetDedupStats failed: %v", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(stats); err != nil {
+			log.Fatalf("Failed to encode statistics: %v", err)
+		}
+		return
+	}
+
+	fmt.Printf("Deduplicated syntheses: %d\n", stats.TotalDedupEvents)
+	fmt.Printf("Azure calls saved:      %d\n", stats.TotalWaitersSaved)
+	fmt.Printf("Avg synthesis time:     %.0fms\n", stats.AvgSynthesisTimeMs)
+
+	events := stats.RecentEvents
+	if len(events) > *recent {
+		events = events[len(events)-*recent:]
+	}
+	if len(events) > 0 {
+		fmt.Printf("\nRecent events:\n")
+	}
+	for _, e := range events {
+		fmt.Printf("  %s  %s  waiters=%d  %dms\n",
+			time.Unix(e.Timestamp, 0).Format(time.DateTime), shortKey(e.CacheKey), e.WaiterCount, e.SynthesisTimeMs)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runDeleteLanguage implements the -delete-lang flag, deleting every cached entry for a language
+func runDeleteLanguage(address, languageCode string) {
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.DeleteByLanguage(ctx, &pb.DeleteByLanguageRequest{LanguageCode: languageCode, Namespace: namespace})
+	if err != nil {
+		log.Fatalf("DeleteByLanguage failed: %v", err)
+	}
+
+	fmt.Printf("Deleted %d entries for %s\n", resp.DeletedCount, languageCode)
+	for _, key := range resp.DeletedKeys {
+		logInfo("  %s\n", key)
+	}
+	if int64(len(resp.DeletedKeys)) < resp.DeletedCount {
+		logInfo("  ... and %d more\n", resp.DeletedCount-int64(len(resp.DeletedKeys)))
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runDeletePattern implements the `delete-pattern` sub-command
+func runDeletePattern(address string, args []string) {
+	fs := flag.NewFlagSet("delete-pattern", flag.ExitOnError)
+	language := fs.String("lang", "", "Only delete entries for this language code")
+	dryRun := fs.Bool("dry-run", false, "Show how many entries match without deleting them")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: client delete-pattern [options] <pattern>\n\n")
+		fmt.Fprintf(os.Stderr, "The pattern uses SQL LIKE syntax (%% matches any text, _ any character) and is case-insensitive.\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	positional := parseInterspersed(fs, args)
+
+	if len(positional) != 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.DeletePattern(ctx, &pb.DeletePatternRequest{
+		TextPattern:  positional[0],
+		LanguageCode: *language,
+		DryRun:       *dryRun,
+		Namespace:    namespace,
+	})
+	if err != nil {
+		log.Fatalf("DeletePattern failed: %v", err)
+	}
+
+	if *dryRun {
+		fmt.Printf("%d entries match, %d bytes would be freed (dry run, nothing deleted)\n",
+			resp.MatchedCount, resp.FreedBytes)
+		return
+	}
+	fmt.Printf("Deleted %d of %d matching entries, freed %d bytes\n",
+		resp.DeletedCount, resp.MatchedCount, resp.FreedBytes)
+}
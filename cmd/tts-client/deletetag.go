@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runDeleteTag implements the `delete-tag` sub-command
+func runDeleteTag(address string, args []string) {
+	fs := flag.NewFlagSet("delete-tag", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: client delete-tag <tag>\n\n")
+		fmt.Fprintf(os.Stderr, "Deletes every cached entry tagged with <tag> (see -tags).\n")
+	}
+	positional := parseInterspersed(fs, args)
+
+	if len(positional) != 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.DeleteByTag(ctx, &pb.DeleteByTagRequest{Tag: positional[0], Namespace: namespace})
+	if err != nil {
+		log.Fatalf("DeleteByTag failed: %v", err)
+	}
+
+	fmt.Printf("Deleted %d entries tagged %s\n", resp.DeletedCount, positional[0])
+}
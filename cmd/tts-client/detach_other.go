@@ -0,0 +1,10 @@
+//go:build !unix
+
+package main
+
+import "syscall"
+
+// detachedProcAttr returns nil; the child runs with default process attributes
+func detachedProcAttr() *syscall.SysProcAttr {
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runDiagnose implements the `diagnose` sub-command
+func runDiagnose(address string, args []string) {
+	fs := flag.NewFlagSet("diagnose", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Print the report as JSON")
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	report, err := client.SelfDiagnose(ctx, &pb.DiagnosticRequest{})
+	if err != nil {
+		log.Fatalf("SelfDiagnose failed: %v", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Failed to encode report: %v", err)
+		}
+	} else {
+		for _, check := range report.Checks {
+			fmt.Printf("[%s] %-14s %s (%dms)\n",
+				strings.ToUpper(check.Status), check.Name, check.Message, check.DurationMs)
+		}
+		fmt.Printf("\nOverall: %s\n", strings.ToUpper(report.Status))
+	}
+
+	if report.Status == "fail" {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	"github.com/mattn/go-runewidth"
+)
+
+// runDiff implements the `diff` sub-command
+func runDiff(address string, args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	language := fs.String("lang", "en-US", "Language code used to compute cache keys")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: client diff [options] <text A> <text B>\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.NormalizationDiff(ctx, &pb.NormalizationDiffRequest{
+		TextA:        fs.Arg(0),
+		TextB:        fs.Arg(1),
+		LanguageCode: *language,
+	})
+	if err != nil {
+		log.Fatalf("NormalizationDiff failed: %v", err)
+	}
+
+	fmt.Printf("A: %q\n   -> %q\n   key %s\n", fs.Arg(0), resp.NormalizedA, resp.KeyA)
+	fmt.Printf("B: %q\n   -> %q\n   key %s\n", fs.Arg(1), resp.NormalizedB, resp.KeyB)
+
+	if resp.KeysEqual {
+		fmt.Println("\nSame cache key")
+		return
+	}
+
+	fmt.Printf("\nDifferent cache keys")
+	if resp.FirstDiffIndex >= 0 {
+		// Point at the first differing character in both normalized strings, under the quoted
+		// prefix's display width so escapes and wide characters line up
+		fmt.Printf(", normalized text differs at character %d (byte %d):\n", resp.FirstDiffRune, resp.FirstDiffIndex)
+		fmt.Printf("   %q\n   %q\n", resp.NormalizedA, resp.NormalizedB)
+		prefix := string([]rune(resp.NormalizedA)[:resp.FirstDiffRune])
+		fmt.Printf("   %s^\n", strings.Repeat(" ", runewidth.StringWidth(strconv.Quote(prefix))-1))
+	} else {
+		fmt.Println()
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// voiceComparisonPause separates the voices when diff-voices plays them
+const voiceComparisonPause = time.Second
+
+// runDiffVoices implements the `diff-voices` sub-command
+func runDiffVoices(address string, args []string) {
+	fs := flag.NewFlagSet("diff-voices", flag.ExitOnError)
+	text := fs.String("text", "", "Text to synthesize")
+	language := fs.String("lang", "en-US", "Language code of the text")
+	voiceList := fs.String("voices", "", "Comma-separated Azure voice names, e.g. en-US-AriaNeural,en-US-GuyNeural")
+	outputDir := fs.String("output-dir", ".", "Directory to save <voice_name>.mp3 files to")
+	playMode := fs.Bool("play", false, "Play the voices one after another")
+	fs.Parse(args)
+
+	var voices []string
+	for _, voice := range strings.Split(*voiceList, ",") {
+		if voice = strings.TrimSpace(voice); voice != "" {
+			voices = append(voices, voice)
+		}
+	}
+	if *text == "" || len(voices) == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: client diff-voices --text <text> --voices <voice>,<voice>[,...] [options]\n\nOptions:\n")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+
+	if err := os.MkdirAll(*outputDir, 0755); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	// Synthesize all voices at once; they're saved and played in the order given
+	responses := make([]*pb.TTSResponse, len(voices))
+	errs := make([]error, len(voices))
+	var wg sync.WaitGroup
+	for i, voice := range voices {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[i], errs[i] = client.FetchTTS(ctx, &pb.TTSRequest{
+				Text:         *text,
+				LanguageCode: *language,
+				VoiceName:    voice,
+				Namespace:    namespace,
+				Tags:         tags,
+				TtlSeconds:   ttlSeconds,
+			})
+		}()
+	}
+	wg.Wait()
+
+	for i, voice := range voices {
+		if errs[i] != nil {
+			log.Fatalf("FetchTTS failed for %s: %v", voice, errs[i])
+		}
+		path := filepath.Join(*outputDir, voice+".mp3")
+		if err := os.WriteFile(path, responses[i].AudioData, 0644); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+		fmt.Printf("Saved %s (%s, %d bytes)\n", path, sourceLabel(responses[i].Cached), responses[i].AudioSize)
+	}
+
+	if !*playMode {
+		return
+	}
+	audioPlayer := newPlayer()
+	defer audioPlayer.Close()
+	for i, voice := range voices {
+		if i > 0 {
+			time.Sleep(voiceComparisonPause)
+		}
+		fmt.Println(voice)
+		if err := audioPlayer.Play(responses[i].AudioData); err != nil {
+			log.Fatalf("Playback of %s failed: %v", voice, err)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runDrain implements the `drain` sub-command
+func runDrain(address string, args []string) {
+	fs := flag.NewFlagSet("drain", flag.ExitOnError)
+	timeout := fs.Int("timeout", 30, "Seconds to wait for active requests before the daemon stops anyway")
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.SetDraining(ctx, &pb.DrainRequest{DrainTimeoutS: int32(*timeout)})
+	if err != nil {
+		log.Fatalf("SetDraining failed: %v", err)
+	}
+
+	fmt.Printf("Daemon draining with %d active requests; it stops once they finish (at most %ds)\n",
+		resp.ActiveRequestsAtDrainStart, *timeout)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runExport implements the -export flag, writing the daemon's cache to a zip archive at path
+func runExport(address, path string) {
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	// Exporting a large cache can take a while; run until done or interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	stream, err := client.ExportCache(ctx, &pb.ExportCacheRequest{Namespace: namespace})
+	if err != nil {
+		log.Fatalf("ExportCache failed: %v", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("Failed to create %s: %v", path, err)
+	}
+	fail := func(format string, args ...any) {
+		f.Close()
+		os.Remove(path)
+		log.Fatalf(format, args...)
+	}
+
+	var size int64
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			fail("ExportCache failed: %v", err)
+		}
+		if chunk.Offset != size {
+			fail("ExportCache sent a chunk at offset %d, expected %d", chunk.Offset, size)
+		}
+		if _, err := f.Write(chunk.Data); err != nil {
+			fail("Failed to write %s: %v", path, err)
+		}
+		size += int64(len(chunk.Data))
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		log.Fatalf("Failed to write %s: %v", path, err)
+	}
+
+	fmt.Printf("Exported the cache to %s (%d bytes)\n", path, size)
+}
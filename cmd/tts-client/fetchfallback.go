@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runFetchFallback implements the `fetch-fallback` sub-command
+func runFetchFallback(address string, args []string) {
+	fs := flag.NewFlagSet("fetch-fallback", flag.ExitOnError)
+	language := fs.String("lang", "en-US", "Language code (e.g., en-US, fr-FR, es-ES)")
+	staleOK := fs.Duration("stale-ok", 500*time.Millisecond, "How long to wait for synthesis when the text isn't cached")
+	fallbackText := fs.String("fallback-text", "unavailable", "Text whose cached audio is used when synthesis takes longer")
+	output := fs.String("output", "", "Write the audio to this file")
+	play := fs.Bool("play", false, "Play the audio")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: client fetch-fallback [--stale-ok 500ms] [--fallback-text <text>] [options] <text>\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	positional := parseInterspersed(fs, args)
+
+	if len(positional) != 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.FetchWithFallback(ctx, &pb.FallbackRequest{
+		Request: &pb.TTSRequest{
+			Text:         positional[0],
+			LanguageCode: *language,
+			Namespace:    namespace,
+			Tags:         tags,
+			TtlSeconds:   ttlSeconds,
+		},
+		StaleOkForMs: staleOK.Milliseconds(),
+		FallbackText: *fallbackText,
+	})
+	if err != nil {
+		log.Fatalf("FetchWithFallback failed: %v", err)
+	}
+
+	source := "fetched from Azure"
+	switch {
+	case resp.Fallback:
+		source = fmt.Sprintf("fallback text %q, synthesis took longer than %s", *fallbackText, *staleOK)
+	case resp.FromStaleCache:
+		source = "from cache"
+	}
+	fmt.Printf("Audio size: %d bytes (%s)\n", resp.AudioSize, source)
+
+	if *output != "" {
+		if err := os.WriteFile(*output, resp.AudioData, 0644); err != nil {
+			log.Fatalf("Failed to write %s: %v", *output, err)
+		}
+	}
+	if *play {
+		audioPlayer := newPlayer()
+		defer audioPlayer.Close()
+		if err := audioPlayer.Play(resp.AudioData); err != nil {
+			log.Fatalf("Playback failed: %v", err)
+		}
+	}
+}
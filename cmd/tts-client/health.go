@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// runHealthCheck implements the -health flag: it prints the daemon's grpc.health.v1 status and
+// exits 0 if it is SERVING and 1 otherwise
+func runHealthCheck(address string) {
+	pool, err := connect(address)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to daemon at %s: %v\n", address, err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(pool.Conn()).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Health check failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(resp.Status)
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// heatmapShades are the cells of the heatmap grid, from no accesses to the busiest interval
+const heatmapShades = " .:-=+*#%@"
+
+// runHeatmap implements the `heatmap` sub-command
+func runHeatmap(address string, args []string) {
+	fs := flag.NewFlagSet("heatmap", flag.ExitOnError)
+	days := fs.Int("days", 7, "Only count entries accessed in this many days")
+	granularity := fs.Int("granularity", 60, "Interval size in minutes (must divide an hour, e.g. 15)")
+	jsonOutput := fs.Bool("json", false, "Print the buckets as JSON")
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.GetCacheHeatmap(ctx, &pb.HeatmapRequest{
+		GranularityMinutes: int32(*granularity),
+		DaysBack:           int32(*days),
+	})
+	if err != nil {
+		log.Fatalf("GetCacheHeatmap failed: %v", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(resp); err != nil {
+			log.Fatalf("Failed to encode heatmap: %v", err)
+		}
+		return
+	}
+
+	printHeatmap(resp)
+}
+
+// printHeatmap prints one row per hour (UTC) with a shaded cell per interval, followed by the
+// hour's totals
+func printHeatmap(resp *pb.HeatmapResponse) {
+	var busiest int64
+	for _, b := range resp.Buckets {
+		if b.AccessCount > busiest {
+			busiest = b.AccessCount
+		}
+	}
+
+	perHour := int(60 / resp.GranularityMinutes)
+	fmt.Printf("Last access time of cache entries (UTC), last %d days, %d-minute intervals\n\n", resp.DaysBack, resp.GranularityMinutes)
+
+	header := "       "
+	for i := 0; i < perHour; i++ {
+		header += fmt.Sprintf(" :%02d", i*int(resp.GranularityMinutes))
+	}
+	fmt.Printf("%s  %9s %9s\n", header, "accesses", "hits")
+
+	for hour := 0; hour < 24 && (hour+1)*perHour <= len(resp.Buckets); hour++ {
+		var row strings.Builder
+		var accesses, hits int64
+		for _, b := range resp.Buckets[hour*perHour : (hour+1)*perHour] {
+			shade := 0
+			if busiest > 0 && b.AccessCount > 0 {
+				// Any access gets at least the lightest visible shade
+				shade = 1 + int(b.AccessCount*int64(len(heatmapShades)-2)/busiest)
+			}
+			row.WriteString(" " + strings.Repeat(string(heatmapShades[shade]), 3))
+			accesses += b.AccessCount
+			hits += b.CacheHitCount
+		}
+		fmt.Printf("%02d:00  %s  %9d %9d\n", hour, row.String(), accesses, hits)
+	}
+
+	if busiest == 0 {
+		fmt.Println("\nNo entries were accessed in this period")
+	}
+}
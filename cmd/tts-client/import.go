@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// importChunkSize is the size of the chunks -import sends the archive in
+const importChunkSize = 64 * 1024
+
+// runImport implements the -import flag, adding the entries of an archive written by -export to
+// the daemon's cache
+func runImport(address, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	// Importing a large archive can take a while; run until done or interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	stream, err := client.ImportCache(ctx)
+	if err != nil {
+		log.Fatalf("ImportCache failed: %v", err)
+	}
+
+	buf := make([]byte, importChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.ImportChunk{Data: buf[:n], Namespace: namespace}); err != nil {
+				break // The daemon ended the call; CloseAndRecv returns its error
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", path, err)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		log.Fatalf("ImportCache failed: %v", err)
+	}
+
+	fmt.Printf("Imported %d entries (%d already cached)\n", resp.Imported, resp.Skipped)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// listTextWidth is how many characters of each entry's text `list` prints
+const listTextWidth = 100
+
+// runList implements the `list` sub-command
+func runList(address string, args []string) {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	language := fs.String("lang", "", "Only list entries for this language (default: all)")
+	prefix := fs.String("prefix", "", "Only list entries whose text starts with this (case-sensitive)")
+	tag := fs.String("tag", "", "Only list entries with this tag")
+	limit := fs.Int("limit", 50, "Maximum entries to list (0 = all)")
+	pageToken := fs.String("page-token", "", "Continue a previous listing from this token")
+	jsonOutput := fs.Bool("json", false, "Print the entries as JSON")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: client list [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Lists cache entries in cache key order.\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	// Request pages until the limit is reached or the entries run out
+	var entries []*pb.CacheEntryInfo
+	token := *pageToken
+	for {
+		pageSize := 0
+		if *limit > 0 {
+			pageSize = *limit - len(entries)
+		}
+		resp, err := client.ListCacheEntries(ctx, &pb.ListCacheEntriesRequest{
+			LanguageCode: *language,
+			TextPrefix:   *prefix,
+			TagFilter:    *tag,
+			PageSize:     int32(pageSize),
+			PageToken:    token,
+			Namespace:    namespace,
+		})
+		if err != nil {
+			log.Fatalf("ListCacheEntries failed: %v", err)
+		}
+		entries = append(entries, resp.Entries...)
+		token = resp.NextPageToken
+		if token == "" || (*limit > 0 && len(entries) >= *limit) {
+			break
+		}
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(&pb.ListCacheEntriesResponse{Entries: entries, NextPageToken: token}); err != nil {
+			log.Fatalf("Failed to encode entries: %v", err)
+		}
+		return
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No cache entries found")
+		return
+	}
+	fmt.Printf("%-12s %-8s %10s %-19s %-19s %s\n", "KEY", "LANGUAGE", "BYTES", "CREATED", "LAST ACCESSED", "TEXT")
+	for _, e := range entries {
+		fmt.Printf("%-12s %-8s %10d %-19s %-19s %q\n", shortKey(e.CacheKey), e.LanguageCode, e.AudioSize,
+			time.Unix(e.CreatedAt, 0).Format(time.DateTime), time.Unix(e.LastAccessed, 0).Format(time.DateTime),
+			truncateText(e.Text, listTextWidth))
+	}
+	if token != "" {
+		fmt.Printf("\nFor more entries: --page-token %s\n", token)
+	}
+}
+
+// truncateText shortens text to at most width characters, ending it with "..." if it was cut
+func truncateText(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width-3]) + "..."
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runListLocales implements the `list-locales` sub-command
+func runListLocales(address string, args []string) {
+	fs := flag.NewFlagSet("list-locales", flag.ExitOnError)
+	hasCache := fs.Bool("has-cache", false, "Only list locales with cached entries")
+	hasVoice := fs.Bool("has-voice", false, "Only list locales Azure has a voice for")
+	jsonOutput := fs.Bool("json", false, "Print the locales as JSON")
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.ListLocales(ctx, &pb.ListLocalesRequest{
+		HasAzureVoiceFilter:    *hasVoice,
+		HasCachedContentFilter: *hasCache,
+		Namespace:              namespace,
+	})
+	if err != nil {
+		log.Fatalf("ListLocales failed: %v", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(resp); err != nil {
+			log.Fatalf("Failed to encode locales: %v", err)
+		}
+		return
+	}
+
+	if len(resp.Locales) == 0 {
+		fmt.Println("No locales found")
+		return
+	}
+	fmt.Printf("%-10s %-28s %-28s %8s %12s\n", "LOCALE", "NAME", "DEFAULT VOICE", "ENTRIES", "BYTES")
+	for _, l := range resp.Locales {
+		voice := l.DefaultVoice
+		if !l.HasAzureVoice {
+			voice = "(no voice)"
+		}
+		fmt.Printf("%-10s %-28s %-28s %8d %12d\n", l.Locale, l.DisplayName, voice, l.CachedEntries, l.TotalCachedBytes)
+	}
+}
@@ -2,18 +2,24 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"strings"
 	"time"
 
 	pb "com.biesnecker/tts-daemon/proto"
+	"com.biesnecker/tts-daemon/internal/client"
+	"com.biesnecker/tts-daemon/internal/config"
 	"com.biesnecker/tts-daemon/internal/player"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
@@ -23,63 +29,344 @@ const (
 
 var verbose bool
 
+// daemonAddresses lists every daemon instance to balance across when -addresses is given
+var (
+	daemonAddresses []string
+	lbPolicy        string
+)
+
+// useTLS connects to the daemon over TLS (e.g. a daemon started with -acme), verifying its
+// certificate against caCertFile if set and the system roots otherwise. clientCertFile and
+// clientKeyFile are presented to daemons that require client certificates.
+var (
+	useTLS         bool
+	caCertFile     string
+	clientCertFile string
+	clientKeyFile  string
+)
+
+// apiKey is sent to daemons that require one (server.api_key). It defaults to the
+// TTS_DAEMON_API_KEY environment variable.
+var apiKey string
+
+// apiKeyEnv is the environment variable apiKey is read from when -api-key isn't given
+const apiKeyEnv = "TTS_DAEMON_API_KEY"
+
+// namespace is the cache namespace requests use (server.namespaces), "" for the default one
+var namespace string
+
+// tags are added to the entries that requests cache or are answered from (-tags)
+var tags []string
+
+// ttlSeconds is the lifetime of the entries requests cache (0 = the daemon's database.ttl_days,
+// -1 = never expire)
+var ttlSeconds int64
+
+// audioConfig holds playback settings loaded from the config file, if present
+var audioConfig config.AudioConfig
+
 func logInfo(format string, v ...interface{}) {
 	if verbose {
 		fmt.Printf(format, v...)
 	}
 }
 
+// cliOptions holds the flags for the default (non sub-command) mode
+type cliOptions struct {
+	playMode     bool
+	language     string
+	cacheOnly    bool
+	forceRefresh bool
+	deleteMode   bool
+	tempo        float64
+	format       string
+	bitrate      string
+	sampleRateHz int
+	ephemeral    bool
+	ssml         bool
+}
+
 func main() {
+	var opts cliOptions
+
 	// Command line flags
 	address := flag.String("address", defaultAddress, "Daemon server address")
+	addressList := flag.String("addresses", "", "Comma-separated daemon addresses to load balance across (overrides -address)")
+	flag.StringVar(&lbPolicy, "lb-policy", client.PolicyRoundRobin, "Load balancing policy for -addresses (round_robin, pick_first)")
+	flag.StringVar(&apiKey, "api-key", "", "API key to send to the daemon (default: $"+apiKeyEnv+")")
+	flag.Int64Var(&ttlSeconds, "ttl-seconds", 0, "Seconds the cached entries live, overriding the daemon's database.ttl_days (-1 = never expire)")
+	tagList := flag.String("tags", "", "Comma-separated tags to add to the cached entries, e.g. feature=onboarding,sprint=42")
+	flag.StringVar(&namespace, "namespace", "", "Cache namespace to use, one of the daemon's server.namespaces (default: the default namespace)")
+	flag.BoolVar(&useTLS, "tls", false, "Connect to the daemon over TLS, verifying its certificate against the system roots")
+	flag.StringVar(&caCertFile, "ca-cert", "", "Connect over TLS, verifying the daemon's certificate against this PEM file (e.g. its self-signed certificate)")
+	flag.StringVar(&clientCertFile, "client-cert", "", "Connect over TLS, presenting this PEM client certificate (with -client-key)")
+	flag.StringVar(&clientKeyFile, "client-key", "", "PEM private key for -client-cert")
 	mcpMode := flag.Bool("mcp", false, "Run in MCP mode")
-	playMode := flag.Bool("play", false, "Play audio (default: just fetch)")
-	language := flag.String("lang", "en-US", "Language code (e.g., en-US, fr-FR, es-ES)")
-	cacheOnly := flag.Bool("cache-only", false, "Only check cache, don't fetch from Azure")
-	forceRefresh := flag.Bool("force", false, "Force refresh from Azure, bypassing cache")
-	flag.BoolVar(forceRefresh, "f", false, "Force refresh from Azure, bypassing cache (shorthand)")
-	deleteMode := flag.Bool("D", false, "Delete cached entry")
+	healthCheck := flag.Bool("health", false, "Check the daemon's health and exit 0 if it is serving, 1 otherwise")
+	var deleteLanguage string
+	flag.StringVar(&deleteLanguage, "delete-lang", "", "Delete every cached entry for this language")
+	flag.StringVar(&deleteLanguage, "L", "", "Delete every cached entry for this language (shorthand)")
+	exportPath := flag.String("export", "", "Export the daemon's cache to this zip file")
+	importPath := flag.String("import", "", "Import the entries of a zip file written by -export into the daemon's cache")
+	verifyCache := flag.Bool("verify", false, "Check the daemon's database for corruption and exit 0 if it is healthy, 1 otherwise")
+	repair := flag.Bool("repair", false, "With -verify, delete the corrupt entries found")
+	warmPath := flag.String("warm", "", "Cache the texts in this file of {\"text\": ..., \"language\": ...} lines (- for stdin)")
+	configPath := flag.String("config", "", "Config file to read audio settings from (default: ~/.config/tts-daemon/config.yaml)")
+	flag.BoolVar(&opts.playMode, "play", false, "Play audio (default: just fetch)")
+	flag.StringVar(&opts.language, "lang", "en-US", "Language code (e.g., en-US, fr-FR, es-ES)")
+	flag.BoolVar(&opts.cacheOnly, "cache-only", false, "Only check cache, don't fetch from Azure")
+	flag.BoolVar(&opts.forceRefresh, "force", false, "Force refresh from Azure, bypassing cache")
+	flag.BoolVar(&opts.forceRefresh, "f", false, "Force refresh from Azure, bypassing cache (shorthand)")
+	flag.BoolVar(&opts.deleteMode, "D", false, "Delete cached entry")
+	flag.Float64Var(&opts.tempo, "tempo", 1.0, "Playback tempo factor without pitch change (0.5-2.0)")
+	flag.StringVar(&opts.format, "format", "", "Audio format to synthesize and cache (mp3, wav, opus, ogg-opus, ogg-opus-24k) (default mp3, or ogg-opus with audio.prefer_opus)")
+	flag.StringVar(&opts.bitrate, "bitrate", "", "MP3 bitrate (32k, 48k, 64k, 96k, 128k, 160k, 192k) (default: the daemon's audio.bitrate)")
+	flag.IntVar(&opts.sampleRateHz, "sample-rate-hz", 0, "MP3 sample rate (16000, 24000, 48000) (default: the daemon's audio.sample_rate_hz)")
+	flag.BoolVar(&opts.ephemeral, "ephemeral", false, "Synthesize without reading or writing the cache")
+	flag.BoolVar(&opts.ssml, "ssml", false, "Text is an SSML document to synthesize as written")
+	flag.BoolVar(&noMux, "no-mux", false, "Connect directly even if a multiplexer is running")
+	flag.StringVar(&muxSocket, "socket", "", "Multiplexer socket path (default: derived from -address)")
 	verboseFlag := flag.Bool("verbose", false, "Enable verbose output")
 	flag.BoolVar(verboseFlag, "v", false, "Enable verbose output (shorthand)")
 	flag.Parse()
 
 	verbose = *verboseFlag
+	if apiKey == "" {
+		apiKey = os.Getenv(apiKeyEnv)
+	}
+	loadAudioConfig(*configPath)
+
+	for _, tag := range strings.Split(*tagList, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	if *addressList != "" {
+		for _, addr := range strings.Split(*addressList, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				daemonAddresses = append(daemonAddresses, addr)
+			}
+		}
+		if len(daemonAddresses) == 0 {
+			log.Fatalf("-addresses must list at least one address")
+		}
+		*address = daemonAddresses[0]
+	}
 
 	if *mcpMode {
 		runMCPServer(*address)
-	} else {
-		runCLI(*address, *playMode, *language, *cacheOnly, *forceRefresh, *deleteMode, flag.Args())
+		return
+	}
+
+	if *healthCheck {
+		runHealthCheck(*address)
+		return
+	}
+
+	if deleteLanguage != "" {
+		runDeleteLanguage(*address, deleteLanguage)
+		return
+	}
+
+	if *exportPath != "" {
+		runExport(*address, *exportPath)
+		return
+	}
+
+	if *importPath != "" {
+		runImport(*address, *importPath)
+		return
+	}
+
+	if *verifyCache {
+		runVerifyCache(*address, *repair)
+		return
+	}
+
+	if *warmPath != "" {
+		runWarm(*address, *warmPath, opts.language)
+		return
+	}
+
+	// Sub-commands take precedence over plain text arguments
+	if args := flag.Args(); len(args) > 0 {
+		if cmd, ok := commands[args[0]]; ok {
+			cmd.run(*address, args[1:])
+			return
+		}
+	}
+
+	runCLI(*address, opts, flag.Args())
+}
+
+// loadAudioConfig loads playback settings from the config file, ignoring a missing default file
+func loadAudioConfig(path string) {
+	explicit := path != ""
+	if !explicit {
+		defaultPath, err := config.GetDefaultConfigPath()
+		if err != nil {
+			return
+		}
+		path = defaultPath
+	}
+
+	cfg, err := config.LoadAudio(path)
+	if err != nil {
+		if explicit || !errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Warning: using default audio settings: %v\n", err)
+		}
+		return
+	}
+	audioConfig = cfg
+}
+
+// newPlayer creates an audio player using the loaded audio settings
+func newPlayer() *player.Player {
+	return player.NewPlayer(audioConfig)
+}
+
+// parseOutputFormat converts a -format flag value to the protocol's output format
+func parseOutputFormat(name string) (pb.OutputFormat, error) {
+	switch strings.ToLower(name) {
+	case "", "mp3":
+		return pb.OutputFormat_MP3, nil
+	case "wav", "wav-16k":
+		return pb.OutputFormat_WAV_16K, nil
+	case "opus", "opus-24k":
+		return pb.OutputFormat_OPUS_24K, nil
+	case "ogg-opus", "ogg-opus-48k":
+		return pb.OutputFormat_OGG_OPUS_48K, nil
+	case "ogg-opus-24k":
+		return pb.OutputFormat_OGG_OPUS_24K, nil
+	default:
+		return pb.OutputFormat_MP3, fmt.Errorf("unknown audio format %q (use mp3, wav, opus, ogg-opus or ogg-opus-24k)", name)
+	}
+}
+
+// connect opens a connection to the daemon. Multiple -addresses are load balanced; a single
+// address goes through a running multiplexer if there is one.
+func connect(address string) (*client.ClientPool, error) {
+	if len(daemonAddresses) > 1 {
+		return client.NewClientPool(daemonAddresses, lbPolicy, transportCredentials(), authOptions()...)
+	}
+
+	// The multiplexer socket is local and unencrypted; it makes the TLS connection upstream and
+	// sends its own API key
+	if socketTarget, ok := muxTarget(address); ok {
+		return client.NewClientPool([]string{socketTarget}, lbPolicy, nil)
+	}
+	return client.NewClientPool([]string{address}, lbPolicy, transportCredentials(), authOptions()...)
+}
+
+// authOptions returns the dial options that send -api-key to the daemon, if set
+func authOptions() []grpc.DialOption {
+	if apiKey == "" {
+		return nil
+	}
+	return client.APIKeyOptions(apiKey)
+}
+
+// transportCredentials returns the credentials for connecting to the daemon (nil = unencrypted)
+func transportCredentials() credentials.TransportCredentials {
+	if !useTLS && caCertFile == "" && clientCertFile == "" {
+		return nil
 	}
+
+	tlsConfig := &tls.Config{}
+	if caCertFile != "" {
+		pemData, err := os.ReadFile(caCertFile)
+		if err != nil {
+			log.Fatalf("Failed to read -ca-cert: %v", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pemData) {
+			log.Fatalf("-ca-cert %s contains no PEM certificates", caCertFile)
+		}
+	}
+	if clientCertFile != "" || clientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+		if err != nil {
+			log.Fatalf("Failed to load -client-cert and -client-key: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsConfig)
 }
 
-func runCLI(address string, playMode bool, language string, cacheOnly bool, forceRefresh bool, deleteMode bool, args []string) {
+func runCLI(address string, opts cliOptions, args []string) {
 	if len(args) == 0 {
 		fmt.Fprintf(os.Stderr, "Usage: client [options] <text>\n")
+		fmt.Fprintf(os.Stderr, "       client [options] <command> [command options]\n")
 		fmt.Fprintf(os.Stderr, "\nOptions:\n")
 		flag.PrintDefaults()
+		printCommands()
 		os.Exit(1)
 	}
 
 	text := args[0]
 
+	// OGG Opus is only the default if it can be played here
+	if opts.format == "" && audioConfig.PreferOpus {
+		if !opts.playMode || player.OpusAvailable() {
+			opts.format = "ogg-opus"
+		} else {
+			logInfo("audio.prefer_opus is set but opusdec is not installed, using MP3\n")
+		}
+	}
+
+	outputFormat, err := parseOutputFormat(opts.format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Connect to daemon
-	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	pool, err := connect(address)
 	if err != nil {
 		log.Fatalf("Failed to connect to daemon at %s: %v", address, err)
 	}
-	defer conn.Close()
+	defer pool.Close()
 
-	client := pb.NewTTSServiceClient(conn)
+	client := pool.Client()
 	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
 	defer cancel()
 
 	req := &pb.TTSRequest{
 		Text:         text,
-		LanguageCode: language,
-		ForceRefresh: forceRefresh,
+		LanguageCode: opts.language,
+		ForceRefresh: opts.forceRefresh,
+		TempoFactor:  opts.tempo,
+		OutputFormat: outputFormat,
+
+		Mp3Bitrate:      opts.bitrate,
+		Mp3SampleRateHz: int32(opts.sampleRateHz),
+		IsSsml:          opts.ssml,
+		Namespace:       namespace,
+		Tags:            tags,
+		TtlSeconds:      ttlSeconds,
 	}
 
-	if deleteMode {
+	if opts.ephemeral {
+		// Synthesize directly from Azure; nothing is cached
+		resp, err := client.SynthesizeEphemeral(ctx, req)
+		if err != nil {
+			log.Fatalf("SynthesizeEphemeral failed: %v", err)
+		}
+
+		logInfo("Audio synthesized (not cached)\n")
+		logInfo("Audio size: %d bytes\n", len(resp.AudioData))
+		logInfo("Duration: %dms\n", resp.DurationMs)
+
+		if opts.playMode {
+			audioPlayer := newPlayer()
+			defer audioPlayer.Close()
+
+			if err := audioPlayer.Play(resp.AudioData, player.WithTempo(opts.tempo)); err != nil {
+				log.Fatalf("Playback failed: %v", err)
+			}
+			logInfo("Audio played successfully\n")
+		}
+	} else if opts.deleteMode {
 		// Delete cached entry
 		resp, err := client.DeleteCached(ctx, req)
 		if err != nil {
@@ -94,7 +381,7 @@ func runCLI(address string, playMode bool, language string, cacheOnly bool, forc
 
 		logInfo("%s\n", resp.Message)
 		logInfo("Cache key: %s\n", resp.CacheKey)
-	} else if cacheOnly {
+	} else if opts.cacheOnly {
 		// Get cached audio only
 		resp, err := client.GetCachedAudio(ctx, req)
 		if err != nil {
@@ -110,7 +397,7 @@ func runCLI(address string, playMode bool, language string, cacheOnly bool, forc
 		logInfo("Audio found in cache\n")
 		logInfo("Cache key: %s\n", resp.CacheKey)
 		logInfo("Audio size: %d bytes\n", resp.AudioSize)
-	} else if playMode {
+	} else if opts.playMode {
 		// Fetch audio and play it locally
 		resp, err := client.FetchTTS(ctx, req)
 		if err != nil {
@@ -118,11 +405,12 @@ func runCLI(address string, playMode bool, language string, cacheOnly bool, forc
 		}
 
 		// Initialize player
-		audioPlayer := player.NewPlayer(44100, 4096)
+		audioPlayer := newPlayer()
 		defer audioPlayer.Close()
+		logInfo("Audio buffer size: %d samples\n", audioPlayer.BufferSize())
 
 		// Play the audio locally
-		err = audioPlayer.PlayMP3(resp.AudioData)
+		err = audioPlayer.Play(resp.AudioData, player.WithTempo(opts.tempo))
 		if err != nil {
 			log.Fatalf("Playback failed: %v", err)
 		}
@@ -143,6 +431,9 @@ func runCLI(address string, playMode bool, language string, cacheOnly bool, forc
 		logInfo("Audio fetched successfully\n")
 		logInfo("Cache key: %s\n", resp.CacheKey)
 		logInfo("Audio size: %d bytes\n", resp.AudioSize)
+		if resp.VoiceFallbackLocale != "" {
+			logInfo("Voice: %s (no voice for %s)\n", resp.VoiceFallbackLocale, opts.language)
+		}
 		if resp.Cached {
 			logInfo("(from cache)\n")
 		} else {
@@ -153,7 +444,7 @@ func runCLI(address string, playMode bool, language string, cacheOnly bool, forc
 
 // MCP (Model Context Protocol) implementation
 type MCPServer struct {
-	address string
+	pool *client.ClientPool
 }
 
 type MCPRequest struct {
@@ -176,7 +467,14 @@ type MCPError struct {
 }
 
 func runMCPServer(address string) {
-	server := &MCPServer{address: address}
+	// One connection is shared by all tool calls so that requests are balanced across daemons
+	pool, err := connect(address)
+	if err != nil {
+		log.Fatalf("Failed to connect to daemon at %s: %v", address, err)
+	}
+	defer pool.Close()
+
+	server := &MCPServer{pool: pool}
 	decoder := json.NewDecoder(os.Stdin)
 	encoder := json.NewEncoder(os.Stdout)
 
@@ -282,6 +580,11 @@ func runMCPServer(address string) {
 									"description": "Language code (e.g., en-US, fr-FR, es-ES)",
 									"default":     "en-US",
 								},
+								"tempo_factor": map[string]interface{}{
+									"type":        "number",
+									"description": "Playback speed without pitch change (0.5-2.0)",
+									"default":     1.0,
+								},
 							},
 							"required": []string{"text"},
 						},
@@ -327,14 +630,7 @@ func (s *MCPServer) handleToolCall(params map[string]interface{}) (interface{},
 		return nil, fmt.Errorf("missing or invalid arguments")
 	}
 
-	// Connect to daemon
-	conn, err := grpc.NewClient(s.address, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to daemon: %w", err)
-	}
-	defer conn.Close()
-
-	client := pb.NewTTSServiceClient(conn)
+	client := s.pool.Client()
 	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
 	defer cancel()
 
@@ -352,8 +648,9 @@ func (s *MCPServer) handleToolCall(params map[string]interface{}) (interface{},
 		}
 
 		req := &pb.TTSRequest{
-			Text:         text,
-			LanguageCode: languageCode,
+			Text:             text,
+			LanguageCode:     languageCode,
+			IncludeTextStats: true,
 		}
 		resp, err := client.FetchTTS(ctx, req)
 		if err != nil {
@@ -365,14 +662,24 @@ func (s *MCPServer) handleToolCall(params map[string]interface{}) (interface{},
 			status = "retrieved from cache"
 		}
 
+		stats := resp.GetTextStats()
 		return map[string]interface{}{
 			"content": []map[string]interface{}{
 				{
 					"type": "text",
-					"text": fmt.Sprintf("Audio %s successfully.\nCache key: %s\nSize: %d bytes",
-						status, resp.CacheKey, resp.AudioSize),
+					"text": fmt.Sprintf("Audio %s successfully.\nCache key: %s\nSize: %d bytes\nWords: %d, estimated reading time: %dms",
+						status, resp.CacheKey, resp.AudioSize, stats.GetWordCount(), stats.GetEstimatedReadingTimeMs()),
 				},
 			},
+			"structuredContent": map[string]interface{}{
+				"cached":                    resp.Cached,
+				"cache_key":                 resp.CacheKey,
+				"audio_size":                resp.AudioSize,
+				"char_count":                stats.GetCharCount(),
+				"word_count":                stats.GetWordCount(),
+				"sentence_count":            stats.GetSentenceCount(),
+				"estimated_reading_time_ms": stats.GetEstimatedReadingTimeMs(),
+			},
 		}, nil
 
 	case "bulk_fetch_tts":
@@ -457,9 +764,15 @@ func (s *MCPServer) handleToolCall(params map[string]interface{}) (interface{},
 			languageCode = lang
 		}
 
+		tempo := 1.0
+		if t, ok := arguments["tempo_factor"].(float64); ok && t != 0 {
+			tempo = t
+		}
+
 		req := &pb.TTSRequest{
 			Text:         text,
 			LanguageCode: languageCode,
+			TempoFactor:  tempo,
 		}
 
 		// Fetch audio
@@ -469,11 +782,11 @@ func (s *MCPServer) handleToolCall(params map[string]interface{}) (interface{},
 		}
 
 		// Create a fresh player for each playback (helps with sleep/wake issues)
-		audioPlayer := player.NewPlayer(44100, 4096)
+		audioPlayer := newPlayer()
 		defer audioPlayer.Close()
 
 		// Play the audio locally
-		err = audioPlayer.PlayMP3(resp.AudioData)
+		err = audioPlayer.Play(resp.AudioData, player.WithTempo(tempo))
 		if err != nil {
 			return nil, fmt.Errorf("playback failed: %v", err)
 		}
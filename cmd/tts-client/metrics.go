@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"google.golang.org/protobuf/proto"
+)
+
+// runMetrics implements the `metrics` sub-command
+func runMetrics(address string, args []string) {
+	fs := flag.NewFlagSet("metrics", flag.ExitOnError)
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.ExportMetrics(ctx, &pb.MetricsRequest{})
+	if err != nil {
+		log.Fatalf("ExportMetrics failed: %v", err)
+	}
+
+	// Print in the Prometheus text exposition format, as a /metrics endpoint would
+	for _, family := range resp.Families {
+		if _, err := expfmt.MetricFamilyToText(os.Stdout, metricFamilyFromProto(family)); err != nil {
+			log.Fatalf("Failed to write metrics: %v", err)
+		}
+	}
+}
+
+// metricFamilyFromProto converts an exported metric family back to the Prometheus data model
+func metricFamilyFromProto(family *pb.MetricFamily) *dto.MetricFamily {
+	out := &dto.MetricFamily{
+		Name: proto.String(family.Name),
+		Type: dto.MetricType(family.Type).Enum(),
+	}
+	if family.Help != "" {
+		out.Help = proto.String(family.Help)
+	}
+
+	for _, m := range family.Metrics {
+		metric := &dto.Metric{}
+		if m.TimestampMs != 0 {
+			metric.TimestampMs = proto.Int64(m.TimestampMs)
+		}
+		for _, label := range m.Labels {
+			metric.Label = append(metric.Label, &dto.LabelPair{Name: proto.String(label.Name), Value: proto.String(label.Value)})
+		}
+
+		switch family.Type {
+		case pb.MetricType_COUNTER:
+			metric.Counter = &dto.Counter{Value: proto.Float64(m.Value)}
+		case pb.MetricType_GAUGE:
+			metric.Gauge = &dto.Gauge{Value: proto.Float64(m.Value)}
+		case pb.MetricType_UNTYPED:
+			metric.Untyped = &dto.Untyped{Value: proto.Float64(m.Value)}
+		case pb.MetricType_SUMMARY:
+			summary := &dto.Summary{SampleCount: proto.Uint64(m.SampleCount), SampleSum: proto.Float64(m.SampleSum)}
+			for _, q := range m.Quantiles {
+				summary.Quantile = append(summary.Quantile, &dto.Quantile{Quantile: proto.Float64(q.Quantile), Value: proto.Float64(q.Value)})
+			}
+			metric.Summary = summary
+		case pb.MetricType_HISTOGRAM:
+			histogram := &dto.Histogram{SampleCount: proto.Uint64(m.SampleCount), SampleSum: proto.Float64(m.SampleSum)}
+			for _, b := range m.Buckets {
+				histogram.Bucket = append(histogram.Bucket, &dto.Bucket{UpperBound: proto.Float64(b.UpperBound), CumulativeCount: proto.Uint64(b.CumulativeCount)})
+			}
+			metric.Histogram = histogram
+		}
+
+		out.Metric = append(out.Metric, metric)
+	}
+	return out
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// Connection multiplexing: `tts-client server` holds a single gRPC connection to the daemon
+// and exposes it on a Unix socket. Later invocations that find a live socket for the same
+// daemon address send their calls through it instead of opening their own TCP connection.
+
+var (
+	// muxSocket overrides the multiplexer socket path (default derived from the daemon address)
+	muxSocket string
+	// noMux disables use of a running multiplexer
+	noMux bool
+)
+
+// defaultSocketPath returns the multiplexer socket path for a daemon address
+func defaultSocketPath(address string) string {
+	name := strings.NewReplacer(":", "_", "/", "_").Replace(address)
+	return filepath.Join(os.TempDir(), fmt.Sprintf("tts-client-%s.sock", name))
+}
+
+// muxSocketPath returns the socket path to use for address
+func muxSocketPath(address string) string {
+	if muxSocket != "" {
+		return muxSocket
+	}
+	return defaultSocketPath(address)
+}
+
+// socketAlive reports whether a multiplexer is accepting connections on path
+func socketAlive(path string) bool {
+	conn, err := net.DialTimeout("unix", path, 100*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// muxTarget returns the gRPC target of a running multiplexer for address, if any
+func muxTarget(address string) (string, bool) {
+	if noMux {
+		return "", false
+	}
+	path := muxSocketPath(address)
+	if !socketAlive(path) {
+		return "", false
+	}
+	return "unix://" + path, true
+}
+
+// runMuxServer implements the `server` sub-command
+func runMuxServer(address string, args []string) {
+	fs := flag.NewFlagSet("server", flag.ExitOnError)
+	socket := fs.String("socket", muxSocketPath(address), "Unix socket to accept client connections on")
+	foreground := fs.Bool("foreground", false, "Run in the foreground instead of detaching")
+	fs.Parse(args)
+
+	if socketAlive(*socket) {
+		log.Fatalf("A multiplexer is already running on %s", *socket)
+	}
+
+	if !*foreground {
+		pid, err := detachMuxServer(address, *socket)
+		if err != nil {
+			log.Fatalf("Failed to start multiplexer: %v", err)
+		}
+		fmt.Printf("Multiplexer started (pid %d) on %s\n", pid, *socket)
+		return
+	}
+
+	// The upstream connection always goes straight to the daemon
+	creds := transportCredentials()
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+	upstream, err := grpc.NewClient(address, append(authOptions(), grpc.WithTransportCredentials(creds))...)
+	if err != nil {
+		log.Fatalf("Failed to connect to daemon at %s: %v", address, err)
+	}
+	defer upstream.Close()
+
+	// Remove a stale socket left behind by a previous multiplexer
+	os.Remove(*socket)
+	listener, err := net.Listen("unix", *socket)
+	if err != nil {
+		log.Fatalf("Failed to listen on %s: %v", *socket, err)
+	}
+	defer os.Remove(*socket)
+
+	grpcServer := newMuxServer(upstream)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		grpcServer.GracefulStop()
+	}()
+
+	log.Printf("Multiplexing %s on %s", address, *socket)
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
+
+// newMuxServer returns a gRPC server that forwards every call it receives over upstream
+func newMuxServer(upstream *grpc.ClientConn) *grpc.Server {
+	proxy := &muxProxy{upstream: upstream}
+	return grpc.NewServer(
+		grpc.UnknownServiceHandler(proxy.handle),
+		grpc.ForceServerCodec(rawCodec{}),
+	)
+}
+
+// detachMuxServer re-executes the client as a background multiplexer and returns its pid
+func detachMuxServer(address, socket string) (int, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+
+	cmd := exec.Command(executable, "-address", address, fmt.Sprintf("-tls=%v", useTLS), "-ca-cert", caCertFile,
+		"-client-cert", clientCertFile, "-client-key", clientKeyFile, "server", "-foreground", "-socket", socket)
+	// The API key goes in the environment, where other users can't see it in the process list
+	cmd.Env = append(os.Environ(), apiKeyEnv+"="+apiKey)
+	cmd.SysProcAttr = detachedProcAttr()
+	if err := cmd.Start(); err != nil {
+		return 0, err
+	}
+	pid := cmd.Process.Pid
+	cmd.Process.Release()
+
+	// Wait briefly for the socket to come up so the next invocation can use it
+	for i := 0; i < 20 && !socketAlive(socket); i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+	return pid, nil
+}
+
+// muxProxy forwards every incoming call, whatever its method, over the upstream connection
+type muxProxy struct {
+	upstream *grpc.ClientConn
+}
+
+// handle proxies a single call between a local client and the daemon
+func (m *muxProxy) handle(srv interface{}, serverStream grpc.ServerStream) error {
+	method, ok := grpc.MethodFromServerStream(serverStream)
+	if !ok {
+		return fmt.Errorf("unable to determine method")
+	}
+
+	ctx := serverStream.Context()
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = metadata.NewOutgoingContext(ctx, md.Copy())
+	}
+
+	desc := &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}
+	clientStream, err := m.upstream.NewStream(ctx, desc, method, grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		return err
+	}
+
+	// Forward client -> daemon in the background
+	go func() {
+		for {
+			frame := &rawFrame{}
+			if err := serverStream.RecvMsg(frame); err != nil {
+				clientStream.CloseSend()
+				return
+			}
+			if err := clientStream.SendMsg(frame); err != nil {
+				return
+			}
+		}
+	}()
+
+	// Forward daemon -> client until the call completes
+	headerSent := false
+	for {
+		frame := &rawFrame{}
+		err := clientStream.RecvMsg(frame)
+		if !headerSent {
+			if header, herr := clientStream.Header(); herr == nil {
+				serverStream.SendHeader(header)
+			}
+			headerSent = true
+		}
+		if err == io.EOF {
+			serverStream.SetTrailer(clientStream.Trailer())
+			return nil
+		}
+		if err != nil {
+			serverStream.SetTrailer(clientStream.Trailer())
+			return err
+		}
+		if err := serverStream.SendMsg(frame); err != nil {
+			return err
+		}
+	}
+}
+
+// rawFrame holds an encoded message that is passed through without decoding
+type rawFrame struct {
+	payload []byte
+}
+
+// rawCodec passes message bytes through untouched so the proxy works for any method
+type rawCodec struct{}
+
+// Marshal implements encoding.Codec
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	frame, ok := v.(*rawFrame)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return frame.payload, nil
+}
+
+// Unmarshal implements encoding.Codec
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	frame, ok := v.(*rawFrame)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	frame.payload = append(frame.payload[:0], data...)
+	return nil
+}
+
+// Name implements encoding.Codec; it reports "proto" so the wire content type is unchanged
+func (rawCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// countingListener counts the connections it accepts
+type countingListener struct {
+	net.Listener
+	accepted atomic.Int32
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.accepted.Add(1)
+	}
+	return conn, err
+}
+
+// diagnoseServer answers SelfDiagnose with a fixed status
+type diagnoseServer struct {
+	pb.UnimplementedTTSServiceServer
+	calls atomic.Int32
+}
+
+func (s *diagnoseServer) SelfDiagnose(ctx context.Context, req *pb.DiagnosticRequest) (*pb.DiagnosticReport, error) {
+	s.calls.Add(1)
+	return &pb.DiagnosticReport{Status: "pass"}, nil
+}
+
+func TestMuxSharesOneConnection(t *testing.T) {
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	daemonListener := &countingListener{Listener: tcp}
+	daemon := grpc.NewServer()
+	diagnose := &diagnoseServer{}
+	pb.RegisterTTSServiceServer(daemon, diagnose)
+	go daemon.Serve(daemonListener)
+	defer daemon.Stop()
+
+	upstream, err := grpc.NewClient(tcp.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer upstream.Close()
+
+	socket := filepath.Join(t.TempDir(), "mux.sock")
+	muxListener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := newMuxServer(upstream)
+	go mux.Serve(muxListener)
+	defer mux.Stop()
+
+	// Each request comes from its own connection to the socket, as separate invocations would
+	const requests = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn, err := grpc.NewClient("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			resp, err := pb.NewTTSServiceClient(conn).SelfDiagnose(ctx, &pb.DiagnosticRequest{})
+			if err != nil {
+				errs <- err
+				return
+			}
+			if resp.Status != "pass" {
+				t.Errorf("Status = %q, want pass", resp.Status)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("request through the multiplexer failed: %v", err)
+	}
+
+	if got := diagnose.calls.Load(); got != requests {
+		t.Errorf("daemon served %d calls, want %d", got, requests)
+	}
+	if got := daemonListener.accepted.Load(); got != 1 {
+		t.Errorf("daemon accepted %d TCP connections, want 1", got)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runNearDuplicates implements the `near-duplicates` sub-command
+func runNearDuplicates(address string, args []string) {
+	fs := flag.NewFlagSet("near-duplicates", flag.ExitOnError)
+	threshold := fs.Float64("threshold", 0.85, "Minimum estimated similarity (0-1) for texts to be grouped")
+	jsonOutput := fs.Bool("json", false, "Print the groups as JSON")
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	// Every entry is read, so allow more time than a single fetch
+	ctx, cancel := context.WithTimeout(context.Background(), 10*defaultTimeout)
+	defer cancel()
+
+	resp, err := client.FindNearDuplicates(ctx, &pb.NearDuplicatesRequest{Threshold: *threshold, Namespace: namespace})
+	if err != nil {
+		log.Fatalf("FindNearDuplicates failed: %v", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(resp); err != nil {
+			log.Fatalf("Failed to encode groups: %v", err)
+		}
+		return
+	}
+
+	if len(resp.Groups) == 0 {
+		fmt.Println("No near-duplicate entries found")
+		return
+	}
+	for i, group := range resp.Groups {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%d similar entries:\n", len(group.Entries))
+		for _, entry := range group.Entries {
+			fmt.Printf("  [%s] %q (%d hits, %s)\n", entry.LanguageCode, entry.Text, entry.HitCount, shortKey(entry.CacheKey))
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runPauseSynthesis implements the `pause-synthesis` sub-command
+func runPauseSynthesis(address string, args []string) {
+	fs := flag.NewFlagSet("pause-synthesis", flag.ExitOnError)
+	reason := fs.String("reason", "", "Why synthesis is paused (shown in logs and diagnostics)")
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.PauseSynthesis(ctx, &pb.PauseRequest{PauseReason: *reason})
+	if err != nil {
+		log.Fatalf("PauseSynthesis failed: %v", err)
+	}
+
+	if resp.WasPaused {
+		fmt.Println("Synthesis was already paused; reason updated")
+	} else {
+		fmt.Println("Synthesis paused; only cached audio will be served")
+	}
+}
+
+// runResumeSynthesis implements the `resume-synthesis` sub-command
+func runResumeSynthesis(address string, args []string) {
+	fs := flag.NewFlagSet("resume-synthesis", flag.ExitOnError)
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.ResumeSynthesis(ctx, &pb.ResumeRequest{})
+	if err != nil {
+		log.Fatalf("ResumeSynthesis failed: %v", err)
+	}
+
+	if resp.WasPaused {
+		fmt.Printf("Synthesis resumed after %s\n", time.Duration(resp.PausedSeconds)*time.Second)
+	} else {
+		fmt.Println("Synthesis was not paused")
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runEnqueue implements the `enqueue` sub-command
+func runEnqueue(address string, args []string) {
+	fs := flag.NewFlagSet("enqueue", flag.ExitOnError)
+	language := fs.String("lang", "en-US", "Language code")
+	priority := fs.Int("priority", 0, "Job priority (higher runs first)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: client enqueue [options] <text>\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	positional := parseInterspersed(fs, args)
+
+	if len(positional) != 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.EnqueueSynthesis(ctx, &pb.EnqueueRequest{
+		Text:         positional[0],
+		LanguageCode: *language,
+		Priority:     int32(*priority),
+	})
+	if err != nil {
+		log.Fatalf("EnqueueSynthesis failed: %v", err)
+	}
+
+	// Print only the ID so it can be captured by scripts
+	fmt.Println(resp.JobId)
+}
+
+// runJobStatus implements the `job-status` sub-command
+func runJobStatus(address string, args []string) {
+	fs := flag.NewFlagSet("job-status", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: client job-status <job id>\n")
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	job, err := client.GetJobStatus(ctx, &pb.JobStatusRequest{JobId: fs.Arg(0)})
+	if err != nil {
+		log.Fatalf("GetJobStatus failed: %v", err)
+	}
+
+	fmt.Printf("Job:      %s\n", job.JobId)
+	fmt.Printf("Status:   %s\n", job.Status)
+	fmt.Printf("Text:     [%s] %q\n", job.LanguageCode, job.Text)
+	fmt.Printf("Priority: %d\n", job.Priority)
+	fmt.Printf("Created:  %s\n", time.Unix(job.CreatedAt, 0).Format(time.DateTime))
+	if job.CompletedAt > 0 {
+		fmt.Printf("Finished: %s\n", time.Unix(job.CompletedAt, 0).Format(time.DateTime))
+	}
+	if job.ErrorMessage != "" {
+		fmt.Printf("Error:    %s\n", job.ErrorMessage)
+	}
+
+	if job.Status == "failed" {
+		os.Exit(1)
+	}
+}
+
+// runReorder implements the `reorder` sub-command
+func runReorder(address string, args []string) {
+	fs := flag.NewFlagSet("reorder", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: client reorder <job id>=<priority> [...]\n")
+	}
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	req := &pb.ReorderRequest{}
+	for _, arg := range fs.Args() {
+		id, priority, ok := strings.Cut(arg, "=")
+		if !ok {
+			log.Fatalf("Invalid update %q: expected <job id>=<priority>", arg)
+		}
+		p, err := strconv.Atoi(priority)
+		if err != nil {
+			log.Fatalf("Invalid priority in %q: %v", arg, err)
+		}
+		req.Updates = append(req.Updates, &pb.PriorityUpdate{JobId: id, NewPriority: int32(p)})
+	}
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.ReorderQueue(ctx, req)
+	if err != nil {
+		log.Fatalf("ReorderQueue failed: %v", err)
+	}
+
+	fmt.Printf("Updated %d jobs\n", resp.UpdatedCount)
+	for _, id := range resp.NotFoundIds {
+		fmt.Fprintf(os.Stderr, "Not found or no longer pending: %s\n", id)
+	}
+	if len(resp.NotFoundIds) > 0 {
+		os.Exit(1)
+	}
+}
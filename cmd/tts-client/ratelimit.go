@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runRateLimitStatus implements the `rate-limit-status` sub-command
+func runRateLimitStatus(address string, args []string) {
+	fs := flag.NewFlagSet("rate-limit-status", flag.ExitOnError)
+	waitForToken := fs.Bool("wait-for-token", false, "Wait until a request can be made immediately before reporting")
+	timeout := fs.Duration("timeout", defaultTimeout, "How long to wait for a token")
+	jsonOutput := fs.Bool("json", false, "Print the status as JSON")
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	resp, err := client.GetRateLimitStatus(ctx, &pb.RLStatusRequest{WaitForToken: *waitForToken})
+	if err != nil {
+		log.Fatalf("GetRateLimitStatus failed: %v", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(resp); err != nil {
+			log.Fatalf("Failed to encode rate limit status: %v", err)
+		}
+		return
+	}
+
+	if *waitForToken {
+		fmt.Printf("Waited %s for a token\n", time.Duration(resp.WaitedMs)*time.Millisecond)
+	}
+	fmt.Printf("Tokens available:   %.2f of %.0f\n", resp.CurrentTokens, resp.MaxTokens)
+	fmt.Printf("Refill rate:        %.2f/s\n", resp.RatePerSecond)
+	fmt.Printf("Next token in:      %s\n", time.Duration(resp.NextTokenAvailableMs)*time.Millisecond)
+	fmt.Printf("Chars synthesized:  %d today\n", resp.DailyCharsUsed)
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runRefreshVoices implements the `refresh-voices` sub-command
+func runRefreshVoices(address string, args []string) {
+	fs := flag.NewFlagSet("refresh-voices", flag.ExitOnError)
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.RefreshVoiceList(ctx, &pb.RefreshRequest{})
+	if err != nil {
+		log.Fatalf("RefreshVoiceList failed: %v", err)
+	}
+
+	fmt.Printf("Loaded %d voices covering %d locales\n", resp.VoiceCount, resp.LocaleCount)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runResynthesize implements the `resynthesize` sub-command
+func runResynthesize(address string, args []string) {
+	fs := flag.NewFlagSet("resynthesize", flag.ExitOnError)
+	language := fs.String("lang", "", "Language code whose entries are re-synthesized (required)")
+	batchSize := fs.Int("batch-size", 100, "Entries read from the cache per page")
+	qps := fs.Float64("qps", 0, "Azure requests per second for this job (default: azure.max_qps)")
+	fs.Parse(args)
+
+	if *language == "" {
+		fmt.Fprintf(os.Stderr, "Usage: client resynthesize --lang <language_code> [options]\n\nOptions:\n")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	// Re-synthesizing a language can take a long time; run until done or interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	stream, err := client.ResynthesizeAll(ctx, &pb.ResynthesizeRequest{
+		LanguageCode: *language,
+		BatchSize:    int32(*batchSize),
+		RateLimitQps: *qps,
+		Namespace:    namespace,
+	})
+	if err != nil {
+		log.Fatalf("Resynthesize failed: %v", err)
+	}
+
+	last := &pb.ResynthesizeProgress{}
+	for {
+		progress, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break // Interrupted; report what was done
+			}
+			log.Fatalf("Resynthesize failed: %v", err)
+		}
+		last = progress
+		if progress.Error != "" {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", progress.CacheKey, progress.Error)
+		}
+		logInfo("%d/%d %s (%dms)\n", progress.Index, progress.Total, progress.CacheKey, progress.DurationMs)
+	}
+
+	fmt.Printf("Re-synthesized %d of %d entries, %d failed\n", last.Resynthesized, last.Total, last.Failed)
+	if last.Failed > 0 {
+		os.Exit(1)
+	}
+}
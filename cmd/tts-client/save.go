@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runSave implements the `save` sub-command
+func runSave(address string, args []string) {
+	fs := flag.NewFlagSet("save", flag.ExitOnError)
+	output := fs.String("output", "", "File to write on the daemon's machine (must be under server.allowed_save_directories)")
+	language := fs.String("lang", "en-US", "Language code (e.g., en-US, fr-FR, es-ES)")
+	format := fs.String("format", "mp3", "Audio format to synthesize and cache (mp3, wav, opus, ogg-opus, ogg-opus-24k)")
+	force := fs.Bool("force", false, "Force refresh from Azure, bypassing cache")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: client save --output <path> [options] <text>\n\n")
+		fmt.Fprintf(os.Stderr, "The daemon writes the file itself, so the path refers to the daemon's file system.\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	positional := parseInterspersed(fs, args)
+
+	if len(positional) != 1 || *output == "" {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	outputFormat, err := parseOutputFormat(*format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.FetchAndSave(ctx, &pb.FetchAndSaveRequest{
+		Request: &pb.TTSRequest{
+			Text:         positional[0],
+			LanguageCode: *language,
+			ForceRefresh: *force,
+			OutputFormat: outputFormat,
+			Namespace:    namespace,
+			Tags:         tags,
+			TtlSeconds:   ttlSeconds,
+		},
+		OutputPath: *output,
+	})
+	if err != nil {
+		log.Fatalf("FetchAndSave failed: %v", err)
+	}
+
+	source := "fetched from Azure"
+	if resp.WasCached {
+		source = "from cache"
+	}
+	fmt.Printf("Saved %d bytes to %s (%s)\n", resp.AudioSize, resp.OutputPath, source)
+}
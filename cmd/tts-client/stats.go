@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runStats implements the `stats` sub-command
+func runStats(address string, args []string) {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Print the stats as JSON")
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.GetCacheStats(ctx, &pb.GetCacheStatsRequest{Namespace: namespace})
+	if err != nil {
+		log.Fatalf("GetCacheStats failed: %v", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(resp); err != nil {
+			log.Fatalf("Failed to encode stats: %v", err)
+		}
+		return
+	}
+
+	sizeMB := float64(resp.TotalSizeBytes) / (1024 * 1024)
+	if resp.MaxSizeMb > 0 {
+		fmt.Printf("Entries: %d, %.2fMB/%.2fMB (%.0f%% used)\n", resp.TotalClips, sizeMB, resp.MaxSizeMb, resp.UsagePercent)
+	} else {
+		fmt.Printf("Entries: %d, %.2fMB\n", resp.TotalClips, sizeMB)
+	}
+	if resp.ExpiredClips > 0 {
+		fmt.Printf("Expired: %d, to be deleted by the next cleanup\n", resp.ExpiredClips)
+	}
+	rate1m := fmt.Sprintf("%.1f%%", resp.HitRate_1M*100)
+	if resp.HitRate_1MNote != "" {
+		rate1m = resp.HitRate_1MNote
+	}
+	fmt.Printf("Hit rate: %s (1m), %.1f%% (5m), %.1f%% (total)\n", rate1m, resp.HitRate_5M*100, resp.HitRateTotal*100)
+	if resp.SynthesisPaused {
+		fmt.Printf("Synthesis paused: %s\n", resp.PauseReason)
+	}
+
+	if len(resp.Languages) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Printf("%-10s %8s %12s %-19s %-19s %7s %7s %7s\n", "LANGUAGE", "ENTRIES", "BYTES", "OLDEST", "NEWEST", "HIT 1M", "HIT 5M", "HIT ALL")
+	for _, l := range resp.Languages {
+		fmt.Printf("%-10s %8d %12d %-19s %-19s %6.1f%% %6.1f%% %6.1f%%\n", l.LanguageCode, l.Count, l.SizeBytes,
+			time.Unix(l.OldestEntry, 0).Format(time.DateTime), time.Unix(l.NewestEntry, 0).Format(time.DateTime),
+			l.HitRate_1M*100, l.HitRate_5M*100, l.HitRateTotal*100)
+	}
+}
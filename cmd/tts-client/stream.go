@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"com.biesnecker/tts-daemon/internal/player"
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runStream implements the `stream` sub-command
+func runStream(address string, args []string) {
+	fs := flag.NewFlagSet("stream", flag.ExitOnError)
+	output := fs.String("output", "", "File to write the audio to")
+	play := fs.Bool("play", false, "Play the audio once it has arrived")
+	file := fs.String("file", "", "Read the text from a file (- for stdin) instead of the command line")
+	language := fs.String("lang", "en-US", "Language code (e.g., en-US, fr-FR, es-ES)")
+	format := fs.String("format", "mp3", "Audio format to synthesize and cache (mp3, wav, opus, ogg-opus, ogg-opus-24k)")
+	force := fs.Bool("force", false, "Force refresh from Azure, bypassing cache")
+	tempo := fs.Float64("tempo", 1.0, "Playback tempo factor without pitch change (0.5-2.0)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: client stream (--output <path> | --play) [options] (<text> | --file <path>)\n\n")
+		fmt.Fprintf(os.Stderr, "Receives the audio in chunks, for texts whose audio is too large for one message.\n\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	positional := parseInterspersed(fs, args)
+
+	if (len(positional) == 1) == (*file != "") || (*output == "" && !*play) {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	text := ""
+	if *file != "" {
+		data, err := readText(*file)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", *file, err)
+		}
+		text = string(bytes.TrimSpace(data))
+	} else {
+		text = positional[0]
+	}
+
+	outputFormat, err := parseOutputFormat(*format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	stream, err := client.StreamTTS(ctx, &pb.TTSRequest{
+		Text:         text,
+		LanguageCode: *language,
+		ForceRefresh: *force,
+		OutputFormat: outputFormat,
+		Namespace:    namespace,
+		Tags:         tags,
+		TtlSeconds:   ttlSeconds,
+	})
+	if err != nil {
+		log.Fatalf("StreamTTS failed: %v", err)
+	}
+
+	var audio bytes.Buffer
+	var cached bool
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			log.Fatalf("StreamTTS ended after %d bytes without the last chunk", audio.Len())
+		}
+		if err != nil {
+			log.Fatalf("StreamTTS failed: %v", err)
+		}
+		if chunk.Offset != int64(audio.Len()) {
+			log.Fatalf("StreamTTS sent a chunk at offset %d, expected %d", chunk.Offset, audio.Len())
+		}
+		audio.Write(chunk.AudioData)
+		cached = chunk.Cached
+		if chunk.IsLast {
+			break
+		}
+	}
+
+	logInfo("Audio size: %d bytes\n", audio.Len())
+	if cached {
+		logInfo("(from cache)\n")
+	} else {
+		logInfo("(fetched from Azure)\n")
+	}
+
+	if *output != "" {
+		if err := os.WriteFile(*output, audio.Bytes(), 0644); err != nil {
+			log.Fatalf("Failed to write %s: %v", *output, err)
+		}
+		logInfo("Saved to %s\n", *output)
+	}
+
+	if *play {
+		audioPlayer := newPlayer()
+		defer audioPlayer.Close()
+
+		if err := audioPlayer.Play(audio.Bytes(), player.WithTempo(*tempo)); err != nil {
+			log.Fatalf("Playback failed: %v", err)
+		}
+		logInfo("Audio played successfully\n")
+	}
+}
+
+// readText reads a whole file, or stdin if path is "-"
+func readText(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runVerify implements the `verify` sub-command
+func runVerify(address string, args []string) {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Print the report as JSON")
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	// Every entry is read, so allow more time than a single fetch
+	ctx, cancel := context.WithTimeout(context.Background(), 10*defaultTimeout)
+	defer cancel()
+
+	report, err := client.VerifyIntegrity(ctx, &pb.VerifyIntegrityRequest{Namespace: namespace})
+	if err != nil {
+		log.Fatalf("VerifyIntegrity failed: %v", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Failed to encode report: %v", err)
+		}
+	} else {
+		fmt.Printf("Checked %d entries\n", report.EntriesChecked)
+		for _, group := range report.Collisions {
+			fmt.Printf("\nCollision: %s is shared by %d entries\n", group.CacheKey, len(group.Entries))
+			for _, entry := range group.Entries {
+				fmt.Printf("  [%s] %q\n", entry.LanguageCode, entry.Text)
+			}
+		}
+		for _, m := range report.Mismatches {
+			fmt.Printf("\nKey mismatch: [%s] %q\n  stored   %s\n  expected %s\n", m.LanguageCode, m.Text, m.CacheKey, m.ExpectedKey)
+		}
+		if report.Ok {
+			fmt.Println("No integrity problems found")
+		}
+	}
+
+	if !report.Ok {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runVerifyCache implements the -verify flag, checking the daemon's database for corruption
+// and, with repair, deleting the corrupt entries found
+func runVerifyCache(address string, repair bool) {
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	// The integrity check reads the whole database file
+	ctx, cancel := context.WithTimeout(context.Background(), 10*defaultTimeout)
+	defer cancel()
+
+	resp, err := client.VerifyCache(ctx, &pb.VerifyCacheRequest{Repair: repair})
+	if err != nil {
+		log.Fatalf("VerifyCache failed: %v", err)
+	}
+
+	for _, message := range resp.IntegrityErrors {
+		fmt.Printf("Integrity check: %s\n", message)
+	}
+	fmt.Printf("Decoded %d entries, %d corrupt\n", resp.EntriesChecked, resp.CorruptEntries)
+	for _, key := range resp.CorruptKeys {
+		fmt.Printf("  %s\n", key)
+	}
+	if extra := resp.CorruptEntries - int64(len(resp.CorruptKeys)); extra > 0 {
+		fmt.Printf("  ... and %d more\n", extra)
+	}
+
+	switch {
+	case resp.IsHealthy:
+		fmt.Println("Cache is healthy")
+	case repair:
+		fmt.Printf("Deleted %d corrupt entries\n", resp.RepairedCount)
+	case resp.CorruptEntries > 0:
+		fmt.Println("Run with -repair to delete the corrupt entries")
+	}
+
+	if !resp.IsHealthy {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runVoiceHistory implements the `voice-history` sub-command
+func runVoiceHistory(address string, args []string) {
+	fs := flag.NewFlagSet("voice-history", flag.ExitOnError)
+	language := fs.String("lang", "", "Only show changes for this locale (default: all)")
+	limit := fs.Int("limit", 0, "Show at most this many of the most recent changes (default: all)")
+	jsonOutput := fs.Bool("json", false, "Print the changes as JSON")
+	fs.Parse(args)
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := client.GetVoiceChangeHistory(ctx, &pb.HistoryRequest{
+		LanguageCode: *language,
+		Limit:        int32(*limit),
+	})
+	if err != nil {
+		log.Fatalf("GetVoiceChangeHistory failed: %v", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(resp); err != nil {
+			log.Fatalf("Failed to encode history: %v", err)
+		}
+		return
+	}
+
+	if len(resp.Changes) == 0 {
+		fmt.Println("No voice changes recorded")
+		return
+	}
+	for _, change := range resp.Changes {
+		fmt.Printf("%s  %-8s %s -> %s (%d cached entries use the old voice)\n",
+			time.Unix(change.DetectedAt, 0).Format(time.RFC3339), change.Locale,
+			change.OldVoice, change.NewVoice, change.OldVoiceEntries)
+	}
+}
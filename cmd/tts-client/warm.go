@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// warmItem is a line of a -warm file
+type warmItem struct {
+	Text     string `json:"text"`
+	Language string `json:"language"`
+}
+
+// runWarm implements the -warm flag, caching the texts listed in path, a file of JSON objects
+// one per line ("-" for stdin). Objects without a language use defaultLanguage.
+func runWarm(address, path, defaultLanguage string) {
+	lines, err := readLines(path)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", path, err)
+	}
+	if len(lines) == 0 {
+		log.Fatalf("%s lists no texts", path)
+	}
+
+	req := &pb.WarmCacheRequest{Requests: make([]*pb.TTSRequest, len(lines))}
+	for i, line := range lines {
+		var item warmItem
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			log.Fatalf("%s line %d: %v", path, i+1, err)
+		}
+		if item.Text == "" {
+			log.Fatalf("%s line %d: text is required", path, i+1)
+		}
+		if item.Language == "" {
+			item.Language = defaultLanguage
+		}
+		req.Requests[i] = &pb.TTSRequest{
+			Text:         item.Text,
+			LanguageCode: item.Language,
+			Namespace:    namespace,
+			Tags:         tags,
+			TtlSeconds:   ttlSeconds,
+		}
+	}
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	// Warming can take a while; run until done or interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	stream, err := client.WarmCache(ctx, req)
+	if err != nil {
+		log.Fatalf("WarmCache failed: %v", err)
+	}
+
+	last := &pb.WarmCacheProgress{Total: int64(len(lines))}
+	for {
+		progress, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Fatalf("WarmCache failed: %v", err)
+		}
+		last = progress
+		if progress.LastError != "" {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", progress.LastError)
+		}
+		logInfo("%d/%d done, cached=%d azure=%d errors=%d\n",
+			progress.Done, progress.Total, progress.CachedHits, progress.AzureFetches, progress.Errors)
+	}
+
+	fmt.Printf("Warmed %d texts: %d already cached, %d fetched from Azure, %d failed\n",
+		last.Done, last.CachedHits, last.AzureFetches, last.Errors)
+	if last.Errors > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// runWatch implements the `watch` sub-command
+func runWatch(address string, args []string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	language := fs.String("lang", "", "Only show events for this language code")
+	events := fs.String("events", "", "Comma-separated event types to show: put, delete (default: all)")
+	jsonOutput := fs.Bool("json", false, "Print one JSON object per event")
+	fs.Parse(args)
+
+	req := &pb.WatchRequest{FilterLanguageCode: *language, Namespace: namespace}
+	if *events != "" {
+		for _, t := range strings.Split(*events, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				req.EventTypes = append(req.EventTypes, t)
+			}
+		}
+	}
+
+	client, pool := mustConnect(address)
+	defer pool.Close()
+
+	// Watch until interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	stream, err := client.WatchCache(ctx, req)
+	if err != nil {
+		log.Fatalf("WatchCache failed: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) || ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Fatalf("WatchCache failed: %v", err)
+		}
+
+		if *jsonOutput {
+			if err := encoder.Encode(event); err != nil {
+				log.Fatalf("Failed to encode event: %v", err)
+			}
+			continue
+		}
+
+		line := fmt.Sprintf("%s %-6s %-6s %s",
+			time.Unix(event.Timestamp, 0).Format(time.TimeOnly), event.EventType, event.LanguageCode, shortKey(event.CacheKey))
+		if event.AudioSize > 0 {
+			line += fmt.Sprintf(" %d bytes", event.AudioSize)
+		}
+		fmt.Println(line)
+	}
+}
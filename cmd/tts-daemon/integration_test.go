@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/config"
+	"com.biesnecker/tts-daemon/internal/daemon"
+	"com.biesnecker/tts-daemon/internal/tts"
+	pb "com.biesnecker/tts-daemon/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// integrationConfigEnv names a config file to run the integration tests with instead of the
+// built-in one. The tests are skipped unless it sets azure.mock: true, so they never call Azure.
+const integrationConfigEnv = "TTS_DAEMON_INTEGRATION_CONFIG"
+
+// integrationConfig loads the config the daemon stack is started with, using a fresh database
+func integrationConfig(t *testing.T) *config.Config {
+	t.Helper()
+	path := os.Getenv(integrationConfigEnv)
+	if path == "" {
+		audioDir, err := filepath.Abs("../../testdata/mock_audio")
+		if err != nil {
+			t.Fatal(err)
+		}
+		path = filepath.Join(t.TempDir(), "config.yaml")
+		yaml := fmt.Sprintf("azure:\n  mock: true\n  mock_audio_dir: %q\n", audioDir)
+		if err := os.WriteFile(path, []byte(yaml), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Azure.Mock {
+		t.Skipf("%s doesn't set azure.mock: true", path)
+	}
+	cfg.Database.Path = filepath.Join(t.TempDir(), "cache.db")
+	return cfg
+}
+
+// startDaemon runs the daemon's cache, service and gRPC server for cfg, as main does, and returns
+// a client connected to it
+func startDaemon(t *testing.T, cfg *config.Config) pb.TTSServiceClient {
+	t.Helper()
+	evictionPolicy, err := tts.NewEvictionPolicy(cfg.Database.EvictionPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := tts.NewCache(cfg.Database.Path, cfg.Database.Compression, cfg.Database.CompressionLevel, cfg.Database.MaxSizeMB, evictionPolicy, cfg.Database.LanguageQuotas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cache.Close() })
+
+	provider, _, _, closeProvider, err := newProvider(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeProvider)
+	if err := provider.FetchVoiceList(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	service := tts.NewService(cache, provider)
+	t.Cleanup(func() { service.Close() })
+	service.SetAudioValidation(false)
+
+	server := daemon.NewServer(service, cfg)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(server.Authenticate, server.CheckNamespaces, server.TrackRequests, server.LogRequests),
+		grpc.ChainStreamInterceptor(server.AuthenticateStreams, server.CheckNamespacesStreams, server.TrackStreams),
+	)
+	pb.RegisterTTSServiceServer(grpcServer, server)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewTTSServiceClient(conn)
+}
+
+func TestMockDaemon(t *testing.T) {
+	cfg := integrationConfig(t)
+	client := startDaemon(t, cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	recorded, err := os.ReadFile(filepath.Join(cfg.Azure.MockAudioDir, "en-US.mp3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Requests run in order against the same daemon
+	tests := []struct {
+		name         string
+		req          *pb.TTSRequest
+		wantCached   bool
+		wantFallback string // Locale whose voice is used instead of the requested one's
+		wantErr      bool
+	}{
+		{"first request synthesizes", &pb.TTSRequest{Text: "Hello world", LanguageCode: "en-US"}, false, "", false},
+		{"repeat is cached", &pb.TTSRequest{Text: "Hello world", LanguageCode: "en-US"}, true, "", false},
+		{"normalized text is cached", &pb.TTSRequest{Text: "  hello   WORLD ", LanguageCode: "en-US"}, true, "", false},
+		{"force refresh synthesizes", &pb.TTSRequest{Text: "Hello world", LanguageCode: "en-US", ForceRefresh: true}, false, "", false},
+		{"other language", &pb.TTSRequest{Text: "Hello world", LanguageCode: "fr-FR"}, false, "", false},
+		{"language without a mock voice", &pb.TTSRequest{Text: "Hello world", LanguageCode: "ko-KR"}, false, "en-US", false},
+		{"missing text", &pb.TTSRequest{LanguageCode: "en-US"}, false, "", true},
+		{"missing language", &pb.TTSRequest{Text: "Hello world"}, false, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.FetchTTS(ctx, tt.req)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("FetchTTS succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Cached != tt.wantCached {
+				t.Errorf("cached = %v, want %v", resp.Cached, tt.wantCached)
+			}
+			if resp.VoiceFallbackLocale != tt.wantFallback {
+				t.Errorf("voice fallback locale = %q, want %q", resp.VoiceFallbackLocale, tt.wantFallback)
+			}
+			if resp.CacheKey == "" || resp.AudioSize != int64(len(resp.AudioData)) {
+				t.Errorf("cache key %q, audio size %d for %d bytes", resp.CacheKey, resp.AudioSize, len(resp.AudioData))
+			}
+			if tt.req.LanguageCode == "en-US" && !bytes.Equal(resp.AudioData, recorded) {
+				t.Error("audio isn't the recorded en-US audio")
+			}
+		})
+	}
+
+	stats, err := client.GetCacheStats(ctx, &pb.GetCacheStatsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalClips != 3 {
+		t.Errorf("cache has %d entries, want 3", stats.TotalClips)
+	}
+
+	locales, err := client.ListLocales(ctx, &pb.ListLocalesRequest{HasAzureVoiceFilter: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locales.Locales) != 9 {
+		t.Errorf("%d locales have mock voices, want 9", len(locales.Locales))
+	}
+}
+
+func TestMockDaemonExportsMetrics(t *testing.T) {
+	client := startDaemon(t, integrationConfig(t))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// No other test synthesizes de-DE, so its series counts only this request
+	if _, err := client.FetchTTS(ctx, &pb.TTSRequest{Text: "Guten Tag", LanguageCode: "de-DE"}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.ExportMetrics(ctx, &pb.MetricsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, family := range resp.Families {
+		if family.Name != "tts_requests_total" {
+			continue
+		}
+		if family.Type != pb.MetricType_COUNTER {
+			t.Errorf("tts_requests_total is a %v, want a counter", family.Type)
+		}
+		for _, metric := range family.Metrics {
+			labels := make(map[string]string)
+			for _, label := range metric.Labels {
+				labels[label.Name] = label.Value
+			}
+			if labels["language"] == "de-DE" && labels["source"] == "azure" && labels["status"] == "ok" {
+				if metric.Value != 1 {
+					t.Errorf("tts_requests_total for the request = %v, want 1", metric.Value)
+				}
+				return
+			}
+		}
+		t.Fatalf("tts_requests_total has no series for the request: %v", family.Metrics)
+	}
+	t.Fatal("tts_requests_total isn't exported")
+}
+
+func TestMockDaemonSamplesRequestLogs(t *testing.T) {
+	cfg := integrationConfig(t)
+	cfg.Server.RequestLogSamplingRate = 0.1
+	client := startDaemon(t, cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// The default text logger writes through the standard log package
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	const requests = 1000
+	for i := 0; i < requests; i++ {
+		if _, err := client.FetchTTS(ctx, &pb.TTSRequest{Text: "Hello world", LanguageCode: "en-US"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Failed requests are logged whether they were sampled or not
+	const failures = 20
+	for i := 0; i < failures; i++ {
+		if _, err := client.FetchTTS(ctx, &pb.TTSRequest{LanguageCode: "en-US"}); err == nil {
+			t.Fatal("FetchTTS without text succeeded")
+		}
+	}
+
+	var logged, failed int
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		switch {
+		case strings.Contains(line, "Error: FetchTTS: error="):
+			failed++
+		case strings.Contains(line, "FetchTTS: "):
+			logged++
+			if !strings.Contains(line, "request_sampled=true") {
+				t.Errorf("a sampled request's log line doesn't say so: %s", line)
+			}
+		}
+	}
+	if logged < 50 || logged > 150 {
+		t.Errorf("%d of %d requests logged at a 10%% sampling rate, want 100 ± 50", logged, requests)
+	}
+	if failed != failures {
+		t.Errorf("%d of %d failed requests logged, want all of them", failed, failures)
+	}
+}
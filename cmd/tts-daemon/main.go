@@ -1,26 +1,55 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"text/tabwriter"
+	"time"
 
 	pb "com.biesnecker/tts-daemon/proto"
 	"com.biesnecker/tts-daemon/internal/config"
 	"com.biesnecker/tts-daemon/internal/daemon"
+	"com.biesnecker/tts-daemon/internal/logging"
+	"com.biesnecker/tts-daemon/internal/tracing"
 	"com.biesnecker/tts-daemon/internal/tts"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
 	// Parse command line flags
 	configPath := flag.String("config", "", "Path to configuration file (default: ~/.tts-daemon/config.yaml)")
+	acme := flag.Bool("acme", false, "Serve over TLS with a Let's Encrypt certificate for server.tls.acme_domain")
+	generateConfig := flag.Bool("generate-config", false, "Print a configuration file with every default value and exit")
+	generateCert := flag.String("generate-cert", "", "Write a self-signed certificate for these comma-separated hosts to server.tls.cert_file and key_file, and exit")
+	output := flag.String("output", "", "With -generate-config, write the file here instead of stdout")
+	listEnv := flag.Bool("list-env", false, "List the environment variables that override configuration settings and exit")
 	flag.Parse()
 
+	if *listEnv {
+		printEnvVars()
+		return
+	}
+
+	if *generateConfig {
+		if err := writeDefaultConfig(*output); err != nil {
+			log.Fatalf("Failed to generate configuration: %v", err)
+		}
+		return
+	}
+
 	// Load configuration
 	var cfg *config.Config
 	var err error
@@ -33,29 +62,160 @@ func main() {
 		*configPath = defaultPath
 	}
 
+	// `tts-daemon validate` checks the configuration without starting the daemon
+	if flag.Arg(0) == "validate" {
+		os.Exit(runValidate(*configPath, flag.Args()[1:]))
+	}
+
 	cfg, err = config.Load(*configPath)
 	if err != nil {
 		log.Fatalf("Failed to load configuration from %s: %v", *configPath, err)
 	}
+	if err := logging.Setup(cfg.Server.LogFormat); err != nil {
+		log.Fatalf("Failed to set up logging: %v", err)
+	}
 
 	log.Printf("Configuration loaded from %s", *configPath)
+
+	if *generateCert != "" {
+		var hosts []string
+		for _, host := range strings.Split(*generateCert, ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				hosts = append(hosts, host)
+			}
+		}
+		if err := daemon.GenerateSelfSignedCert(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile, hosts); err != nil {
+			log.Fatalf("Failed to generate certificate: %v", err)
+		}
+		log.Printf("Wrote a self-signed certificate for %s to %s (key: %s)", strings.Join(hosts, ", "), cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		return
+	}
 	log.Printf("Azure: region=%s, rate_limit=%.1fqps", cfg.Azure.Region, cfg.Azure.MaxQPS)
 	log.Printf("Cache: path=%s", cfg.Database.Path)
-	log.Printf("Cache: compression=%v", cfg.Database.Compression)
+	switch cfg.Database.Compression {
+	case tts.CompressionZstd:
+		level := cfg.Database.CompressionLevel
+		if level == 0 {
+			level = tts.DefaultCompressionLevel
+		}
+		log.Printf("Cache: compression=zstd, level=%d", level)
+	case tts.CompressionNone:
+		log.Printf("Cache: compression=none")
+	default:
+		log.Printf("Cache: compression=%s", cfg.Database.Compression)
+	}
 	if cfg.Database.MaxSizeMB > 0 {
-		log.Printf("Cache: LRU eviction enabled, max_size=%dMB", cfg.Database.MaxSizeMB)
+		log.Printf("Cache: %s eviction enabled, max_size=%dMB", strings.ToUpper(cfg.Database.EvictionPolicy), cfg.Database.MaxSizeMB)
 	} else {
-		log.Printf("Cache: LRU eviction disabled (unlimited size)")
+		log.Printf("Cache: eviction disabled (unlimited size)")
+	}
+	for lang, mb := range cfg.Database.LanguageQuotas {
+		log.Printf("Cache: quota for %s, max_size=%dMB", lang, mb)
+	}
+	if err := tts.ValidateMP3Quality(cfg.Audio.SampleRateHz, cfg.Audio.Bitrate); err != nil {
+		log.Fatalf("Invalid audio.bitrate or audio.sample_rate_hz: %v", err)
+	}
+	if cfg.Server.RequestLogSamplingRate < 1 {
+		log.Printf("Server: logging %.1f%% of requests, plus errors and requests slower than %dms",
+			cfg.Server.RequestLogSamplingRate*100, cfg.Server.SlowRequestThresholdMs)
+	}
+	if cfg.Audio.InjectBreaks || cfg.Audio.BreakAtNewlines {
+		log.Printf("Synthesis: inject_breaks=%v, break_at_newlines=%v", cfg.Audio.InjectBreaks, cfg.Audio.BreakAtNewlines)
+	}
+	if cfg.Audio.RestorePunctuation {
+		log.Printf("Synthesis: restore_punctuation=true, language=%q", cfg.Audio.RestorePunctuationLanguage)
 	}
 	log.Printf("Server: listening on %s:%d", cfg.Server.Address, cfg.Server.Port)
 
 	// Initialize cache
-	cache, err := tts.NewCache(cfg.Database.Path, cfg.Database.Compression, cfg.Database.MaxSizeMB)
+	evictionPolicy, err := tts.NewEvictionPolicy(cfg.Database.EvictionPolicy)
+	if err != nil {
+		log.Fatalf("Invalid database.eviction_policy: %v", err)
+	}
+	cache, err := tts.NewCache(cfg.Database.Path, cfg.Database.Compression, cfg.Database.CompressionLevel, cfg.Database.MaxSizeMB, evictionPolicy, cfg.Database.LanguageQuotas)
 	if err != nil {
 		log.Fatalf("Failed to initialize cache: %v", err)
 	}
 	defer cache.Close()
 
+	if cfg.Database.ReplayLogPath != "" {
+		if err := cache.SetReplayLog(cfg.Database.ReplayLogPath, cfg.Database.ReplayLogMaxMB); err != nil {
+			log.Fatalf("Failed to open replay log: %v", err)
+		}
+		log.Printf("Cache: replay_log=%s, max=%dMB", cfg.Database.ReplayLogPath, cfg.Database.ReplayLogMaxMB)
+	}
+
+	if cfg.Database.FingerprintDedup {
+		if err := cache.BuildFingerprintIndex(); err != nil {
+			log.Fatalf("Failed to build fingerprint index: %v", err)
+		}
+		cache.SetFingerprintDedup(true)
+		log.Printf("Cache: fingerprint deduplication enabled")
+	}
+
+	if cfg.Database.DeltaCompression {
+		if err := cache.SetDeltaCompression(true); err != nil {
+			log.Fatalf("Failed to enable delta compression: %v", err)
+		}
+		log.Printf("Cache: delta compression enabled")
+	}
+
+	if cfg.Database.HotCacheBackend != "" {
+		if err := cache.SetHotCache(cfg.Database.HotCacheBackend, cfg.Database.HotCacheThresholdAccessesPerDay); err != nil {
+			log.Fatalf("Failed to enable hot cache: %v", err)
+		}
+	}
+
+	if cfg.Database.MemoryCacheEntries > 0 {
+		if err := cache.SetMemoryCache(cfg.Database.MemoryCacheEntries); err != nil {
+			log.Fatalf("Failed to enable memory cache: %v", err)
+		}
+	}
+
+	if cfg.Database.RedisURL != "" {
+		redisCache, err := tts.NewRedisCache(cfg.Database.RedisURL, cfg.Database.RedisNamespace)
+		if err != nil {
+			log.Fatalf("Failed to enable Redis: %v", err)
+		}
+		cache.SetRedis(redisCache)
+		log.Printf("Cache: sharing entries through Redis, namespace=%s", cfg.Database.RedisNamespace)
+	}
+
+	if cfg.Database.S3Bucket != "" {
+		s3Cache, err := tts.NewS3Cache(context.Background(), cfg.Database.S3Bucket, cfg.Database.S3Prefix, cfg.Database.S3Region, cfg.Database.S3Endpoint)
+		if err != nil {
+			log.Fatalf("Failed to enable S3: %v", err)
+		}
+		cache.SetS3(s3Cache)
+		log.Printf("Cache: archiving entries in S3 bucket %s", cfg.Database.S3Bucket)
+	}
+
+	// Entries stored with their own TTL expire even without a global one
+	cache.SetTTL(time.Duration(cfg.Database.TTLDays)*24*time.Hour, time.Duration(cfg.Database.CleanupIntervalMinutes)*time.Minute)
+	if cfg.Database.TTLDays > 0 {
+		log.Printf("Cache: entries expire after %d days, cleanup every %dm", cfg.Database.TTLDays, cfg.Database.CleanupIntervalMinutes)
+	}
+
+	if cfg.Database.VacuumIntervalHours > 0 {
+		if err := cache.SetIncrementalVacuum(time.Duration(cfg.Database.VacuumIntervalHours) * time.Hour); err != nil {
+			log.Fatalf("Failed to enable incremental vacuum: %v", err)
+		}
+		log.Printf("Cache: incremental vacuum every %dh", cfg.Database.VacuumIntervalHours)
+	}
+
+	if cfg.Server.AlertWebhookURL != "" {
+		if cfg.Database.MaxSizeMB <= 0 {
+			log.Printf("Warning: server.alert_webhook_url is set but database.max_size_mb is unlimited, alerts are disabled")
+		}
+		cache.SetAlertWebhook(&tts.AlertWebhook{
+			URL:              cfg.Server.AlertWebhookURL,
+			Method:           cfg.Server.AlertWebhookMethod,
+			ThresholdPercent: cfg.Server.AlertCacheThresholdPercent,
+			Cooldown:         time.Duration(cfg.Server.AlertCooldownMinutes) * time.Minute,
+		})
+		log.Printf("Alerts: cache_threshold=%.1f%%, cooldown=%dm, method=%s", cfg.Server.AlertCacheThresholdPercent, cfg.Server.AlertCooldownMinutes, cfg.Server.AlertWebhookMethod)
+	}
+
 	// Print cache stats
 	stats, err := cache.GetStats()
 	if err != nil {
@@ -67,32 +227,161 @@ func main() {
 		} else {
 			log.Printf("Cache: %d entries, %.2fMB", stats["total_clips"], stats["size_mb"])
 		}
+		if expired, _ := stats["expired_clips"].(int64); expired > 0 {
+			log.Printf("Cache: %d entries expired, to be deleted by the next cleanup", expired)
+		}
 	}
 
-	// Initialize Azure TTS client with rate limiting
-	azureClient := tts.NewAzureClient(cfg.Azure.SubscriptionKey, cfg.Azure.Region, cfg.Azure.MaxQPS, cfg.Azure.Voices)
-	if len(cfg.Azure.Voices) > 0 {
-		log.Printf("Azure: custom voice mappings configured:")
-		for locale, voice := range cfg.Azure.Voices {
+	// Initialize the TTS provider with rate limiting
+	provider, providerName, customVoices, closeProvider, err := newProvider(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize the provider: %v", err)
+	}
+	defer closeProvider()
+	if len(customVoices) > 0 {
+		log.Printf("%s: custom voice mappings configured:", providerName)
+		for locale, voice := range customVoices {
 			log.Printf("  %s -> %s", locale, voice)
 		}
 	}
 
-	// Fetch available voices from Azure
-	log.Printf("Fetching available voices from Azure...")
-	if err := azureClient.FetchVoiceList(); err != nil {
-		log.Fatalf("Failed to fetch voice list from Azure: %v", err)
+	if cfg.Azure.BatchSynthesis {
+		if batchProvider, ok := provider.(tts.BatchProvider); ok {
+			provider = tts.NewRequestBatcher(batchProvider, time.Duration(cfg.Azure.BatchWindowMs)*time.Millisecond)
+			log.Printf("Azure: batching WAV requests within %dms", cfg.Azure.BatchWindowMs)
+		}
+	}
+
+	// Fetch available voices from the provider
+	log.Printf("Fetching available voices from %s...", providerName)
+	if err := provider.FetchVoiceList(context.Background()); err != nil {
+		log.Fatalf("Failed to fetch voice list from %s: %v", providerName, err)
 	}
 
 	// Initialize TTS service
-	ttsService := tts.NewService(cache, azureClient)
+	ttsService := tts.NewService(cache, provider)
 	defer ttsService.Close()
+	ttsService.SetLanguageFallbackChains(cfg.Azure.LanguageFallbackChains)
+	if cfg.Azure.VoiceCacheRefreshIntervalH > 0 {
+		ttsService.StartVoiceRefresh(time.Duration(cfg.Azure.VoiceCacheRefreshIntervalH) * time.Hour)
+	}
+	if cfg.Database.CompactSchedule != "" {
+		idleThreshold := time.Duration(cfg.Database.CompactIdleThresholdS) * time.Second
+		if err := ttsService.StartCompactionSchedule(cfg.Database.CompactSchedule, idleThreshold); err != nil {
+			log.Fatalf("Failed to schedule compaction: %v", err)
+		}
+		log.Printf("Cache: compaction scheduled for %q once idle for %s", cfg.Database.CompactSchedule, idleThreshold)
+	}
+	if err := ttsService.SetLanguageFamilyFallback(cfg.Azure.LanguageFamilyFallback); err != nil {
+		log.Fatalf("Failed to set up language family fallback: %v", err)
+	}
+	if cfg.Azure.PreprocessWithOpenAI {
+		ttsService.SetTextPreprocessor(&tts.OpenAIPreprocessor{
+			Endpoint: cfg.Azure.OpenAIPreprocessEndpoint,
+			APIKey:   cfg.Azure.OpenAIAPIKey,
+			Model:    cfg.Azure.PreprocessModel,
+		})
+		log.Printf("Azure: preprocessing text with %s at %s", cfg.Azure.PreprocessModel, cfg.Azure.OpenAIPreprocessEndpoint)
+	}
+	// The recorded mock audio is short silence, which validation would reject
+	ttsService.SetAudioValidation(!cfg.Azure.Mock)
+
+	// Note default voices that changed since the last run, so affected entries can be re-synthesized
+	voiceChanges, err := ttsService.RecordVoiceChanges()
+	if err != nil {
+		log.Printf("Warning: failed to record voice changes: %v", err)
+	}
+	for _, change := range voiceChanges {
+		log.Printf("Azure: default voice for %s changed from %s to %s", change.Locale, change.OldVoice, change.NewVoice)
+	}
+
+	// Set up tracing (spans are no-ops unless server.tracing.enabled is set)
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Server.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Printf("Warning: failed to flush traces: %v", err)
+		}
+	}()
+	if cfg.Server.Tracing.Enabled {
+		log.Printf("Tracing: exporting to %s as %s", cfg.Server.Tracing.Endpoint, cfg.Server.Tracing.ServiceName)
+	}
 
-	// Create gRPC server
-	grpcServer := grpc.NewServer()
-	ttsServer := daemon.NewServer(ttsService)
+	ttsServer := daemon.NewServer(ttsService, cfg)
+
+	// Create gRPC server; the stats handler picks up trace context from incoming metadata, and the
+	// interceptors check the API key, count requests in progress for draining and sample request
+	// logging
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(ttsServer.Authenticate, ttsServer.CheckNamespaces, ttsServer.TrackRequests, ttsServer.LogRequests),
+		grpc.ChainStreamInterceptor(ttsServer.AuthenticateStreams, ttsServer.CheckNamespacesStreams, ttsServer.TrackStreams),
+	}
+	if *acme && cfg.Server.TLS.CertFile != "" {
+		log.Fatalf("-acme can't be used with server.tls.cert_file")
+	}
+	if cfg.Server.TLS.CertFile != "" {
+		creds, err := daemon.FileCredentials(cfg.Server.TLS)
+		if err != nil {
+			log.Fatalf("Failed to set up TLS: %v", err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+		log.Printf("TLS: certificate from %s", cfg.Server.TLS.CertFile)
+	} else if *acme {
+		creds, err := daemon.ACMECredentials(cfg.Server.TLS)
+		if err != nil {
+			log.Fatalf("Failed to set up ACME: %v", err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+		log.Printf("TLS: Let's Encrypt certificate for %s (cache=%s, challenge port=%d)",
+			cfg.Server.TLS.AcmeDomain, cfg.Server.TLS.AcmeCacheDir, cfg.Server.TLS.AcmeHTTPPort)
+	} else if cfg.Server.TLS.AcmeDomain != "" {
+		log.Printf("Warning: server.tls.acme_domain is set but -acme was not given; serving without TLS")
+	}
+	if cfg.Server.TLS.ClientCACertFile != "" {
+		// Without TLS there would be no client certificates to check
+		if cfg.Server.TLS.CertFile == "" && !*acme {
+			log.Fatalf("server.tls.client_ca_cert_file needs TLS (server.tls.cert_file or -acme)")
+		}
+		log.Printf("TLS: client certificates verified against %s (required=%v)", cfg.Server.TLS.ClientCACertFile, cfg.Server.TLS.RequireClientCert)
+	}
+	if cfg.Server.APIKey != "" {
+		if cfg.Server.TLS.CertFile == "" && !*acme {
+			log.Printf("Warning: server.api_key is set without TLS; clients send the key unencrypted")
+		}
+		log.Printf("Auth: API key required")
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
 	pb.RegisterTTSServiceServer(grpcServer, ttsServer)
 
+	// Reflection lets grpcurl and similar tools discover the methods; its stream goes through the
+	// same interceptors, so it needs the API key when one is set
+	reflection.Register(grpcServer)
+	if version, err := daemon.DescriptorVersion(); err != nil {
+		log.Printf("Warning: %v", err)
+	} else {
+		log.Printf("gRPC: serving %s with reflection", version)
+	}
+
+	// The cache is open and the voice list loaded, so the daemon is healthy until a voice list
+	// refresh fails or it starts draining
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	daemon.SetHealth(healthServer, true)
+	ttsService.SetVoiceRefreshHandler(func(err error) {
+		if err != nil {
+			log.Printf("Health: NOT_SERVING, the voice list could not be refreshed")
+		}
+		daemon.SetHealth(healthServer, err == nil)
+	})
+
+	// Process queued (fire-and-forget) synthesis jobs in the background
+	ttsService.StartQueueWorker(time.Duration(cfg.Server.QueuePollIntervalMs)*time.Millisecond, ttsServer.DefaultOptions())
+
 	// Start listening
 	address := fmt.Sprintf("%s:%d", cfg.Server.Address, cfg.Server.Port)
 	listener, err := net.Listen("tcp", address)
@@ -100,20 +389,226 @@ func main() {
 		log.Fatalf("Failed to listen on %s: %v", address, err)
 	}
 
+	// Serve Prometheus metrics for scraping
+	if cfg.Server.Metrics.Enabled {
+		metricsListener, err := net.Listen("tcp", cfg.Server.Metrics.Address)
+		if err != nil {
+			log.Fatalf("Failed to listen for metrics on %s: %v", cfg.Server.Metrics.Address, err)
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		go func() {
+			if err := http.Serve(metricsListener, mux); err != nil {
+				log.Printf("Warning: metrics server stopped: %v", err)
+			}
+		}()
+		log.Printf("Metrics: serving /metrics on %s", cfg.Server.Metrics.Address)
+	}
+
+	// Serve the readiness probe, which fails once the daemon is draining
+	if cfg.Server.ReadinessPort > 0 {
+		readinessAddr := fmt.Sprintf(":%d", cfg.Server.ReadinessPort)
+		readinessListener, err := net.Listen("tcp", readinessAddr)
+		if err != nil {
+			log.Fatalf("Failed to listen for readiness probes on %s: %v", readinessAddr, err)
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/ready", ttsServer.ReadinessHandler())
+		go func() {
+			if err := http.Serve(readinessListener, mux); err != nil {
+				log.Printf("Warning: readiness probe stopped: %v", err)
+			}
+		}()
+		log.Printf("Server: readiness probe on %s/ready", readinessAddr)
+	}
+
+	// Draining refuses new connections, lets existing ones finish their requests and then stops
+	drained := make(chan struct{})
+	ttsServer.SetDrainHandler(func(timeout time.Duration) {
+		defer close(drained)
+		log.Printf("Draining: no longer accepting connections, waiting up to %s for requests", timeout)
+		healthServer.Shutdown()
+		listener.Close()
+		// Watch streams never finish on their own; end them so clients reconnect elsewhere
+		ttsService.StopWatchers()
+		if ttsServer.WaitForRequests(timeout) {
+			log.Println("Draining: all requests finished, stopping...")
+			grpcServer.GracefulStop()
+		} else {
+			log.Println("Draining: timed out waiting for requests, stopping...")
+			grpcServer.Stop()
+		}
+	})
+
 	log.Printf("Daemon started successfully")
 
 	// Handle graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
+	// SIGUSR1 reloads the config file, applying the settings that don't need a restart
+	reloadChan := make(chan os.Signal, 1)
+	notifyReload(reloadChan)
+	go func() {
+		running := cfg
+		for range reloadChan {
+			running = reloadConfig(*configPath, running, ttsServer, ttsService, cache)
+		}
+	}()
+
 	go func() {
 		<-sigChan
 		log.Println("Shutdown signal received, stopping...")
+		healthServer.Shutdown()
+		// Watch streams never finish on their own; end them so GracefulStop doesn't wait forever
+		ttsService.StopWatchers()
 		grpcServer.GracefulStop()
 	}()
 
 	// Start serving
-	if err := grpcServer.Serve(listener); err != nil {
+	if err := grpcServer.Serve(listener); err != nil && !ttsServer.Draining() {
 		log.Fatalf("Failed to serve: %v", err)
 	}
+	if ttsServer.Draining() {
+		<-drained
+	}
+}
+
+// newProvider creates the synthesis provider selected by cfg, returning its name for log
+// messages, its custom voice mappings and a function that releases it
+func newProvider(cfg *config.Config) (provider tts.Provider, name string, customVoices map[string]string, closeProvider func(), err error) {
+	closeProvider = func() {}
+	switch {
+	case cfg.Azure.Mock:
+		log.Printf("Azure: MOCK mode, serving recorded audio from %s", cfg.Azure.MockAudioDir)
+		return tts.NewMockAzureClient(cfg.Azure.MockAudioDir, cfg.Azure.MaxQPS, cfg.Azure.Voices), "Azure", cfg.Azure.Voices, closeProvider, nil
+	case cfg.Provider == "gcloud":
+		client, err := tts.NewGCloudClient(context.Background(), cfg.GCloud.CredentialsFile, cfg.GCloud.Region, cfg.GCloud.MaxQPS, cfg.GCloud.Voices)
+		if err != nil {
+			return nil, "", nil, nil, fmt.Errorf("Google Cloud TTS: %w", err)
+		}
+		log.Printf("Google Cloud TTS: region=%q", cfg.GCloud.Region)
+		return client, "Google Cloud TTS", cfg.GCloud.Voices, func() { client.Close() }, nil
+	case cfg.Provider == "polly":
+		client, err := tts.NewPollyClient(context.Background(), cfg.Polly.Region, cfg.Polly.MaxQPS, cfg.Polly.Voices)
+		if err != nil {
+			return nil, "", nil, nil, fmt.Errorf("Polly: %w", err)
+		}
+		return client, "Polly", cfg.Polly.Voices, closeProvider, nil
+	case cfg.Provider == "elevenlabs":
+		client := tts.NewElevenLabsClient(cfg.ElevenLabs.APIKey, cfg.ElevenLabs.ModelID, cfg.ElevenLabs.DefaultVoiceID, cfg.ElevenLabs.MaxQPS, cfg.ElevenLabs.Voices)
+		return client, "ElevenLabs", cfg.ElevenLabs.Voices, closeProvider, nil
+	case cfg.Provider == "openai":
+		log.Printf("OpenAI: model=%s, voice=%s, base_url=%s", cfg.OpenAI.Model, cfg.OpenAI.Voice, cfg.OpenAI.BaseURL)
+		return tts.NewOpenAIClient(cfg.OpenAI.BaseURL, cfg.OpenAI.APIKey, cfg.OpenAI.Model, cfg.OpenAI.Voice, cfg.OpenAI.MaxQPS), "OpenAI", nil, closeProvider, nil
+	}
+
+	client := tts.NewAzureClient(cfg.Azure.SubscriptionKey, cfg.Azure.Region, cfg.Azure.MaxQPS, cfg.Azure.Voices)
+	if cfg.Azure.Region == "" {
+		log.Printf("Azure: detecting region for subscription key...")
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		region, err := client.AutoDetectRegion(ctx)
+		cancel()
+		if err != nil {
+			return nil, "", nil, nil, fmt.Errorf("failed to detect the Azure region: %w", err)
+		}
+		log.Printf("Azure: detected region=%s", region)
+	}
+	return client, "Azure", cfg.Azure.Voices, closeProvider, nil
+}
+
+// reloadConfig reads the config file at path again and applies the settings that changed from
+// current and can be changed while the daemon runs, returning the configuration now in effect.
+// Other changes are logged and ignored, and so is a file that fails to load.
+func reloadConfig(path string, current *config.Config, server *daemon.Server, service *tts.Service, cache *tts.Cache) *config.Config {
+	log.Printf("Reload signal received, reloading configuration from %s", path)
+	cfg, err := config.Load(path)
+	if err != nil {
+		log.Printf("Warning: configuration not reloaded: %v", err)
+		return current
+	}
+
+	reloadable, restart := config.Changes(current, cfg)
+	for _, setting := range restart {
+		log.Printf("Warning: %s changed, restart the daemon to apply it", setting)
+	}
+
+	// Keep the settings that need a restart as they are, so the running configuration stays
+	// consistent with what the daemon is doing
+	applied := *current
+	for _, setting := range reloadable {
+		if err := config.CopySetting(&applied, cfg, setting); err != nil {
+			log.Printf("Warning: failed to apply %s: %v", setting, err)
+			continue
+		}
+		switch setting {
+		case "database.max_size_mb":
+			cache.SetMaxSize(applied.Database.MaxSizeMB)
+		case "azure.voices":
+			if !service.SetCustomVoices(applied.Azure.Voices) {
+				log.Printf("Warning: %s changed, restart the daemon to apply it", setting)
+				continue
+			}
+		}
+		log.Printf("Config: applied new %s", setting)
+	}
+	if qps := maxQPS(&applied); qps != maxQPS(current) {
+		service.SetMaxQPS(qps)
+	}
+	server.SetConfig(&applied)
+
+	if len(reloadable) == 0 && len(restart) == 0 {
+		log.Printf("Configuration unchanged")
+	}
+	return &applied
+}
+
+// maxQPS returns the rate limit of the configured provider
+func maxQPS(cfg *config.Config) float64 {
+	if cfg.Azure.Mock {
+		return cfg.Azure.MaxQPS
+	}
+	switch cfg.Provider {
+	case "gcloud":
+		return cfg.GCloud.MaxQPS
+	case "polly":
+		return cfg.Polly.MaxQPS
+	case "elevenlabs":
+		return cfg.ElevenLabs.MaxQPS
+	case "openai":
+		return cfg.OpenAI.MaxQPS
+	default:
+		return cfg.Azure.MaxQPS
+	}
+}
+
+// printEnvVars lists the environment variables that override settings, with their descriptions
+func printEnvVars() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, v := range config.EnvVars() {
+		fmt.Fprintf(w, "%s\t%s\n", v.Name, v.Description)
+	}
+	w.Flush()
+}
+
+// writeDefaultConfig writes a commented configuration file with the default settings and
+// placeholder credentials to path, or to stdout if path is empty
+func writeDefaultConfig(path string) error {
+	cfg := config.Defaults()
+	cfg.Azure.SubscriptionKey = "YOUR_KEY_HERE"
+	cfg.Azure.Region = "YOUR_REGION_HERE"
+
+	data, err := config.Generate(cfg)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	log.Printf("Configuration written to %s", path)
+	return nil
 }
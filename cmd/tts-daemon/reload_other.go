@@ -0,0 +1,8 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// notifyReload does nothing, as there is no SIGUSR1 on this platform
+func notifyReload(c chan<- os.Signal) {}
@@ -0,0 +1,14 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyReload relays SIGUSR1, which asks the daemon to reload its config file, to c
+func notifyReload(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGUSR1)
+}
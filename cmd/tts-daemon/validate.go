@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/config"
+	"com.biesnecker/tts-daemon/internal/tts"
+	"github.com/robfig/cron/v3"
+)
+
+// validateTimeout bounds the provider check, which may detect the Azure region first
+const validateTimeout = time.Minute
+
+// validationCheck is the result of one step of `validate`
+type validationCheck struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// validationReport is the result of `validate`, printed with --json
+type validationReport struct {
+	Config   string            `json:"config"`
+	Valid    bool              `json:"valid"`
+	Checks   []validationCheck `json:"checks"`
+	Warnings []string          `json:"warnings,omitempty"`
+}
+
+// add records a check, marking the report invalid if err is set
+func (r *validationReport) add(name string, err error) {
+	check := validationCheck{Name: name, OK: err == nil}
+	if err != nil {
+		check.Error = err.Error()
+		r.Valid = false
+	}
+	r.Checks = append(r.Checks, check)
+}
+
+// runValidate implements the `validate` sub-command: it loads the config file at path and
+// checks it as the daemon would at startup, including the provider's credentials, without
+// opening the database or listening on any port. It returns the exit status.
+func runValidate(path string, args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Print the report as JSON")
+	fs.Parse(args)
+
+	report := validate(path)
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode report: %v\n", err)
+			return 1
+		}
+	} else {
+		for _, check := range report.Checks {
+			if check.OK {
+				fmt.Printf("ok      %s\n", check.Name)
+			} else {
+				fmt.Printf("FAILED  %s: %s\n", check.Name, check.Error)
+			}
+		}
+		for _, warning := range report.Warnings {
+			fmt.Printf("Warning: %s\n", warning)
+		}
+		if report.Valid {
+			fmt.Printf("%s is valid\n", path)
+		} else {
+			fmt.Printf("%s is invalid\n", path)
+		}
+	}
+
+	if !report.Valid {
+		return 1
+	}
+	return 0
+}
+
+// validate runs the checks of `validate` on the config file at path
+func validate(path string) *validationReport {
+	report := &validationReport{Config: path, Valid: true}
+
+	cfg, err := config.Load(path)
+	report.add("config", err)
+	if err != nil {
+		return report
+	}
+
+	_, err = tts.NewEvictionPolicy(cfg.Database.EvictionPolicy)
+	report.add("database.eviction_policy", err)
+	if cfg.Database.CompactSchedule != "" {
+		_, err := cron.ParseStandard(cfg.Database.CompactSchedule)
+		report.add("database.compact_schedule", err)
+	}
+	report.add("audio", tts.ValidateMP3Quality(cfg.Audio.SampleRateHz, cfg.Audio.Bitrate))
+
+	if cfg.Server.TLS.CertFile != "" {
+		_, err := tls.LoadX509KeyPair(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		report.add("server.tls", err)
+	}
+	if cfg.Server.TLS.ClientCACertFile != "" {
+		_, err := os.ReadFile(cfg.Server.TLS.ClientCACertFile)
+		report.add("server.tls.client_ca_cert_file", err)
+	}
+
+	report.add("provider", validateProvider(cfg, report))
+	return report
+}
+
+// validateProvider checks the provider's credentials by loading its voice list, adding a
+// warning for each custom voice it doesn't offer
+func validateProvider(cfg *config.Config, report *validationReport) error {
+	provider, name, _, closeProvider, err := newProvider(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeProvider()
+
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	if err := provider.FetchVoiceList(ctx); err != nil {
+		return fmt.Errorf("failed to fetch voice list from %s: %w", name, err)
+	}
+
+	missing := provider.MissingCustomVoices()
+	locales := make([]string, 0, len(missing))
+	for locale := range missing {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	for _, locale := range locales {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%s doesn't offer voice %s, configured for %s", name, missing[locale], locale))
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	"com.biesnecker/tts-daemon/internal/client"
+	"com.biesnecker/tts-daemon/internal/tts"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// requestTimeout bounds each FetchTTS call; a long section can take a while to synthesize
+const requestTimeout = 2 * time.Minute
+
+// section is a top-level section of the document, spoken as one audio file
+type section struct {
+	title  string
+	chunks []chunk
+}
+
+// chunk is a heading and the text under it up to the next heading
+type chunk struct {
+	level int // Heading level, or 0 for text before the first heading
+	text  string
+}
+
+func main() {
+	input := flag.String("input", "", "Markdown file to synthesize")
+	lang := flag.String("lang", "en-US", "Language code of the document")
+	outputDir := flag.String("output-dir", ".", "Directory to write the MP3 files to")
+	pauseH1 := flag.Int("pause-h1-ms", 1500, "Pause before a top-level heading")
+	pauseH2 := flag.Int("pause-h2-ms", 800, "Pause before a lower-level heading")
+	concat := flag.Bool("concat", false, "Write a single MP3 of the whole document instead of one per section")
+	address := flag.String("address", "localhost:50051", "Address of the daemon")
+	flag.Parse()
+
+	if *input == "" {
+		fmt.Fprintf(os.Stderr, "Usage: tts-md2audio --input <file.md> [--lang <code>] [--output-dir <dir>] [--concat]\n\nOptions:\n")
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	source, err := os.ReadFile(*input)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", *input, err)
+	}
+	name := strings.TrimSuffix(filepath.Base(*input), filepath.Ext(*input))
+	sections := parseSections(source, name)
+	if len(sections) == 0 {
+		log.Fatalf("%s has no text to synthesize", *input)
+	}
+
+	if err := os.MkdirAll(*outputDir, 0755); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	pool, err := client.NewClientPool([]string{*address}, client.PolicyPickFirst, nil)
+	if err != nil {
+		log.Fatalf("Failed to connect to daemon at %s: %v", *address, err)
+	}
+	defer pool.Close()
+	ttsClient := pool.Client()
+
+	pauses := func(level int) time.Duration {
+		if level == 1 {
+			return time.Duration(*pauseH1) * time.Millisecond
+		}
+		return time.Duration(*pauseH2) * time.Millisecond
+	}
+
+	var document []byte
+	for i, s := range sections {
+		var audio []byte
+		for j, c := range s.chunks {
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			resp, err := ttsClient.FetchTTS(ctx, &pb.TTSRequest{Text: c.text, LanguageCode: *lang})
+			cancel()
+			if err != nil {
+				log.Fatalf("Failed to synthesize section %q: %v", s.title, err)
+			}
+
+			// Sections after the first are preceded by a pause too when they're concatenated
+			if c.level > 0 && (j > 0 || (*concat && i > 0)) {
+				silence, err := tts.MP3Silence(resp.AudioData, pauses(c.level))
+				if err != nil {
+					log.Fatalf("Failed to create pause: %v", err)
+				}
+				audio = append(audio, silence...)
+			}
+			audio = append(audio, resp.AudioData...)
+		}
+
+		if *concat {
+			document = append(document, audio...)
+			continue
+		}
+		path := filepath.Join(*outputDir, fmt.Sprintf("%02d-%s.mp3", i+1, fileName(s.title)))
+		if err := os.WriteFile(path, audio, 0644); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+		fmt.Printf("Wrote %s (%d bytes)\n", path, len(audio))
+	}
+
+	if *concat {
+		path := filepath.Join(*outputDir, fileName(name)+".mp3")
+		if err := os.WriteFile(path, document, 0644); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+		fmt.Printf("Wrote %s (%d sections, %d bytes)\n", path, len(sections), len(document))
+	}
+}
+
+// parseSections splits a Markdown document into sections at its highest-level headings, each
+// made of chunks that start at a heading. Text before the first heading becomes a section named
+// untitled. Code blocks, HTML and thematic breaks aren't spoken.
+func parseSections(source []byte, untitled string) []section {
+	doc := goldmark.DefaultParser().Parse(text.NewReader(source))
+
+	topLevel := 0
+	for n := doc.FirstChild(); n != nil; n = n.NextSibling() {
+		if h, ok := n.(*ast.Heading); ok && (topLevel == 0 || h.Level < topLevel) {
+			topLevel = h.Level
+		}
+	}
+
+	var sections []section
+	var body strings.Builder
+	level := 0
+	finish := func() {
+		t := strings.TrimSpace(body.String())
+		body.Reset()
+		if t == "" {
+			return
+		}
+		if len(sections) == 0 {
+			sections = append(sections, section{title: untitled})
+		}
+		s := &sections[len(sections)-1]
+		s.chunks = append(s.chunks, chunk{level: level, text: t})
+	}
+
+	for n := doc.FirstChild(); n != nil; n = n.NextSibling() {
+		switch n.Kind() {
+		case ast.KindFencedCodeBlock, ast.KindCodeBlock, ast.KindHTMLBlock, ast.KindThematicBreak:
+			continue
+		}
+
+		h, ok := n.(*ast.Heading)
+		if !ok {
+			body.WriteString(nodeText(n, source))
+			body.WriteString("\n")
+			continue
+		}
+
+		finish()
+		title := strings.TrimSpace(nodeText(h, source))
+		if h.Level == topLevel {
+			sections = append(sections, section{title: title})
+		}
+		level = min(h.Level-topLevel+1, 2)
+
+		// The heading is spoken as its own sentence before the text under it
+		if title != "" && !strings.ContainsAny(title[len(title)-1:], ".!?:") {
+			title += "."
+		}
+		body.WriteString(title)
+		body.WriteString("\n\n")
+	}
+	finish()
+
+	// Drop top-level headings with no text at all
+	var result []section
+	for _, s := range sections {
+		if len(s.chunks) > 0 {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// nodeText returns the text of the Text nodes under n, with soft line breaks as spaces
+func nodeText(n ast.Node, source []byte) string {
+	var b strings.Builder
+	ast.Walk(n, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			if node.Kind() == ast.KindListItem || node.Kind() == ast.KindParagraph {
+				b.WriteString("\n")
+			}
+			return ast.WalkContinue, nil
+		}
+		switch t := node.(type) {
+		case *ast.Text:
+			b.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				b.WriteString(" ")
+			}
+		case *ast.String:
+			b.Write(t.Value)
+		case *ast.AutoLink, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return b.String()
+}
+
+// fileName turns a section title into a file name, keeping letters and digits and joining
+// words with hyphens, e.g. "Getting Started!" becomes "Getting-Started"
+func fileName(title string) string {
+	words := strings.FieldsFunc(title, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	if len(words) == 0 {
+		return "Section"
+	}
+	return strings.Join(words, "-")
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	"com.biesnecker/tts-daemon/internal/client"
+	"com.biesnecker/tts-daemon/internal/tts"
+)
+
+// requestTimeout bounds each FetchTTS call made while replaying
+const requestTimeout = 30 * time.Second
+
+func main() {
+	logPath := flag.String("log-path", "", "Replay log to restore from (database.replay_log_path); rotated files next to it are read too")
+	dbPath := flag.String("db", "", "Cache database of the daemon being restored; entries already in it are skipped without a request (optional)")
+	address := flag.String("address", "localhost:50051", "Address of the daemon that re-synthesizes and caches each text")
+	flag.Parse()
+
+	if *logPath == "" {
+		fmt.Fprintf(os.Stderr, "Usage: tts-restore --log-path <file> [--address <address>] [--db <file>]\n\nOptions:\n")
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	// Without a database every record is sent; the daemon answers cached texts without calling Azure
+	exists := func(string) (bool, error) { return false, nil }
+	if *dbPath != "" {
+		cache, err := tts.NewCache(*dbPath, tts.CompressionNone, 0, 0, nil, nil)
+		if err != nil {
+			log.Fatalf("Failed to open cache database: %v", err)
+		}
+		defer cache.Close()
+		exists = cache.HasKey
+	}
+
+	pool, err := client.NewClientPool([]string{*address}, client.PolicyPickFirst, nil)
+	if err != nil {
+		log.Fatalf("Failed to connect to daemon at %s: %v", *address, err)
+	}
+	defer pool.Close()
+	ttsClient := pool.Client()
+
+	// Restoring can take a long time; stop cleanly on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	started := time.Now()
+	replayed, skipped, err := tts.ReplayLog(*logPath, exists, func(record tts.ReplayRecord) error {
+		req := &pb.TTSRequest{
+			Text:         record.Text,
+			LanguageCode: record.LanguageCode,
+		}
+		opts := record.Options()
+		req.IsSsml = opts.SSML
+		switch opts.Format {
+		case tts.FormatWAV16K:
+			req.OutputFormat = pb.OutputFormat_WAV_16K
+		case tts.FormatOpus24K:
+			req.OutputFormat = pb.OutputFormat_OPUS_24K
+		case tts.FormatOggOpus48K:
+			req.OutputFormat = pb.OutputFormat_OGG_OPUS_48K
+		case tts.FormatOggOpus24K:
+			req.OutputFormat = pb.OutputFormat_OGG_OPUS_24K
+		default:
+			req.Mp3Bitrate = opts.MP3Bitrate
+			req.Mp3SampleRateHz = int32(opts.MP3SampleRateHz)
+		}
+
+		reqCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+		resp, err := ttsClient.FetchTTS(reqCtx, req)
+		if err != nil {
+			return err
+		}
+		if resp.CacheKey != record.CacheKey {
+			// The daemon's synthesis options differ from those the entry was cached with
+			log.Printf("Warning: %s was restored under key %s", record.CacheKey, resp.CacheKey)
+		}
+		return nil
+	})
+
+	fmt.Printf("Replayed %d entries, skipped %d already cached, in %s\n", replayed, skipped, time.Since(started).Round(time.Second))
+	if err != nil {
+		log.Fatalf("Restore stopped: %v", err)
+	}
+}
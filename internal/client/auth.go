@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// APIKeyOptions returns dial options that send key to the daemon as "authorization: Bearer
+// <key>" on every call, replacing any authorization metadata already on the call
+func APIKeyOptions(key string) []grpc.DialOption {
+	authorize := func(ctx context.Context) context.Context {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		md = md.Copy()
+		md.Set("authorization", "Bearer "+key)
+		return metadata.NewOutgoingContext(ctx, md)
+	}
+
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(authorize(ctx), method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(authorize(ctx), desc, cc, method, opts...)
+		}),
+	}
+}
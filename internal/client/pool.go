@@ -0,0 +1,93 @@
+package client
+
+import (
+	"fmt"
+	"net"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+// Load balancing policies supported by ClientPool
+const (
+	PolicyRoundRobin = "round_robin"
+	PolicyPickFirst  = "pick_first"
+)
+
+// resolverScheme is the scheme of the static resolver that serves the pool's addresses
+const resolverScheme = "tts-daemon"
+
+// ClientPool holds a single gRPC connection balanced across one or more daemon instances
+type ClientPool struct {
+	conn *grpc.ClientConn
+}
+
+// NewClientPool creates a pool for the given daemon addresses using the named load balancing policy.
+// A single address is dialed directly, so targets such as unix:// sockets keep working.
+// If creds is nil the connection is unencrypted. opts are added to the connection's dial options
+// (see APIKeyOptions).
+func NewClientPool(addresses []string, policy string, creds credentials.TransportCredentials, opts ...grpc.DialOption) (*ClientPool, error) {
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("at least one address is required")
+	}
+	if policy != PolicyRoundRobin && policy != PolicyPickFirst {
+		return nil, fmt.Errorf("unsupported load balancing policy %q (use %s or %s)", policy, PolicyRoundRobin, PolicyPickFirst)
+	}
+
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+
+	if len(addresses) == 1 {
+		conn, err := grpc.NewClient(addresses[0], append(opts, grpc.WithTransportCredentials(creds))...)
+		if err != nil {
+			return nil, err
+		}
+		return &ClientPool{conn: conn}, nil
+	}
+
+	// Static resolver that always returns every configured address
+	r := manual.NewBuilderWithScheme(resolverScheme)
+	state := resolver.State{}
+	for _, addr := range addresses {
+		// Each address keeps its own host name for TLS verification (the target itself is synthetic)
+		serverName := addr
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			serverName = host
+		}
+		state.Addresses = append(state.Addresses, resolver.Address{Addr: addr, ServerName: serverName})
+	}
+	r.InitialState(state)
+
+	conn, err := grpc.NewClient(
+		r.Scheme()+":///daemons",
+		append(opts,
+			grpc.WithResolvers(r),
+			grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingPolicy":%q}`, policy)),
+			grpc.WithTransportCredentials(creds),
+		)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientPool{conn: conn}, nil
+}
+
+// Client returns a TTS service client that uses the pool's connection
+func (p *ClientPool) Client() pb.TTSServiceClient {
+	return pb.NewTTSServiceClient(p.conn)
+}
+
+// Conn returns the underlying connection
+func (p *ClientPool) Conn() *grpc.ClientConn {
+	return p.conn
+}
+
+// Close closes the underlying connection
+func (p *ClientPool) Close() error {
+	return p.conn.Close()
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	"google.golang.org/grpc"
+)
+
+// countingServer counts the SelfDiagnose calls it answers
+type countingServer struct {
+	pb.UnimplementedTTSServiceServer
+	calls atomic.Int32
+}
+
+func (s *countingServer) SelfDiagnose(ctx context.Context, req *pb.DiagnosticRequest) (*pb.DiagnosticReport, error) {
+	s.calls.Add(1)
+	return &pb.DiagnosticReport{}, nil
+}
+
+// startServers starts n mock daemons, returning their addresses and call counters
+func startServers(t *testing.T, n int) ([]string, []*countingServer) {
+	t.Helper()
+	var addresses []string
+	var servers []*countingServer
+	for i := 0; i < n; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		server := &countingServer{}
+		grpcServer := grpc.NewServer()
+		pb.RegisterTTSServiceServer(grpcServer, server)
+		go grpcServer.Serve(listener)
+		t.Cleanup(grpcServer.Stop)
+
+		addresses = append(addresses, listener.Addr().String())
+		servers = append(servers, server)
+	}
+	return addresses, servers
+}
+
+func TestClientPoolPolicies(t *testing.T) {
+	tests := []struct {
+		policy      string
+		wantServers int // Servers that should receive calls
+	}{
+		{PolicyRoundRobin, 2},
+		{PolicyPickFirst, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			addresses, servers := startServers(t, 2)
+			pool, err := NewClientPool(addresses, tt.policy, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer pool.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			// The first call waits for the connection; round robin then uses every address as
+			// soon as it is ready, so wait for both before counting
+			if _, err := pool.Client().SelfDiagnose(ctx, &pb.DiagnosticRequest{}, grpc.WaitForReady(true)); err != nil {
+				t.Fatal(err)
+			}
+			time.Sleep(100 * time.Millisecond)
+			for _, server := range servers {
+				server.calls.Store(0)
+			}
+
+			const calls = 20
+			for i := 0; i < calls; i++ {
+				if _, err := pool.Client().SelfDiagnose(ctx, &pb.DiagnosticRequest{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			used := 0
+			for i, server := range servers {
+				n := server.calls.Load()
+				if n > 0 {
+					used++
+				}
+				if tt.policy == PolicyRoundRobin && n != calls/2 {
+					t.Errorf("server %d got %d of %d calls, want %d", i, n, calls, calls/2)
+				}
+			}
+			if used != tt.wantServers {
+				t.Errorf("%d servers got calls, want %d", used, tt.wantServers)
+			}
+		})
+	}
+}
+
+func TestNewClientPoolErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses []string
+		policy    string
+	}{
+		{"no addresses", nil, PolicyRoundRobin},
+		{"unknown policy", []string{"localhost:50051"}, "random"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if pool, err := NewClientPool(tt.addresses, tt.policy, nil); err == nil {
+				pool.Close()
+				t.Error("NewClientPool succeeded, want an error")
+			}
+		})
+	}
+}
@@ -4,16 +4,27 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
+// namespacePattern matches valid cache namespace names
+var namespacePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
 // Config represents the application configuration
 type Config struct {
-	Azure     AzureConfig     `yaml:"azure"`
-	Database  DatabaseConfig  `yaml:"database"`
-	Server    ServerConfig    `yaml:"server"`
-	Audio     AudioConfig     `yaml:"audio"`
+	Provider   string           `yaml:"provider"` // Synthesis backend: azure (default), gcloud, polly, elevenlabs or openai
+	Azure      AzureConfig      `yaml:"azure"`
+	GCloud     GCloudConfig     `yaml:"gcloud"`
+	Polly      PollyConfig      `yaml:"polly"`
+	ElevenLabs ElevenLabsConfig `yaml:"elevenlabs"`
+	OpenAI     OpenAIConfig     `yaml:"openai"`
+	Database   DatabaseConfig   `yaml:"database"`
+	Server     ServerConfig     `yaml:"server"`
+	Audio      AudioConfig      `yaml:"audio"`
 }
 
 // AzureConfig holds Azure Cognitive Services credentials
@@ -22,25 +33,232 @@ type AzureConfig struct {
 	Region          string            `yaml:"region"`
 	MaxQPS          float64           `yaml:"max_qps"` // Maximum queries per second
 	Voices          map[string]string `yaml:"voices"`  // Custom voice mappings (language_code -> voice_name)
+
+	LanguageFallbackChains map[string][]string `yaml:"language_fallback_chains"` // Locales to try, in order, for a language without a voice
+	LanguageFamilyFallback bool                `yaml:"language_family_fallback"` // Then try related languages before en-US (default true)
+
+	EphemeralDailyBudget int `yaml:"ephemeral_daily_budget"` // Characters per day for uncached (ephemeral) synthesis (0 = unlimited)
+
+	AutoDetectRegion bool `yaml:"auto_detect_region"` // Find the key's region at startup when region is empty
+
+	VoiceCacheRefreshIntervalH int `yaml:"voice_cache_refresh_interval_h"` // Hours between voice list refreshes (default 24, 0 = never)
+
+	PreprocessWithOpenAI     bool   `yaml:"preprocess_with_openai"`     // Rewrite text with an OpenAI model before synthesis
+	OpenAIPreprocessEndpoint string `yaml:"openai_preprocess_endpoint"` // chat/completions URL (default https://api.openai.com/v1/chat/completions)
+	OpenAIAPIKey             string `yaml:"openai_api_key"`             // Sent as a bearer token to the endpoint
+	PreprocessModel          string `yaml:"preprocess_model"`           // Chat model used for preprocessing (default gpt-4o-mini)
+
+	BatchSynthesis bool `yaml:"batch_synthesis"` // Combine WAV requests arriving together into one Azure request
+	BatchWindowMs  int  `yaml:"batch_window_ms"` // How long to collect requests for a batch (default 50)
+
+	// Development mode: serve pre-recorded audio instead of calling Azure
+	Mock         bool   `yaml:"mock"`
+	MockAudioDir string `yaml:"mock_audio_dir"` // Directory of <language_code>.mp3 files (default testdata/mock_audio)
+}
+
+// GCloudConfig holds Google Cloud Text-to-Speech settings, used when provider is gcloud
+type GCloudConfig struct {
+	CredentialsFile string            `yaml:"credentials_file"` // Service account JSON key (empty = application default credentials)
+	Region          string            `yaml:"region"`           // Regional endpoint such as eu or us (empty = global)
+	MaxQPS          float64           `yaml:"max_qps"`          // Maximum queries per second
+	Voices          map[string]string `yaml:"voices"`           // Custom voice mappings (language_code -> voice_name)
+}
+
+// PollyConfig holds Amazon Polly settings, used when provider is polly. Credentials come from the
+// standard AWS chain (environment, ~/.aws, instance roles).
+type PollyConfig struct {
+	Region string            `yaml:"region"`  // AWS region (empty = the chain's, e.g. AWS_REGION)
+	MaxQPS float64           `yaml:"max_qps"` // Maximum queries per second
+	Voices map[string]string `yaml:"voices"`  // Custom voice mappings (language_code -> voice ID)
+}
+
+// ElevenLabsConfig holds ElevenLabs settings, used when provider is elevenlabs. Its voices aren't
+// tied to a locale, so each language's voice is configured here.
+type ElevenLabsConfig struct {
+	APIKey         string            `yaml:"api_key"`
+	DefaultVoiceID string            `yaml:"default_voice_id"` // Voice for languages not in voices
+	Voices         map[string]string `yaml:"voices"`           // Voice IDs by language code
+	ModelID        string            `yaml:"model_id"`         // Synthesis model (default eleven_multilingual_v2)
+	MaxQPS         float64           `yaml:"max_qps"`          // Maximum queries per second (default 2, ElevenLabs quotas are low)
+}
+
+// OpenAIConfig holds OpenAI TTS settings, used when provider is openai
+type OpenAIConfig struct {
+	APIKey  string  `yaml:"api_key"`
+	Model   string  `yaml:"model"`    // tts-1 (default) or tts-1-hd
+	Voice   string  `yaml:"voice"`    // alloy (default), echo, fable, onyx, nova or shimmer; used for every language
+	BaseURL string  `yaml:"base_url"` // API base, e.g. an Azure OpenAI deployment or a proxy (default https://api.openai.com/v1)
+	MaxQPS  float64 `yaml:"max_qps"`  // Maximum queries per second
 }
 
 // DatabaseConfig holds database settings
 type DatabaseConfig struct {
-	Path        string `yaml:"path"`
-	Compression bool   `yaml:"compression"` // Enable zstd compression for cached audio
-	MaxSizeMB   int64  `yaml:"max_size_mb"` // Maximum cache size in MB (0 = unlimited)
+	Path             string `yaml:"path"`
+	Compression      string `yaml:"compression"`       // Compression for cached audio: zstd, lz4 or none (true/false mean zstd/none)
+	CompressionLevel int    `yaml:"compression_level"` // zstd level for newly cached audio, 1-19 (0 = the default, 3)
+	MaxSizeMB        int64  `yaml:"max_size_mb"`       // Maximum cache size in MB (0 = unlimited)
+
+	EvictionPolicy string `yaml:"eviction_policy"` // Which entries to evict when over max_size_mb: lru, lfu or fifo
+
+	LanguageQuotas map[string]int `yaml:"language_quotas"` // Maximum size in MB per language code
+
+	ReplayLogPath  string `yaml:"replay_log_path"`   // Append a record of every cached text here for tts-restore (empty = disabled)
+	ReplayLogMaxMB int64  `yaml:"replay_log_max_mb"` // Rotate the replay log at this size (0 = never)
+
+	FingerprintDedup bool `yaml:"fingerprint_dedup"` // Don't store audio identical to an entry cached for another text
+	DeltaCompression bool `yaml:"delta_compression"` // Store entries as deltas against the same text cached with other options
+
+	CompactSchedule       string `yaml:"compact_schedule"`         // Cron expression for compacting the database with VACUUM, e.g. "0 3 * * *" (empty = never)
+	CompactIdleThresholdS int    `yaml:"compact_idle_threshold_s"` // Seconds without requests before a scheduled compaction starts (default 60)
+	VacuumIntervalHours   int    `yaml:"vacuum_interval_hours"`    // How often pages freed by deletions are returned to the file system with an incremental vacuum (default 24, 0 = disabled)
+
+	HotCacheBackend                 string `yaml:"hot_cache_backend"`                    // Keep the most accessed entries outside SQLite: memory or bbolt (empty = disabled)
+	HotCacheThresholdAccessesPerDay int    `yaml:"hot_cache_threshold_accesses_per_day"` // Accesses per day that make an entry hot (default 100)
+
+	MemoryCacheEntries int `yaml:"memory_cache_entries"` // Most recently used entries kept decoded in memory in front of SQLite (0 = disabled)
+
+	RedisURL       string `yaml:"redis_url"`       // Redis server to share entries with other daemons through, e.g. redis://localhost:6379/0 (empty = disabled)
+	RedisNamespace string `yaml:"redis_namespace"` // Prefix of the keys entries are stored under in Redis (default "tts")
+
+	S3Bucket   string `yaml:"s3_bucket"`   // S3 bucket to archive entries in, behind SQLite (empty = disabled)
+	S3Prefix   string `yaml:"s3_prefix"`   // Prefix of the object keys, e.g. tts/cache (empty = bucket root)
+	S3Region   string `yaml:"s3_region"`   // AWS region of the bucket (empty = from the AWS configuration)
+	S3Endpoint string `yaml:"s3_endpoint"` // Endpoint of an S3-compatible service such as MinIO, e.g. http://localhost:9000 (empty = AWS)
+
+	TTLDays                int `yaml:"ttl_days"`                 // Days after which entries are no longer served and get deleted (0 = never)
+	CleanupIntervalMinutes int `yaml:"cleanup_interval_minutes"` // How often expired entries are deleted (default 60)
 }
 
 // ServerConfig holds gRPC server settings
 type ServerConfig struct {
 	Address string `yaml:"address"`
 	Port    int    `yaml:"port"`
+
+	QueuePollIntervalMs int `yaml:"queue_poll_interval_ms"` // How often the synthesis queue worker checks for jobs
+
+	EphemeralMaxTextLength int `yaml:"ephemeral_max_text_length"` // Maximum characters per SynthesizeEphemeral request
+
+	AdaptiveBatchSize int `yaml:"adaptive_batch_size"` // Initial concurrency of adaptive BulkFetchTTS requests (default 5)
+
+	WarmConcurrency int `yaml:"warm_concurrency"` // Texts a WarmCache request fetches at once (default 4)
+
+	StreamChunkSizeKB int `yaml:"stream_chunk_size_kb"` // Size of the chunks StreamTTS sends (default 32)
+
+	AllowedSaveDirectories []string `yaml:"allowed_save_directories"` // Where FetchAndSave may write files (none = disabled)
+
+	ReadinessPort int `yaml:"readiness_port"` // HTTP port for the readiness probe (0 = disabled)
+
+	LogFormat string `yaml:"log_format"` // text (default) or json, for log aggregation tools
+
+	// Request logging for high-traffic deployments
+	RequestLogSamplingRate float64 `yaml:"request_log_sampling_rate"` // Fraction of requests logged, 0.0-1.0 (default 1.0 = all)
+	SlowRequestThresholdMs int     `yaml:"slow_request_threshold_ms"` // Requests slower than this are logged even when not sampled (default 1000, negative disables)
+
+	// Cache utilization alerts (requires database.max_size_mb)
+	AlertWebhookURL            string  `yaml:"alert_webhook_url"`             // Notified when the cache passes the threshold (empty = disabled)
+	AlertWebhookMethod         string  `yaml:"alert_webhook_method"`          // POST (JSON body, default) or GET (query parameters)
+	AlertCacheThresholdPercent float64 `yaml:"alert_cache_threshold_percent"` // Percentage of max_size_mb (default 90)
+	AlertCooldownMinutes       int     `yaml:"alert_cooldown_minutes"`        // Minimum time between alerts (default 60)
+
+	APIKey string `yaml:"api_key"` // Key clients must send as "authorization: Bearer <key>" (empty = no authentication)
+
+	Namespaces  []string           `yaml:"namespaces"`  // Cache namespaces requests may use, besides the default one
+	Credentials []CredentialConfig `yaml:"credentials"` // Which clients may use which namespaces (empty = every client may use all of them)
+
+	TLS     TLSConfig     `yaml:"tls"`
+	Tracing TracingConfig `yaml:"tracing"`
+	Metrics MetricsConfig `yaml:"metrics"`
+}
+
+// CredentialConfig lets a client use namespaces. The client is identified by the API key it sends,
+// or by the name in its client certificate; either is enough.
+type CredentialConfig struct {
+	APIKey     string   `yaml:"api_key"`     // Sent as "authorization: Bearer <key>", like server.api_key
+	ClientName string   `yaml:"client_name"` // Common name or DNS name of a certificate signed by tls.client_ca_cert_file
+	Namespaces []string `yaml:"namespaces"`  // Namespaces, from server.namespaces, the client may use
+}
+
+// TLSConfig holds settings for serving gRPC over TLS, with a certificate from files or from
+// Let's Encrypt
+type TLSConfig struct {
+	CertFile string `yaml:"cert_file"` // PEM certificate (chain) to serve; enables TLS together with key_file
+	KeyFile  string `yaml:"key_file"`  // PEM private key for cert_file
+
+	// Mutual TLS: clients present certificates signed by client_ca_cert_file
+	ClientCACertFile  string `yaml:"client_ca_cert_file"` // PEM CA certificates that sign client certificates
+	RequireClientCert bool   `yaml:"require_client_cert"` // Reject clients without one (otherwise they are only verified if presented)
+
+	AcmeDomain       string `yaml:"acme_domain"`        // Domain to obtain a certificate for
+	AcmeCacheDir     string `yaml:"acme_cache_dir"`     // Where certificates and the ACME account key are stored
+	AcmeHTTPPort     int    `yaml:"acme_http_port"`     // Port for the HTTP-01 challenge responder (default 80)
+	AcmeDirectoryURL string `yaml:"acme_directory_url"` // ACME directory to use (default Let's Encrypt production)
+}
+
+// TracingConfig holds OpenTelemetry tracing settings
+type TracingConfig struct {
+	Enabled     bool    `yaml:"enabled"`
+	Endpoint    string  `yaml:"endpoint"`     // OTLP/gRPC collector address (default localhost:4317)
+	ServiceName string  `yaml:"service_name"` // Service name attached to spans (default tts-daemon)
+	TraceRatio  float64 `yaml:"trace_ratio"`  // Fraction of new traces sampled, 0.0-1.0 (default 1.0 = all)
+}
+
+// MetricsConfig holds settings for serving Prometheus metrics over HTTP
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Address string `yaml:"address"` // Address to serve /metrics on (default :9090)
 }
 
 // AudioConfig holds audio playback settings
 type AudioConfig struct {
 	SampleRate  int `yaml:"sample_rate"`
 	BufferSize  int `yaml:"buffer_size"`
+	FadeInMs    int `yaml:"fade_in_ms"`  // Volume ramp at the start of playback (0 = 20ms default, negative disables)
+	FadeOutMs   int `yaml:"fade_out_ms"` // Volume ramp at the end of playback (0 = 20ms default, negative disables)
+
+	PreferOpus bool `yaml:"prefer_opus"` // Client requests OGG Opus instead of MP3 when no -format is given
+
+	// MP3 quality requested from Azure (see tts.ValidateMP3Quality for the supported combinations)
+	Bitrate      string `yaml:"bitrate"`        // 32k, 48k, 64k, 96k, 128k (default), 160k or 192k
+	SampleRateHz int    `yaml:"sample_rate_hz"` // 16000 (default), 24000 or 48000; unrelated to the playback sample_rate
+
+	// Synthesis pauses (applied by the daemon before sending text to Azure)
+	InjectBreaks    bool `yaml:"inject_breaks"`     // Short pause after sentence-ending punctuation
+	BreakAtNewlines bool `yaml:"break_at_newlines"` // Pauses at line and paragraph breaks
+
+	// Punctuation restoration for unpunctuated input such as speech recognition output
+	RestorePunctuation         bool   `yaml:"restore_punctuation"`
+	RestorePunctuationLanguage string `yaml:"restore_punctuation_language"` // Rules to use (default: the request's language)
+
+	// Per-platform overrides keyed by GOOS (linux, darwin, windows)
+	PlatformOverrides map[string]AudioConfig `yaml:"platform_overrides,omitempty"`
+}
+
+// ForPlatform returns the audio settings with any overrides for goos merged over the base values
+func (a AudioConfig) ForPlatform(goos string) AudioConfig {
+	merged := AudioConfig{
+		SampleRate:      a.SampleRate,
+		BufferSize:      a.BufferSize,
+		FadeInMs:        a.FadeInMs,
+		FadeOutMs:       a.FadeOutMs,
+		PreferOpus:      a.PreferOpus,
+		InjectBreaks:    a.InjectBreaks,
+		BreakAtNewlines: a.BreakAtNewlines,
+
+		RestorePunctuation:         a.RestorePunctuation,
+		RestorePunctuationLanguage: a.RestorePunctuationLanguage,
+	}
+
+	override, ok := a.PlatformOverrides[goos]
+	if !ok {
+		return merged
+	}
+	if override.SampleRate != 0 {
+		merged.SampleRate = override.SampleRate
+	}
+	if override.BufferSize != 0 {
+		merged.BufferSize = override.BufferSize
+	}
+	return merged
 }
 
 // Load reads and parses the configuration file
@@ -50,31 +268,217 @@ func Load(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var config Config
+	// The file overrides the defaults of settings whose zero value means something else
+	config := presetDefaults()
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	if err := applyEnv(&config); err != nil {
+		return nil, err
+	}
+
+	switch config.Provider {
+	case "azure", "gcloud", "polly", "elevenlabs", "openai":
+	default:
+		return nil, fmt.Errorf("provider must be azure, gcloud, polly, elevenlabs or openai, got %q", config.Provider)
+	}
+
+	// Validate required fields (credentials aren't needed in mock mode, and Google's and AWS's
+	// can come from the environment)
+	switch {
+	case config.Azure.Mock:
+		// Pre-recorded audio is served instead
+	case config.Provider == "azure":
+		if config.Azure.SubscriptionKey == "" {
+			return nil, fmt.Errorf("azure.subscription_key (or %s) is required", envName("azure.subscription_key"))
+		}
+		if config.Azure.Region == "" && !config.Azure.AutoDetectRegion {
+			return nil, fmt.Errorf("azure.region is required (or set azure.auto_detect_region)")
+		}
+	case config.Provider == "elevenlabs":
+		if config.ElevenLabs.APIKey == "" {
+			return nil, fmt.Errorf("elevenlabs.api_key is required")
+		}
+		if config.ElevenLabs.DefaultVoiceID == "" && len(config.ElevenLabs.Voices) == 0 {
+			return nil, fmt.Errorf("elevenlabs.default_voice_id or elevenlabs.voices is required")
+		}
+	case config.Provider == "openai":
+		// Proxies may not need a key, but OpenAI itself does
+		if config.OpenAI.APIKey == "" && config.OpenAI.BaseURL == "" {
+			return nil, fmt.Errorf("openai.api_key is required (unless openai.base_url points elsewhere)")
+		}
+	}
+
+	if config.Database.Path == "" {
+		path, err := defaultDatabasePath()
+		if err != nil {
+			return nil, err
+		}
+		config.Database.Path = path
+	}
+	applyDefaults(&config)
+
+	if (config.Server.TLS.CertFile == "") != (config.Server.TLS.KeyFile == "") {
+		return nil, fmt.Errorf("server.tls.cert_file and server.tls.key_file must be set together")
+	}
+	if config.Server.TLS.RequireClientCert && config.Server.TLS.ClientCACertFile == "" {
+		return nil, fmt.Errorf("server.tls.require_client_cert needs server.tls.client_ca_cert_file")
+	}
+	for _, namespace := range config.Server.Namespaces {
+		if !namespacePattern.MatchString(namespace) {
+			return nil, fmt.Errorf("server.namespaces: invalid namespace %q (use letters, digits, '.', '-' and '_')", namespace)
+		}
+	}
+	for i, credential := range config.Server.Credentials {
+		if credential.APIKey == "" && credential.ClientName == "" {
+			return nil, fmt.Errorf("server.credentials[%d]: needs an api_key or a client_name", i)
+		}
+		if credential.ClientName != "" && config.Server.TLS.ClientCACertFile == "" {
+			return nil, fmt.Errorf("server.credentials[%d]: client_name needs server.tls.client_ca_cert_file", i)
+		}
+		for _, namespace := range credential.Namespaces {
+			if !slices.Contains(config.Server.Namespaces, namespace) {
+				return nil, fmt.Errorf("server.credentials[%d]: namespace %q isn't in server.namespaces", i, namespace)
+			}
+		}
+	}
+
+	// compression used to be a boolean for zstd
+	switch strings.ToLower(config.Database.Compression) {
+	case "true", "zstd":
+		config.Database.Compression = "zstd"
+	case "", "false", "none":
+		config.Database.Compression = ""
+	case "lz4":
+		config.Database.Compression = "lz4"
+	default:
+		return nil, fmt.Errorf("database.compression must be zstd, lz4 or none, got %q", config.Database.Compression)
+	}
+
+	if config.Database.CompressionLevel < 0 || config.Database.CompressionLevel > 19 {
+		return nil, fmt.Errorf("database.compression_level must be between 1 and 19 (0 = default), got %d", config.Database.CompressionLevel)
+	}
+
+	if config.Database.VacuumIntervalHours < 0 {
+		return nil, fmt.Errorf("database.vacuum_interval_hours can't be negative (0 = disabled), got %d", config.Database.VacuumIntervalHours)
+	}
+
+	if config.Database.TTLDays < 0 {
+		return nil, fmt.Errorf("database.ttl_days can't be negative, got %d", config.Database.TTLDays)
+	}
+
+	if config.Database.MemoryCacheEntries < 0 {
+		return nil, fmt.Errorf("database.memory_cache_entries can't be negative, got %d", config.Database.MemoryCacheEntries)
+	}
+
+	if config.Server.LogFormat != "text" && config.Server.LogFormat != "json" {
+		return nil, fmt.Errorf("server.log_format must be text or json, got %q", config.Server.LogFormat)
+	}
+	if config.Server.RequestLogSamplingRate < 0 || config.Server.RequestLogSamplingRate > 1 {
+		return nil, fmt.Errorf("server.request_log_sampling_rate must be between 0.0 and 1.0, got %g", config.Server.RequestLogSamplingRate)
+	}
+	if config.Server.Tracing.TraceRatio < 0 || config.Server.Tracing.TraceRatio > 1 {
+		return nil, fmt.Errorf("server.tracing.trace_ratio must be between 0.0 and 1.0, got %g", config.Server.Tracing.TraceRatio)
+	}
 
-	// Validate required fields
-	if config.Azure.SubscriptionKey == "" {
-		return nil, fmt.Errorf("azure.subscription_key is required")
+	config.Server.AlertWebhookMethod = strings.ToUpper(config.Server.AlertWebhookMethod)
+	if config.Server.AlertWebhookMethod != "POST" && config.Server.AlertWebhookMethod != "GET" {
+		return nil, fmt.Errorf("server.alert_webhook_method must be POST or GET, got %q", config.Server.AlertWebhookMethod)
 	}
-	if config.Azure.Region == "" {
-		return nil, fmt.Errorf("azure.region is required")
+
+	return &config, nil
+}
+
+// Defaults returns the configuration used when every setting is left unset. The Azure
+// credentials are empty, so it must be filled in before it can be loaded.
+func Defaults() *Config {
+	config := presetDefaults()
+	config.Database.Path, _ = defaultDatabasePath() // Left empty without a home directory
+	applyDefaults(&config)
+	return &config
+}
+
+// presetDefaults returns a Config with the defaults of settings whose zero value means something
+// else, so they can't be filled in after parsing like the others
+func presetDefaults() Config {
+	var config Config
+	config.Provider = "azure"
+	config.Azure.LanguageFamilyFallback = true
+	config.Azure.VoiceCacheRefreshIntervalH = 24
+	config.Server.RequestLogSamplingRate = 1.0
+	config.Server.Tracing.TraceRatio = 1.0
+	config.Database.VacuumIntervalHours = 24
+	return config
+}
+
+// defaultDatabasePath returns where the cache database is kept when database.path is unset
+func defaultDatabasePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
 	}
+	return filepath.Join(homeDir, ".local", "share", "tts-daemon", "cache.db"), nil
+}
 
+// applyDefaults fills in unset settings. database.path must already be set, since other paths
+// default to being next to the database.
+func applyDefaults(config *Config) {
 	// Set default for MaxQPS if not specified
 	if config.Azure.MaxQPS <= 0 {
 		config.Azure.MaxQPS = 10.0 // Default: 10 requests per second
 	}
+	if config.GCloud.MaxQPS <= 0 {
+		config.GCloud.MaxQPS = 10.0
+	}
+	if config.Polly.MaxQPS <= 0 {
+		config.Polly.MaxQPS = 10.0
+	}
+	if config.Azure.MockAudioDir == "" {
+		config.Azure.MockAudioDir = filepath.Join("testdata", "mock_audio")
+	}
+	if config.ElevenLabs.ModelID == "" {
+		config.ElevenLabs.ModelID = "eleven_multilingual_v2"
+	}
+	if config.ElevenLabs.MaxQPS <= 0 {
+		config.ElevenLabs.MaxQPS = 2.0
+	}
+	if config.OpenAI.Model == "" {
+		config.OpenAI.Model = "tts-1"
+	}
+	if config.OpenAI.Voice == "" {
+		config.OpenAI.Voice = "alloy"
+	}
+	if config.OpenAI.BaseURL == "" {
+		config.OpenAI.BaseURL = "https://api.openai.com/v1"
+	}
+	if config.OpenAI.MaxQPS <= 0 {
+		config.OpenAI.MaxQPS = 10.0
+	}
+	if config.Azure.BatchWindowMs <= 0 {
+		config.Azure.BatchWindowMs = 50
+	}
+	if config.Azure.OpenAIPreprocessEndpoint == "" {
+		config.Azure.OpenAIPreprocessEndpoint = "https://api.openai.com/v1/chat/completions"
+	}
+	if config.Azure.PreprocessModel == "" {
+		config.Azure.PreprocessModel = "gpt-4o-mini"
+	}
 
 	// Set defaults
-	if config.Database.Path == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get home directory: %w", err)
-		}
-		config.Database.Path = filepath.Join(homeDir, ".local", "share", "tts-daemon", "cache.db")
+	if config.Database.EvictionPolicy == "" {
+		config.Database.EvictionPolicy = "lru"
+	}
+	if config.Database.CompactIdleThresholdS <= 0 {
+		config.Database.CompactIdleThresholdS = 60
+	}
+	if config.Database.HotCacheThresholdAccessesPerDay <= 0 {
+		config.Database.HotCacheThresholdAccessesPerDay = 100
+	}
+	if config.Database.CleanupIntervalMinutes <= 0 {
+		config.Database.CleanupIntervalMinutes = 60
+	}
+	if config.Database.RedisNamespace == "" {
+		config.Database.RedisNamespace = "tts"
 	}
 
 	if config.Server.Address == "" {
@@ -83,6 +487,51 @@ func Load(configPath string) (*Config, error) {
 	if config.Server.Port == 0 {
 		config.Server.Port = 50051
 	}
+	if config.Server.QueuePollIntervalMs <= 0 {
+		config.Server.QueuePollIntervalMs = 100
+	}
+	if config.Server.EphemeralMaxTextLength <= 0 {
+		config.Server.EphemeralMaxTextLength = 500
+	}
+	if config.Server.AdaptiveBatchSize <= 0 {
+		config.Server.AdaptiveBatchSize = 5
+	}
+	if config.Server.WarmConcurrency <= 0 {
+		config.Server.WarmConcurrency = 4
+	}
+	if config.Server.StreamChunkSizeKB <= 0 {
+		config.Server.StreamChunkSizeKB = 32
+	}
+	if config.Server.LogFormat == "" {
+		config.Server.LogFormat = "text"
+	}
+	if config.Server.SlowRequestThresholdMs == 0 {
+		config.Server.SlowRequestThresholdMs = 1000
+	}
+	if config.Server.AlertWebhookMethod == "" {
+		config.Server.AlertWebhookMethod = "POST"
+	}
+	if config.Server.AlertCacheThresholdPercent <= 0 {
+		config.Server.AlertCacheThresholdPercent = 90.0
+	}
+	if config.Server.AlertCooldownMinutes <= 0 {
+		config.Server.AlertCooldownMinutes = 60
+	}
+	if config.Server.TLS.AcmeCacheDir == "" {
+		config.Server.TLS.AcmeCacheDir = filepath.Join(filepath.Dir(config.Database.Path), "acme")
+	}
+	if config.Server.TLS.AcmeHTTPPort == 0 {
+		config.Server.TLS.AcmeHTTPPort = 80
+	}
+	if config.Server.Tracing.Endpoint == "" {
+		config.Server.Tracing.Endpoint = "localhost:4317"
+	}
+	if config.Server.Tracing.ServiceName == "" {
+		config.Server.Tracing.ServiceName = "tts-daemon"
+	}
+	if config.Server.Metrics.Address == "" {
+		config.Server.Metrics.Address = ":9090"
+	}
 
 	if config.Audio.SampleRate == 0 {
 		config.Audio.SampleRate = 44100
@@ -90,8 +539,30 @@ func Load(configPath string) (*Config, error) {
 	if config.Audio.BufferSize == 0 {
 		config.Audio.BufferSize = 4096
 	}
+	if config.Audio.Bitrate == "" {
+		config.Audio.Bitrate = "128k"
+	}
+	if config.Audio.SampleRateHz == 0 {
+		config.Audio.SampleRateHz = 16000
+	}
+}
 
-	return &config, nil
+// LoadAudio reads only the audio section of the configuration file, without requiring
+// the daemon settings to be present. Unset values are left as zero.
+func LoadAudio(configPath string) (AudioConfig, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return AudioConfig{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var config struct {
+		Audio AudioConfig `yaml:"audio"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return AudioConfig{}, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	return config.Audio, nil
 }
 
 // GetDefaultConfigPath returns the default configuration file path
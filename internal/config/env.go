@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix starts the name of every environment variable that overrides a setting
+const EnvPrefix = "TTS_DAEMON_"
+
+// EnvVar is an environment variable that overrides a setting
+type EnvVar struct {
+	Name        string // e.g. TTS_DAEMON_AZURE_SUBSCRIPTION_KEY
+	Path        string // Dotted YAML path of the setting, e.g. azure.subscription_key
+	Description string
+}
+
+// EnvVars lists the environment variables that override settings, in the order the settings
+// appear in the file
+func EnvVars() []EnvVar {
+	var vars []EnvVar
+	walkSettings(reflect.ValueOf(&Config{}).Elem(), "", func(path string, _ reflect.Value) {
+		vars = append(vars, EnvVar{Name: envName(path), Path: path, Description: fieldComments[path]})
+	})
+	return vars
+}
+
+// envName returns the environment variable overriding the setting at path
+func envName(path string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+}
+
+// applyEnv overrides settings with the environment variables set for them. String settings take
+// the value as is; others are parsed as YAML, e.g. true, 20, [app-a, app-b] or {en-US: en-US-AriaNeural}.
+func applyEnv(config *Config) error {
+	var err error
+	walkSettings(reflect.ValueOf(config).Elem(), "", func(path string, field reflect.Value) {
+		value, ok := os.LookupEnv(envName(path))
+		if !ok || err != nil {
+			return
+		}
+		if field.Kind() == reflect.String {
+			field.SetString(value)
+			return
+		}
+
+		parsed := reflect.New(field.Type())
+		if yamlErr := yaml.Unmarshal([]byte(value), parsed.Interface()); yamlErr != nil {
+			err = fmt.Errorf("invalid %s: %w", envName(path), yamlErr)
+			return
+		}
+		field.Set(parsed.Elem())
+	})
+	return err
+}
+
+// walkSettings calls fn with the dotted YAML path of every setting under v, a struct whose path is
+// prefix, descending into nested sections
+func walkSettings(v reflect.Value, prefix string, fn func(path string, field reflect.Value)) {
+	for i := 0; i < v.NumField(); i++ {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			walkSettings(field, path, fn)
+			continue
+		}
+		fn(path, field)
+	}
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// generatedHeader starts every generated configuration file
+const generatedHeader = `# TTS Daemon Configuration
+# Generated by tts-daemon --generate-config. Fill in your Azure credentials and save it as
+# ~/.config/tts-daemon/config.yaml; every other value shown is the default.
+`
+
+// fieldComments documents each setting in generated files, keyed by its dotted YAML path
+var fieldComments = map[string]string{
+	"provider": "Synthesis backend: azure, gcloud, polly, elevenlabs or openai (default: azure)",
+
+	"azure":                                "Azure Cognitive Services settings",
+	"azure.subscription_key":               "Your Azure subscription key for Speech Services (required with provider azure)",
+	"azure.region":                         "Azure region, e.g. westus or westeurope (required with provider azure unless auto_detect_region is set)",
+	"azure.max_qps":                        "Maximum requests per second to Azure (default: 10.0)",
+	"azure.voices":                         "Custom voice mappings, e.g. en-US: en-US-AriaNeural (default: Azure's voice for each locale)",
+	"azure.language_fallback_chains":       "Locales to try, in order, for a language with no voice, e.g. pt-AO: [pt-BR, pt-PT] (default: en-US)",
+	"azure.language_family_fallback":       "Then try the closest related languages with a voice, e.g. pt-MZ, pt-BR for pt-AO (default: true)",
+	"azure.ephemeral_daily_budget":         "Characters per day (UTC) for uncached ephemeral synthesis (default: 0, unlimited)",
+	"azure.auto_detect_region":             "Find the key's region at startup when region is empty (default: false)",
+	"azure.voice_cache_refresh_interval_h": "Hours between reloads of Azure's voice list, to pick up new voices (default: 24, 0 = never)",
+	"azure.preprocess_with_openai":         "Rewrite text with an OpenAI chat model before synthesis; entries are cached under the original text (default: false)",
+	"azure.openai_preprocess_endpoint":     "chat/completions URL used for preprocessing (default: https://api.openai.com/v1/chat/completions)",
+	"azure.openai_api_key":                 "API key sent as a bearer token to the preprocessing endpoint",
+	"azure.preprocess_model":               "Chat model used for preprocessing (default: gpt-4o-mini)",
+	"azure.batch_synthesis":                "Combine WAV requests arriving together into one Azure request (default: false)",
+	"azure.batch_window_ms":                "How long to collect requests for a batch (default: 50)",
+	"azure.mock":                           "Serve pre-recorded audio instead of calling Azure, for development (default: false)",
+	"azure.mock_audio_dir":                 "Directory of <language_code>.mp3 files served in mock mode (default: testdata/mock_audio)",
+
+	"gcloud":                  "Google Cloud Text-to-Speech settings, used with provider gcloud",
+	"gcloud.credentials_file": "Service account JSON key (default: empty, application default credentials)",
+	"gcloud.region":           "Regional endpoint such as eu or us (default: empty, global)",
+	"gcloud.max_qps":          "Maximum requests per second to Google (default: 10.0)",
+	"gcloud.voices":           "Custom voice mappings, e.g. en-US: en-US-Neural2-F (default: Google's best voice for each locale)",
+
+	"polly":         "Amazon Polly settings, used with provider polly; credentials come from the standard AWS chain",
+	"polly.region":  "AWS region, e.g. us-east-1 (default: empty, the chain's region such as AWS_REGION)",
+	"polly.max_qps": "Maximum requests per second to Polly (default: 10.0)",
+	"polly.voices":  "Custom voice mappings, e.g. en-US: Joanna (default: a neural voice for each locale, else a standard one)",
+
+	"elevenlabs":                  "ElevenLabs settings, used with provider elevenlabs",
+	"elevenlabs.api_key":          "Your ElevenLabs API key (required with provider elevenlabs)",
+	"elevenlabs.default_voice_id": "Voice ID for languages not in voices (required unless voices is set)",
+	"elevenlabs.voices":           "Voice IDs by language code, e.g. fr-FR: <voice_id>; ElevenLabs voices have no locale",
+	"elevenlabs.model_id":         "Synthesis model (default: eleven_multilingual_v2)",
+	"elevenlabs.max_qps":          "Maximum requests per second to ElevenLabs (default: 2.0)",
+
+	"openai":          "OpenAI TTS settings, used with provider openai",
+	"openai.api_key":  "Your OpenAI API key (required with provider openai unless base_url is changed)",
+	"openai.model":    "tts-1 or tts-1-hd (default: tts-1)",
+	"openai.voice":    "alloy, echo, fable, onyx, nova or shimmer, used for every language (default: alloy)",
+	"openai.base_url": "API base URL, e.g. an Azure OpenAI deployment or a local proxy (default: https://api.openai.com/v1)",
+	"openai.max_qps":  "Maximum requests per second to OpenAI (default: 10.0)",
+
+	"database":                   "Cache database settings",
+	"database.path":              "Path to the SQLite cache (default: ~/.local/share/tts-daemon/cache.db)",
+	"database.compression":       "Compress cached audio with zstd or lz4 (faster to decompress), or none (default: none)",
+	"database.compression_level": "zstd level for newly cached audio, 1-19 (default: 0, zstd's default of 3)",
+	"database.max_size_mb":       "Maximum cache size in MB before entries are evicted (default: 0, unlimited)",
+	"database.eviction_policy":   "Which entries to evict when over max_size_mb: lru, lfu or fifo (default: lru)",
+	"database.language_quotas":   "Maximum size in MB per language code, e.g. en-US: 200 (default: none)",
+	"database.replay_log_path":   "Append a record of every cached text here for tts-restore (default: empty, disabled)",
+	"database.replay_log_max_mb": "Rotate the replay log at this size (default: 0, never)",
+	"database.delta_compression": "Store an entry as a binary delta against the same text cached with other options when that is smaller (default: false)",
+	"database.fingerprint_dedup": "Don't store audio identical to an entry cached for another text (default: false)",
+
+	"database.compact_schedule":         "Cron expression for compacting the database with VACUUM, e.g. \"0 3 * * *\" (default: empty, never)",
+	"database.compact_idle_threshold_s": "Seconds without requests before a scheduled compaction starts; it retries every 10 minutes until then (default: 60)",
+	"database.vacuum_interval_hours":    "How often pages freed by evictions and deletions are returned to the file system with an incremental vacuum (default: 24, 0 disables it)",
+
+	"database.hot_cache_backend":                    "Keep the most accessed entries outside SQLite: memory or bbolt (default: empty, disabled)",
+	"database.hot_cache_threshold_accesses_per_day": "Accesses per day that make an entry hot, and keep it hot (default: 100)",
+	"database.memory_cache_entries":                 "Most recently used entries kept decoded in memory in front of SQLite (default: 0, disabled)",
+	"database.redis_url":                            "Redis server to share entries with other daemons through, e.g. redis://localhost:6379/0 (default: empty, disabled)",
+	"database.redis_namespace":                      "Prefix of the keys entries are stored under in Redis (default: tts)",
+	"database.s3_bucket":                            "S3 bucket to archive entries in, behind SQLite (default: empty, disabled)",
+	"database.s3_prefix":                            "Prefix of the object keys, e.g. tts/cache (default: empty, bucket root)",
+	"database.s3_region":                            "AWS region of the bucket (default: empty, from the AWS configuration)",
+	"database.s3_endpoint":                          "Endpoint of an S3-compatible service such as MinIO, e.g. http://localhost:9000 (default: empty, AWS)",
+
+	"database.ttl_days":                 "Days after which entries count as cache misses and are deleted (default: 0, never)",
+	"database.cleanup_interval_minutes": "How often expired entries, past ttl_days or their own ttl_seconds, are deleted (default: 60)",
+
+	"server":                               "gRPC server settings",
+	"server.address":                       "Address to listen on (default: localhost)",
+	"server.port":                          "Port to listen on (default: 50051)",
+	"server.queue_poll_interval_ms":        "How often the synthesis queue worker checks for jobs (default: 100)",
+	"server.ephemeral_max_text_length":     "Maximum characters per ephemeral synthesis request (default: 500)",
+	"server.adaptive_batch_size":           "Initial concurrency of adaptive bulk fetches (default: 5)",
+	"server.warm_concurrency":              "Texts a cache warming request fetches at once (default: 4)",
+	"server.stream_chunk_size_kb":          "Size of the audio chunks StreamTTS sends, in KiB (default: 32)",
+	"server.allowed_save_directories":      "Where FetchAndSave may write files (default: none, disabled)",
+	"server.readiness_port":                "HTTP port serving the /ready probe, which fails while draining (default: 0, disabled)",
+	"server.log_format":                    "text, or json to write one JSON object per line for log aggregation tools (default: text)",
+	"server.request_log_sampling_rate":     "Fraction of requests logged, 0.0-1.0; errors and slow requests are always logged (default: 1.0, all)",
+	"server.slow_request_threshold_ms":     "Requests slower than this are logged even when not sampled (default: 1000, negative disables)",
+	"server.alert_webhook_url":             "Notified when the cache passes alert_cache_threshold_percent of max_size_mb (default: empty, disabled)",
+	"server.alert_webhook_method":          "POST (JSON body) or GET (query parameters) (default: POST)",
+	"server.alert_cache_threshold_percent": "Percentage of database.max_size_mb that triggers an alert (default: 90)",
+	"server.alert_cooldown_minutes":        "Minimum time between alerts (default: 60)",
+	"server.api_key":                       "Key clients must send with -api-key or TTS_DAEMON_API_KEY (default: empty, no authentication)",
+	"server.namespaces":                    "Cache namespaces requests may use, each cached separately; requests for others are rejected (default: none, only the default namespace)",
+	"server.credentials":                   "Clients allowed to use each namespace, by api_key or client certificate client_name, e.g. [{api_key: KEY, namespaces: [app-a]}] (default: none, every client may use every namespace)",
+	"server.tls":                           "TLS with a certificate from files, or from Let's Encrypt with the -acme flag",
+	"server.tls.cert_file":                 "PEM certificate to serve over TLS, with key_file (default: none, TLS disabled)",
+	"server.tls.key_file":                  "PEM private key for cert_file",
+	"server.tls.client_ca_cert_file":       "PEM CA certificates that client certificates must be signed by (default: none)",
+	"server.tls.require_client_cert":       "Reject clients without a certificate signed by client_ca_cert_file (default: false)",
+	"server.tls.acme_domain":               "Domain to obtain a certificate for",
+	"server.tls.acme_cache_dir":            "Where certificates and the ACME account key are stored (default: acme next to the database)",
+	"server.tls.acme_http_port":            "Port for the HTTP-01 challenge responder (default: 80)",
+	"server.tls.acme_directory_url":        "ACME directory to use, e.g. https://acme-staging-v02.api.letsencrypt.org/directory for staging (default: Let's Encrypt production)",
+	"server.tracing":                       "OpenTelemetry tracing",
+	"server.tracing.enabled":               "Export spans for each request (default: false)",
+	"server.tracing.endpoint":              "OTLP/gRPC collector address (default: localhost:4317)",
+	"server.tracing.service_name":          "Service name attached to spans (default: tts-daemon)",
+	"server.tracing.trace_ratio":           "Fraction of new traces sampled, 0.0-1.0; traces started by callers keep their decision (default: 1.0, all)",
+	"server.metrics":                       "Prometheus metrics over HTTP",
+	"server.metrics.enabled":               "Serve /metrics (default: false)",
+	"server.metrics.address":               "Address to serve /metrics on (default: :9090)",
+
+	"audio":                              "Audio settings",
+	"audio.sample_rate":                  "Playback sample rate in Hz (default: 44100)",
+	"audio.buffer_size":                  "Playback buffer size in samples (default: 4096)",
+	"audio.fade_in_ms":                   "Volume ramp at the start of playback (default: 0, 20ms; negative disables)",
+	"audio.fade_out_ms":                  "Volume ramp at the end of playback (default: 0, 20ms; negative disables)",
+	"audio.prefer_opus":                  "Client requests OGG Opus instead of MP3 when no -format is given (default: false)",
+	"audio.bitrate":                      "MP3 bitrate requested from Azure: 32k, 48k, 64k, 96k, 128k, 160k or 192k (default: 128k)",
+	"audio.sample_rate_hz":               "MP3 sample rate requested from Azure: 16000, 24000 or 48000 (default: 16000)",
+	"audio.inject_breaks":                "Short pause after sentence-ending punctuation (default: false)",
+	"audio.break_at_newlines":            "Pauses at line and paragraph breaks (default: false)",
+	"audio.restore_punctuation":          "Restore punctuation in unpunctuated input such as speech recognition output (default: false)",
+	"audio.restore_punctuation_language": "Punctuation rules to use (default: empty, the request's language)",
+}
+
+// Generate returns config as a YAML configuration file with a comment describing each setting
+func Generate(config *Config) ([]byte, error) {
+	var doc yaml.Node
+	if err := doc.Encode(config); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	addComments(&doc, "")
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	// Separate the top-level sections, whose comments are the only ones not indented
+	body := bytes.ReplaceAll(buf.Bytes(), []byte("\n# "), []byte("\n\n# "))
+	return append([]byte(generatedHeader+"\n"), body...), nil
+}
+
+// addComments attaches fieldComments to the keys of mapping nodes under node, whose path is prefix
+func addComments(node *yaml.Node, prefix string) {
+	if node.Kind != yaml.MappingNode {
+		for _, child := range node.Content {
+			addComments(child, prefix)
+		}
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		path := key.Value
+		if prefix != "" {
+			path = prefix + "." + key.Value
+		}
+		if comment, ok := fieldComments[path]; ok {
+			key.HeadComment = comment
+		}
+		if value.Kind == yaml.MappingNode {
+			addComments(value, path)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// compareSettings reports every setting of got that differs from want
+func compareSettings(t *testing.T, got, want *Config) {
+	t.Helper()
+	wantFields := make(map[string]reflect.Value)
+	walkSettings(reflect.ValueOf(want).Elem(), "", func(path string, field reflect.Value) {
+		wantFields[path] = field
+	})
+	walkSettings(reflect.ValueOf(got).Elem(), "", func(path string, field reflect.Value) {
+		wantField := wantFields[path]
+		// An empty list or map is written as [] or {}, which reads back empty rather than nil
+		if (field.Kind() == reflect.Map || field.Kind() == reflect.Slice) && field.Len() == 0 && wantField.Len() == 0 {
+			return
+		}
+		if !reflect.DeepEqual(field.Interface(), wantField.Interface()) {
+			t.Errorf("%s = %#v, want the default %#v", path, field.Interface(), wantField.Interface())
+		}
+	})
+	if len(wantFields) == 0 {
+		t.Fatal("no settings were compared")
+	}
+}
+
+// loadConfig loads a config file with the given contents
+func loadConfig(t *testing.T, data []byte) *Config {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	config, err := Load(path)
+	if err != nil {
+		t.Fatalf("the config doesn't load: %v\n%s", err, data)
+	}
+	return config
+}
+
+func TestGenerateRoundTrip(t *testing.T) {
+	defaults := Defaults()
+	defaults.Azure.SubscriptionKey = "YOUR_KEY_HERE"
+	defaults.Azure.Region = "YOUR_REGION_HERE"
+
+	data, err := Generate(defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Run("generated file", func(t *testing.T) {
+		compareSettings(t, loadConfig(t, data), defaults)
+	})
+	// The defaults written out must be the ones a file leaving everything unset gets
+	t.Run("unset settings", func(t *testing.T) {
+		minimal := loadConfig(t, []byte("azure:\n  subscription_key: YOUR_KEY_HERE\n  region: YOUR_REGION_HERE\n"))
+		compareSettings(t, minimal, defaults)
+	})
+}
+
+func TestGenerateComments(t *testing.T) {
+	data, err := Generate(Defaults())
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(data)
+	if !strings.HasPrefix(text, generatedHeader) {
+		t.Error("the generated config doesn't start with the header")
+	}
+	for _, want := range []string{
+		"# Maximum requests per second to Azure (default: 10.0)\n  max_qps: 10",
+		"# Azure Cognitive Services settings\nazure:",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("the generated config doesn't contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// reloadableSettings are the settings a running daemon applies when it reloads its config file.
+// Settings in the audio section that aren't listed are only read by clients.
+var reloadableSettings = map[string]bool{
+	"azure.max_qps":      true,
+	"gcloud.max_qps":     true,
+	"polly.max_qps":      true,
+	"elevenlabs.max_qps": true,
+	"openai.max_qps":     true,
+	"azure.voices":       true,
+
+	"database.max_size_mb": true,
+
+	"server.api_key":                   true,
+	"server.namespaces":                true,
+	"server.credentials":               true,
+	"server.request_log_sampling_rate": true,
+	"server.slow_request_threshold_ms": true,
+	"server.stream_chunk_size_kb":      true,
+	"server.allowed_save_directories":  true,
+	"server.adaptive_batch_size":       true,
+	"server.warm_concurrency":          true,
+	"server.ephemeral_max_text_length": true,
+
+	"audio.bitrate":                      true,
+	"audio.sample_rate_hz":               true,
+	"audio.inject_breaks":                true,
+	"audio.break_at_newlines":            true,
+	"audio.restore_punctuation":          true,
+	"audio.restore_punctuation_language": true,
+}
+
+// Changes compares the settings of two configurations, returning the dotted paths of the ones
+// that differ, split into those a running daemon applies on reload and those that only take
+// effect after a restart. Client-only settings aren't returned.
+func Changes(old, new *Config) (reloadable, restart []string) {
+	oldSettings := make(map[string]reflect.Value)
+	walkSettings(reflect.ValueOf(old).Elem(), "", func(path string, field reflect.Value) {
+		oldSettings[path] = field
+	})
+	walkSettings(reflect.ValueOf(new).Elem(), "", func(path string, field reflect.Value) {
+		if reflect.DeepEqual(oldSettings[path].Interface(), field.Interface()) {
+			return
+		}
+		switch {
+		case reloadableSettings[path]:
+			reloadable = append(reloadable, path)
+		case strings.HasPrefix(path, "audio."):
+			// Read by clients only
+		default:
+			restart = append(restart, path)
+		}
+	})
+	return reloadable, restart
+}
+
+// CopySetting sets the setting at path, a dotted YAML path, in dst to its value in src
+func CopySetting(dst, src *Config, path string) error {
+	var value reflect.Value
+	walkSettings(reflect.ValueOf(src).Elem(), "", func(p string, field reflect.Value) {
+		if p == path {
+			value = field
+		}
+	})
+	if !value.IsValid() {
+		return fmt.Errorf("unknown setting %s", path)
+	}
+	walkSettings(reflect.ValueOf(dst).Elem(), "", func(p string, field reflect.Value) {
+		if p == path {
+			field.Set(value)
+		}
+	})
+	return nil
+}
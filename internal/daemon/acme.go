@@ -0,0 +1,65 @@
+package daemon
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"com.biesnecker/tts-daemon/internal/config"
+	"com.biesnecker/tts-daemon/internal/logging"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+	"google.golang.org/grpc/credentials"
+)
+
+// ACMECredentials returns gRPC transport credentials backed by a Let's Encrypt certificate for
+// cfg.AcmeDomain. The certificate is obtained on the first TLS handshake and renewed automatically.
+// It also starts the HTTP-01 challenge responder on cfg.AcmeHTTPPort.
+func ACMECredentials(cfg config.TLSConfig) (credentials.TransportCredentials, error) {
+	tlsConfig, _, err := acmeTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// acmeTLSConfig returns the TLS config for ACMECredentials, after starting the challenge
+// responder, along with the address the responder listens on
+func acmeTLSConfig(cfg config.TLSConfig) (*tls.Config, net.Addr, error) {
+	if cfg.AcmeDomain == "" {
+		return nil, nil, fmt.Errorf("server.tls.acme_domain is required")
+	}
+	if err := os.MkdirAll(cfg.AcmeCacheDir, 0700); err != nil {
+		return nil, nil, fmt.Errorf("failed to create ACME cache directory: %w", err)
+	}
+
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(cfg.AcmeDomain),
+		Cache:      autocert.DirCache(cfg.AcmeCacheDir),
+	}
+	if cfg.AcmeDirectoryURL != "" {
+		manager.Client = &acme.Client{DirectoryURL: cfg.AcmeDirectoryURL}
+	}
+
+	// Listen before returning so a port conflict is reported at startup
+	challengeAddr := fmt.Sprintf(":%d", cfg.AcmeHTTPPort)
+	listener, err := net.Listen("tcp", challengeAddr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to listen for ACME challenges on %s: %w", challengeAddr, err)
+	}
+	go func() {
+		if err := http.Serve(listener, manager.HTTPHandler(nil)); err != nil {
+			logging.Warn("ACME challenge responder stopped", "error", err)
+		}
+	}()
+
+	tlsConfig := manager.TLSConfig()
+	if err := clientAuth(tlsConfig, cfg); err != nil {
+		listener.Close()
+		return nil, nil, err
+	}
+	return tlsConfig, listener.Addr(), nil
+}
@@ -0,0 +1,371 @@
+package daemon
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// mockCA is an ACME (RFC 8555) server that validates HTTP-01 challenges and issues certificates
+// signed by its own root. It supports one domain per order and skips JWS signature checks.
+type mockCA struct {
+	t        *testing.T
+	server   *httptest.Server
+	rootKey  *ecdsa.PrivateKey
+	rootCert *x509.Certificate
+
+	mu             sync.Mutex
+	challengeAddr  string // Where HTTP-01 challenges are fetched from, standing in for DNS
+	orders         []*mockOrder
+	validations    int // HTTP-01 challenges validated
+	certsIssued    int
+	issuedForNames []string
+}
+
+// mockOrder is an order for one domain, with a single authorization
+type mockOrder struct {
+	domain      string
+	status      string
+	authzStatus string
+	leaf        []byte // DER certificate once issued
+}
+
+func newMockCA(t *testing.T) *mockCA {
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Mock ACME Root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, rootKey.Public(), rootKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootCert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ca := &mockCA{t: t, rootKey: rootKey, rootCert: rootCert}
+	ca.server = httptest.NewServer(http.HandlerFunc(ca.handle))
+	t.Cleanup(ca.server.Close)
+	return ca
+}
+
+// roots returns a pool containing the CA's root certificate
+func (ca *mockCA) roots() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.rootCert)
+	return pool
+}
+
+func (ca *mockCA) url(format string, args ...any) string {
+	return ca.server.URL + fmt.Sprintf(format, args...)
+}
+
+// orderJSON is the order object for order i, whose status must be read with ca.mu held
+func (ca *mockCA) orderJSON(i int) map[string]any {
+	o := ca.orders[i]
+	order := map[string]any{
+		"status":         o.status,
+		"identifiers":    []map[string]string{{"type": "dns", "value": o.domain}},
+		"authorizations": []string{ca.url("/authz/%d", i)},
+		"finalize":       ca.url("/finalize/%d", i),
+	}
+	if o.leaf != nil {
+		order["certificate"] = ca.url("/cert/%d", i)
+	}
+	return order
+}
+
+func (ca *mockCA) handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Replay-Nonce", fmt.Sprintf("nonce-%d", time.Now().UnixNano()))
+	var id int
+	switch {
+	case r.URL.Path == "/directory":
+		writeJSON(w, http.StatusOK, map[string]string{
+			"newNonce":   ca.url("/new-nonce"),
+			"newAccount": ca.url("/new-account"),
+			"newOrder":   ca.url("/new-order"),
+		})
+
+	case r.URL.Path == "/new-nonce":
+		w.WriteHeader(http.StatusOK)
+
+	case r.URL.Path == "/new-account":
+		w.Header().Set("Location", ca.url("/account/1"))
+		writeJSON(w, http.StatusCreated, map[string]string{"status": "valid"})
+
+	case r.URL.Path == "/new-order":
+		var req struct {
+			Identifiers []struct{ Value string }
+		}
+		if err := decodeJWSPayload(r.Body, &req); err != nil || len(req.Identifiers) != 1 {
+			http.Error(w, "want one identifier", http.StatusBadRequest)
+			return
+		}
+		ca.mu.Lock()
+		defer ca.mu.Unlock()
+		ca.orders = append(ca.orders, &mockOrder{domain: req.Identifiers[0].Value, status: "pending", authzStatus: "pending"})
+		i := len(ca.orders) - 1
+		w.Header().Set("Location", ca.url("/order/%d", i))
+		writeJSON(w, http.StatusCreated, ca.orderJSON(i))
+
+	case scanPath(r.URL.Path, "/order/%d", &id):
+		ca.mu.Lock()
+		defer ca.mu.Unlock()
+		writeJSON(w, http.StatusOK, ca.orderJSON(id))
+
+	case scanPath(r.URL.Path, "/authz/%d", &id):
+		ca.mu.Lock()
+		defer ca.mu.Unlock()
+		o := ca.orders[id]
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status":     o.authzStatus,
+			"identifier": map[string]string{"type": "dns", "value": o.domain},
+			"challenges": []map[string]string{{
+				"type":   "http-01",
+				"url":    ca.url("/challenge/%d", id),
+				"token":  fmt.Sprintf("token%d", id),
+				"status": o.authzStatus,
+			}},
+		})
+
+	case scanPath(r.URL.Path, "/challenge/%d", &id):
+		status := "valid"
+		if err := ca.validate(id); err != nil {
+			ca.t.Errorf("HTTP-01 validation failed: %v", err)
+			status = "invalid"
+		}
+		ca.mu.Lock()
+		defer ca.mu.Unlock()
+		ca.orders[id].authzStatus = status
+		ca.orders[id].status = map[string]string{"valid": "ready", "invalid": "invalid"}[status]
+		writeJSON(w, http.StatusOK, map[string]string{
+			"type":   "http-01",
+			"url":    ca.url("/challenge/%d", id),
+			"token":  fmt.Sprintf("token%d", id),
+			"status": status,
+		})
+
+	case scanPath(r.URL.Path, "/finalize/%d", &id):
+		var req struct {
+			CSR string `json:"csr"`
+		}
+		if err := decodeJWSPayload(r.Body, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		csrDER, err := base64.RawURLEncoding.DecodeString(req.CSR)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		csr, err := x509.ParseCertificateRequest(csrDER)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ca.mu.Lock()
+		defer ca.mu.Unlock()
+		o := ca.orders[id]
+		if o.status != "ready" {
+			http.Error(w, "order is "+o.status, http.StatusForbidden)
+			return
+		}
+		ca.certsIssued++
+		ca.issuedForNames = append(ca.issuedForNames, csr.DNSNames...)
+		leaf := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(ca.certsIssued + 1)),
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			DNSNames:     csr.DNSNames,
+		}
+		o.leaf, err = x509.CreateCertificate(rand.Reader, leaf, ca.rootCert, csr.PublicKey, ca.rootKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		o.status = "valid"
+		w.Header().Set("Location", ca.url("/order/%d", id))
+		writeJSON(w, http.StatusOK, ca.orderJSON(id))
+
+	case scanPath(r.URL.Path, "/cert/%d", &id):
+		ca.mu.Lock()
+		defer ca.mu.Unlock()
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		pem.Encode(w, &pem.Block{Type: "CERTIFICATE", Bytes: ca.orders[id].leaf})
+		pem.Encode(w, &pem.Block{Type: "CERTIFICATE", Bytes: ca.rootCert.Raw})
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// validate fetches order id's HTTP-01 challenge response from the daemon's responder
+func (ca *mockCA) validate(id int) error {
+	ca.mu.Lock()
+	domain, addr := ca.orders[id].domain, ca.challengeAddr
+	ca.mu.Unlock()
+
+	token := fmt.Sprintf("token%d", id)
+	req, err := http.NewRequest("GET", "http://"+addr+"/.well-known/acme-challenge/"+token, nil)
+	if err != nil {
+		return err
+	}
+	req.Host = domain
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(string(body), token+".") {
+		return fmt.Errorf("got %d %q, want the key authorization for %s", resp.StatusCode, body, token)
+	}
+
+	ca.mu.Lock()
+	ca.validations++
+	ca.mu.Unlock()
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// scanPath reports whether path matches format, storing its number in id
+func scanPath(path, format string, id *int) bool {
+	_, err := fmt.Sscanf(path, format, id)
+	return err == nil && fmt.Sprintf(format, *id) == path
+}
+
+// decodeJWSPayload decodes the payload of a flattened JWS request body into v
+func decodeJWSPayload(r io.Reader, v any) error {
+	var jws struct{ Payload string }
+	if err := json.NewDecoder(r).Decode(&jws); err != nil {
+		return err
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, v)
+}
+
+func TestACMECredentials(t *testing.T) {
+	const domain = "tts.example.test"
+	ca := newMockCA(t)
+
+	tlsConfig, challengeAddr, err := acmeTLSConfig(config.TLSConfig{
+		AcmeDomain:       domain,
+		AcmeCacheDir:     filepath.Join(t.TempDir(), "acme"),
+		AcmeDirectoryURL: ca.url("/directory"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Resolve the domain to the challenge responder, as DNS would for a real deployment
+	_, port, _ := net.SplitHostPort(challengeAddr.String())
+	ca.mu.Lock()
+	ca.challengeAddr = net.JoinHostPort("127.0.0.1", port)
+	ca.mu.Unlock()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(listener)
+	defer server.Stop()
+
+	clientCreds := credentials.NewTLS(&tls.Config{ServerName: domain, RootCAs: ca.roots()})
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(clientCreds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// The certificate is obtained during the first handshake, and reused for later ones
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		_, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		cancel()
+		if err != nil {
+			t.Fatalf("health check over TLS: %v", err)
+		}
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	if ca.validations != 1 {
+		t.Errorf("%d HTTP-01 challenges validated, want 1", ca.validations)
+	}
+	if ca.certsIssued != 1 || len(ca.issuedForNames) != 1 || ca.issuedForNames[0] != domain {
+		t.Errorf("issued %d certificates for %v, want 1 for %s", ca.certsIssued, ca.issuedForNames, domain)
+	}
+}
+
+func TestACMECredentialsRejectsOtherDomains(t *testing.T) {
+	ca := newMockCA(t)
+	tlsConfig, _, err := acmeTLSConfig(config.TLSConfig{
+		AcmeDomain:       "tts.example.test",
+		AcmeCacheDir:     filepath.Join(t.TempDir(), "acme"),
+		AcmeDirectoryURL: ca.url("/directory"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tlsConfig.GetCertificate(&tls.ClientHelloInfo{ServerName: "other.example.test"}); err == nil {
+		t.Error("got a certificate for a domain other than acme_domain")
+	}
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	if len(ca.orders) != 0 {
+		t.Errorf("%d orders were placed, want none", len(ca.orders))
+	}
+}
+
+func TestACMECredentialsNeedsDomain(t *testing.T) {
+	if _, err := ACMECredentials(config.TLSConfig{AcmeCacheDir: t.TempDir()}); err == nil {
+		t.Error("ACMECredentials succeeded without acme_domain")
+	}
+}
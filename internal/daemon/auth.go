@@ -0,0 +1,99 @@
+package daemon
+
+import (
+	"context"
+	"crypto/subtle"
+	"slices"
+
+	"com.biesnecker/tts-daemon/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Authenticate is a unary interceptor that rejects calls without the configured API key
+// (server.api_key), or the key or client certificate of one of server.credentials, in their
+// authorization metadata. Every call is accepted when no key is set, and health checks always
+// are, so probes don't need the key.
+func (s *Server) Authenticate(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if isHealthCheck(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	if err := s.checkAPIKey(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// AuthenticateStreams is the stream interceptor counterpart of Authenticate
+func (s *Server) AuthenticateStreams(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isHealthCheck(info.FullMethod) {
+		return handler(srv, ss)
+	}
+	if err := s.checkAPIKey(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// checkAPIKey returns an Unauthenticated error unless ctx carries "authorization: Bearer <key>"
+// with server.api_key or a credential's key, or the client certificate of a credential
+func (s *Server) checkAPIKey(ctx context.Context) error {
+	cfg := s.config.Load().Server
+	keys := []string{cfg.APIKey}
+	for _, credential := range cfg.Credentials {
+		keys = append(keys, credential.APIKey)
+	}
+	keys = slices.DeleteFunc(keys, func(key string) bool { return key == "" })
+	if len(keys) == 0 {
+		return nil
+	}
+
+	for _, key := range keys {
+		if hasAPIKey(ctx, key) {
+			return nil
+		}
+	}
+	for _, credential := range cfg.Credentials {
+		if presents(ctx, credential) {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid API key")
+}
+
+// hasAPIKey reports whether ctx carries "authorization: Bearer <key>"
+func hasAPIKey(ctx context.Context, key string) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	want := []byte("Bearer " + key)
+	for _, value := range md.Get("authorization") {
+		if subtle.ConstantTimeCompare([]byte(value), want) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// presents reports whether the client of ctx sent credential's API key, or a verified client
+// certificate with its client name as the common name or a DNS name
+func presents(ctx context.Context, credential config.CredentialConfig) bool {
+	if credential.APIKey != "" && hasAPIKey(ctx, credential.APIKey) {
+		return true
+	}
+	if credential.ClientName == "" {
+		return false
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return false
+	}
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	return cert.Subject.CommonName == credential.ClientName || slices.Contains(cert.DNSNames, credential.ClientName)
+}
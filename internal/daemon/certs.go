@@ -0,0 +1,131 @@
+package daemon
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/config"
+	"google.golang.org/grpc/credentials"
+)
+
+// selfSignedValidity is how long certificates from GenerateSelfSignedCert are valid
+const selfSignedValidity = 2 * 365 * 24 * time.Hour
+
+// FileCredentials returns gRPC transport credentials for the certificate and key in
+// cfg.CertFile and cfg.KeyFile, verifying client certificates as configured (see clientAuth)
+func FileCredentials(cfg config.TLSConfig) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server.tls.cert_file and key_file: %w", err)
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+	if err := clientAuth(tlsConfig, cfg); err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// clientAuth sets up tlsConfig to verify client certificates against cfg.ClientCACertFile, if
+// set. Clients without a certificate are rejected when cfg.RequireClientCert is set.
+func clientAuth(tlsConfig *tls.Config, cfg config.TLSConfig) error {
+	if cfg.ClientCACertFile == "" {
+		return nil
+	}
+	pemData, err := os.ReadFile(cfg.ClientCACertFile)
+	if err != nil {
+		return fmt.Errorf("failed to read server.tls.client_ca_cert_file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemData) {
+		return fmt.Errorf("server.tls.client_ca_cert_file contains no PEM certificates")
+	}
+
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	if cfg.RequireClientCert {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return nil
+}
+
+// GenerateSelfSignedCert writes a self-signed certificate valid for hosts (host names or IP
+// addresses) to certFile, and its private key to keyFile. Clients trust it by passing the
+// certificate file as their CA certificate.
+func GenerateSelfSignedCert(certFile, keyFile string, hosts []string) error {
+	if certFile == "" || keyFile == "" {
+		return fmt.Errorf("server.tls.cert_file and server.tls.key_file must be set")
+	}
+	if len(hosts) == 0 {
+		return fmt.Errorf("at least one host is required")
+	}
+	for _, path := range []string{certFile, keyFile} {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists", path)
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: hosts[0], Organization: []string{"tts-daemon"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true, // So clients can use it as their CA certificate
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return fmt.Errorf("failed to create certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode key: %w", err)
+	}
+
+	if err := writePEM(keyFile, "PRIVATE KEY", keyDER, 0600); err != nil {
+		return err
+	}
+	return writePEM(certFile, "CERTIFICATE", certDER, 0644)
+}
+
+// writePEM writes a single PEM block to path, refusing to overwrite an existing file
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := pem.Encode(f, &pem.Block{Type: blockType, Bytes: der}); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,97 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	"google.golang.org/grpc"
+)
+
+// defaultDrainTimeout is how long SetDraining waits for requests when it's given no timeout
+const defaultDrainTimeout = 30 * time.Second
+
+// drainPollInterval is how often WaitForRequests checks the number of active requests
+const drainPollInterval = 100 * time.Millisecond
+
+// SetDrainHandler sets the function SetDraining calls, in the background, to stop the daemon.
+// It should stop accepting connections, wait up to timeout for active requests (see
+// WaitForRequests) and then stop the gRPC server.
+func (s *Server) SetDrainHandler(drain func(timeout time.Duration)) {
+	s.drain = drain
+}
+
+// Draining reports whether SetDraining has been called
+func (s *Server) Draining() bool {
+	return s.draining.Load()
+}
+
+// SetDraining implements the SetDraining RPC method
+func (s *Server) SetDraining(ctx context.Context, req *pb.DrainRequest) (*pb.DrainResponse, error) {
+	if s.drain == nil {
+		return nil, fmt.Errorf("draining is not supported by this server")
+	}
+	if !s.draining.CompareAndSwap(false, true) {
+		return nil, fmt.Errorf("the daemon is already draining")
+	}
+
+	timeout := time.Duration(req.DrainTimeoutS) * time.Second
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+	active := s.activeRequests.Load() - 1 // Not counting this request
+
+	logRequest(ctx, "SetDraining", "active_requests", active, "timeout", timeout)
+	go s.drain(timeout)
+	return &pb.DrainResponse{ActiveRequestsAtDrainStart: active}, nil
+}
+
+// WaitForRequests waits until no requests are active, and reports whether that happened within
+// timeout
+func (s *Server) WaitForRequests(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for s.activeRequests.Load() > 0 {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(drainPollInterval)
+	}
+	return true
+}
+
+// TrackRequests is a unary interceptor counting the requests in progress, for SetDraining, and
+// noting when the last one arrived, for scheduled compaction
+func (s *Server) TrackRequests(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if isHealthCheck(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	s.ttsService.RecordRequest()
+	s.activeRequests.Add(1)
+	defer s.activeRequests.Add(-1)
+	return handler(ctx, req)
+}
+
+// TrackStreams is the stream interceptor counterpart of TrackRequests
+func (s *Server) TrackStreams(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isHealthCheck(info.FullMethod) {
+		return handler(srv, ss)
+	}
+	s.ttsService.RecordRequest()
+	s.activeRequests.Add(1)
+	defer s.activeRequests.Add(-1)
+	return handler(srv, ss)
+}
+
+// ReadinessHandler serves the readiness probe: 200 while the daemon accepts requests and 503
+// once it is draining
+func (s *Server) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.draining.Load() {
+			http.Error(w, "draining", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ready")
+	})
+}
@@ -0,0 +1,38 @@
+package daemon
+
+import (
+	"bufio"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// exportWriter sends what is written to it as ExportChunk messages
+type exportWriter struct {
+	stream pb.TTSService_ExportCacheServer
+	offset int64
+}
+
+// Write implements io.Writer
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&pb.ExportChunk{Data: p, Offset: w.offset}); err != nil {
+		return 0, err
+	}
+	w.offset += int64(len(p))
+	return len(p), nil
+}
+
+// ExportCache implements the ExportCache RPC method, streaming the archive in chunks of
+// server.stream_chunk_size_kb
+func (s *Server) ExportCache(req *pb.ExportCacheRequest, stream pb.TTSService_ExportCacheServer) error {
+	w := &exportWriter{stream: stream}
+	buffered := bufio.NewWriterSize(w, s.config.Load().Server.StreamChunkSizeKB*1024)
+	if err := s.ttsService.ExportCache(buffered, req.Namespace); err != nil {
+		return err
+	}
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+
+	logRequest(stream.Context(), "ExportCache", "namespace", req.Namespace, "size", w.offset)
+	return nil
+}
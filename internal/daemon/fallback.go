@@ -0,0 +1,101 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// defaultFallbackText is spoken by FetchWithFallback when a request doesn't name a fallback text
+const defaultFallbackText = "unavailable"
+
+// fetchResult is the outcome of a GetAudio call made in the background
+type fetchResult struct {
+	audioData []byte
+	cacheKey  string
+	cached    bool
+	err       error
+}
+
+// FetchWithFallback implements the FetchWithFallback RPC method. Cached audio is returned right
+// away; the cache has no expiry, so any entry counts. Otherwise synthesis is started and awaited
+// for up to stale_ok_for_ms, after which the cached audio of the fallback text is returned. The
+// synthesis carries on either way, so the text is cached for the next request.
+func (s *Server) FetchWithFallback(ctx context.Context, req *pb.FallbackRequest) (*pb.TTSResponse, error) {
+	ttsReq := req.GetRequest()
+	if ttsReq.GetText() == "" {
+		return nil, fmt.Errorf("text is required")
+	}
+	if ttsReq.GetLanguageCode() == "" {
+		return nil, fmt.Errorf("language_code is required")
+	}
+	opts := s.options(ttsReq)
+
+	audioData, cacheKey, found, err := s.ttsService.GetCachedAudio(ttsReq.Text, ttsReq.LanguageCode, opts)
+	if err != nil {
+		return nil, fmt.Errorf("cache lookup failed: %w", err)
+	}
+	if found {
+		logRequest(ctx, "FetchWithFallback", "lang", ttsReq.LanguageCode, "source", "cache", "size", len(audioData))
+		return s.fallbackResponse(ttsReq, audioData, cacheKey, true, false), nil
+	}
+
+	done := make(chan fetchResult, 1)
+	go func() {
+		audioData, cacheKey, cached, err := s.ttsService.GetAudio(context.WithoutCancel(ctx), ttsReq.Text, ttsReq.LanguageCode, opts, false)
+		done <- fetchResult{audioData, cacheKey, cached, err}
+	}()
+
+	wait := time.Duration(req.StaleOkForMs) * time.Millisecond
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case result := <-done:
+		if result.err != nil {
+			return nil, fmt.Errorf("failed to get audio: %w", result.err)
+		}
+		source := "azure"
+		if result.cached {
+			source = "cache" // Cached by another request meanwhile
+		}
+		logRequest(ctx, "FetchWithFallback", "lang", ttsReq.LanguageCode, "source", source, "size", len(result.audioData))
+		return s.fallbackResponse(ttsReq, result.audioData, result.cacheKey, result.cached, false), nil
+	case <-timer.C:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	fallbackText := req.FallbackText
+	if fallbackText == "" {
+		fallbackText = defaultFallbackText
+	}
+	audioData, cacheKey, found, err = s.ttsService.GetCachedAudio(fallbackText, ttsReq.LanguageCode, opts)
+	if err != nil {
+		return nil, fmt.Errorf("cache lookup failed: %w", err)
+	}
+	if !found {
+		// Synthesize it for next time, without waiting for it now
+		go s.ttsService.GetAudio(context.WithoutCancel(ctx), fallbackText, ttsReq.LanguageCode, opts, false)
+		return nil, fmt.Errorf("synthesis didn't finish within %s and the fallback text %q isn't cached", wait, fallbackText)
+	}
+
+	logRequest(ctx, "FetchWithFallback", "lang", ttsReq.LanguageCode, "source", "fallback", "waited", wait)
+	return s.fallbackResponse(ttsReq, audioData, cacheKey, false, true), nil
+}
+
+// fallbackResponse builds a FetchWithFallback response
+func (s *Server) fallbackResponse(req *pb.TTSRequest, audioData []byte, cacheKey string, fromCache, fallback bool) *pb.TTSResponse {
+	return &pb.TTSResponse{
+		Cached:              fromCache || fallback,
+		AudioData:           audioData,
+		CacheKey:            cacheKey,
+		AudioSize:           int64(len(audioData)),
+		VoiceFallbackLocale: s.ttsService.VoiceFallbackLocale(req.LanguageCode),
+		EffectiveLocale:     s.ttsService.EffectiveLocale(req.LanguageCode),
+		FromStaleCache:      fromCache,
+		Fallback:            fallback,
+	}
+}
@@ -0,0 +1,29 @@
+package daemon
+
+import (
+	"strings"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthMethodPrefix starts the full method names of the grpc.health.v1 service
+var healthMethodPrefix = "/" + healthpb.Health_ServiceDesc.ServiceName + "/"
+
+// isHealthCheck reports whether fullMethod belongs to the health service, whose calls skip the
+// API key check and aren't counted as requests (a Watch stream would otherwise hold up draining)
+func isHealthCheck(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, healthMethodPrefix)
+}
+
+// SetHealth sets the status healthServer reports for the daemon as a whole ("") and for the TTS
+// service
+func SetHealth(healthServer *health.Server, serving bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	healthServer.SetServingStatus("", status)
+	healthServer.SetServingStatus(pb.TTSService_ServiceDesc.ServiceName, status)
+}
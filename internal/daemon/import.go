@@ -0,0 +1,50 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	pb "com.biesnecker/tts-daemon/proto"
+)
+
+// ImportCache implements the ImportCache RPC method. The archive's entries must belong to the
+// namespace of the first chunk.
+func (s *Server) ImportCache(stream pb.TTSService_ImportCacheServer) error {
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		first = &pb.ImportChunk{}
+	} else if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		for chunk := first; ; {
+			if _, err := pw.Write(chunk.Data); err != nil {
+				return // The import failed and stopped reading
+			}
+			var err error
+			if chunk, err = stream.Recv(); errors.Is(err, io.EOF) {
+				pw.Close()
+				return
+			} else if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	imported, skipped, err := s.ttsService.ImportCache(pr, first.Namespace)
+	pr.Close()
+	if err != nil {
+		return fmt.Errorf("failed to import cache: %w", err)
+	}
+
+	logRequest(stream.Context(), "ImportCache", "namespace", first.Namespace, "imported", imported, "skipped", skipped)
+
+	return stream.SendAndClose(&pb.ImportCacheResponse{
+		Imported: int64(imported),
+		Skipped:  int64(skipped),
+	})
+}
@@ -0,0 +1,39 @@
+package daemon
+
+import (
+	"context"
+	"math/rand/v2"
+	"path"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/logging"
+	"google.golang.org/grpc"
+)
+
+// requestSampledKey is the context key recording whether a request was sampled for logging
+type requestSampledKey struct{}
+
+// LogRequests is a unary interceptor implementing server.request_log_sampling_rate: only that
+// fraction of requests is logged by its handler. Failed requests, and those slower than
+// server.slow_request_threshold_ms, are logged whether they were sampled or not.
+func (s *Server) LogRequests(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	rate := s.config.Load().Server.RequestLogSamplingRate
+	if rate >= 1 {
+		return handler(ctx, req)
+	}
+
+	sampled := rand.Float64() < rate
+	started := time.Now()
+	resp, err := handler(context.WithValue(ctx, requestSampledKey{}, sampled), req)
+	elapsed := time.Since(started)
+
+	method := path.Base(info.FullMethod)
+	threshold := time.Duration(s.config.Load().Server.SlowRequestThresholdMs) * time.Millisecond
+	switch {
+	case err != nil:
+		logging.Error(method, "error", err, "duration", elapsed.Round(time.Millisecond), "request_sampled", sampled)
+	case threshold > 0 && elapsed > threshold:
+		logging.Warn(method+" slow request", "duration", elapsed.Round(time.Millisecond), "request_sampled", sampled)
+	}
+	return resp, err
+}
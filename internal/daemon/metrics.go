@@ -0,0 +1,68 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// ExportMetrics implements the ExportMetrics RPC method
+func (s *Server) ExportMetrics(ctx context.Context, req *pb.MetricsRequest) (*pb.MetricsResponse, error) {
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		return nil, fmt.Errorf("failed to gather metrics: %w", err)
+	}
+
+	resp := &pb.MetricsResponse{}
+	for _, family := range families {
+		resp.Families = append(resp.Families, metricFamilyToProto(family))
+	}
+
+	logRequest(ctx, "ExportMetrics", "families", len(resp.Families))
+	return resp, nil
+}
+
+// metricFamilyToProto converts a gathered Prometheus metric family to its protobuf form
+func metricFamilyToProto(family *dto.MetricFamily) *pb.MetricFamily {
+	out := &pb.MetricFamily{
+		Name: family.GetName(),
+		Help: family.GetHelp(),
+		Type: pb.MetricType(family.GetType()), // The enums share their values
+	}
+
+	for _, m := range family.GetMetric() {
+		metric := &pb.Metric{TimestampMs: m.GetTimestampMs()}
+		for _, label := range m.GetLabel() {
+			metric.Labels = append(metric.Labels, &pb.LabelPair{Name: label.GetName(), Value: label.GetValue()})
+		}
+
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			metric.Value = m.GetCounter().GetValue()
+		case dto.MetricType_GAUGE:
+			metric.Value = m.GetGauge().GetValue()
+		case dto.MetricType_UNTYPED:
+			metric.Value = m.GetUntyped().GetValue()
+		case dto.MetricType_SUMMARY:
+			summary := m.GetSummary()
+			metric.SampleCount = summary.GetSampleCount()
+			metric.SampleSum = summary.GetSampleSum()
+			for _, q := range summary.GetQuantile() {
+				metric.Quantiles = append(metric.Quantiles, &pb.Quantile{Quantile: q.GetQuantile(), Value: q.GetValue()})
+			}
+		case dto.MetricType_HISTOGRAM:
+			histogram := m.GetHistogram()
+			metric.SampleCount = histogram.GetSampleCount()
+			metric.SampleSum = histogram.GetSampleSum()
+			for _, b := range histogram.GetBucket() {
+				metric.Buckets = append(metric.Buckets, &pb.Bucket{UpperBound: b.GetUpperBound(), CumulativeCount: b.GetCumulativeCount()})
+			}
+		}
+
+		out.Metrics = append(out.Metrics, metric)
+	}
+	return out
+}
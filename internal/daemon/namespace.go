@@ -0,0 +1,91 @@
+package daemon
+
+import (
+	"context"
+	"slices"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// namespaced is implemented by requests that select a cache namespace
+type namespaced interface {
+	GetNamespace() string
+}
+
+// CheckNamespaces is a unary interceptor that rejects requests for namespaces other than the
+// configured ones (server.namespaces), or, when server.credentials are configured, other than
+// those of the credentials the client presents. The default namespace ("") is always allowed.
+func (s *Server) CheckNamespaces(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.checkNamespaces(ctx, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// CheckNamespacesStreams is the stream interceptor counterpart of CheckNamespaces, checking
+// each message the client sends
+func (s *Server) CheckNamespacesStreams(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &namespaceCheckingStream{ServerStream: ss, server: s})
+}
+
+// namespaceCheckingStream checks the namespaces of the messages received on a stream
+type namespaceCheckingStream struct {
+	grpc.ServerStream
+	server *Server
+}
+
+// RecvMsg receives a message, returning an error if it is for an unknown namespace
+func (n *namespaceCheckingStream) RecvMsg(m any) error {
+	if err := n.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return n.server.checkNamespaces(n.Context(), m)
+}
+
+// checkNamespaces returns a PermissionDenied error if msg, or a TTSRequest it carries, is for a
+// namespace the client of ctx may not use
+func (s *Server) checkNamespaces(ctx context.Context, msg any) error {
+	var requests []*pb.TTSRequest
+	switch m := msg.(type) {
+	case interface{ GetRequest() *pb.TTSRequest }:
+		requests = append(requests, m.GetRequest())
+	case interface{ GetRequests() []*pb.TTSRequest }:
+		requests = m.GetRequests()
+	}
+
+	if m, ok := msg.(namespaced); ok {
+		if err := s.checkNamespace(ctx, m.GetNamespace()); err != nil {
+			return err
+		}
+	}
+	for _, req := range requests {
+		if err := s.checkNamespace(ctx, req.GetNamespace()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkNamespace returns a PermissionDenied error unless namespace is "", or configured and, if
+// there are credentials, one of those of a credential the client of ctx presents
+func (s *Server) checkNamespace(ctx context.Context, namespace string) error {
+	cfg := s.config.Load().Server
+	if namespace == "" {
+		return nil
+	}
+	if !slices.Contains(cfg.Namespaces, namespace) {
+		return status.Errorf(codes.PermissionDenied, "unknown namespace %q", namespace)
+	}
+	if len(cfg.Credentials) == 0 {
+		return nil
+	}
+	for _, credential := range cfg.Credentials {
+		if slices.Contains(credential.Namespaces, namespace) && presents(ctx, credential) {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "namespace %q isn't allowed for this client", namespace)
+}
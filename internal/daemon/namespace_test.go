@@ -0,0 +1,121 @@
+package daemon
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"com.biesnecker/tts-daemon/internal/config"
+	pb "com.biesnecker/tts-daemon/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// withAPIKey returns a context of a client sending key
+func withAPIKey(key string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+key))
+}
+
+// withClientCert returns a context of a client that presented cert, verified unless verified is
+// false
+func withClientCert(cert *x509.Certificate, verified bool) context.Context {
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	if verified {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestNamespacesAreBoundToCredentials(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Server.Namespaces = []string{"team-a", "team-b", "team-c"}
+	cfg.Server.Credentials = []config.CredentialConfig{
+		{APIKey: "key-a", Namespaces: []string{"team-a"}},
+		{ClientName: "team-b.example.com", Namespaces: []string{"team-b"}},
+		{APIKey: "key-ops", ClientName: "ops", Namespaces: []string{"team-a", "team-b"}},
+	}
+	server := NewServer(nil, cfg)
+
+	teamB := &x509.Certificate{Subject: pkix.Name{CommonName: "team-b.example.com"}}
+	ops := &x509.Certificate{Subject: pkix.Name{CommonName: "someone"}, DNSNames: []string{"ops"}}
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		allowed []string // Besides the default namespace
+		authErr bool     // Whether checkAPIKey rejects the client
+	}{
+		{"no credentials", context.Background(), nil, true},
+		{"team A's key", withAPIKey("key-a"), []string{"team-a"}, false},
+		{"ops key", withAPIKey("key-ops"), []string{"team-a", "team-b"}, false},
+		{"unknown key", withAPIKey("key-b"), nil, true},
+		{"team B's certificate", withClientCert(teamB, true), []string{"team-b"}, false},
+		{"ops certificate by DNS name", withClientCert(ops, true), []string{"team-a", "team-b"}, false},
+		{"unverified certificate", withClientCert(teamB, false), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := server.checkAPIKey(tt.ctx); (err != nil) != tt.authErr {
+				t.Errorf("checkAPIKey = %v, want an error: %v", err, tt.authErr)
+			}
+			for _, namespace := range []string{"", "team-a", "team-b", "team-c", "team-d"} {
+				want := namespace == ""
+				for _, allowed := range tt.allowed {
+					want = want || namespace == allowed
+				}
+				req := &pb.TTSRequest{Text: "Hello", LanguageCode: "en-US", Namespace: namespace}
+				err := server.checkNamespaces(tt.ctx, req)
+				if want && err != nil {
+					t.Errorf("namespace %q rejected: %v", namespace, err)
+				}
+				if !want && status.Code(err) != codes.PermissionDenied {
+					t.Errorf("namespace %q = %v, want PermissionDenied", namespace, err)
+				}
+			}
+		})
+	}
+
+	// Without credentials, every client may use every configured namespace
+	open := &config.Config{}
+	open.Server.Namespaces = []string{"team-a"}
+	server.SetConfig(open)
+	if err := server.checkNamespaces(context.Background(), &pb.TTSRequest{Namespace: "team-a"}); err != nil {
+		t.Errorf("team-a without credentials = %v, want it allowed", err)
+	}
+	if err := server.checkNamespaces(context.Background(), &pb.TTSRequest{Namespace: "team-b"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("unconfigured team-b = %v, want PermissionDenied", err)
+	}
+}
+
+func TestCacheWideRequestsAreBoundToCredentials(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Server.Namespaces = []string{"team-a", "team-b"}
+	cfg.Server.Credentials = []config.CredentialConfig{{APIKey: "key-a", Namespaces: []string{"team-a"}}}
+	server := NewServer(nil, cfg)
+
+	requests := map[string]func(namespace string) namespaced{
+		"ExportCache":        func(ns string) namespaced { return &pb.ExportCacheRequest{Namespace: ns} },
+		"ImportCache":        func(ns string) namespaced { return &pb.ImportChunk{Namespace: ns} },
+		"DeletePattern":      func(ns string) namespaced { return &pb.DeletePatternRequest{Namespace: ns} },
+		"FindNearDuplicates": func(ns string) namespaced { return &pb.NearDuplicatesRequest{Namespace: ns} },
+		"VerifyIntegrity":    func(ns string) namespaced { return &pb.VerifyIntegrityRequest{Namespace: ns} },
+		"WatchCache":         func(ns string) namespaced { return &pb.WatchRequest{Namespace: ns} },
+		"ResynthesizeAll":    func(ns string) namespaced { return &pb.ResynthesizeRequest{Namespace: ns} },
+		"GetCacheStats":      func(ns string) namespaced { return &pb.GetCacheStatsRequest{Namespace: ns} },
+		"ListLocales":        func(ns string) namespaced { return &pb.ListLocalesRequest{Namespace: ns} },
+	}
+	for name, request := range requests {
+		t.Run(name, func(t *testing.T) {
+			if err := server.checkNamespaces(withAPIKey("key-a"), request("team-a")); err != nil {
+				t.Errorf("team-a rejected: %v", err)
+			}
+			if err := server.checkNamespaces(withAPIKey("key-a"), request("team-b")); status.Code(err) != codes.PermissionDenied {
+				t.Errorf("team-b = %v, want PermissionDenied", err)
+			}
+		})
+	}
+}
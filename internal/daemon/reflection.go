@@ -0,0 +1,25 @@
+package daemon
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	pb "com.biesnecker/tts-daemon/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+)
+
+// DescriptorVersion describes the compiled-in TTS service: its name, number of methods and a
+// digest of tts.proto's descriptor, which changes whenever the proto does. Daemons logging the
+// same digest serve the same API.
+func DescriptorVersion() (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(protodesc.ToFileDescriptorProto(pb.File_proto_tts_proto))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the proto descriptor: %w", err)
+	}
+	digest := sha256.Sum256(data)
+
+	service := pb.File_proto_tts_proto.Services().ByName("TTSService")
+	return fmt.Sprintf("%s (%d methods, descriptor %s)", service.FullName(), service.Methods().Len(), hex.EncodeToString(digest[:6])), nil
+}
@@ -0,0 +1,81 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// checkSavePath validates a FetchAndSave output path and returns it cleaned. The path must be
+// absolute, must not contain ".." segments, and its directory (after resolving symlinks) must
+// be inside one of the allowed directories.
+func checkSavePath(path string, allowedDirs []string) (string, error) {
+	if len(allowedDirs) == 0 {
+		return "", fmt.Errorf("saving files is disabled (no server.allowed_save_directories configured)")
+	}
+	if path == "" {
+		return "", fmt.Errorf("output_path is required")
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("output_path must be absolute: %s", path)
+	}
+	for _, segment := range strings.Split(filepath.ToSlash(path), "/") {
+		if segment == ".." {
+			return "", fmt.Errorf("output_path must not contain '..': %s", path)
+		}
+	}
+
+	if strings.HasSuffix(path, string(filepath.Separator)) {
+		return "", fmt.Errorf("output_path must name a file: %s", path)
+	}
+	path = filepath.Clean(path)
+
+	// Resolve symlinks in the directory so a link inside an allowed directory can't point outside it
+	dir, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		return "", fmt.Errorf("output directory is not accessible: %w", err)
+	}
+
+	for _, allowed := range allowedDirs {
+		allowed, err := filepath.EvalSymlinks(allowed)
+		if err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(allowed, dir); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("output_path is not under an allowed save directory: %s", path)
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it into place, so
+// readers never see a partially written file
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
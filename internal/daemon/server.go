@@ -3,9 +3,18 @@ package daemon
 import (
 	"context"
 	"fmt"
-	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 
 	pb "com.biesnecker/tts-daemon/proto"
+	"com.biesnecker/tts-daemon/internal/client"
+	"com.biesnecker/tts-daemon/internal/config"
+	"com.biesnecker/tts-daemon/internal/logging"
+	"com.biesnecker/tts-daemon/internal/metrics"
+	"com.biesnecker/tts-daemon/internal/tracing"
 	"com.biesnecker/tts-daemon/internal/tts"
 )
 
@@ -13,13 +22,115 @@ import (
 type Server struct {
 	pb.UnimplementedTTSServiceServer
 	ttsService *tts.Service
+	config     atomic.Pointer[config.Config] // Replaced on reload (see SetConfig)
+
+	activeWatchers atomic.Int32 // Number of open WatchCache streams
+
+	// Connection draining (see SetDraining)
+	activeRequests atomic.Int32 // Requests and streams in progress
+	draining       atomic.Bool
+	drain          func(timeout time.Duration)
+
+	// Characters sent to Azure by SynthesizeEphemeral, tracked separately from cached synthesis
+	ephemeralBudget *tts.DailyBudget
 }
 
+// maxWatchStreams limits how many WatchCache streams can be open at once
+const maxWatchStreams = 20
+
+// Page sizes for ListCacheEntries (and the batches Clone requests from its source)
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// maxDeletedKeys limits how many cache keys a DeleteByLanguage response lists
+const maxDeletedKeys = 1000
+
+// maxCorruptKeys limits how many corrupt cache keys a VerifyCache response lists
+const maxCorruptKeys = 100
+
+// defaultNearDuplicateThreshold is the similarity used when FindNearDuplicates is given none
+const defaultNearDuplicateThreshold = 0.85
+
 // NewServer creates a new gRPC server
-func NewServer(ttsService *tts.Service) *Server {
-	return &Server{
-		ttsService: ttsService,
+func NewServer(ttsService *tts.Service, cfg *config.Config) *Server {
+	s := &Server{
+		ttsService:      ttsService,
+		ephemeralBudget: tts.NewDailyBudget(cfg.Azure.EphemeralDailyBudget),
+	}
+	s.config.Store(cfg)
+	return s
+}
+
+// SetConfig replaces the configuration requests are handled with, e.g. after the daemon reloads
+// its config file. Requests in progress keep the configuration they started with.
+func (s *Server) SetConfig(cfg *config.Config) {
+	s.config.Store(cfg)
+}
+
+// DefaultOptions returns the synthesis options used when a request doesn't override them
+func (s *Server) DefaultOptions() tts.Options {
+	return s.options(nil)
+}
+
+// options returns the synthesis options for a request, combining the daemon's configuration
+// with per-request settings (req may be nil to get the defaults)
+func (s *Server) options(req *pb.TTSRequest) tts.Options {
+	audio := s.config.Load().Audio
+	opts := tts.Options{
+		InjectBreaks:    audio.InjectBreaks,
+		BreakAtNewlines: audio.BreakAtNewlines,
+		MP3Bitrate:      audio.Bitrate,
+		MP3SampleRateHz: audio.SampleRateHz,
+
+		RestorePunctuation:  audio.RestorePunctuation,
+		PunctuationLanguage: audio.RestorePunctuationLanguage,
 	}
+	if req != nil {
+		switch req.OutputFormat {
+		case pb.OutputFormat_WAV_16K:
+			opts.Format = tts.FormatWAV16K
+		case pb.OutputFormat_OPUS_24K:
+			opts.Format = tts.FormatOpus24K
+		case pb.OutputFormat_OGG_OPUS_48K:
+			opts.Format = tts.FormatOggOpus48K
+		case pb.OutputFormat_OGG_OPUS_24K:
+			opts.Format = tts.FormatOggOpus24K
+		}
+		if req.Mp3Bitrate != "" {
+			opts.MP3Bitrate = req.Mp3Bitrate
+		}
+		if req.Mp3SampleRateHz != 0 {
+			opts.MP3SampleRateHz = int(req.Mp3SampleRateHz)
+		}
+		opts.Voice = req.VoiceName
+		opts.Namespace = req.Namespace
+		opts.Tags = req.Tags
+		opts.TTLSeconds = req.TtlSeconds
+		if req.IsSsml {
+			// The document is synthesized as written
+			opts.SSML = true
+			opts.InjectBreaks, opts.BreakAtNewlines, opts.RestorePunctuation = false, false, false
+		}
+	}
+	return opts
+}
+
+// logRequest logs msg with the request's fields at the info level, adding the request's trace
+// ID when it is being traced. Requests left out by log sampling (see LogRequests) aren't logged.
+func logRequest(ctx context.Context, msg string, args ...any) {
+	sampled, sampling := ctx.Value(requestSampledKey{}).(bool)
+	if sampling && !sampled {
+		return
+	}
+	if sampling {
+		args = append(args, "request_sampled", true)
+	}
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		args = append(args, "trace_id", traceID)
+	}
+	logging.Info(msg, args...)
 }
 
 // FetchTTS implements the FetchTTS RPC method
@@ -31,8 +142,19 @@ func (s *Server) FetchTTS(ctx context.Context, req *pb.TTSRequest) (*pb.TTSRespo
 		return nil, fmt.Errorf("language_code is required")
 	}
 
+	var textStats *pb.TextStats
+	if req.IncludeTextStats {
+		stats := tts.ComputeTextStats(req.Text)
+		textStats = &pb.TextStats{
+			CharCount:              int32(stats.CharCount),
+			WordCount:              int32(stats.WordCount),
+			SentenceCount:          int32(stats.SentenceCount),
+			EstimatedReadingTimeMs: stats.EstimatedReadingTime.Milliseconds(),
+		}
+	}
+
 	// Get audio (from cache or fetch from Azure)
-	audioData, cacheKey, cached, err := s.ttsService.GetAudio(req.Text, req.LanguageCode, req.ForceRefresh)
+	audioData, cacheKey, cached, err := s.ttsService.GetAudio(ctx, req.Text, req.LanguageCode, s.options(req), req.ForceRefresh)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get audio: %w", err)
 	}
@@ -41,45 +163,126 @@ func (s *Server) FetchTTS(ctx context.Context, req *pb.TTSRequest) (*pb.TTSRespo
 	if cached {
 		source = "cache"
 	}
-	log.Printf("FetchTTS: lang=%s, source=%s, size=%d", req.LanguageCode, source, len(audioData))
+	logRequest(ctx, "FetchTTS", "lang", req.LanguageCode, "source", source, "size", len(audioData))
 
 	return &pb.TTSResponse{
-		Cached:    cached,
-		AudioData: audioData,
-		CacheKey:  cacheKey,
-		AudioSize: int64(len(audioData)),
+		Cached:              cached,
+		AudioData:           audioData,
+		CacheKey:            cacheKey,
+		AudioSize:           int64(len(audioData)),
+		TextStats:           textStats,
+		VoiceFallbackLocale: s.ttsService.VoiceFallbackLocale(req.LanguageCode),
+		EffectiveLocale:     s.ttsService.EffectiveLocale(req.LanguageCode),
 	}, nil
 }
 
-// BulkFetchTTS implements the BulkFetchTTS RPC method
-func (s *Server) BulkFetchTTS(ctx context.Context, req *pb.BulkTTSRequest) (*pb.BulkTTSResponse, error) {
-	if len(req.Requests) == 0 {
-		return nil, fmt.Errorf("at least one request is required")
+// StreamTTS implements the StreamTTS RPC method. The audio is fetched whole, as for FetchTTS,
+// and then sent in chunks of server.stream_chunk_size_kb.
+func (s *Server) StreamTTS(req *pb.TTSRequest, stream pb.TTSService_StreamTTSServer) error {
+	if req.Text == "" {
+		return fmt.Errorf("text is required")
+	}
+	if req.LanguageCode == "" {
+		return fmt.Errorf("language_code is required")
 	}
 
-	// Validate all requests
-	for i, r := range req.Requests {
-		if r.Text == "" {
-			return nil, fmt.Errorf("request %d: text is required", i)
+	audioData, cacheKey, cached, err := s.ttsService.GetAudio(stream.Context(), req.Text, req.LanguageCode, s.options(req), req.ForceRefresh)
+	if err != nil {
+		return fmt.Errorf("failed to get audio: %w", err)
+	}
+
+	source := "azure"
+	if cached {
+		source = "cache"
+	}
+	logRequest(stream.Context(), "StreamTTS", "lang", req.LanguageCode, "source", source, "size", len(audioData))
+
+	chunkSize := s.config.Load().Server.StreamChunkSizeKB * 1024
+	for offset := 0; ; offset += chunkSize {
+		end := min(offset+chunkSize, len(audioData))
+		chunk := &pb.AudioChunk{
+			AudioData: audioData[offset:end],
+			Offset:    int64(offset),
+			IsLast:    end == len(audioData),
+			Cached:    cached,
+			CacheKey:  cacheKey,
 		}
-		if r.LanguageCode == "" {
-			return nil, fmt.Errorf("request %d: language_code is required", i)
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+		if chunk.IsLast {
+			return nil
 		}
 	}
+}
+
+// FetchAndSave implements the FetchAndSave RPC method
+func (s *Server) FetchAndSave(ctx context.Context, req *pb.FetchAndSaveRequest) (*pb.FetchAndSaveResponse, error) {
+	ttsReq := req.GetRequest()
+	if ttsReq.GetText() == "" {
+		return nil, fmt.Errorf("text is required")
+	}
+	if ttsReq.GetLanguageCode() == "" {
+		return nil, fmt.Errorf("language_code is required")
+	}
+
+	outputPath, err := checkSavePath(req.OutputPath, s.config.Load().Server.AllowedSaveDirectories)
+	if err != nil {
+		return nil, err
+	}
+
+	audioData, _, cached, err := s.ttsService.GetAudio(ctx, ttsReq.Text, ttsReq.LanguageCode, s.options(ttsReq), ttsReq.ForceRefresh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audio: %w", err)
+	}
+
+	if err := writeFileAtomic(outputPath, audioData); err != nil {
+		return nil, fmt.Errorf("failed to save audio: %w", err)
+	}
+
+	logRequest(ctx, "FetchAndSave", "lang", ttsReq.LanguageCode, "cached", cached, "size", len(audioData), "path", outputPath)
+
+	return &pb.FetchAndSaveResponse{
+		Saved:      true,
+		OutputPath: outputPath,
+		AudioSize:  int64(len(audioData)),
+		WasCached:  cached,
+	}, nil
+}
+
+// BulkFetchTTS implements the BulkFetchTTS RPC method
+func (s *Server) BulkFetchTTS(ctx context.Context, req *pb.BulkTTSRequest) (*pb.BulkTTSResponse, error) {
+	if err := validateBulkRequest(req); err != nil {
+		return nil, err
+	}
 
 	// Convert to service request format
-	serviceReqs := make([]struct{ Text, LanguageCode string }, len(req.Requests))
+	serviceReqs := make([]struct {
+		Text, LanguageCode string
+		Options            tts.Options
+	}, len(req.Requests))
 	forceRefresh := false
 	for i, r := range req.Requests {
 		serviceReqs[i].Text = r.Text
 		serviceReqs[i].LanguageCode = r.LanguageCode
+		serviceReqs[i].Options = s.options(r)
 		if r.ForceRefresh {
 			forceRefresh = true
 		}
 	}
 
-	// Fetch all audio concurrently
-	results := s.ttsService.BulkGetAudio(serviceReqs, forceRefresh)
+	// Fetch all audio concurrently, or in batches that adapt to Azure's rate limits
+	var results []struct {
+		AudioData []byte
+		CacheKey  string
+		Cached    bool
+		Err       error
+	}
+	if req.Adaptive {
+		results = s.ttsService.AdaptiveBulkGetAudio(ctx, serviceReqs, forceRefresh, s.config.Load().Server.AdaptiveBatchSize)
+	} else {
+		results = s.ttsService.BulkGetAudio(ctx, serviceReqs, forceRefresh)
+	}
 
 	// Convert results to response format
 	responses := make([]*pb.TTSResponse, len(results))
@@ -92,14 +295,16 @@ func (s *Server) BulkFetchTTS(ctx context.Context, req *pb.BulkTTSRequest) (*pb.
 		if result.Cached {
 			source = "cache"
 		}
-		log.Printf("BulkFetchTTS[%d]: lang=%s, source=%s, size=%d",
-			i, req.Requests[i].LanguageCode, source, len(result.AudioData))
+		logRequest(ctx, "BulkFetchTTS",
+			"index", i, "lang", req.Requests[i].LanguageCode, "source", source, "size", len(result.AudioData))
 
 		responses[i] = &pb.TTSResponse{
-			Cached:    result.Cached,
-			AudioData: result.AudioData,
-			CacheKey:  result.CacheKey,
-			AudioSize: int64(len(result.AudioData)),
+			Cached:              result.Cached,
+			AudioData:           result.AudioData,
+			CacheKey:            result.CacheKey,
+			AudioSize:           int64(len(result.AudioData)),
+			VoiceFallbackLocale: s.ttsService.VoiceFallbackLocale(req.Requests[i].LanguageCode),
+			EffectiveLocale:     s.ttsService.EffectiveLocale(req.Requests[i].LanguageCode),
 		}
 	}
 
@@ -108,11 +313,202 @@ func (s *Server) BulkFetchTTS(ctx context.Context, req *pb.BulkTTSRequest) (*pb.
 	}, nil
 }
 
+// StreamBulkFetchTTS implements the StreamBulkFetchTTS RPC method
+// Each item is sent to the client as soon as it completes, in completion order
+func (s *Server) StreamBulkFetchTTS(req *pb.BulkTTSRequest, stream pb.TTSService_StreamBulkFetchTTSServer) error {
+	if err := validateBulkRequest(req); err != nil {
+		return err
+	}
+
+	// gRPC streams are not safe for concurrent sends
+	var sendMu sync.Mutex
+	var sendErr error
+
+	var wg sync.WaitGroup
+	for i, r := range req.Requests {
+		wg.Add(1)
+		go func(idx int, r *pb.TTSRequest) {
+			defer wg.Done()
+
+			result := &pb.BulkItemResult{Index: int32(idx)}
+			audioData, cacheKey, cached, err := s.ttsService.GetAudio(stream.Context(), r.Text, r.LanguageCode, s.options(r), r.ForceRefresh)
+			if err != nil {
+				result.ErrorMessage = err.Error()
+				logRequest(stream.Context(), "StreamBulkFetchTTS", "index", idx, "lang", r.LanguageCode, "error", err)
+			} else {
+				result.Response = &pb.TTSResponse{
+					Cached:              cached,
+					AudioData:           audioData,
+					CacheKey:            cacheKey,
+					AudioSize:           int64(len(audioData)),
+					VoiceFallbackLocale: s.ttsService.VoiceFallbackLocale(r.LanguageCode),
+					EffectiveLocale:     s.ttsService.EffectiveLocale(r.LanguageCode),
+				}
+
+				source := "azure"
+				if cached {
+					source = "cache"
+				}
+				logRequest(stream.Context(), "StreamBulkFetchTTS",
+					"index", idx, "lang", r.LanguageCode, "source", source, "size", len(audioData))
+			}
+
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			if sendErr != nil {
+				return
+			}
+			if err := stream.Send(result); err != nil {
+				sendErr = fmt.Errorf("failed to send result %d: %w", idx, err)
+			}
+		}(i, r)
+	}
+	wg.Wait()
+
+	return sendErr
+}
+
+// validateBulkRequest checks that a bulk request is non-empty and every item is complete
+func validateBulkRequest(req *pb.BulkTTSRequest) error {
+	return validateRequests(req.Requests)
+}
+
+// validateRequests checks that requests is non-empty and every item is complete
+func validateRequests(requests []*pb.TTSRequest) error {
+	if len(requests) == 0 {
+		return fmt.Errorf("at least one request is required")
+	}
+
+	for i, r := range requests {
+		if r.Text == "" {
+			return fmt.Errorf("request %d: text is required", i)
+		}
+		if r.LanguageCode == "" {
+			return fmt.Errorf("request %d: language_code is required", i)
+		}
+	}
+
+	return nil
+}
+
+// WarmCache implements the WarmCache RPC method
+func (s *Server) WarmCache(req *pb.WarmCacheRequest, stream pb.TTSService_WarmCacheServer) error {
+	if err := validateRequests(req.Requests); err != nil {
+		return err
+	}
+	concurrency := int(req.Concurrency)
+	if concurrency <= 0 {
+		concurrency = s.config.Load().Server.WarmConcurrency
+	}
+
+	serviceReqs := make([]struct {
+		Text, LanguageCode string
+		Options            tts.Options
+	}, len(req.Requests))
+	for i, r := range req.Requests {
+		serviceReqs[i].Text = r.Text
+		serviceReqs[i].LanguageCode = r.LanguageCode
+		serviceReqs[i].Options = s.options(r)
+	}
+
+	var sendErr error
+	totals := s.ttsService.WarmCache(stream.Context(), serviceReqs, concurrency, func(progress tts.WarmProgress) {
+		if sendErr != nil {
+			return
+		}
+		msg := &pb.WarmCacheProgress{
+			Total:        int64(progress.Total),
+			Done:         int64(progress.Done),
+			CachedHits:   int64(progress.CachedHits),
+			AzureFetches: int64(progress.AzureFetches),
+			Errors:       int64(progress.Errors),
+		}
+		if progress.LastError != nil {
+			msg.LastError = progress.LastError.Error()
+		}
+		sendErr = stream.Send(msg)
+	})
+
+	logRequest(stream.Context(), "WarmCache",
+		"total", totals.Total, "cached", totals.CachedHits, "azure", totals.AzureFetches, "errors", totals.Errors)
+	return sendErr
+}
+
+// EnqueueSynthesis implements the EnqueueSynthesis RPC method
+func (s *Server) EnqueueSynthesis(ctx context.Context, req *pb.EnqueueRequest) (*pb.EnqueueResponse, error) {
+	if req.Text == "" {
+		return nil, fmt.Errorf("text is required")
+	}
+	if req.LanguageCode == "" {
+		return nil, fmt.Errorf("language_code is required")
+	}
+
+	jobID, err := s.ttsService.EnqueueSynthesis(req.Text, req.LanguageCode, int(req.Priority))
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue synthesis: %w", err)
+	}
+
+	logRequest(ctx, "EnqueueSynthesis", "lang", req.LanguageCode, "priority", req.Priority, "job", jobID)
+	return &pb.EnqueueResponse{JobId: jobID}, nil
+}
+
+// GetJobStatus implements the GetJobStatus RPC method
+func (s *Server) GetJobStatus(ctx context.Context, req *pb.JobStatusRequest) (*pb.JobStatus, error) {
+	if req.JobId == "" {
+		return nil, fmt.Errorf("job_id is required")
+	}
+
+	job, err := s.ttsService.GetJob(req.JobId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job status: %w", err)
+	}
+	if job == nil {
+		return nil, fmt.Errorf("job %s not found", req.JobId)
+	}
+
+	return &pb.JobStatus{
+		JobId:        job.ID,
+		Status:       job.Status,
+		Text:         job.Text,
+		LanguageCode: job.LanguageCode,
+		Priority:     int32(job.Priority),
+		CreatedAt:    job.CreatedAt,
+		CompletedAt:  job.CompletedAt,
+		ErrorMessage: job.ErrorMessage,
+	}, nil
+}
+
+// ReorderQueue implements the ReorderQueue RPC method
+func (s *Server) ReorderQueue(ctx context.Context, req *pb.ReorderRequest) (*pb.ReorderResponse, error) {
+	if len(req.Updates) == 0 {
+		return nil, fmt.Errorf("at least one update is required")
+	}
+
+	updates := make([]tts.PriorityUpdate, len(req.Updates))
+	for i, u := range req.Updates {
+		if u.JobId == "" {
+			return nil, fmt.Errorf("update %d: job_id is required", i)
+		}
+		updates[i] = tts.PriorityUpdate{JobID: u.JobId, NewPriority: int(u.NewPriority)}
+	}
+
+	updated, notFound, err := s.ttsService.ReorderQueue(updates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reorder queue: %w", err)
+	}
+
+	logRequest(ctx, "ReorderQueue", "updated", updated, "not_found", len(notFound))
+	return &pb.ReorderResponse{
+		UpdatedCount: int32(updated),
+		NotFoundIds:  notFound,
+	}, nil
+}
+
 // PlayTTS implements the PlayTTS RPC method
 // NOTE: This method is deprecated. Clients should use FetchTTS and play audio locally.
 // Kept for backward compatibility - just returns success without playing.
 func (s *Server) PlayTTS(ctx context.Context, req *pb.TTSRequest) (*pb.PlayResponse, error) {
-	log.Printf("Warning: PlayTTS is deprecated, client should use FetchTTS")
+	logging.Warn("PlayTTS is deprecated, client should use FetchTTS")
 
 	if req.Text == "" {
 		return nil, fmt.Errorf("text is required")
@@ -122,7 +518,7 @@ func (s *Server) PlayTTS(ctx context.Context, req *pb.TTSRequest) (*pb.PlayRespo
 	}
 
 	// Get audio (from cache or fetch from Azure) but don't play it
-	_, _, cached, err := s.ttsService.GetAudio(req.Text, req.LanguageCode, req.ForceRefresh)
+	_, _, cached, err := s.ttsService.GetAudio(ctx, req.Text, req.LanguageCode, s.options(req), req.ForceRefresh)
 	if err != nil {
 		return &pb.PlayResponse{
 			Success:   false,
@@ -138,6 +534,44 @@ func (s *Server) PlayTTS(ctx context.Context, req *pb.TTSRequest) (*pb.PlayRespo
 	}, nil
 }
 
+// SynthesizeEphemeral implements the SynthesizeEphemeral RPC method
+// The audio is returned directly and never stored in the cache
+func (s *Server) SynthesizeEphemeral(ctx context.Context, req *pb.TTSRequest) (*pb.EphemeralResponse, error) {
+	if req.Text == "" {
+		return nil, fmt.Errorf("text is required")
+	}
+	if req.LanguageCode == "" {
+		return nil, fmt.Errorf("language_code is required")
+	}
+
+	length := utf8.RuneCountInString(req.Text)
+	if maxLength := s.config.Load().Server.EphemeralMaxTextLength; maxLength > 0 && length > maxLength {
+		return nil, fmt.Errorf("text is too long for ephemeral synthesis (%d characters, limit %d)", length, maxLength)
+	}
+	if err := s.ephemeralBudget.Consume(length); err != nil {
+		return nil, err
+	}
+
+	metrics.EphemeralRequests.Inc()
+
+	audioData, err := s.ttsService.SynthesizeEphemeral(ctx, req.Text, req.LanguageCode, s.options(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to synthesize audio: %w", err)
+	}
+
+	duration, err := tts.AudioDuration(audioData)
+	if err != nil {
+		logging.Warn("SynthesizeEphemeral: failed to measure duration", "error", err)
+	}
+
+	logRequest(ctx, "SynthesizeEphemeral", "lang", req.LanguageCode, "size", len(audioData), "duration", duration)
+
+	return &pb.EphemeralResponse{
+		AudioData:  audioData,
+		DurationMs: duration.Milliseconds(),
+	}, nil
+}
+
 // GetCachedAudio implements the GetCachedAudio RPC method
 func (s *Server) GetCachedAudio(ctx context.Context, req *pb.TTSRequest) (*pb.TTSResponse, error) {
 	if req.Text == "" {
@@ -148,7 +582,7 @@ func (s *Server) GetCachedAudio(ctx context.Context, req *pb.TTSRequest) (*pb.TT
 	}
 
 	// Get audio from cache only
-	audioData, cacheKey, found, err := s.ttsService.GetCachedAudio(req.Text, req.LanguageCode)
+	audioData, cacheKey, found, err := s.ttsService.GetCachedAudio(req.Text, req.LanguageCode, s.options(req))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cached audio: %w", err)
 	}
@@ -180,7 +614,7 @@ func (s *Server) DeleteCached(ctx context.Context, req *pb.TTSRequest) (*pb.Dele
 	}
 
 	// Delete from cache
-	cacheKey, deleted, err := s.ttsService.DeleteCached(req.Text, req.LanguageCode)
+	cacheKey, deleted, err := s.ttsService.DeleteCached(req.Text, req.LanguageCode, s.options(req))
 	if err != nil {
 		return &pb.DeleteResponse{
 			Success:  false,
@@ -197,10 +631,711 @@ func (s *Server) DeleteCached(ctx context.Context, req *pb.TTSRequest) (*pb.Dele
 		}, nil
 	}
 
-	log.Printf("DeleteCached: lang=%s, key=%s", req.LanguageCode, cacheKey[:12])
+	logRequest(ctx, "DeleteCached", "lang", req.LanguageCode, "key", cacheKey[:12])
 	return &pb.DeleteResponse{
 		Success:  true,
 		Message:  "Cache entry deleted successfully",
 		CacheKey: cacheKey,
 	}, nil
 }
+
+// DeletePattern implements the DeletePattern RPC method
+func (s *Server) DeletePattern(ctx context.Context, req *pb.DeletePatternRequest) (*pb.DeletePatternResponse, error) {
+	if req.TextPattern == "" {
+		return nil, fmt.Errorf("text_pattern is required")
+	}
+
+	matched, deleted, freed, err := s.ttsService.DeletePattern(req.Namespace, req.TextPattern, req.LanguageCode, req.DryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete by pattern: %w", err)
+	}
+
+	logRequest(ctx, "DeletePattern", "namespace", req.Namespace, "pattern", req.TextPattern, "lang", req.LanguageCode,
+		"dry_run", req.DryRun, "matched", matched, "deleted", deleted, "freed", freed)
+
+	return &pb.DeletePatternResponse{
+		MatchedCount: matched,
+		DeletedCount: deleted,
+		FreedBytes:   freed,
+	}, nil
+}
+
+// DeleteByLanguage implements the DeleteByLanguage RPC method
+func (s *Server) DeleteByLanguage(ctx context.Context, req *pb.DeleteByLanguageRequest) (*pb.DeleteByLanguageResponse, error) {
+	if req.LanguageCode == "" {
+		return nil, fmt.Errorf("language_code is required")
+	}
+
+	deleted, keys, err := s.ttsService.DeleteByLanguage(req.Namespace, req.LanguageCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete language: %w", err)
+	}
+
+	logRequest(ctx, "DeleteByLanguage", "namespace", req.Namespace, "lang", req.LanguageCode, "deleted", deleted)
+
+	if len(keys) > maxDeletedKeys {
+		keys = keys[:maxDeletedKeys]
+	}
+	return &pb.DeleteByLanguageResponse{
+		DeletedCount: deleted,
+		DeletedKeys:  keys,
+	}, nil
+}
+
+// DeleteByTag implements the DeleteByTag RPC method
+func (s *Server) DeleteByTag(ctx context.Context, req *pb.DeleteByTagRequest) (*pb.DeleteByTagResponse, error) {
+	if req.Tag == "" {
+		return nil, fmt.Errorf("tag is required")
+	}
+
+	deleted, err := s.ttsService.DeleteByTag(req.Namespace, req.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	logRequest(ctx, "DeleteByTag", "namespace", req.Namespace, "tag", req.Tag, "deleted", deleted)
+	return &pb.DeleteByTagResponse{DeletedCount: deleted}, nil
+}
+
+// NormalizationDiff implements the NormalizationDiff RPC method
+func (s *Server) NormalizationDiff(ctx context.Context, req *pb.NormalizationDiffRequest) (*pb.NormalizationDiffResponse, error) {
+	if req.LanguageCode == "" {
+		return nil, fmt.Errorf("language_code is required")
+	}
+
+	normalizedA := tts.NormalizeText(req.TextA)
+	normalizedB := tts.NormalizeText(req.TextB)
+	keyA := tts.GenerateCacheKey(req.TextA, req.LanguageCode, s.options(nil))
+	keyB := tts.GenerateCacheKey(req.TextB, req.LanguageCode, s.options(nil))
+
+	return &pb.NormalizationDiffResponse{
+		NormalizedA:    normalizedA,
+		NormalizedB:    normalizedB,
+		KeyA:           keyA,
+		KeyB:           keyB,
+		KeysEqual:      keyA == keyB,
+		FirstDiffIndex: int32(tts.FirstDiffIndex(normalizedA, normalizedB)),
+		FirstDiffRune:  int32(tts.FirstDiffRune(normalizedA, normalizedB)),
+	}, nil
+}
+
+// SelfDiagnose implements the SelfDiagnose RPC method
+func (s *Server) SelfDiagnose(ctx context.Context, req *pb.DiagnosticRequest) (*pb.DiagnosticReport, error) {
+	results := s.ttsService.RunDiagnostics(ctx)
+
+	checks := make([]*pb.DiagnosticCheck, len(results))
+	for i, r := range results {
+		checks[i] = &pb.DiagnosticCheck{
+			Name:       r.Name,
+			Status:     r.Status,
+			Message:    r.Message,
+			DurationMs: r.Duration.Milliseconds(),
+		}
+	}
+
+	status := tts.WorstStatus(results)
+	logRequest(ctx, "SelfDiagnose", "status", status, "checks", len(checks))
+
+	return &pb.DiagnosticReport{
+		Status: status,
+		Checks: checks,
+	}, nil
+}
+
+// GetDedupStats implements the GetDedupStats RPC method
+func (s *Server) GetDedupStats(ctx context.Context, req *pb.StatsRequest) (*pb.DedupStatsResponse, error) {
+	stats := s.ttsService.DedupStats()
+
+	events := make([]*pb.DedupEvent, len(stats.RecentEvents))
+	for i, e := range stats.RecentEvents {
+		events[i] = &pb.DedupEvent{
+			Timestamp:       e.Timestamp,
+			CacheKey:        e.CacheKey,
+			WaiterCount:     int32(e.WaiterCount),
+			SynthesisTimeMs: e.SynthesisTimeMs,
+		}
+	}
+
+	return &pb.DedupStatsResponse{
+		TotalDedupEvents:   stats.TotalEvents,
+		TotalWaitersSaved:  stats.TotalWaitersSaved,
+		AvgSynthesisTimeMs: stats.AvgSynthesisTimeMs,
+		RecentEvents:       events,
+	}, nil
+}
+
+// VerifyIntegrity implements the VerifyIntegrity RPC method
+func (s *Server) VerifyIntegrity(ctx context.Context, req *pb.VerifyIntegrityRequest) (*pb.IntegrityReport, error) {
+	checked, collisions, mismatches, err := s.ttsService.VerifyIntegrity(req.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify integrity: %w", err)
+	}
+
+	report := &pb.IntegrityReport{
+		Ok:             len(collisions) == 0 && len(mismatches) == 0,
+		EntriesChecked: checked,
+	}
+	for _, group := range collisions {
+		pbGroup := &pb.CollisionGroup{CacheKey: group.CacheKey}
+		for _, entry := range group.Entries {
+			pbGroup.Entries = append(pbGroup.Entries, &pb.CacheEntryRef{
+				Text:         entry.Text,
+				LanguageCode: entry.LanguageCode,
+			})
+		}
+		report.Collisions = append(report.Collisions, pbGroup)
+	}
+	for _, m := range mismatches {
+		report.Mismatches = append(report.Mismatches, &pb.KeyMismatch{
+			CacheKey:     m.CacheKey,
+			Text:         m.Text,
+			LanguageCode: m.LanguageCode,
+			ExpectedKey:  m.ExpectedKey,
+		})
+	}
+
+	logRequest(ctx, "VerifyIntegrity", "namespace", req.Namespace, "checked", checked, "collisions", len(collisions), "mismatches", len(mismatches))
+	return report, nil
+}
+
+// VerifyCache implements the VerifyCache RPC method
+func (s *Server) VerifyCache(ctx context.Context, req *pb.VerifyCacheRequest) (*pb.VerifyCacheResponse, error) {
+	checked, integrityErrors, corruptKeys, repaired, err := s.ttsService.VerifyCache(req.Repair)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify cache: %w", err)
+	}
+
+	logRequest(ctx, "VerifyCache", "checked", checked, "integrity_errors", len(integrityErrors), "corrupt", len(corruptKeys), "repaired", repaired)
+	return &pb.VerifyCacheResponse{
+		IsHealthy:       len(integrityErrors) == 0 && len(corruptKeys) == 0,
+		CorruptEntries:  int64(len(corruptKeys)),
+		CorruptKeys:     corruptKeys[:min(len(corruptKeys), maxCorruptKeys)],
+		IntegrityErrors: integrityErrors,
+		EntriesChecked:  checked,
+		RepairedCount:   repaired,
+	}, nil
+}
+
+// FindNearDuplicates implements the FindNearDuplicates RPC method
+func (s *Server) FindNearDuplicates(ctx context.Context, req *pb.NearDuplicatesRequest) (*pb.NearDuplicatesResponse, error) {
+	threshold := req.Threshold
+	if threshold == 0 {
+		threshold = defaultNearDuplicateThreshold
+	}
+	if threshold < 0 || threshold > 1 {
+		return nil, fmt.Errorf("threshold must be between 0 and 1, got %g", threshold)
+	}
+
+	groups, err := s.ttsService.FindNearDuplicates(req.Namespace, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find near duplicates: %w", err)
+	}
+
+	resp := &pb.NearDuplicatesResponse{}
+	for _, group := range groups {
+		pbGroup := &pb.NearDuplicateGroup{}
+		for _, e := range group {
+			pbGroup.Entries = append(pbGroup.Entries, entryInfoToProto(e))
+		}
+		resp.Groups = append(resp.Groups, pbGroup)
+	}
+
+	logRequest(ctx, "FindNearDuplicates", "namespace", req.Namespace, "threshold", threshold, "groups", len(groups))
+	return resp, nil
+}
+
+// PauseSynthesis implements the PauseSynthesis RPC method
+func (s *Server) PauseSynthesis(ctx context.Context, req *pb.PauseRequest) (*pb.PauseResponse, error) {
+	wasPaused := s.ttsService.PauseSynthesis(req.PauseReason)
+	logRequest(ctx, "PauseSynthesis", "reason", req.PauseReason, "was_paused", wasPaused)
+	return &pb.PauseResponse{WasPaused: wasPaused}, nil
+}
+
+// ResumeSynthesis implements the ResumeSynthesis RPC method
+func (s *Server) ResumeSynthesis(ctx context.Context, req *pb.ResumeRequest) (*pb.ResumeResponse, error) {
+	wasPaused, pausedFor := s.ttsService.ResumeSynthesis()
+	logRequest(ctx, "ResumeSynthesis", "was_paused", wasPaused, "paused_for", pausedFor.Round(time.Second))
+	return &pb.ResumeResponse{
+		WasPaused:     wasPaused,
+		PausedSeconds: int64(pausedFor.Seconds()),
+	}, nil
+}
+
+// RunCompaction implements the RunCompaction RPC method
+func (s *Server) RunCompaction(ctx context.Context, req *pb.CompactionRequest) (*pb.CompactionResponse, error) {
+	result, err := s.ttsService.Compact()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compact database: %w", err)
+	}
+
+	logRequest(ctx, "RunCompaction", "before", result.SizeBefore, "after", result.SizeAfter, "duration", result.Duration.Round(time.Millisecond))
+	return &pb.CompactionResponse{
+		SizeBeforeBytes: result.SizeBefore,
+		SizeAfterBytes:  result.SizeAfter,
+		DurationMs:      result.Duration.Milliseconds(),
+	}, nil
+}
+
+// GetVoiceChangeHistory implements the GetVoiceChangeHistory RPC method
+func (s *Server) GetVoiceChangeHistory(ctx context.Context, req *pb.HistoryRequest) (*pb.VoiceChangeHistoryResponse, error) {
+	changes, err := s.ttsService.VoiceChangeHistory(req.LanguageCode, int(req.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voice history: %w", err)
+	}
+
+	resp := &pb.VoiceChangeHistoryResponse{}
+	for _, change := range changes {
+		entries, err := s.ttsService.CountEntriesWithVoice(change.OldVoice)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count entries: %w", err)
+		}
+		resp.Changes = append(resp.Changes, &pb.VoiceChange{
+			Locale:          change.Locale,
+			OldVoice:        change.OldVoice,
+			NewVoice:        change.NewVoice,
+			DetectedAt:      change.DetectedAt,
+			OldVoiceEntries: entries,
+		})
+	}
+
+	logRequest(ctx, "GetVoiceChangeHistory", "lang", req.LanguageCode, "changes", len(changes))
+	return resp, nil
+}
+
+// RefreshVoiceList implements the RefreshVoiceList RPC method
+func (s *Server) RefreshVoiceList(ctx context.Context, req *pb.RefreshRequest) (*pb.RefreshResponse, error) {
+	voices, locales, err := s.ttsService.RefreshVoiceList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh voice list: %w", err)
+	}
+
+	logRequest(ctx, "RefreshVoiceList", "voices", voices, "locales", locales)
+	return &pb.RefreshResponse{VoiceCount: int32(voices), LocaleCount: int32(locales)}, nil
+}
+
+// ListLocales implements the ListLocales RPC method
+func (s *Server) ListLocales(ctx context.Context, req *pb.ListLocalesRequest) (*pb.ListLocalesResponse, error) {
+	locales, err := s.ttsService.ListLocales(req.Namespace, req.HasAzureVoiceFilter, req.HasCachedContentFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locales: %w", err)
+	}
+
+	resp := &pb.ListLocalesResponse{}
+	for _, l := range locales {
+		resp.Locales = append(resp.Locales, &pb.LocaleInfo{
+			Locale:           l.Locale,
+			DisplayName:      l.DisplayName,
+			HasAzureVoice:    l.HasVoice,
+			CachedEntries:    l.CachedEntries,
+			TotalCachedBytes: l.CachedBytes,
+			DefaultVoice:     l.DefaultVoice,
+		})
+	}
+
+	logRequest(ctx, "ListLocales", "namespace", req.Namespace, "locales", len(locales))
+	return resp, nil
+}
+
+// GetCacheStats implements the GetCacheStats RPC method
+func (s *Server) GetCacheStats(ctx context.Context, req *pb.GetCacheStatsRequest) (*pb.CacheStatsResponse, error) {
+	stats, err := s.ttsService.GetCacheStats(req.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache stats: %w", err)
+	}
+
+	resp := &pb.CacheStatsResponse{}
+	resp.TotalClips, _ = stats["total_clips"].(int64)
+	resp.TotalSizeBytes, _ = stats["total_size"].(int64)
+	resp.MaxSizeMb, _ = stats["max_size_mb"].(float64)
+	resp.UsagePercent, _ = stats["usage_percent"].(float64)
+	resp.ExpiredClips, _ = stats["expired_clips"].(int64)
+	resp.SynthesisPaused, _ = stats["synthesis_paused"].(bool)
+	resp.PauseReason, _ = stats["pause_reason"].(string)
+	if rate, ok := stats["hit_rate_1m"].(float64); ok {
+		resp.HitRate_1M = rate
+	} else {
+		resp.HitRate_1MNote, _ = stats["hit_rate_1m"].(string)
+	}
+	resp.HitRate_5M, _ = stats["hit_rate_5m"].(float64)
+	resp.HitRateTotal, _ = stats["hit_rate_total"].(float64)
+
+	languages, _ := stats["languages"].(map[string]tts.LanguageStat)
+	hitRates, _ := stats["language_hit_rates"].(map[string]tts.HitRate)
+	for lang, l := range languages {
+		resp.Languages = append(resp.Languages, &pb.LanguageStats{
+			LanguageCode: lang,
+			Count:        l.Count,
+			SizeBytes:    l.SizeBytes,
+			OldestEntry:  l.OldestEntry,
+			NewestEntry:  l.NewestEntry,
+			HitRate_1M:   hitRates[lang].OneMinute,
+			HitRate_5M:   hitRates[lang].FiveMinutes,
+			HitRateTotal: hitRates[lang].Total,
+		})
+	}
+	sort.Slice(resp.Languages, func(i, j int) bool {
+		return resp.Languages[i].LanguageCode < resp.Languages[j].LanguageCode
+	})
+
+	logRequest(ctx, "GetCacheStats", "namespace", req.Namespace, "clips", resp.TotalClips, "languages", len(resp.Languages))
+	return resp, nil
+}
+
+// CheckVoiceConsistency implements the CheckVoiceConsistency RPC method
+func (s *Server) CheckVoiceConsistency(ctx context.Context, req *pb.ConsistencyRequest) (*pb.ConsistencyResponse, error) {
+	inconsistencies, err := s.ttsService.CheckVoiceConsistency(req.LanguageCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check voices: %w", err)
+	}
+
+	resp := &pb.ConsistencyResponse{}
+	for _, i := range inconsistencies {
+		resp.Inconsistencies = append(resp.Inconsistencies, &pb.Inconsistency{
+			Locale:          i.Locale,
+			CurrentVoice:    i.CurrentVoice,
+			CachedVoices:    i.CachedVoices,
+			AffectedEntries: i.AffectedEntries,
+		})
+	}
+
+	logRequest(ctx, "CheckVoiceConsistency", "lang", req.LanguageCode, "inconsistent", len(inconsistencies))
+	return resp, nil
+}
+
+// Cache heatmap defaults
+const (
+	defaultHeatmapGranularityMinutes = 60
+	defaultHeatmapDaysBack           = 7
+)
+
+// GetCacheHeatmap implements the GetCacheHeatmap RPC method
+func (s *Server) GetCacheHeatmap(ctx context.Context, req *pb.HeatmapRequest) (*pb.HeatmapResponse, error) {
+	granularity := int(req.GranularityMinutes)
+	if granularity == 0 {
+		granularity = defaultHeatmapGranularityMinutes
+	}
+	daysBack := int(req.DaysBack)
+	if daysBack == 0 {
+		daysBack = defaultHeatmapDaysBack
+	}
+
+	buckets, err := s.ttsService.AccessHeatmap(granularity, daysBack)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build heatmap: %w", err)
+	}
+
+	resp := &pb.HeatmapResponse{
+		GranularityMinutes: int32(granularity),
+		DaysBack:           int32(daysBack),
+	}
+	for _, b := range buckets {
+		resp.Buckets = append(resp.Buckets, &pb.HeatmapBucket{
+			HourOfDay:     int32(b.HourOfDay),
+			MinuteOfHour:  int32(b.MinuteOfHour),
+			AccessCount:   b.AccessCount,
+			CacheHitCount: b.CacheHitCount,
+		})
+	}
+
+	logRequest(ctx, "GetCacheHeatmap", "granularity_minutes", granularity, "days", daysBack)
+	return resp, nil
+}
+
+// GetRateLimitStatus implements the GetRateLimitStatus RPC method
+func (s *Server) GetRateLimitStatus(ctx context.Context, req *pb.RLStatusRequest) (*pb.RLStatusResponse, error) {
+	var waited time.Duration
+	if req.WaitForToken {
+		var err error
+		if waited, err = s.ttsService.WaitForCapacity(ctx); err != nil {
+			return nil, fmt.Errorf("failed to wait for rate limiter: %w", err)
+		}
+	}
+
+	status := s.ttsService.RateLimitStatus()
+	logRequest(ctx, "GetRateLimitStatus", "tokens", status.CurrentTokens, "waited", waited)
+	return &pb.RLStatusResponse{
+		CurrentTokens:        status.CurrentTokens,
+		MaxTokens:            status.MaxTokens,
+		RatePerSecond:        status.RatePerSecond,
+		NextTokenAvailableMs: status.NextTokenAvailable.Milliseconds(),
+		DailyCharsUsed:       int64(status.DailyCharsUsed),
+		WaitedMs:             waited.Milliseconds(),
+	}, nil
+}
+
+// entryInfoToProto converts a cache entry description to its protobuf form
+func entryInfoToProto(e tts.CacheEntryInfo) *pb.CacheEntryInfo {
+	return &pb.CacheEntryInfo{
+		CacheKey:     e.CacheKey,
+		Text:         e.Text,
+		LanguageCode: e.LanguageCode,
+		AudioSize:    e.AudioSize,
+		CreatedAt:    e.CreatedAt,
+		HitCount:     e.HitCount,
+		LastAccessed: e.LastAccessed,
+		VoiceName:    e.VoiceName,
+		Namespace:    e.Namespace,
+	}
+}
+
+// ListCacheEntries implements the ListCacheEntries RPC method
+func (s *Server) ListCacheEntries(ctx context.Context, req *pb.ListCacheEntriesRequest) (*pb.ListCacheEntriesResponse, error) {
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	entries, err := s.ttsService.ListCacheEntries(req.Namespace, req.LanguageCode, req.TextPrefix, req.TagFilter, req.SinceUnix, req.PageToken, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cache entries: %w", err)
+	}
+
+	resp := &pb.ListCacheEntriesResponse{
+		Entries: make([]*pb.CacheEntryInfo, len(entries)),
+	}
+	for i, e := range entries {
+		resp.Entries[i] = entryInfoToProto(e)
+	}
+	// A full page may be followed by more entries; the key it ended on is where the next one starts
+	if len(entries) == pageSize {
+		resp.NextPageToken = entries[len(entries)-1].CacheKey
+	}
+
+	return resp, nil
+}
+
+// GetCacheEntry implements the GetCacheEntry RPC method
+func (s *Server) GetCacheEntry(ctx context.Context, req *pb.GetCacheEntryRequest) (*pb.GetCacheEntryResponse, error) {
+	if req.CacheKey == "" {
+		return nil, fmt.Errorf("cache_key is required")
+	}
+
+	entry, err := s.ttsService.GetCacheEntry(req.Namespace, req.CacheKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache entry: %w", err)
+	}
+	if entry == nil {
+		return &pb.GetCacheEntryResponse{Found: false}, nil
+	}
+
+	return &pb.GetCacheEntryResponse{
+		Found: true,
+		Entry: &pb.CacheEntryInfo{
+			CacheKey:     entry.CacheKey,
+			Text:         entry.Text,
+			LanguageCode: entry.LanguageCode,
+			AudioSize:    int64(len(entry.AudioData)),
+			CreatedAt:    entry.CreatedAt,
+			LastAccessed: entry.LastAccessed,
+			VoiceName:    entry.VoiceName,
+			Namespace:    entry.Namespace,
+		},
+		AudioData: entry.AudioData,
+	}, nil
+}
+
+// DeleteCacheEntry implements the DeleteCacheEntry RPC method
+func (s *Server) DeleteCacheEntry(ctx context.Context, req *pb.GetCacheEntryRequest) (*pb.DeleteResponse, error) {
+	if req.CacheKey == "" {
+		return nil, fmt.Errorf("cache_key is required")
+	}
+
+	deleted, err := s.ttsService.DeleteCacheEntry(req.Namespace, req.CacheKey)
+	if err != nil {
+		return &pb.DeleteResponse{
+			Success:  false,
+			Message:  fmt.Sprintf("Failed to delete: %v", err),
+			CacheKey: req.CacheKey,
+		}, nil
+	}
+	if !deleted {
+		return &pb.DeleteResponse{
+			Success:  false,
+			Message:  "Entry not found in cache",
+			CacheKey: req.CacheKey,
+		}, nil
+	}
+
+	logRequest(ctx, "DeleteCacheEntry", "key", req.CacheKey[:min(12, len(req.CacheKey))])
+	return &pb.DeleteResponse{
+		Success:  true,
+		Message:  "Cache entry deleted successfully",
+		CacheKey: req.CacheKey,
+	}, nil
+}
+
+// Clone implements the Clone RPC method. It pages through the source daemon's entries and copies
+// every one that isn't already cached here, keeping its cache key.
+func (s *Server) Clone(req *pb.CloneRequest, stream pb.TTSService_CloneServer) error {
+	if req.SourceAddress == "" {
+		return fmt.Errorf("source_address is required")
+	}
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPageSize
+	}
+
+	pool, err := client.NewClientPool([]string{req.SourceAddress}, client.PolicyPickFirst, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to source daemon: %w", err)
+	}
+	defer pool.Close()
+	source := pool.Client()
+	ctx := stream.Context()
+
+	logRequest(ctx, "Clone started", "source", req.SourceAddress, "namespace", req.Namespace, "lang", req.LanguageCodeFilter, "since", req.SinceUnix)
+
+	var progress pb.CloneProgress
+	pageToken := ""
+	for {
+		page, err := source.ListCacheEntries(ctx, &pb.ListCacheEntriesRequest{
+			LanguageCode: req.LanguageCodeFilter,
+			SinceUnix:    req.SinceUnix,
+			PageSize:     batchSize,
+			PageToken:    pageToken,
+			Namespace:    req.Namespace,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list source entries: %w", err)
+		}
+
+		for _, info := range page.Entries {
+			progress.CurrentLanguage = info.LanguageCode
+
+			exists, err := s.ttsService.HasCacheEntry(info.CacheKey)
+			if err != nil {
+				return fmt.Errorf("failed to check local cache: %w", err)
+			}
+			if exists {
+				progress.Skipped++
+				continue
+			}
+
+			entry, err := source.GetCacheEntry(ctx, &pb.GetCacheEntryRequest{CacheKey: info.CacheKey, Namespace: req.Namespace})
+			if err != nil || !entry.Found {
+				// The entry may have been evicted or deleted since the page was listed
+				logging.Warn("Clone: failed to fetch entry", "key", info.CacheKey, "error", err)
+				progress.Failed++
+				continue
+			}
+
+			if err := s.ttsService.ImportCacheEntry(info.CacheKey, req.Namespace, info.Text, info.LanguageCode, entry.AudioData); err != nil {
+				logging.Warn("Clone: failed to store entry", "key", info.CacheKey, "error", err)
+				progress.Failed++
+				continue
+			}
+			progress.Copied++
+		}
+
+		if err := stream.Send(&progress); err != nil {
+			return fmt.Errorf("failed to send progress: %w", err)
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+
+	logRequest(ctx, "Clone finished",
+		"source", req.SourceAddress, "copied", progress.Copied, "skipped", progress.Skipped, "failed", progress.Failed)
+	return nil
+}
+
+// ResynthesizeAll implements the ResynthesizeAll RPC method
+func (s *Server) ResynthesizeAll(req *pb.ResynthesizeRequest, stream pb.TTSService_ResynthesizeAllServer) error {
+	if req.LanguageCode == "" {
+		return fmt.Errorf("language_code is required")
+	}
+	batchSize := int(req.BatchSize)
+	if batchSize <= 0 {
+		batchSize = defaultPageSize
+	}
+	ctx := stream.Context()
+
+	logRequest(ctx, "ResynthesizeAll started", "namespace", req.Namespace, "lang", req.LanguageCode, "batch_size", batchSize, "qps", req.RateLimitQps)
+
+	stats, err := s.ttsService.ResynthesizeAll(ctx, req.Namespace, req.LanguageCode, batchSize, req.RateLimitQps, func(p tts.ResynthesizeProgress) error {
+		progress := &pb.ResynthesizeProgress{
+			Index:         p.Index,
+			Total:         p.Total,
+			CacheKey:      p.CacheKey,
+			DurationMs:    p.Duration.Milliseconds(),
+			Resynthesized: p.Resynthesized,
+			Failed:        p.Failed,
+		}
+		if p.Err != nil {
+			logging.Warn("ResynthesizeAll: failed to re-synthesize entry", "key", p.CacheKey, "error", p.Err)
+			progress.Error = p.Err.Error()
+		}
+		return stream.Send(progress)
+	})
+	if err != nil {
+		return fmt.Errorf("resynthesis failed: %w", err)
+	}
+
+	state := "finished"
+	if ctx.Err() != nil {
+		state = "cancelled"
+	}
+	logRequest(ctx, "ResynthesizeAll "+state,
+		"lang", req.LanguageCode, "resynthesized", stats.Resynthesized, "failed", stats.Failed, "total", stats.Total)
+	return nil
+}
+
+// WatchCache implements the WatchCache RPC method
+// Events are streamed until the client disconnects or the daemon shuts down
+func (s *Server) WatchCache(req *pb.WatchRequest, stream pb.TTSService_WatchCacheServer) error {
+	eventTypes := make(map[string]bool)
+	for _, t := range req.EventTypes {
+		if t != tts.EventPut && t != tts.EventDelete {
+			return fmt.Errorf("unknown event type %q (use %s or %s)", t, tts.EventPut, tts.EventDelete)
+		}
+		eventTypes[t] = true
+	}
+
+	if s.activeWatchers.Add(1) > maxWatchStreams {
+		s.activeWatchers.Add(-1)
+		return fmt.Errorf("too many watch streams (limit %d)", maxWatchStreams)
+	}
+	defer s.activeWatchers.Add(-1)
+
+	events, unsubscribe := s.ttsService.WatchCache()
+	defer unsubscribe()
+
+	logRequest(stream.Context(), "WatchCache started", "namespace", req.Namespace, "lang", req.FilterLanguageCode, "types", req.EventTypes)
+	defer logging.Info("WatchCache stopped")
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if event.Namespace != req.Namespace {
+				continue
+			}
+			if req.FilterLanguageCode != "" && event.LanguageCode != req.FilterLanguageCode {
+				continue
+			}
+			if len(eventTypes) > 0 && !eventTypes[event.Type] {
+				continue
+			}
+
+			if err := stream.Send(&pb.CacheEvent{
+				EventType:    event.Type,
+				CacheKey:     event.CacheKey,
+				LanguageCode: event.LanguageCode,
+				AudioSize:    event.AudioSize,
+				Timestamp:    event.Timestamp,
+			}); err != nil {
+				return fmt.Errorf("failed to send event: %w", err)
+			}
+		}
+	}
+}
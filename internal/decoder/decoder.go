@@ -0,0 +1,22 @@
+// Package decoder decodes in-memory audio into beep streams, for both playback and analysis
+package decoder
+
+import (
+	"bytes"
+
+	"github.com/gopxl/beep"
+	"github.com/gopxl/beep/mp3"
+)
+
+// nopSeekCloser adds a no-op Close to a bytes.Reader
+type nopSeekCloser struct {
+	*bytes.Reader
+}
+
+func (nopSeekCloser) Close() error { return nil }
+
+// MP3 decodes MP3 audio data. The reader passed to the decoder can seek, so the stream knows its
+// length and can be seeked too.
+func MP3(audioData []byte) (beep.StreamSeekCloser, beep.Format, error) {
+	return mp3.Decode(nopSeekCloser{bytes.NewReader(audioData)})
+}
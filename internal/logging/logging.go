@@ -0,0 +1,131 @@
+// Package logging writes the daemon's log as plain text or as JSON, for log aggregation tools
+package logging
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Log formats (server.log_format)
+const (
+	FormatText = "text" // "message: key=value, key=value", through the standard log package
+	FormatJSON = "json" // One JSON object per line, from log/slog
+)
+
+// Logger logs messages with structured fields, given as alternating keys and values as with
+// log/slog, e.g. Info("FetchTTS", "lang", "en-US", "size", 1024)
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// current is the logger used by the package-level functions
+var current atomic.Pointer[Logger]
+
+func init() {
+	var text Logger = textLogger{}
+	current.Store(&text)
+}
+
+// New returns a logger writing in format, FormatText or FormatJSON, to standard error
+func New(format string) (Logger, error) {
+	switch format {
+	case FormatText, "":
+		return textLogger{}, nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+			Level:       slog.LevelDebug,
+			ReplaceAttr: readableDurations,
+		})), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q (must be %s or %s)", format, FormatText, FormatJSON)
+	}
+}
+
+// Setup makes the package-level functions log in format. With FormatJSON, messages still logged
+// with the standard log package are written as JSON too, at the info level.
+func Setup(format string) error {
+	logger, err := New(format)
+	if err != nil {
+		return err
+	}
+	if slogger, ok := logger.(*slog.Logger); ok {
+		slog.SetDefault(slogger)
+	}
+	current.Store(&logger)
+	return nil
+}
+
+// Debug logs msg at the debug level with the logger set up by Setup
+func Debug(msg string, args ...any) { (*current.Load()).Debug(msg, args...) }
+
+// Info logs msg at the info level with the logger set up by Setup
+func Info(msg string, args ...any) { (*current.Load()).Info(msg, args...) }
+
+// Warn logs msg at the warning level with the logger set up by Setup
+func Warn(msg string, args ...any) { (*current.Load()).Warn(msg, args...) }
+
+// Error logs msg at the error level with the logger set up by Setup
+func Error(msg string, args ...any) { (*current.Load()).Error(msg, args...) }
+
+// readableDurations writes durations as strings such as "1.5s" rather than nanoseconds
+func readableDurations(_ []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() == slog.KindDuration {
+		return slog.String(a.Key, a.Value.Duration().String())
+	}
+	return a
+}
+
+// textLogger logs in the daemon's original plain-text format, prefixing warnings, errors and
+// debug messages with their level
+type textLogger struct{}
+
+func (textLogger) Debug(msg string, args ...any) { log.Print("Debug: " + formatText(msg, args)) }
+func (textLogger) Info(msg string, args ...any)  { log.Print(formatText(msg, args)) }
+func (textLogger) Warn(msg string, args ...any)  { log.Print("Warning: " + formatText(msg, args)) }
+func (textLogger) Error(msg string, args ...any) { log.Print("Error: " + formatText(msg, args)) }
+
+// formatText returns "msg: key=value, key=value", quoting values that are empty or contain
+// spaces or separators. Like log/slog, a key that isn't a string is logged as !BADKEY.
+func formatText(msg string, args []any) string {
+	if len(args) == 0 {
+		return msg
+	}
+
+	var b strings.Builder
+	b.WriteString(msg)
+	b.WriteString(": ")
+	for i := 0; len(args) > 0; i++ {
+		key, ok := args[0].(string)
+		var value any
+		if ok && len(args) > 1 {
+			value, args = args[1], args[2:]
+		} else {
+			key, value, args = "!BADKEY", args[0], args[1:]
+		}
+
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(formatValue(value))
+	}
+	return b.String()
+}
+
+// formatValue formats one field's value for formatText
+func formatValue(value any) string {
+	s := fmt.Sprint(value)
+	if _, isString := value.(string); isString && (s == "" || strings.ContainsAny(s, " \t\n\"=,")) {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import "sync/atomic"
+
+// OtherLanguage is the language label of requests and cache entries for languages outside
+// SetLanguages, so arbitrary language codes in requests can't create new series
+const OtherLanguage = "other"
+
+// languages holds the language codes with a language label of their own (nil = every language)
+var languages atomic.Pointer[map[string]bool]
+
+// SetLanguages sets the language codes that get a language label of their own; any other is
+// labelled OtherLanguage. Until it is called every language code is its own label.
+func SetLanguages(languageCodes []string) {
+	known := make(map[string]bool, len(languageCodes))
+	for _, languageCode := range languageCodes {
+		known[languageCode] = true
+	}
+	languages.Store(&known)
+}
+
+// LanguageLabel returns the language label of languageCode (see SetLanguages)
+func LanguageLabel(languageCode string) string {
+	known := languages.Load()
+	if known == nil || (*known)[languageCode] {
+		return languageCode
+	}
+	return OtherLanguage
+}
@@ -0,0 +1,103 @@
+// Package metrics defines the daemon's Prometheus metrics
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// CacheSizeBytes is the stored size of cached audio per language label (see LanguageLabel),
+	// updated periodically when the cache has changed
+	CacheSizeBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tts_cache_size_bytes",
+		Help: "Stored size of cached audio in bytes.",
+	}, []string{"language"})
+
+	// CacheEntries is the number of cached entries per language, updated with CacheSizeBytes
+	CacheEntries = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tts_cache_entries_total",
+		Help: "Number of cached audio entries.",
+	}, []string{"language"})
+
+	// Requests counts GetAudio requests by where the audio came from (cache or azure) and language
+	// label (see LanguageLabel)
+	Requests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tts_requests_total",
+		Help: "Number of audio requests by source, language and status.",
+	}, []string{"source", "language", "status"})
+
+	// RequestDuration is the latency of GetAudio requests, cache hits and synthesis alike
+	RequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tts_request_duration_seconds",
+		Help:    "Time taken to return audio, by source.",
+		Buckets: []float64{0.001, 0.005, 0.025, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"source"})
+
+	// AzureAPICalls counts synthesis calls to the provider (Azure or the configured alternative)
+	AzureAPICalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tts_azure_api_calls_total",
+		Help: "Number of synthesis calls to the TTS provider by status.",
+	}, []string{"status"})
+
+	// EphemeralRequests counts SynthesizeEphemeral calls, which bypass the cache
+	EphemeralRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "tts_ephemeral_requests_total",
+		Help: "Number of ephemeral (uncached) synthesis requests.",
+	})
+
+	// DedupWaitersSaved counts requests that shared another request's synthesis instead of calling Azure
+	DedupWaitersSaved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "tts_dedup_waiters_saved_total",
+		Help: "Number of Azure synthesis calls saved by deduplicating concurrent requests.",
+	})
+
+	// AdaptiveBatchSize is the number of concurrent requests in the current adaptive bulk fetch batch
+	AdaptiveBatchSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "tts_adaptive_batch_size",
+		Help: "Concurrent requests per batch of the most recent adaptive bulk fetch.",
+	})
+
+	// HotCacheHits counts cache lookups served from the hot cache instead of SQLite
+	HotCacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "tts_hot_cache_hits_total",
+		Help: "Number of cache lookups served from the hot cache.",
+	})
+
+	// MemoryCacheHits counts cache lookups served from the in-memory LRU tier
+	MemoryCacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "tts_memory_cache_hits_total",
+		Help: "Number of cache lookups served from the memory cache.",
+	})
+
+	// RedisCacheHits counts cache lookups that missed SQLite and were served from Redis
+	RedisCacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "tts_redis_cache_hits_total",
+		Help: "Number of cache lookups served from Redis.",
+	})
+
+	// S3CacheHits counts cache lookups that missed SQLite and were served from S3
+	S3CacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "tts_s3_cache_hits_total",
+		Help: "Number of cache lookups served from S3.",
+	})
+
+	// VoiceCacheRefreshes counts successful reloads of the Azure voice list after startup
+	VoiceCacheRefreshes = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "tts_voice_cache_refreshes_total",
+		Help: "Number of times the voice list was refreshed.",
+	})
+
+	// InvalidAudio counts synthesized audio rejected as truncated, corrupt or silent
+	InvalidAudio = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "tts_invalid_audio_total",
+		Help: "Number of synthesis responses rejected as truncated, corrupt or silent.",
+	})
+
+	// PreprocessDuration is the latency of rewriting text with OpenAI before synthesis
+	PreprocessDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "tts_preprocess_duration_seconds",
+		Help:    "Time spent rewriting text with OpenAI before synthesis.",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	})
+)
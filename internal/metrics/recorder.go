@@ -0,0 +1,26 @@
+package metrics
+
+import "time"
+
+// PrometheusRecorder records the TTS service's request metrics in this package's metrics.
+// It implements tts.MetricsRecorder.
+type PrometheusRecorder struct{}
+
+// RecordRequest implements tts.MetricsRecorder
+func (PrometheusRecorder) RecordRequest(source, languageCode string, err error, duration time.Duration) {
+	Requests.WithLabelValues(source, LanguageLabel(languageCode), status(err)).Inc()
+	RequestDuration.WithLabelValues(source).Observe(duration.Seconds())
+}
+
+// RecordProviderCall implements tts.MetricsRecorder
+func (PrometheusRecorder) RecordProviderCall(err error) {
+	AzureAPICalls.WithLabelValues(status(err)).Inc()
+}
+
+// status returns the status label for a call that returned err
+func status(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
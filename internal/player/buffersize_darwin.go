@@ -0,0 +1,31 @@
+package player
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// DetectOptimalBufferSize returns the buffer size reported by system_profiler for the
+// default output device, or the default buffer size if none can be determined
+func DetectOptimalBufferSize() int {
+	out, err := exec.Command("system_profiler", "SPAudioDataType").Output()
+	if err != nil {
+		return defaultBufferSize
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		name, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok || !strings.Contains(strings.ToLower(name), "buffer") {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		if size, err := strconv.Atoi(fields[0]); err == nil && size > 0 {
+			return size
+		}
+	}
+	return defaultBufferSize
+}
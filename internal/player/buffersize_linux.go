@@ -0,0 +1,30 @@
+package player
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DetectOptimalBufferSize returns the hardware period size of the first active ALSA
+// playback device, or the default buffer size if none can be determined
+func DetectOptimalBufferSize() int {
+	paths, _ := filepath.Glob("/proc/asound/card*/pcm*p/sub*/hw_params")
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			value, ok := strings.CutPrefix(line, "period_size:")
+			if !ok {
+				continue
+			}
+			if size, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && size > 0 {
+				return size
+			}
+		}
+	}
+	return defaultBufferSize
+}
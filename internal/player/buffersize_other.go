@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package player
+
+// DetectOptimalBufferSize returns the default buffer size; detection is not supported on this platform
+func DetectOptimalBufferSize() int {
+	return defaultBufferSize
+}
@@ -0,0 +1,55 @@
+package player
+
+import (
+	"github.com/gopxl/beep"
+)
+
+// FadeIn ramps the volume of streamer linearly from silence to full over its first n samples,
+// which avoids the click some hardware produces when audio starts abruptly. The first sample is
+// silent and the nth is at full volume.
+func FadeIn(streamer beep.Streamer, n int) beep.Streamer {
+	if n <= 1 {
+		return streamer
+	}
+
+	pos := 0
+	return beep.StreamerFunc(func(samples [][2]float64) (int, bool) {
+		read, ok := streamer.Stream(samples)
+		for i := 0; i < read && pos < n; i++ {
+			gain := float64(pos) / float64(n-1)
+			samples[i][0] *= gain
+			samples[i][1] *= gain
+			pos++
+		}
+		return read, ok
+	})
+}
+
+// FadeOut ramps the volume of streamer linearly down to silence over the last n of its total
+// samples, mirroring FadeIn: the first of them is at full volume and the last is silent
+func FadeOut(streamer beep.Streamer, total, n int) beep.Streamer {
+	if n <= 1 || total <= 0 {
+		return streamer
+	}
+	if n > total {
+		n = total
+	}
+
+	pos := 0
+	start := total - n
+	return beep.StreamerFunc(func(samples [][2]float64) (int, bool) {
+		read, ok := streamer.Stream(samples)
+		for i := 0; i < read; i++ {
+			if pos >= start {
+				gain := float64(total-pos-1) / float64(n-1)
+				if gain < 0 {
+					gain = 0
+				}
+				samples[i][0] *= gain
+				samples[i][1] *= gain
+			}
+			pos++
+		}
+		return read, ok
+	})
+}
@@ -0,0 +1,96 @@
+package player
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/gopxl/beep"
+)
+
+// squareWave returns n samples alternating between +1 and -1 every 5 samples
+func squareWave(n int) [][2]float64 {
+	samples := make([][2]float64, n)
+	for i := range samples {
+		v := 1.0
+		if i/5%2 == 1 {
+			v = -1
+		}
+		samples[i] = [2]float64{v, v}
+	}
+	return samples
+}
+
+// streamAll reads streamer to the end, chunk samples at a time so the fade spans several calls
+func streamAll(streamer beep.Streamer, chunk int) [][2]float64 {
+	var out [][2]float64
+	buf := make([][2]float64, chunk)
+	for {
+		n, ok := streamer.Stream(buf)
+		out = append(out, buf[:n]...)
+		if !ok {
+			return out
+		}
+	}
+}
+
+func TestFades(t *testing.T) {
+	const total = 100
+	const fade = 10
+	const tolerance = 1e-9
+
+	tests := []struct {
+		name  string
+		apply func(beep.Streamer) beep.Streamer
+		gain  func(i int) float64 // Expected gain of sample i
+	}{
+		{
+			name:  "fade in",
+			apply: func(s beep.Streamer) beep.Streamer { return FadeIn(s, fade) },
+			gain:  func(i int) float64 { return math.Min(float64(i)/(fade-1), 1) },
+		},
+		{
+			name:  "fade out",
+			apply: func(s beep.Streamer) beep.Streamer { return FadeOut(s, total, fade) },
+			gain:  func(i int) float64 { return math.Min(float64(total-1-i)/(fade-1), 1) },
+		},
+		{
+			name:  "fade in disabled",
+			apply: func(s beep.Streamer) beep.Streamer { return FadeIn(s, 0) },
+			gain:  func(i int) float64 { return 1 },
+		},
+		{
+			name:  "fade out longer than the audio",
+			apply: func(s beep.Streamer) beep.Streamer { return FadeOut(s, total, 1000) },
+			gain:  func(i int) float64 { return float64(total-1-i) / (total - 1) },
+		},
+	}
+	for _, tt := range tests {
+		for _, chunk := range []int{1, 3, 512} {
+			t.Run(fmt.Sprintf("%s/chunks of %d", tt.name, chunk), func(t *testing.T) {
+				input := squareWave(total)
+				got := streamAll(tt.apply(&sliceStreamer{samples: squareWave(total)}), chunk)
+				if len(got) != total {
+					t.Fatalf("streamed %d samples, want %d", len(got), total)
+				}
+				for i, sample := range got {
+					for channel := range sample {
+						want := input[i][channel] * tt.gain(i)
+						if math.Abs(sample[channel]-want) > tolerance {
+							t.Errorf("sample %d channel %d = %v, want %v", i, channel, sample[channel], want)
+						}
+					}
+				}
+			})
+		}
+	}
+
+	// The request's own check: the first sample is silent and the 10th at full amplitude
+	got := streamAll(FadeIn(&sliceStreamer{samples: squareWave(total)}, fade), total)
+	if math.Abs(got[0][0]) > tolerance {
+		t.Errorf("first sample = %v, want 0", got[0][0])
+	}
+	if math.Abs(got[fade-1][0]) < 1-tolerance {
+		t.Errorf("sample %d = %v, want full amplitude", fade, got[fade-1][0])
+	}
+}
@@ -0,0 +1,47 @@
+package player
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/gopxl/beep"
+	"github.com/gopxl/beep/wav"
+)
+
+// opusDecoder is the opus-tools command used to decode OGG Opus
+const opusDecoder = "opusdec"
+
+// isOgg reports whether audioData starts with an OGG page header
+func isOgg(audioData []byte) bool {
+	return len(audioData) >= 4 && string(audioData[0:4]) == "OggS"
+}
+
+// OpusAvailable reports whether OGG Opus audio can be played, which needs opusdec on the PATH
+func OpusAvailable() bool {
+	_, err := exec.LookPath(opusDecoder)
+	return err == nil
+}
+
+// decodeOggOpus decodes OGG Opus audio by piping it through opusdec, which writes 48kHz WAV
+func decodeOggOpus(audioData []byte) (beep.StreamSeekCloser, beep.Format, error) {
+	if !OpusAvailable() {
+		return nil, beep.Format{}, fmt.Errorf("playing Opus audio requires %s (opus-tools)", opusDecoder)
+	}
+
+	var out, stderr bytes.Buffer
+	cmd := exec.Command(opusDecoder, "--quiet", "--force-wav", "--rate", "48000", "-", "-")
+	cmd.Stdin = bytes.NewReader(audioData)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, beep.Format{}, fmt.Errorf("failed to decode Opus: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	streamer, format, err := wav.Decode(bytes.NewReader(out.Bytes()))
+	if err != nil {
+		return nil, format, fmt.Errorf("failed to decode Opus: %w", err)
+	}
+	return streamer, format, nil
+}
@@ -0,0 +1,26 @@
+package player
+
+import "testing"
+
+func TestIsOgg(t *testing.T) {
+	tests := []struct {
+		name      string
+		audioData []byte
+		want      bool
+	}{
+		{"ogg page", []byte("OggS\x00\x02\x00\x00"), true},
+		{"magic alone", []byte("OggS"), true},
+		{"too short", []byte("Ogg"), false},
+		{"wrong case", []byte("oggs\x00\x02"), false},
+		{"wav", testWAV(16000, make([]int16, 10)), false},
+		{"mp3", []byte{0xFF, 0xFB, 0x90, 0xC4}, false},
+		{"empty", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isOgg(tt.audioData); got != tt.want {
+				t.Errorf("isOgg = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
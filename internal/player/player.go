@@ -3,13 +3,16 @@ package player
 import (
 	"bytes"
 	"fmt"
-	"io"
+	"runtime"
 	"sync"
 	"time"
 
+	"com.biesnecker/tts-daemon/internal/config"
+	"com.biesnecker/tts-daemon/internal/decoder"
+
 	"github.com/gopxl/beep"
-	"github.com/gopxl/beep/mp3"
 	"github.com/gopxl/beep/speaker"
+	"github.com/gopxl/beep/wav"
 )
 
 var (
@@ -22,25 +25,123 @@ var (
 type Player struct {
 	sampleRate beep.SampleRate
 	bufferSize int
+	fadeIn     time.Duration
+	fadeOut    time.Duration
 	mu         sync.Mutex
 }
 
-// NewPlayer creates a new audio player
-func NewPlayer(sampleRate, bufferSize int) *Player {
+// Defaults used when the audio configuration leaves a value unset
+const (
+	defaultSampleRate = 44100
+	defaultBufferSize = 4096
+	defaultFadeMs     = 20
+)
+
+// NewPlayer creates a new audio player, applying any overrides for the current platform.
+// If no buffer size is configured, the hardware's preferred size is detected.
+func NewPlayer(cfg config.AudioConfig) *Player {
+	cfg = cfg.ForPlatform(runtime.GOOS)
+	if cfg.SampleRate == 0 {
+		cfg.SampleRate = defaultSampleRate
+	}
+	if cfg.BufferSize == 0 {
+		cfg.BufferSize = DetectOptimalBufferSize()
+	}
+	if cfg.FadeInMs == 0 {
+		cfg.FadeInMs = defaultFadeMs
+	}
+	if cfg.FadeOutMs == 0 {
+		cfg.FadeOutMs = defaultFadeMs
+	}
+
 	return &Player{
-		sampleRate: beep.SampleRate(sampleRate),
-		bufferSize: bufferSize,
+		sampleRate: beep.SampleRate(cfg.SampleRate),
+		bufferSize: cfg.BufferSize,
+		fadeIn:     time.Duration(cfg.FadeInMs) * time.Millisecond,
+		fadeOut:    time.Duration(cfg.FadeOutMs) * time.Millisecond,
+	}
+}
+
+// BufferSize returns the speaker buffer size in samples
+func (p *Player) BufferSize() int {
+	return p.bufferSize
+}
+
+// PlayOption configures a single playback
+type PlayOption func(*playOptions)
+
+// playOptions holds the settings applied by PlayOptions
+type playOptions struct {
+	tempo   float64
+	fadeIn  time.Duration
+	fadeOut time.Duration
+}
+
+// WithTempo changes the playback tempo by factor without changing pitch
+// (1.0 = unchanged, valid range MinTempo to MaxTempo)
+func WithTempo(factor float64) PlayOption {
+	return func(o *playOptions) {
+		o.tempo = factor
+	}
+}
+
+// WithFadeIn overrides the configured fade-in length (0 disables the fade)
+func WithFadeIn(d time.Duration) PlayOption {
+	return func(o *playOptions) {
+		o.fadeIn = d
+	}
+}
+
+// WithFadeOut overrides the configured fade-out length (0 disables the fade)
+func WithFadeOut(d time.Duration) PlayOption {
+	return func(o *playOptions) {
+		o.fadeOut = d
+	}
+}
+
+// isWAV reports whether audioData starts with a RIFF/WAVE header
+func isWAV(audioData []byte) bool {
+	return len(audioData) >= 12 && string(audioData[0:4]) == "RIFF" && string(audioData[8:12]) == "WAVE"
+}
+
+// decode picks a decoder based on the audio data's magic bytes (WAV, OGG Opus or MP3)
+func decode(audioData []byte) (beep.StreamSeekCloser, beep.Format, error) {
+	if isOgg(audioData) {
+		return decodeOggOpus(audioData)
+	}
+
+	if isWAV(audioData) {
+		streamer, format, err := wav.Decode(bytes.NewReader(audioData))
+		if err != nil {
+			return nil, format, fmt.Errorf("failed to decode WAV: %w", err)
+		}
+		return streamer, format, nil
+	}
+
+	// The stream's length is needed for the fade-out
+	streamer, format, err := decoder.MP3(audioData)
+	if err != nil {
+		return nil, format, fmt.Errorf("failed to decode MP3: %w", err)
 	}
+	return streamer, format, nil
 }
 
-// PlayMP3 plays MP3 audio data
-func (p *Player) PlayMP3(audioData []byte) error {
+// Play plays MP3, WAV or OGG Opus audio data, detecting the format from its magic bytes
+func (p *Player) Play(audioData []byte, opts ...PlayOption) error {
+	options := playOptions{tempo: 1.0, fadeIn: p.fadeIn, fadeOut: p.fadeOut}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.tempo < MinTempo || options.tempo > MaxTempo {
+		return fmt.Errorf("tempo factor %.2f out of range (%.1f-%.1f)", options.tempo, MinTempo, MaxTempo)
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	// Initialize speaker once globally (beep/speaker doesn't support reinitialization)
 	speakerOnce.Do(func() {
-		speakerErr = speaker.Init(p.sampleRate, p.sampleRate.N(time.Second/10))
+		speakerErr = speaker.Init(p.sampleRate, p.bufferSize)
 	})
 	if speakerErr != nil {
 		return fmt.Errorf("failed to initialize speaker: %w", speakerErr)
@@ -49,22 +150,32 @@ func (p *Player) PlayMP3(audioData []byte) error {
 	// Clear any queued audio before playing
 	speaker.Clear()
 
-	// Create a reader from the audio data
-	reader := bytes.NewReader(audioData)
-
-	// Decode MP3
-	streamer, format, err := mp3.Decode(io.NopCloser(reader))
+	streamer, format, err := decode(audioData)
 	if err != nil {
-		return fmt.Errorf("failed to decode MP3: %w", err)
+		return err
 	}
 	defer streamer.Close()
 
-	// Resample if the MP3 sample rate doesn't match our speaker's sample rate
+	// Resample if the audio's sample rate doesn't match our speaker's sample rate
 	var resampled beep.Streamer = streamer
 	if format.SampleRate != p.sampleRate {
 		resampled = beep.Resample(4, format.SampleRate, p.sampleRate, streamer)
 	}
 
+	// Length of the output in speaker samples, needed to know where the fade-out starts
+	total := p.sampleRate.N(format.SampleRate.D(streamer.Len()))
+
+	// Time-stretch the decoded PCM if a tempo change was requested
+	if options.tempo != 1.0 {
+		stretched := TimeStretch(readAll(resampled), options.tempo, p.sampleRate.N(40*time.Millisecond))
+		resampled = &sliceStreamer{samples: stretched}
+		total = len(stretched)
+	}
+
+	// Ramp the start and end to avoid clicks
+	resampled = FadeIn(resampled, p.sampleRate.N(options.fadeIn))
+	resampled = FadeOut(resampled, total, p.sampleRate.N(options.fadeOut))
+
 	// Create a done channel to wait for playback to finish
 	done := make(chan bool)
 
@@ -0,0 +1,74 @@
+package player
+
+import (
+	"encoding/binary"
+	"os"
+	"strings"
+	"testing"
+)
+
+// testWAV returns 16-bit mono PCM WAV audio with the given sample rate and samples
+func testWAV(sampleRate int, samples []int16) []byte {
+	data := make([]byte, 44+2*len(samples))
+	copy(data[0:], "RIFF")
+	binary.LittleEndian.PutUint32(data[4:], uint32(len(data)-8))
+	copy(data[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(data[16:], 16)
+	binary.LittleEndian.PutUint16(data[20:], 1) // PCM
+	binary.LittleEndian.PutUint16(data[22:], 1) // Mono
+	binary.LittleEndian.PutUint32(data[24:], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(data[28:], uint32(2*sampleRate))
+	binary.LittleEndian.PutUint16(data[32:], 2)
+	binary.LittleEndian.PutUint16(data[34:], 16)
+	copy(data[36:], "data")
+	binary.LittleEndian.PutUint32(data[40:], uint32(2*len(samples)))
+	for i, sample := range samples {
+		binary.LittleEndian.PutUint16(data[44+2*i:], uint16(sample))
+	}
+	return data
+}
+
+func TestDecodeDetectsFormat(t *testing.T) {
+	mp3Data, err := os.ReadFile("../../testdata/mock_audio/en-US.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The MP3 decoder doesn't support 11025Hz, so that rate shows the WAV decoder read the header
+	wavData := testWAV(11025, make([]int16, 1000))
+
+	tests := []struct {
+		name           string
+		audioData      []byte
+		wantSampleRate int
+		wantLen        int // Decoded samples, or -1 if unchecked
+		wantErr        string
+	}{
+		{"wav", wavData, 11025, 1000, ""},
+		{"mp3", mp3Data, 32000, -1, ""},
+		{"truncated wav goes to the WAV decoder", wavData[:20], 0, 0, "failed to decode WAV"},
+		// Whether or not opusdec is installed, the error comes from the Opus decoder
+		{"ogg goes to the Opus decoder", []byte("OggS\x00\x02 not really opus"), 0, 0, "Opus"},
+		{"unknown data goes to the MP3 decoder", []byte("not audio at all"), 0, 0, "failed to decode MP3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streamer, format, err := decode(tt.audioData)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("decode error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer streamer.Close()
+			if int(format.SampleRate) != tt.wantSampleRate {
+				t.Errorf("sample rate = %d, want %d", format.SampleRate, tt.wantSampleRate)
+			}
+			if tt.wantLen >= 0 && streamer.Len() != tt.wantLen {
+				t.Errorf("length = %d samples, want %d", streamer.Len(), tt.wantLen)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package player
+
+import (
+	"math"
+
+	"github.com/gopxl/beep"
+)
+
+// Supported range for tempo factors
+const (
+	MinTempo = 0.5
+	MaxTempo = 2.0
+)
+
+// TimeStretch changes the tempo of samples by factor without changing pitch, using
+// windowed overlap-add (OLA). A factor above 1 speeds playback up, below 1 slows it down.
+// frameSize is the analysis window length in samples.
+func TimeStretch(samples [][2]float64, factor float64, frameSize int) [][2]float64 {
+	if factor == 1 || len(samples) == 0 || frameSize < 2 {
+		return samples
+	}
+
+	synthesisHop := frameSize / 2
+	analysisHop := float64(synthesisHop) * factor
+
+	frames := int(float64(len(samples))/analysisHop) + 1
+	outLen := (frames-1)*synthesisHop + frameSize
+	out := make([][2]float64, outLen)
+	weights := make([]float64, outLen)
+
+	// Hann window, which sums to a constant at 50% overlap
+	window := make([]float64, frameSize)
+	for i := range window {
+		window[i] = 0.5 * (1 - math.Cos(2*math.Pi*float64(i)/float64(frameSize-1)))
+	}
+
+	for f := 0; f < frames; f++ {
+		in := int(float64(f) * analysisHop)
+		outPos := f * synthesisHop
+		for i := 0; i < frameSize && in+i < len(samples); i++ {
+			w := window[i]
+			out[outPos+i][0] += samples[in+i][0] * w
+			out[outPos+i][1] += samples[in+i][1] * w
+			weights[outPos+i] += w
+		}
+	}
+
+	// Normalize by the accumulated window weight and trim the silent tail
+	end := 0
+	for i := range out {
+		if weights[i] > 1e-3 {
+			out[i][0] /= weights[i]
+			out[i][1] /= weights[i]
+			end = i + 1
+		}
+	}
+	return out[:end]
+}
+
+// sliceStreamer streams samples from an in-memory buffer
+type sliceStreamer struct {
+	samples [][2]float64
+	pos     int
+}
+
+// Stream implements beep.Streamer
+func (s *sliceStreamer) Stream(samples [][2]float64) (n int, ok bool) {
+	if s.pos >= len(s.samples) {
+		return 0, false
+	}
+	n = copy(samples, s.samples[s.pos:])
+	s.pos += n
+	return n, true
+}
+
+// Err implements beep.Streamer
+func (s *sliceStreamer) Err() error {
+	return nil
+}
+
+// readAll drains a streamer into memory
+func readAll(streamer beep.Streamer) [][2]float64 {
+	var all [][2]float64
+	buf := make([][2]float64, 4096)
+	for {
+		n, ok := streamer.Stream(buf)
+		all = append(all, buf[:n]...)
+		if !ok {
+			return all
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/metrics"
+)
+
+// Adaptive bulk fetch limits
+const (
+	maxAdaptiveBatchSize   = 50 // Upper bound on concurrent requests per batch
+	adaptiveMaxAttempts    = 3  // Attempts per request before its error is returned
+	adaptiveLatencyFactor  = 2  // A batch this many times slower than the first counts as congested
+	adaptiveAdditiveStep   = 1  // Batch size increase after a clean batch
+	adaptiveDecreaseFactor = 2  // Batch size divisor after a congested batch
+)
+
+// AdaptiveBulkGetAudio is BulkGetAudio for large requests that would exhaust Azure's rate
+// limits if sent at once. Requests run in batches, starting with min(len(requests), batchSize)
+// concurrent requests. The batch size is adjusted with additive-increase/multiplicative-decrease:
+// it grows by one after a batch in which every request succeeded without slowing down compared
+// to the first batch, and is halved otherwise. Failed requests are retried in later batches.
+func (s *Service) AdaptiveBulkGetAudio(ctx context.Context, requests []struct {
+	Text, LanguageCode string
+	Options            Options
+}, forceRefresh bool, batchSize int) []struct {
+	AudioData []byte
+	CacheKey  string
+	Cached    bool
+	Err       error
+} {
+	results := make([]struct {
+		AudioData []byte
+		CacheKey  string
+		Cached    bool
+		Err       error
+	}, len(requests))
+
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	if batchSize > len(requests) {
+		batchSize = len(requests)
+	}
+
+	pending := make([]int, len(requests))
+	for i := range pending {
+		pending[i] = i
+	}
+	attempts := make([]int, len(requests))
+
+	var baseline time.Duration // Mean synthesis latency of the first batch
+	for len(pending) > 0 {
+		if err := ctx.Err(); err != nil {
+			for _, idx := range pending {
+				results[idx].Err = err
+			}
+			break
+		}
+
+		metrics.AdaptiveBatchSize.Set(float64(batchSize))
+
+		n := min(batchSize, len(pending))
+		batch := pending[:n]
+		pending = pending[n:]
+
+		latencies := make([]time.Duration, n)
+		var wg sync.WaitGroup
+		for i, idx := range batch {
+			wg.Add(1)
+			go func(i, idx int) {
+				defer wg.Done()
+				req := requests[idx]
+				started := time.Now()
+				audioData, cacheKey, cached, err := s.GetAudio(ctx, req.Text, req.LanguageCode, req.Options, forceRefresh)
+				if !cached {
+					latencies[i] = time.Since(started)
+				}
+				results[idx].AudioData = audioData
+				results[idx].CacheKey = cacheKey
+				results[idx].Cached = cached
+				results[idx].Err = err
+			}(i, idx)
+		}
+		wg.Wait()
+
+		// Measure the batch and requeue failures that are worth retrying
+		failed := 0
+		var total time.Duration
+		synthesized := 0
+		for i, idx := range batch {
+			attempts[idx]++
+			if err := results[idx].Err; err != nil {
+				failed++
+				if attempts[idx] < adaptiveMaxAttempts && !errors.Is(err, ErrSynthesisPaused) && ctx.Err() == nil {
+					pending = append(pending, idx)
+				}
+				continue
+			}
+			if latencies[i] > 0 {
+				total += latencies[i]
+				synthesized++
+			}
+		}
+
+		var mean time.Duration
+		if synthesized > 0 {
+			mean = total / time.Duration(synthesized)
+			if baseline == 0 {
+				baseline = mean
+			}
+		}
+
+		congested := failed > 0 || (baseline > 0 && mean > adaptiveLatencyFactor*baseline)
+		if congested {
+			batchSize = max(1, batchSize/adaptiveDecreaseFactor)
+		} else {
+			batchSize = min(maxAdaptiveBatchSize, batchSize+adaptiveAdditiveStep)
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,87 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// errRateLimited is what the rate-limiting mock returns, as Azure answers 429 when overloaded
+var errRateLimited = errors.New("429 Too Many Requests")
+
+func TestAdaptiveBulkGetAudioBacksOffFromRateLimits(t *testing.T) {
+	const requestCount = 30
+	const initialBatchSize = 8
+	const rateLimit = 3 // Concurrent requests the mock serves before rejecting
+
+	provider := newMockProvider(t)
+	audioData := readTestAudio(t, "en-US")
+
+	var mu sync.Mutex
+	active, calls, rejected := 0, 0, 0
+	peakAfterFirstBatch := 0
+	provider.audio = func(text, languageCode string) ([]byte, error) {
+		mu.Lock()
+		active++
+		calls++
+		// The first batch's calls all start before any later batch does
+		if calls > initialBatchSize {
+			peakAfterFirstBatch = max(peakAfterFirstBatch, active)
+		}
+		limited := active > rateLimit
+		if limited {
+			rejected++
+		}
+		mu.Unlock()
+
+		// Hold the slot so the batch's requests overlap
+		time.Sleep(50 * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+		if limited {
+			return nil, errRateLimited
+		}
+		return audioData, nil
+	}
+	service := NewService(newTestCache(t), provider)
+
+	requests := make([]struct {
+		Text, LanguageCode string
+		Options            Options
+	}, requestCount)
+	for i := range requests {
+		requests[i].Text = fmt.Sprintf("Request number %d", i) // Distinct, so none are deduplicated
+		requests[i].LanguageCode = "en-US"
+	}
+
+	results := service.AdaptiveBulkGetAudio(context.Background(), requests, false, initialBatchSize)
+
+	for i, result := range results {
+		if result.Err != nil {
+			t.Errorf("request %d failed: %v", i, result.Err)
+		} else if len(result.AudioData) == 0 {
+			t.Errorf("request %d returned no audio", i)
+		}
+	}
+	if rejected == 0 {
+		t.Fatal("the mock never rate limited, so the test exercised nothing")
+	}
+	if calls != requestCount+rejected {
+		t.Errorf("%d provider calls, want %d requests plus %d retries", calls, requestCount, rejected)
+	}
+	// Additive increase probes one request above the limit before backing off again
+	if peakAfterFirstBatch > rateLimit+1 {
+		t.Errorf("%d concurrent requests after the first batch, want at most %d", peakAfterFirstBatch, rateLimit+1)
+	}
+	if size := testutil.ToFloat64(metrics.AdaptiveBatchSize); size > rateLimit+1 {
+		t.Errorf("final batch size = %v, want at most %d", size, rateLimit+1)
+	}
+}
@@ -0,0 +1,142 @@
+package tts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Webhook delivery settings
+const (
+	alertAttempts   = 3
+	alertRetryDelay = 5 * time.Second
+)
+
+// AlertWebhook configures the notification sent when the cache fills past a threshold
+type AlertWebhook struct {
+	URL              string
+	Method           string        // POST sends a JSON body, GET sends the fields as query parameters
+	ThresholdPercent float64       // Alert when usage of max_size_mb exceeds this percentage
+	Cooldown         time.Duration // Minimum time between alerts
+}
+
+// cacheThresholdAlert is the payload sent to the alert webhook
+type cacheThresholdAlert struct {
+	Event        string  `json:"event"`
+	UsagePercent float64 `json:"usage_percent"`
+	TotalEntries int64   `json:"total_entries"`
+	MaxSizeMB    int64   `json:"max_size_mb"`
+	Timestamp    string  `json:"timestamp"`
+}
+
+// SetAlertWebhook enables cache utilization alerts. Alerts are only checked when the cache has a
+// maximum size.
+func (c *Cache) SetAlertWebhook(webhook *AlertWebhook) {
+	c.alert = webhook
+}
+
+// checkUsageAlert notifies the alert webhook if totalSize is above the threshold and the last
+// alert is older than the cooldown. Delivery happens in the background.
+func (c *Cache) checkUsageAlert(totalSize int64) {
+	maxSizeBytes := c.maxSizeBytes.Load()
+	if c.alert == nil || maxSizeBytes <= 0 {
+		return
+	}
+
+	usagePercent := float64(totalSize) / float64(maxSizeBytes) * 100
+	if usagePercent <= c.alert.ThresholdPercent {
+		return
+	}
+
+	now := time.Now()
+	last := c.lastAlert.Load()
+	if last != 0 && now.Sub(time.Unix(last, 0)) < c.alert.Cooldown {
+		return
+	}
+	if !c.lastAlert.CompareAndSwap(last, now.Unix()) {
+		return // Another pass is already sending this alert
+	}
+
+	var totalEntries int64
+	if err := c.db.QueryRow(`SELECT COUNT(*) FROM audio_cache`).Scan(&totalEntries); err != nil {
+		log.Printf("Warning: cache alert: failed to count entries: %v", err)
+	}
+
+	alert := cacheThresholdAlert{
+		Event:        "cache_threshold",
+		UsagePercent: math.Round(usagePercent*10) / 10,
+		TotalEntries: totalEntries,
+		MaxSizeMB:    maxSizeBytes / 1024 / 1024,
+		Timestamp:    now.UTC().Format(time.RFC3339),
+	}
+	log.Printf("Cache usage %.1f%% exceeds alert threshold %.1f%%, notifying webhook", usagePercent, c.alert.ThresholdPercent)
+
+	go c.sendAlert(alert)
+}
+
+// sendAlert delivers alert to the webhook, retrying on failure
+func (c *Cache) sendAlert(alert cacheThresholdAlert) {
+	var err error
+	for attempt := 1; attempt <= alertAttempts; attempt++ {
+		if err = postAlert(c.alert, alert); err == nil {
+			return
+		}
+		if attempt < alertAttempts {
+			time.Sleep(alertRetryDelay)
+		}
+	}
+	log.Printf("Warning: cache alert webhook failed after %d attempts: %v", alertAttempts, err)
+}
+
+// postAlert makes a single webhook request
+func postAlert(webhook *AlertWebhook, alert cacheThresholdAlert) error {
+	var req *http.Request
+	var err error
+
+	if webhook.Method == http.MethodGet {
+		u, err := url.Parse(webhook.URL)
+		if err != nil {
+			return fmt.Errorf("invalid webhook URL: %w", err)
+		}
+		q := u.Query()
+		q.Set("event", alert.Event)
+		q.Set("usage_percent", strconv.FormatFloat(alert.UsagePercent, 'f', 1, 64))
+		q.Set("total_entries", strconv.FormatInt(alert.TotalEntries, 10))
+		q.Set("max_size_mb", strconv.FormatInt(alert.MaxSizeMB, 10))
+		q.Set("timestamp", alert.Timestamp)
+		u.RawQuery = q.Encode()
+
+		req, err = http.NewRequest(http.MethodGet, u.String(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+	} else {
+		body, err := json.Marshal(alert)
+		if err != nil {
+			return fmt.Errorf("failed to encode alert: %w", err)
+		}
+		req, err = http.NewRequest(webhook.Method, webhook.URL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -6,27 +6,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
+	"strings"
 	"sync"
+	"time"
 
+	"com.biesnecker/tts-daemon/internal/logging"
+	"com.biesnecker/tts-daemon/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/time/rate"
 )
 
-// Voice represents an Azure TTS voice from the API
-type Voice struct {
-	Name              string   `json:"Name"`
-	DisplayName       string   `json:"DisplayName"`
-	ShortName         string   `json:"ShortName"`
-	Gender            string   `json:"Gender"`
-	Locale            string   `json:"Locale"`
-	VoiceType         string   `json:"VoiceType"`
-	Status            string   `json:"Status"`
-	WordsPerMinute    string   `json:"WordsPerMinute"`
-	SampleRateHertz   string   `json:"SampleRateHertz"`
-	StyleList         []string `json:"StyleList,omitempty"`
-}
-
 // AzureClient wraps the Azure Speech REST API
 type AzureClient struct {
 	subscriptionKey string
@@ -35,7 +26,8 @@ type AzureClient struct {
 	httpClient      *http.Client
 	customVoices    map[string]string // Custom voice mappings (overrides)
 	voiceCache      map[string]string // Cached locale -> voice mappings from Azure
-	voiceCacheMu    sync.RWMutex      // Protects voiceCache
+	voices          []Voice           // Full voice list from the last FetchVoiceList
+	voiceCacheMu    sync.RWMutex      // Protects customVoices, voiceCache and voices
 }
 
 // NewAzureClient creates a new Azure TTS client with rate limiting
@@ -54,8 +46,7 @@ func NewAzureClient(subscriptionKey, region string, maxQPS float64, customVoices
 }
 
 // FetchVoiceList fetches available voices from Azure and populates the voice cache
-func (a *AzureClient) FetchVoiceList() error {
-	ctx := context.Background()
+func (a *AzureClient) FetchVoiceList(ctx context.Context) error {
 	url := fmt.Sprintf("https://%s.tts.speech.microsoft.com/cognitiveservices/voices/list", a.region)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -81,10 +72,9 @@ func (a *AzureClient) FetchVoiceList() error {
 		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	// Build voice cache: prefer Neural voices, prefer female voices as default
-	a.voiceCacheMu.Lock()
-	defer a.voiceCacheMu.Unlock()
-
+	// Build voice cache: prefer Neural voices, prefer female voices as default. It replaces the
+	// previous one in one step, so a refresh never leaves requests without voices.
+	voiceCache := make(map[string]string)
 	for _, voice := range voices {
 		// Only use Neural voices
 		if voice.VoiceType != "Neural" {
@@ -94,43 +84,162 @@ func (a *AzureClient) FetchVoiceList() error {
 		locale := voice.Locale
 
 		// If this locale doesn't have a voice yet, use this one
-		if _, exists := a.voiceCache[locale]; !exists {
-			a.voiceCache[locale] = voice.ShortName
+		if _, exists := voiceCache[locale]; !exists {
+			voiceCache[locale] = voice.ShortName
 			continue
 		}
 
 		// If we already have a voice but this one is female and the existing is male, prefer female
 		if voice.Gender == "Female" {
-			a.voiceCache[locale] = voice.ShortName
+			voiceCache[locale] = voice.ShortName
 		}
 	}
 
-	log.Printf("Loaded %d neural voices from Azure covering %d locales", len(voices), len(a.voiceCache))
+	a.voiceCacheMu.Lock()
+	a.voices = voices
+	a.voiceCache = voiceCache
+	a.voiceCacheMu.Unlock()
+
+	logging.Info("Loaded neural voices from Azure", "voices", len(voices), "locales", len(voiceCache))
 	return nil
 }
 
-// SynthesizeToMP3 synthesizes text to speech and returns MP3 audio data
-func (a *AzureClient) SynthesizeToMP3(text, languageCode string) ([]byte, error) {
+// ListVoices implements Provider
+func (a *AzureClient) ListVoices(ctx context.Context) ([]Voice, error) {
+	a.voiceCacheMu.RLock()
+	defer a.voiceCacheMu.RUnlock()
+	return append([]Voice(nil), a.voices...), nil
+}
+
+// VoiceCount implements Provider
+func (a *AzureClient) VoiceCount() int {
+	a.voiceCacheMu.RLock()
+	defer a.voiceCacheMu.RUnlock()
+	return len(a.voices)
+}
+
+// Ping checks that the Azure voice list endpoint is reachable with the configured credentials
+func (a *AzureClient) Ping(ctx context.Context) error {
+	url := fmt.Sprintf("https://%s.tts.speech.microsoft.com/cognitiveservices/voices/list", a.region)
+
+	req, err := http.NewRequestWithContext(ctx, "HEAD", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Ocp-Apim-Subscription-Key", a.subscriptionKey)
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Azure API error (status %d)", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// RateLimiterTokens returns the number of tokens currently available in the rate limiter
+func (a *AzureClient) RateLimiterTokens() float64 {
+	return a.rateLimiter.Tokens()
+}
+
+// RateLimiter returns the limiter that requests to Azure wait on
+func (a *AzureClient) RateLimiter() *rate.Limiter {
+	return a.rateLimiter
+}
+
+// MissingCustomVoices returns the custom voice mappings whose voice is not offered by Azure
+func (a *AzureClient) MissingCustomVoices() map[string]string {
+	a.voiceCacheMu.RLock()
+	defer a.voiceCacheMu.RUnlock()
+
+	available := make(map[string]bool, len(a.voices))
+	for _, voice := range a.voices {
+		available[voice.ShortName] = true
+	}
+
+	missing := make(map[string]string)
+	for locale, voice := range a.customVoices {
+		if !available[voice] {
+			missing[locale] = voice
+		}
+	}
+	return missing
+}
+
+// Synthesize synthesizes text to speech and returns audio data in opts.Format (MP3 by default)
+func (a *AzureClient) Synthesize(ctx context.Context, text, languageCode string, opts Options) ([]byte, error) {
 	// Wait for rate limiter before making API call
-	ctx := context.Background()
 	if err := a.rateLimiter.Wait(ctx); err != nil {
 		return nil, fmt.Errorf("rate limiter error: %w", err)
 	}
 
-	// Get voice name for language
-	voiceName, err := a.getVoiceNameForLanguage(languageCode)
+	// Get voice name for language (or the fallback locale chosen by the service, or the
+	// requested voice)
+	locale := voiceLocale(languageCode, opts)
+	voiceName, err := voiceFor(a, languageCode, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get voice for language %s: %w", languageCode, err)
+		return nil, fmt.Errorf("failed to get voice for language %s: %w", locale, err)
+	}
+
+	// SSML documents name their own voices
+	if opts.SSML {
+		return a.synthesizeSSML(ctx, text, opts)
 	}
 
 	// Build SSML request
 	ssml := fmt.Sprintf(`<speak version='1.0' xml:lang='%s'>
 		<voice xml:lang='%s' name='%s'>%s</voice>
-	</speak>`, languageCode, languageCode, voiceName, escapeXML(text))
+	</speak>`, locale, locale, voiceName, insertBreaks(escapeXML(text), opts))
+
+	return a.synthesizeSSML(ctx, ssml, opts)
+}
+
+// SynthesizeBatch implements BatchProvider with one request whose SSML has a voice element per
+// text, each followed by a break of gap. The audio is returned unsplit.
+func (a *AzureClient) SynthesizeBatch(ctx context.Context, texts []string, languageCode string, opts Options, gap time.Duration) ([]byte, error) {
+	if err := a.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter error: %w", err)
+	}
+
+	locale := voiceLocale(languageCode, opts)
+	voiceName, err := voiceFor(a, languageCode, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voice for language %s: %w", locale, err)
+	}
+
+	var ssml strings.Builder
+	fmt.Fprintf(&ssml, "<speak version='1.0' xml:lang='%s'>", locale)
+	for i, text := range texts {
+		brk := ""
+		if i < len(texts)-1 {
+			brk = fmt.Sprintf(`<break time="%dms"/>`, gap.Milliseconds())
+		}
+		fmt.Fprintf(&ssml, "\n\t\t<voice xml:lang='%s' name='%s'>%s%s</voice>", locale, voiceName, insertBreaks(escapeXML(text), opts), brk)
+	}
+	ssml.WriteString("\n\t</speak>")
+
+	return a.synthesizeSSML(ctx, ssml.String(), opts)
+}
+
+// synthesizeSSML sends an SSML document to Azure and returns the audio in opts.Format. The
+// request gets its own span, with the HTTP status code as an attribute.
+func (a *AzureClient) synthesizeSSML(ctx context.Context, ssml string, opts Options) (audioData []byte, err error) {
+	outputFormat, err := opts.azureOutputFormat()
+	if err != nil {
+		return nil, err
+	}
 
 	// Build request URL
 	url := fmt.Sprintf("https://%s.tts.speech.microsoft.com/cognitiveservices/v1", a.region)
 
+	ctx, span := tracing.Start(ctx, "azure_http")
+	defer func() { endSpan(span, err) }()
+
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBufferString(ssml))
 	if err != nil {
@@ -140,7 +249,7 @@ func (a *AzureClient) SynthesizeToMP3(text, languageCode string) ([]byte, error)
 	// Set headers
 	req.Header.Set("Ocp-Apim-Subscription-Key", a.subscriptionKey)
 	req.Header.Set("Content-Type", "application/ssml+xml")
-	req.Header.Set("X-Microsoft-OutputFormat", "audio-16khz-128kbitrate-mono-mp3")
+	req.Header.Set("X-Microsoft-OutputFormat", outputFormat)
 	req.Header.Set("User-Agent", "tts-daemon/1.0")
 
 	// Make request
@@ -149,6 +258,7 @@ func (a *AzureClient) SynthesizeToMP3(text, languageCode string) ([]byte, error)
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
@@ -157,7 +267,7 @@ func (a *AzureClient) SynthesizeToMP3(text, languageCode string) ([]byte, error)
 	}
 
 	// Read audio data
-	audioData, err := io.ReadAll(resp.Body)
+	audioData, err = io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
@@ -191,58 +301,34 @@ func escapeXML(text string) string {
 	return result.String()
 }
 
-// getVoiceNameForLanguage maps language codes to Azure voice names
-// Priority order:
-// 1. Custom voice exact match (e.g., es-MX in config)
-// 2. Azure cache exact match (e.g., es-MX from Azure)
-// 3. Custom voice base language (e.g., es in config as fallback)
-// 4. Azure cache base language (e.g., es from Azure as fallback)
-func (a *AzureClient) getVoiceNameForLanguage(languageCode string) (string, error) {
-	// 1. Check custom voice mapping for exact match
-	if a.customVoices != nil {
-		if voice, ok := a.customVoices[languageCode]; ok {
-			return voice, nil
-		}
-	}
+// VoiceName implements Provider
+func (a *AzureClient) VoiceName(languageCode string) (string, error) {
+	return a.getVoiceNameForLanguage(languageCode)
+}
 
-	// 2. Check dynamic voice cache from Azure for exact match
+// DefaultVoices returns a copy of the default voice Azure offers for each locale, as chosen by
+// the last FetchVoiceList
+func (a *AzureClient) DefaultVoices() map[string]string {
 	a.voiceCacheMu.RLock()
-	if voice, ok := a.voiceCache[languageCode]; ok {
-		a.voiceCacheMu.RUnlock()
-		return voice, nil
-	}
-	a.voiceCacheMu.RUnlock()
-
-	// Extract base language for fallback checks
-	var baseLanguage string
-	if len(languageCode) > 2 && languageCode[2] == '-' {
-		baseLanguage = languageCode[:2]
-
-		// 3. Check custom voice mapping for base language
-		if a.customVoices != nil {
-			if voice, ok := a.customVoices[baseLanguage]; ok {
-				return voice, nil
-			}
-		}
+	defer a.voiceCacheMu.RUnlock()
 
-		// 4. Check Azure cache for base language
-		a.voiceCacheMu.RLock()
-		if voice, ok := a.voiceCache[baseLanguage]; ok {
-			a.voiceCacheMu.RUnlock()
-			return voice, nil
-		}
-		a.voiceCacheMu.RUnlock()
+	voices := make(map[string]string, len(a.voiceCache))
+	for locale, voice := range a.voiceCache {
+		voices[locale] = voice
 	}
+	return voices
+}
 
-	// If voice cache is empty, it means FetchVoiceList hasn't been called
-	a.voiceCacheMu.RLock()
-	cacheSize := len(a.voiceCache)
-	a.voiceCacheMu.RUnlock()
-
-	if cacheSize == 0 {
-		return "", fmt.Errorf("voice cache not initialized - call FetchVoiceList first")
-	}
+// SetCustomVoices implements CustomVoiceProvider
+func (a *AzureClient) SetCustomVoices(customVoices map[string]string) {
+	a.voiceCacheMu.Lock()
+	defer a.voiceCacheMu.Unlock()
+	a.customVoices = customVoices
+}
 
-	// No matching voice found
-	return "", fmt.Errorf("no voice available for language code: %s", languageCode)
+// getVoiceNameForLanguage maps language codes to Azure voice names (see selectVoice)
+func (a *AzureClient) getVoiceNameForLanguage(languageCode string) (string, error) {
+	a.voiceCacheMu.RLock()
+	defer a.voiceCacheMu.RUnlock()
+	return selectVoice(languageCode, a.customVoices, a.voiceCache)
 }
@@ -0,0 +1,149 @@
+package tts
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// BatchProvider is a Provider that can synthesize several texts in a single request, returning
+// their audio one after the other separated by gap of silence
+type BatchProvider interface {
+	Provider
+	SynthesizeBatch(ctx context.Context, texts []string, languageCode string, opts Options, gap time.Duration) ([]byte, error)
+}
+
+const (
+	// batchGap is the silence inserted between the texts of a batch. It is much longer than the
+	// pauses insertBreaks adds, so the gaps can be told apart from pauses within a text.
+	batchGap = 1500 * time.Millisecond
+
+	// batchMinGapSeconds is the shortest silence treated as a gap between texts when splitting
+	batchMinGapSeconds = 1.0
+
+	// maxBatchTexts is the most texts sent in one request; a full batch is sent immediately
+	maxBatchTexts = 16
+)
+
+// RequestBatcher is a Provider that collects WAV synthesis requests arriving within a short
+// window and sends each group with the same language and options to Azure as one request, then
+// splits the combined audio at the silent gaps between the texts. This saves an HTTPS round trip
+// (and a rate limiter token) per text during bulk fetches.
+//
+// Only 16kHz WAV output is batched: compressed formats can't be cut without re-encoding, so MP3
+// and Opus requests are passed straight through. If the combined audio can't be split into the
+// expected number of parts, the texts are synthesized one by one instead.
+type RequestBatcher struct {
+	BatchProvider
+	window time.Duration
+
+	mu      sync.Mutex
+	pending map[batchKey]*pendingBatch
+}
+
+// batchKey groups requests that can share an SSML document. Options are compared by their cache
+// key variant, which covers every option affecting the audio.
+type batchKey struct {
+	languageCode string
+	variant      string
+}
+
+// pendingBatch is a batch collecting requests until its window ends
+type pendingBatch struct {
+	opts    Options // Options of the first request, which the whole batch is synthesized with
+	texts   []string
+	results []chan batchResult
+}
+
+// batchResult is the audio for one text of a batch
+type batchResult struct {
+	audioData []byte
+	err       error
+}
+
+// NewRequestBatcher wraps provider so that requests arriving within window of each other are
+// batched
+func NewRequestBatcher(provider BatchProvider, window time.Duration) *RequestBatcher {
+	return &RequestBatcher{
+		BatchProvider: provider,
+		window:        window,
+		pending:       make(map[batchKey]*pendingBatch),
+	}
+}
+
+// Synthesize implements Provider, adding WAV requests to the current batch for their
+// language and options and waiting for it to be synthesized. SSML documents aren't batched.
+func (b *RequestBatcher) Synthesize(ctx context.Context, text, languageCode string, opts Options) ([]byte, error) {
+	if opts.Format != FormatWAV16K || opts.SSML {
+		return b.BatchProvider.Synthesize(ctx, text, languageCode, opts)
+	}
+
+	result := make(chan batchResult, 1)
+	key := batchKey{languageCode: languageCode, variant: opts.variant()}
+
+	b.mu.Lock()
+	batch, ok := b.pending[key]
+	if !ok {
+		batch = &pendingBatch{opts: opts}
+		b.pending[key] = batch
+		time.AfterFunc(b.window, func() { b.flush(key, batch) })
+	}
+	batch.texts = append(batch.texts, text)
+	batch.results = append(batch.results, result)
+	full := len(batch.texts) >= maxBatchTexts
+	b.mu.Unlock()
+
+	if full {
+		b.flush(key, batch)
+	}
+
+	select {
+	case r := <-result:
+		return r.audioData, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// flush synthesizes batch unless it has already been sent. Results are delivered to every
+// waiting request; a request that gave up doesn't cancel the others.
+func (b *RequestBatcher) flush(key batchKey, batch *pendingBatch) {
+	b.mu.Lock()
+	if b.pending[key] != batch {
+		b.mu.Unlock()
+		return
+	}
+	delete(b.pending, key)
+	b.mu.Unlock()
+
+	ctx := context.Background()
+	if len(batch.texts) == 1 {
+		audioData, err := b.BatchProvider.Synthesize(ctx, batch.texts[0], key.languageCode, batch.opts)
+		batch.results[0] <- batchResult{audioData, err}
+		return
+	}
+
+	audioData, err := b.BatchProvider.SynthesizeBatch(ctx, batch.texts, key.languageCode, batch.opts, batchGap)
+	if err != nil {
+		for _, result := range batch.results {
+			result <- batchResult{err: err}
+		}
+		return
+	}
+
+	segments, err := splitAtSilences(audioData, len(batch.texts), batchMinGapSeconds)
+	if err != nil {
+		log.Printf("Warning: batch of %d texts could not be split (%v), synthesizing them separately", len(batch.texts), err)
+		for i, text := range batch.texts {
+			audioData, err := b.BatchProvider.Synthesize(ctx, text, key.languageCode, batch.opts)
+			batch.results[i] <- batchResult{audioData, err}
+		}
+		return
+	}
+
+	log.Printf("Batcher: synthesized %d texts in one request, lang=%s", len(batch.texts), key.languageCode)
+	for i, result := range batch.results {
+		result <- batchResult{audioData: segments[i]}
+	}
+}
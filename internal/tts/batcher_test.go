@@ -0,0 +1,213 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ssmlDocument is the part of an SSML document the batch tests check
+type ssmlDocument struct {
+	Lang   string `xml:"lang,attr"`
+	Voices []struct {
+		Lang  string `xml:"lang,attr"`
+		Name  string `xml:"name,attr"`
+		Text  string `xml:",chardata"`
+		Break *struct {
+			Time string `xml:"time,attr"`
+		} `xml:"break"`
+	} `xml:"voice"`
+}
+
+// mockAzureServer is an Azure speech endpoint that answers synthesis requests with the mock
+// client's recorded audio, one recording per voice element, and records the SSML and output
+// formats it receives
+type mockAzureServer struct {
+	mock *MockAzureClient
+
+	mu      sync.Mutex
+	ssml    []string
+	parts   []ssmlDocument
+	formats []string // X-Microsoft-OutputFormat headers
+}
+
+func (s *mockAzureServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/cognitiveservices/voices/list":
+		json.NewEncoder(w).Encode(mockVoices)
+	case "/cognitiveservices/v1":
+		body, _ := io.ReadAll(r.Body)
+		var doc ssmlDocument
+		if err := xml.Unmarshal(body, &doc); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.ssml = append(s.ssml, string(body))
+		s.parts = append(s.parts, doc)
+		s.formats = append(s.formats, r.Header.Get("X-Microsoft-OutputFormat"))
+		s.mu.Unlock()
+
+		texts := make([]string, len(doc.Voices))
+		for i, voice := range doc.Voices {
+			texts[i] = voice.Text
+		}
+		audioData, err := s.mock.SynthesizeBatch(r.Context(), texts, doc.Lang, Options{Format: FormatWAV16K}, batchGap)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(audioData)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// newMockAzure returns an AzureClient whose requests go to a mockAzureServer serving recording
+// as every language's WAV audio
+func newMockAzure(t *testing.T, recording []byte) (*AzureClient, *mockAzureServer) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "en-US.wav"), recording, 0644); err != nil {
+		t.Fatal(err)
+	}
+	mock := NewMockAzureClient(dir, 1000, nil)
+	if err := mock.FetchVoiceList(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	handler := &mockAzureServer{mock: mock}
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	// The client builds regional Azure URLs, so every connection is sent to the server instead
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	transport.TLSClientConfig = &tls.Config{RootCAs: transport.TLSClientConfig.RootCAs, ServerName: "example.com"}
+
+	client := NewAzureClient("test-key", "eastus", 1000, nil)
+	client.httpClient = &http.Client{Transport: transport}
+	if err := client.FetchVoiceList(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return client, handler
+}
+
+// testTone returns a WAV file of a 16kHz square wave lasting duration, without any silence
+func testTone(duration time.Duration) []byte {
+	wav := &pcmWAV{sampleRate: 16000}
+	for i := 0; i < int(duration.Seconds()*16000); i++ {
+		sample := int16(8000)
+		if i/20%2 == 1 {
+			sample = -8000
+		}
+		wav.samples = append(wav.samples, byte(sample), byte(uint16(sample)>>8))
+	}
+	return wav.encode()
+}
+
+func TestRequestBatcherSendsOneSSMLDocument(t *testing.T) {
+	recording := testTone(300 * time.Millisecond)
+	client, server := newMockAzure(t, recording)
+	batcher := NewRequestBatcher(client, 200*time.Millisecond)
+
+	texts := []string{"Salt & pepper", "It's <fine>", "Third"}
+	results := make([][]byte, len(texts))
+	errs := make([]error, len(texts))
+	var wg sync.WaitGroup
+	for i, text := range texts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = batcher.Synthesize(context.Background(), text, "en-US", Options{Format: FormatWAV16K})
+		}()
+		time.Sleep(10 * time.Millisecond) // Join the batch in order
+	}
+	wg.Wait()
+
+	if len(server.parts) != 1 {
+		t.Fatalf("%d synthesis requests, want 1 batch", len(server.parts))
+	}
+	if err := ValidateSSML(server.ssml[0]); err != nil {
+		t.Errorf("batch SSML is invalid: %v\n%s", err, server.ssml[0])
+	}
+	doc := server.parts[0]
+	if doc.Lang != "en-US" {
+		t.Errorf("speak xml:lang = %q, want en-US", doc.Lang)
+	}
+	if len(doc.Voices) != len(texts) {
+		t.Fatalf("%d voice elements, want %d", len(doc.Voices), len(texts))
+	}
+	for i, voice := range doc.Voices {
+		if voice.Text != texts[i] {
+			t.Errorf("voice %d text = %q, want %q", i, voice.Text, texts[i])
+		}
+		if voice.Name != "en-US-AriaNeural" || voice.Lang != "en-US" {
+			t.Errorf("voice %d is %s (%s), want en-US-AriaNeural (en-US)", i, voice.Name, voice.Lang)
+		}
+		// Every text but the last is followed by the gap the audio is split at
+		last := i == len(doc.Voices)-1
+		if last && voice.Break != nil {
+			t.Errorf("the last voice has a break")
+		} else if !last && (voice.Break == nil || voice.Break.Time != "1500ms") {
+			t.Errorf("voice %d break = %+v, want 1500ms", i, voice.Break)
+		}
+	}
+	if strings.Contains(server.ssml[0], "Salt & pepper") {
+		t.Error("text isn't escaped in the SSML")
+	}
+
+	// Each request gets its own utterance back, with the tenth of a second of the gaps next to it
+	tone, err := parsePCMWAV(recording)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pad := make([]byte, 2*16000/10)
+	for i := range texts {
+		if errs[i] != nil {
+			t.Errorf("request %d failed: %v", i, errs[i])
+			continue
+		}
+		want := &pcmWAV{sampleRate: 16000, samples: tone.samples}
+		if i > 0 {
+			want.samples = append(pad, want.samples...)
+		}
+		if i < len(texts)-1 {
+			want.samples = append(append([]byte(nil), want.samples...), pad...)
+		}
+		if !bytes.Equal(results[i], want.encode()) {
+			t.Errorf("request %d got %d bytes of audio, want the %d byte recording with its padding", i, len(results[i]), len(want.encode()))
+		}
+	}
+}
+
+func TestRequestBatcherSendsLoneRequestAlone(t *testing.T) {
+	recording := testTone(300 * time.Millisecond)
+	client, server := newMockAzure(t, recording)
+	batcher := NewRequestBatcher(client, 50*time.Millisecond)
+
+	// A lone WAV request is sent as it is once the window ends
+	audioData, err := batcher.Synthesize(context.Background(), "Alone", "en-US", Options{Format: FormatWAV16K})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(audioData, recording) {
+		t.Error("a lone request didn't get the recording")
+	}
+	if len(server.parts) != 1 || len(server.parts[0].Voices) != 1 || server.parts[0].Voices[0].Break != nil {
+		t.Errorf("a lone request sent %+v, want one voice without a break", server.parts)
+	}
+}
@@ -0,0 +1,54 @@
+package tts
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExceeded is returned when a request would exceed a daily character budget
+var ErrBudgetExceeded = errors.New("daily character budget exceeded")
+
+// DailyBudget limits how many characters can be sent to Azure per day (UTC)
+type DailyBudget struct {
+	mu    sync.Mutex
+	limit int // 0 = unlimited
+	used  int
+	day   string // UTC date the usage applies to
+}
+
+// NewDailyBudget creates a budget of limit characters per day; 0 means unlimited
+func NewDailyBudget(limit int) *DailyBudget {
+	return &DailyBudget{limit: limit}
+}
+
+// Consume records n characters of usage, or returns ErrBudgetExceeded (without recording
+// anything) if that would take today's usage over the limit
+func (b *DailyBudget) Consume(n int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	today := time.Now().UTC().Format(time.DateOnly)
+	if b.day != today {
+		b.day = today
+		b.used = 0
+	}
+
+	if b.limit > 0 && b.used+n > b.limit {
+		return fmt.Errorf("%w (%d of %d characters used today)", ErrBudgetExceeded, b.used, b.limit)
+	}
+	b.used += n
+	return nil
+}
+
+// Used returns the number of characters used today
+func (b *DailyBudget) Used() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.day != time.Now().UTC().Format(time.DateOnly) {
+		return 0
+	}
+	return b.used
+}
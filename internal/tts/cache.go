@@ -5,12 +5,18 @@ import (
 	"database/sql"
 	"encoding/hex"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 	"unicode"
+	"unicode/utf8"
+
+	"com.biesnecker/tts-daemon/internal/logging"
+	"com.biesnecker/tts-daemon/internal/metrics"
+	lru "github.com/hashicorp/golang-lru/v2"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/klauspost/compress/zstd"
@@ -19,54 +25,102 @@ import (
 // Cache manages the audio clip cache
 type Cache struct {
 	db                *sql.DB
-	compressionEnabled bool
-	maxSizeBytes      int64 // Maximum cache size in bytes (0 = unlimited)
+	path              string // Path to the SQLite database file
+	compression       string // Algorithm new entries are compressed with (CompressionNone, CompressionZstd or CompressionLZ4)
+	maxSizeBytes      atomic.Int64 // Maximum cache size in bytes (0 = unlimited, see SetMaxSize)
+	languageQuotas    map[string]int64 // Maximum size in bytes per language code
+	evictMu           sync.Mutex       // Serializes eviction passes so concurrent puts don't over-evict
+	evictionPolicy    EvictionPolicy
+	alert             *AlertWebhook // Cache utilization alerts (nil = disabled)
+	lastAlert         atomic.Int64  // Unix time of the last alert sent
+	replay            *replayLog    // Record of every put for disaster recovery (nil = disabled)
+	fingerprintDedup  bool          // Share identical audio cached for different texts
+	hot               *hotCache     // Frequently accessed entries kept outside SQLite (nil = disabled)
+	memory            *lru.Cache[string, *CachedAudio] // Most recently used entries, decoded (nil = disabled, see SetMemoryCache)
+	redis             *RedisCache   // Entries shared with other daemons (nil = disabled, see SetRedis)
+	s3                *S3Cache      // Entries archived in S3 (nil = disabled, see SetS3)
+	deltaCompression  bool          // Store entries as deltas against another option's entry (see SetDeltaCompression)
+	ttl               time.Duration // Age at which entries expire (0 = never, see SetTTL)
+	ttlStop           chan struct{} // Closed to stop the expired entry cleanup
+	queryMu           sync.RWMutex  // Read-locked once by lookups and writes, so an incremental vacuum only runs between them
+	vacuumStop        chan struct{} // Closed to stop the incremental vacuum (see SetIncrementalVacuum)
+	sizeMetricsStale  atomic.Bool   // Set when entries change, so the size gauges are recomputed (see recordSizeMetricsEvery)
+	metricsStop       chan struct{} // Closed to stop the size gauge updates
+	closed            atomic.Bool   // Set by the first Close
+	events            *eventBroadcaster
 	encoder           *zstd.Encoder
 	decoder           *zstd.Decoder
 }
 
+// DefaultCompressionLevel is the zstd level entries are compressed at unless NewCache is given one
+const DefaultCompressionLevel = 3
+
 // CachedAudio represents a cached audio clip
 type CachedAudio struct {
 	CacheKey     string
 	Text         string
 	LanguageCode string
 	AudioData    []byte
-	Compression  sql.NullString // "zstd" or NULL for uncompressed
+	Compression  sql.NullString // "zstd", "lz4" or NULL for uncompressed
 	CreatedAt    int64
 	LastAccessed int64
+	VoiceName    string // Only filled in by GetByKey
+	Namespace    string // Only filled in by GetByKey ("" = the default namespace)
+	ExpiresAt    int64  // Unix time the entry expires at, overriding the TTL (0 = the TTL applies)
+	Format       string // AudioFormat name such as "wav-16k", or "" if cached before formats were recorded
+
+	// Set while the entry's audio is still the delta it was stored as (see applyStoredDelta)
+	deltaBaseKey sql.NullString
+	deltaData    []byte
 }
 
 // NewCache creates a new cache instance
-func NewCache(dbPath string, compressionEnabled bool, maxSizeMB int64) (*Cache, error) {
+// compression is the algorithm new entries are compressed with (CompressionNone, CompressionZstd
+// or CompressionLZ4), and compressionLevel the zstd level (0 = DefaultCompressionLevel)
+// languageQuotasMB limits the size of individual languages (language code -> MB) on top of maxSizeMB
+func NewCache(dbPath string, compression string, compressionLevel int, maxSizeMB int64, evictionPolicy EvictionPolicy, languageQuotasMB map[string]int) (*Cache, error) {
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(dbPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create database directory: %w", err)
 	}
 
-	// Open database
-	db, err := sql.Open("sqlite3", dbPath)
+	// Open database. Transactions take the write lock when they begin: one that reads before
+	// writing (see materializeDependents) would otherwise fail with "database is locked" when
+	// another connection writes in between.
+	db, err := sql.Open("sqlite3", dbPath+"?_txlock=immediate")
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	// Initialize encoder/decoder if compression is enabled
+	// Initialize the zstd encoder if zstd compression is enabled
 	var encoder *zstd.Encoder
-	var decoder *zstd.Decoder
-	if compressionEnabled {
-		// Create encoder with default compression level
-		encoder, err = zstd.NewWriter(nil)
+	switch compression {
+	case CompressionZstd:
+		// Create encoder with the configured compression level
+		var encoderOpts []zstd.EOption
+		if compressionLevel > 0 {
+			encoderOpts = append(encoderOpts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(compressionLevel)))
+		}
+		encoder, err = zstd.NewWriter(nil, encoderOpts...)
 		if err != nil {
 			db.Close()
 			return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
 		}
+	case CompressionNone, CompressionLZ4:
+	default:
+		db.Close()
+		return nil, fmt.Errorf("unknown compression algorithm %q (valid: zstd, lz4, none)", compression)
+	}
 
-		// Create decoder
-		decoder, err = zstd.NewReader(nil)
-		if err != nil {
-			db.Close()
-			return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+	// The decoder is always created, as entries stored with zstd can remain after switching
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		if encoder != nil {
+			encoder.Close()
 		}
+		db.Close()
+		return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
 	}
 
 	// Convert MB to bytes (0 means unlimited)
@@ -75,95 +129,63 @@ func NewCache(dbPath string, compressionEnabled bool, maxSizeMB int64) (*Cache,
 		maxSizeBytes = maxSizeMB * 1024 * 1024
 	}
 
+	languageQuotas := make(map[string]int64)
+	for lang, mb := range languageQuotasMB {
+		if mb > 0 {
+			languageQuotas[lang] = int64(mb) * 1024 * 1024
+		}
+	}
+
+	if evictionPolicy == nil {
+		evictionPolicy = LRUEviction{}
+	}
+
 	// Create cache instance
 	cache := &Cache{
 		db:                db,
-		compressionEnabled: compressionEnabled,
-		maxSizeBytes:      maxSizeBytes,
+		path:              dbPath,
+		compression:       compression,
+		languageQuotas:    languageQuotas,
+		evictionPolicy:    evictionPolicy,
+		events:            newEventBroadcaster(),
+		metricsStop:       make(chan struct{}),
 		encoder:           encoder,
 		decoder:           decoder,
 	}
+	cache.maxSizeBytes.Store(maxSizeBytes)
 
 	// Initialize schema
 	if err := cache.initSchema(); err != nil {
 		db.Close()
 		return nil, err
 	}
+	cache.recordSizeMetrics()
+	go cache.recordSizeMetricsEvery(sizeMetricsInterval, cache.metricsStop)
 
 	return cache, nil
 }
 
-// initSchema creates the database schema
+// initSchema brings the database schema up to date (see migrate) and resets state left by a
+// previous run
 func (c *Cache) initSchema() error {
-	// Create table if it doesn't exist
-	schema := `
-	CREATE TABLE IF NOT EXISTS audio_cache (
-		cache_key TEXT PRIMARY KEY,
-		text TEXT NOT NULL,
-		language_code TEXT NOT NULL,
-		audio_data BLOB NOT NULL,
-		audio_size INTEGER NOT NULL,
-		created_at INTEGER NOT NULL
-	);
-
-	CREATE INDEX IF NOT EXISTS idx_language_code ON audio_cache(language_code);
-	CREATE INDEX IF NOT EXISTS idx_created_at ON audio_cache(created_at);
-	`
-
-	_, err := c.db.Exec(schema)
-	if err != nil {
-		return fmt.Errorf("failed to create schema: %w", err)
-	}
-
-	// Check if compression column exists and add it if it doesn't
-	var compressionExists bool
-	row := c.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('audio_cache') WHERE name='compression'`)
-	if err := row.Scan(&compressionExists); err != nil {
-		return fmt.Errorf("failed to check for compression column: %w", err)
-	}
-
-	if !compressionExists {
-		// Add compression column if it doesn't exist
-		_, err := c.db.Exec(`ALTER TABLE audio_cache ADD COLUMN compression TEXT`)
-		if err != nil {
-			return fmt.Errorf("failed to add compression column: %w", err)
-		}
-	}
-
-	// Create compression index if it doesn't exist
-	_, err = c.db.Exec(`CREATE INDEX IF NOT EXISTS idx_compression ON audio_cache(compression)`)
-	if err != nil {
-		return fmt.Errorf("failed to create compression index: %w", err)
+	// WAL lets lookups proceed while an entry is being written. The mode is stored in the
+	// database file, so every pooled connection uses it; SQLite's default checkpoint threshold of
+	// 1000 pages, set per connection, keeps the WAL file from growing unbounded.
+	if _, err := c.db.Exec(`PRAGMA journal_mode=WAL`); err != nil {
+		return fmt.Errorf("failed to enable WAL mode: %w", err)
 	}
 
-	// Check if last_accessed column exists and add it if it doesn't
-	var lastAccessedExists bool
-	row = c.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('audio_cache') WHERE name='last_accessed'`)
-	if err := row.Scan(&lastAccessedExists); err != nil {
-		return fmt.Errorf("failed to check for last_accessed column: %w", err)
+	if err := c.migrate(); err != nil {
+		return err
 	}
 
-	if !lastAccessedExists {
-		// Add last_accessed column if it doesn't exist (default to created_at for existing rows)
-		_, err := c.db.Exec(`ALTER TABLE audio_cache ADD COLUMN last_accessed INTEGER`)
-		if err != nil {
-			return fmt.Errorf("failed to add last_accessed column: %w", err)
-		}
-
-		// Set last_accessed to created_at for existing rows
-		_, err = c.db.Exec(`UPDATE audio_cache SET last_accessed = created_at WHERE last_accessed IS NULL`)
-		if err != nil {
-			return fmt.Errorf("failed to initialize last_accessed column: %w", err)
-		}
-	}
-
-	// Create last_accessed index if it doesn't exist (for efficient LRU eviction)
-	_, err = c.db.Exec(`CREATE INDEX IF NOT EXISTS idx_last_accessed ON audio_cache(last_accessed)`)
+	// Entries flagged by a previous run were interrupted; their old audio is still in place
+	_, err := c.db.Exec(`UPDATE audio_cache SET resynth_in_progress = 0 WHERE resynth_in_progress != 0`)
 	if err != nil {
-		return fmt.Errorf("failed to create last_accessed index: %w", err)
+		return fmt.Errorf("failed to reset resynth_in_progress: %w", err)
 	}
 
-	return nil
+	return c.resetInterruptedJobs()
 }
 
 // NormalizeText normalizes text for consistent caching
@@ -183,25 +205,90 @@ func NormalizeText(text string) string {
 	return text
 }
 
-// GenerateCacheKey generates a cache key for the given text and language
-func GenerateCacheKey(text, languageCode string) string {
-	normalized := NormalizeText(text)
+// FirstDiffIndex returns the byte offset at which a and b first differ, or -1 if they are equal
+func FirstDiffIndex(a, b string) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	if len(a) != len(b) {
+		return n
+	}
+	return -1
+}
+
+// FirstDiffRune returns the offset in runes of the first rune that differs between a and b, or -1
+// if they are equal. Unlike FirstDiffIndex, a difference inside a multi-byte rune is reported at
+// the rune's start.
+func FirstDiffRune(a, b string) int {
+	i := FirstDiffIndex(a, b)
+	if i < 0 {
+		return -1
+	}
+	longer := a
+	if i >= len(a) {
+		longer = b
+	}
+	for i > 0 && !utf8.RuneStart(longer[i]) {
+		i--
+	}
+	return utf8.RuneCountInString(a[:i])
+}
+
+// GenerateCacheKey generates a cache key for the given text, language and synthesis options.
+// Keys only differ for different requests if the language code is valid (see
+// ErrInvalidLanguageCode) and the namespace is a valid server.namespaces name.
+func GenerateCacheKey(text, languageCode string, opts Options) string {
+	normalized := normalizeForKey(text, opts)
 	// Include language code in hash to differentiate same text in different languages
 	combined := fmt.Sprintf("%s:%s", languageCode, normalized)
+	if opts.Namespace != "" {
+		// Tenants never share keys; the default namespace keeps the keys cached before namespaces
+		combined = opts.Namespace + "/" + combined
+	}
+	if variant := opts.variant(); variant != "" {
+		combined += "|" + variant
+	}
 
 	hash := sha256.Sum256([]byte(combined))
 	return hex.EncodeToString(hash[:])
 }
 
 // Get retrieves audio from cache
-func (c *Cache) Get(text, languageCode string) (*CachedAudio, error) {
-	cacheKey := GenerateCacheKey(text, languageCode)
+func (c *Cache) Get(text, languageCode string, opts Options) (*CachedAudio, error) {
+	c.queryMu.RLock()
+	defer c.queryMu.RUnlock()
+
+	cacheKey := GenerateCacheKey(text, languageCode, opts)
+	now := getCurrentTimestamp()
 
+	if c.memory != nil {
+		if audio := c.getMemory(cacheKey); audio != nil && !c.expired(audio.CreatedAt, audio.ExpiresAt) {
+			go c.updateLastAccessed(cacheKey, now)
+			return audio, nil
+		}
+	}
+
+	if c.hot != nil {
+		if audio := c.getHot(cacheKey); audio != nil && !c.expired(audio.CreatedAt, audio.ExpiresAt) {
+			go c.updateLastAccessed(cacheKey, now)
+			return audio, nil
+		}
+	}
+
+	// A key with identical audio to another entry is an alias of it (see SetFingerprintDedup)
 	var audio CachedAudio
 	err := c.db.QueryRow(
-		`SELECT cache_key, text, language_code, audio_data, compression, created_at, last_accessed
-		 FROM audio_cache WHERE cache_key = ?`,
-		cacheKey,
+		`SELECT cache_key, text, language_code, audio_data, compression, created_at, last_accessed,
+		 COALESCE(format, ''), COALESCE(expires_at, 0), delta_base_key, delta_data FROM audio_cache
+		 WHERE cache_key = COALESCE((SELECT target_key FROM audio_cache_aliases WHERE cache_key = ?), ?)
+		 AND (expires_at IS NULL OR expires_at > ?)`,
+		cacheKey, cacheKey, now,
 	).Scan(
 		&audio.CacheKey,
 		&audio.Text,
@@ -210,43 +297,86 @@ func (c *Cache) Get(text, languageCode string) (*CachedAudio, error) {
 		&audio.Compression,
 		&audio.CreatedAt,
 		&audio.LastAccessed,
+		&audio.Format,
+		&audio.ExpiresAt,
+		&audio.deltaBaseKey,
+		&audio.deltaData,
 	)
 
-	if err == sql.ErrNoRows {
-		return nil, nil // Not found
+	if err == sql.ErrNoRows && (c.redis != nil || c.s3 != nil) {
+		return c.getShared(text, languageCode, cacheKey, opts) // Possibly cached by another daemon
+	}
+	if err == sql.ErrNoRows || (err == nil && c.expired(audio.CreatedAt, audio.ExpiresAt)) {
+		return nil, nil // Not found, or to be synthesized again
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to query cache: %w", err)
 	}
 
-	// Update last_accessed timestamp for LRU tracking
-	now := getCurrentTimestamp()
-	go c.updateLastAccessed(cacheKey, now)
+	isDelta := audio.deltaBaseKey.Valid
+	if err := c.decompress(&audio); err != nil {
+		return nil, err
+	}
+	if err := c.applyStoredDelta(&audio); err != nil {
+		return nil, err
+	}
 
-	// Decompress if needed
-	if audio.Compression.Valid && audio.Compression.String == "zstd" {
-		if c.decoder == nil {
-			return nil, fmt.Errorf("zstd decoder not initialized")
-		}
-		decompressed, err := c.decoder.DecodeAll(audio.AudioData, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decompress audio data: %w", err)
-		}
-		audio.AudioData = decompressed
+	// Update last_accessed timestamp and hit count for eviction tracking
+	if c.hot != nil {
+		go c.recordHotAccess(audio, now)
+	} else {
+		go c.updateLastAccessed(audio.CacheKey, now)
 	}
 
 	// If compression is enabled but data is uncompressed, spawn background job to compress it
-	if c.compressionEnabled && !audio.Compression.Valid {
-		go c.recompressEntry(cacheKey, audio.AudioData)
+	if c.compression != CompressionNone && opts.Format.compressible() && !audio.Compression.Valid && !isDelta {
+		go c.recompressEntry(audio.CacheKey, audio.AudioData)
 	}
 
+	c.putMemory(&audio)
 	return &audio, nil
 }
 
-// updateLastAccessed updates the last_accessed timestamp for a cache entry
+// getShared looks up an entry that isn't in SQLite in Redis and then S3, copying it into SQLite
+// if found, and into Redis if only S3 had it. queryMu must be read-locked.
+func (c *Cache) getShared(text, languageCode, cacheKey string, opts Options) (*CachedAudio, error) {
+	var audio *CachedAudio
+	if c.redis != nil {
+		audio = c.getRedis(cacheKey)
+	}
+	if audio == nil && c.s3 != nil {
+		if audio = c.getS3(cacheKey); audio != nil {
+			c.putRedis(audio, opts.Format.compressible())
+		}
+	}
+	if audio == nil {
+		return nil, nil
+	}
+
+	// S3 leaves out long texts, and the key was generated from these anyway
+	audio.Text, audio.LanguageCode = text, languageCode
+	if err := c.storeEntry(cacheKey, opts.Namespace, text, languageCode, audio.Format, opts.Format.compressible(), audio.AudioData, audio.VoiceName, audio.ExpiresAt); err != nil {
+		logging.Warn("failed to copy entry into SQLite", "key", cacheKey, "error", err)
+	}
+	return audio, nil
+}
+
+// putShared writes an entry to Redis and S3, whichever are enabled
+func (c *Cache) putShared(audio *CachedAudio, compressible bool) {
+	c.putRedis(audio, compressible)
+	c.putS3(audio)
+}
+
+// dropShared deletes entries from Redis and S3, whichever are enabled
+func (c *Cache) dropShared(keys ...string) {
+	c.dropRedis(keys...)
+	c.dropS3(keys...)
+}
+
+// updateLastAccessed updates the last_accessed timestamp and increments the hit count for a cache entry
 func (c *Cache) updateLastAccessed(cacheKey string, timestamp int64) {
 	_, err := c.db.Exec(
-		`UPDATE audio_cache SET last_accessed = ? WHERE cache_key = ?`,
+		`UPDATE audio_cache SET last_accessed = ?, hit_count = hit_count + 1 WHERE cache_key = ?`,
 		timestamp,
 		cacheKey,
 	)
@@ -257,69 +387,195 @@ func (c *Cache) updateLastAccessed(cacheKey string, timestamp int64) {
 }
 
 // Put stores audio in cache
-func (c *Cache) Put(text, languageCode string, audioData []byte) (string, error) {
-	cacheKey := GenerateCacheKey(text, languageCode)
+func (c *Cache) Put(text, languageCode string, opts Options, audioData []byte, voiceName string) (string, error) {
+	cacheKey := GenerateCacheKey(text, languageCode, opts)
 	now := getCurrentTimestamp()
+	expiresAt := opts.expiresAt(now)
+	c.putShared(&CachedAudio{
+		CacheKey:     cacheKey,
+		Text:         text,
+		LanguageCode: languageCode,
+		AudioData:    audioData,
+		CreatedAt:    now,
+		VoiceName:    voiceName,
+		Format:       opts.Format.String(),
+		ExpiresAt:    expiresAt,
+	}, opts.Format.compressible())
+
+	// Identical audio already cached for another text is shared instead of stored twice
+	if c.fingerprintDedup {
+		existingKey, err := c.keyForFingerprint(AudioFingerprint(audioData), opts.Namespace, cacheKey)
+		if err != nil {
+			logging.Warn("fingerprint lookup failed", "key", cacheKey, "error", err)
+		} else if existingKey != "" {
+			logging.Info("Cache: identical audio already cached", "key", cacheKey, "existing", existingKey)
+			if err := c.putAlias(cacheKey, existingKey); err != nil {
+				return "", err
+			}
+			return existingKey, c.AddTags(existingKey, opts.Tags)
+		}
+	}
 
-	var dataToStore []byte
-	var compression sql.NullString
+	if err := c.putEntry(cacheKey, opts.Namespace, text, languageCode, opts.Format.String(), opts.Format.compressible(), audioData, voiceName, expiresAt, opts.Tags...); err != nil {
+		return "", err
+	}
+	return cacheKey, nil
+}
+
+// putEntry stores audio under cacheKey in namespace, compressing it if compression is enabled and
+// compressible is set, and adds tags to it. format and voiceName are the audio's format and the
+// voice it was synthesized with ("" if unknown), and expiresAt when it expires (0 = per the TTL).
+func (c *Cache) putEntry(cacheKey, namespace, text, languageCode, format string, compressible bool, audioData []byte, voiceName string, expiresAt int64, tags ...string) error {
+	c.queryMu.RLock()
+	defer c.queryMu.RUnlock()
+	return c.storeEntry(cacheKey, namespace, text, languageCode, format, compressible, audioData, voiceName, expiresAt, tags...)
+}
+
+// storeEntry is putEntry for callers that already hold queryMu's read lock, which mustn't be
+// taken twice: a waiting vacuum blocks new readers.
+func (c *Cache) storeEntry(cacheKey, namespace, text, languageCode, format string, compressible bool, audioData []byte, voiceName string, expiresAt int64, tags ...string) error {
+	now := getCurrentTimestamp()
+	stats := ComputeTextStats(text)
 
-	// Compress if enabled
-	if c.compressionEnabled {
-		if c.encoder == nil {
-			return "", fmt.Errorf("zstd encoder not initialized")
+	dataToStore, compression, err := c.encodeForStorage(audioData, compressible)
+	if err != nil {
+		return err
+	}
+	storedSize := len(dataToStore)
+
+	// Store a delta instead if another option's entry for the text makes it smaller
+	var deltaBaseKey sql.NullString
+	var deltaData []byte
+	if c.deltaCompression {
+		baseKey, baseAudio, err := c.deltaBase(cacheKey, namespace, text, languageCode)
+		if err != nil {
+			logging.Warn("delta compression skipped", "key", cacheKey, "error", err)
+		} else if baseKey != "" {
+			if delta := ComputeDelta(baseAudio, audioData); len(delta) < len(dataToStore) {
+				deltaBaseKey = sql.NullString{String: baseKey, Valid: true}
+				deltaData = delta
+				dataToStore, compression, storedSize = []byte{}, sql.NullString{}, len(delta)
+			}
 		}
-		compressed := c.encoder.EncodeAll(audioData, nil)
-		dataToStore = compressed
-		compression = sql.NullString{String: "zstd", Valid: true}
-	} else {
-		dataToStore = audioData
-		compression = sql.NullString{Valid: false}
 	}
 
-	_, err := c.db.Exec(
+	tx, err := c.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Entries stored against the audio being replaced keep the audio they were made from
+	lost, err := c.materializeDependents(tx, cacheKey)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
 		`INSERT OR REPLACE INTO audio_cache
-		 (cache_key, text, language_code, audio_data, audio_size, compression, created_at, last_accessed)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		 (cache_key, text, language_code, audio_data, audio_size, compression, created_at, last_accessed,
+		  minhash, voice_name, format, audio_fingerprint, word_count, char_count, delta_base_key, delta_data, namespace,
+		  expires_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		cacheKey,
 		text,
 		languageCode,
 		dataToStore,
-		len(dataToStore),
+		storedSize,
 		compression,
 		now,
 		now, // Set last_accessed to now on insert
+		encodeMinHash(MinHash(text, minhashSize)),
+		sql.NullString{String: voiceName, Valid: voiceName != ""},
+		sql.NullString{String: format, Valid: format != ""},
+		encodeFingerprint(AudioFingerprint(audioData)),
+		stats.WordCount,
+		stats.CharCount,
+		deltaBaseKey,
+		deltaData,
+		namespace,
+		sql.NullInt64{Int64: expiresAt, Valid: expiresAt != 0},
 	)
 
 	if err != nil {
-		return "", fmt.Errorf("failed to insert into cache: %w", err)
+		return fmt.Errorf("failed to insert into cache: %w", err)
+	}
+	// The key now has audio of its own
+	if _, err := tx.Exec(`DELETE FROM audio_cache_aliases WHERE cache_key = ?`, cacheKey); err != nil {
+		return fmt.Errorf("failed to insert into cache: %w", err)
+	}
+	if err := insertTags(tx, cacheKey, tags); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to insert into cache: %w", err)
+	}
+	c.dropLost(lost)
+	c.dropHot(cacheKey)
+	c.putMemory(&CachedAudio{
+		CacheKey:     cacheKey,
+		Text:         text,
+		LanguageCode: languageCode,
+		AudioData:    audioData,
+		CreatedAt:    now,
+		LastAccessed: now,
+		Format:       format,
+		ExpiresAt:    expiresAt,
+	})
+
+	if c.replay != nil {
+		c.replay.append(ReplayRecord{
+			CacheKey:     cacheKey,
+			LanguageCode: languageCode,
+			Text:         text,
+			AudioSize:    uint32(len(audioData)),
+			Timestamp:    now,
+		})
 	}
 
-	// Evict old entries if cache size limit is set
-	if c.maxSizeBytes > 0 {
+	c.events.publish(CacheEvent{
+		Type:         EventPut,
+		CacheKey:     cacheKey,
+		LanguageCode: languageCode,
+		Namespace:    namespace,
+		AudioSize:    int64(len(audioData)),
+		Timestamp:    now,
+	})
+
+	// Evict old entries if a size limit is set
+	c.sizeMetricsStale.Store(true)
+	if c.maxSizeBytes.Load() > 0 || len(c.languageQuotas) > 0 {
 		go c.evictIfNeeded()
 	}
 
-	return cacheKey, nil
+	return nil
+}
+
+// encodeForStorage returns audioData as it should be stored, compressed if compression is enabled
+// and compressible is set, along with the compression column value
+func (c *Cache) encodeForStorage(audioData []byte, compressible bool) ([]byte, sql.NullString, error) {
+	// Uncompressed formats such as WAV are stored as-is
+	if c.compression == CompressionNone || !compressible {
+		return audioData, sql.NullString{Valid: false}, nil
+	}
+	return c.compress(audioData)
 }
 
-// recompressEntry compresses an uncompressed cache entry in the background
+// recompressEntry compresses an uncompressed cache entry in the background, with the configured
+// algorithm
 func (c *Cache) recompressEntry(cacheKey string, uncompressedData []byte) {
-	if c.encoder == nil {
+	compressed, compression, err := c.compress(uncompressedData)
+	if err != nil || !compression.Valid {
 		return
 	}
 
-	// Compress the data
-	compressed := c.encoder.EncodeAll(uncompressedData, nil)
-
 	// Update the database entry
-	_, err := c.db.Exec(
+	_, err = c.db.Exec(
 		`UPDATE audio_cache
 		 SET audio_data = ?, audio_size = ?, compression = ?
-		 WHERE cache_key = ? AND compression IS NULL`,
+		 WHERE cache_key = ? AND compression IS NULL AND delta_base_key IS NULL`,
 		compressed,
 		len(compressed),
-		"zstd",
+		compression,
 		cacheKey,
 	)
 
@@ -331,76 +587,362 @@ func (c *Cache) recompressEntry(cacheKey string, uncompressedData []byte) {
 }
 
 // Delete removes audio from cache
-func (c *Cache) Delete(text, languageCode string) (string, bool, error) {
-	cacheKey := GenerateCacheKey(text, languageCode)
+func (c *Cache) Delete(text, languageCode string, opts Options) (string, bool, error) {
+	cacheKey := GenerateCacheKey(text, languageCode, opts)
+
+	c.queryMu.RLock()
+	defer c.queryMu.RUnlock()
+	tx, err := c.db.Begin()
+	if err != nil {
+		return cacheKey, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
-	result, err := c.db.Exec(
+	lost, err := c.materializeDependents(tx, cacheKey)
+	if err != nil {
+		return cacheKey, false, err
+	}
+	result, err := tx.Exec(
 		`DELETE FROM audio_cache WHERE cache_key = ?`,
 		cacheKey,
 	)
-
 	if err != nil {
 		return cacheKey, false, fmt.Errorf("failed to delete from cache: %w", err)
 	}
+	if err := tx.Commit(); err != nil {
+		return cacheKey, false, fmt.Errorf("failed to delete from cache: %w", err)
+	}
+	c.dropLost(lost)
+	c.dropHot(cacheKey)
+	c.dropMemory(cacheKey)
+	c.dropShared(cacheKey)
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return cacheKey, false, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
+	// Deleting an alias leaves the entry it shares audio with in place
+	if rowsAffected == 0 {
+		result, err := c.db.Exec(`DELETE FROM audio_cache_aliases WHERE cache_key = ?`, cacheKey)
+		if err != nil {
+			return cacheKey, false, fmt.Errorf("failed to delete from cache: %w", err)
+		}
+		if rowsAffected, err = result.RowsAffected(); err != nil {
+			return cacheKey, false, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+	}
+
+	if rowsAffected > 0 {
+		c.events.publish(CacheEvent{
+			Type:         EventDelete,
+			CacheKey:     cacheKey,
+			LanguageCode: languageCode,
+			Namespace:    opts.Namespace,
+			Timestamp:    getCurrentTimestamp(),
+		})
+	}
+
 	return cacheKey, rowsAffected > 0, nil
 }
 
-// evictIfNeeded removes least recently used entries if cache exceeds size limit
+// DeletePattern deletes every entry in namespace whose text matches the SQL LIKE pattern
+// (case-insensitive), optionally limited to one language. With dryRun nothing is deleted and the
+// counts describe what would be.
+func (c *Cache) DeletePattern(namespace, pattern, languageCode string, dryRun bool) (matched, deleted, freedBytes int64, err error) {
+	query := `SELECT cache_key, language_code, audio_size FROM audio_cache WHERE namespace = ? AND LOWER(text) LIKE ?`
+	queryArgs := []interface{}{namespace, strings.ToLower(pattern)}
+	if languageCode != "" {
+		query += ` AND language_code = ?`
+		queryArgs = append(queryArgs, languageCode)
+	}
+
+	rows, err := c.db.Query(query, queryArgs...)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to query cache: %w", err)
+	}
+
+	var keys, languages []string
+	for rows.Next() {
+		var key, lang string
+		var size int64
+		if err := rows.Scan(&key, &lang, &size); err != nil {
+			rows.Close()
+			return 0, 0, 0, fmt.Errorf("failed to scan cache entry: %w", err)
+		}
+		keys = append(keys, key)
+		languages = append(languages, lang)
+		freedBytes += size
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to query cache: %w", err)
+	}
+
+	matched = int64(len(keys))
+	if dryRun || matched == 0 {
+		return matched, 0, freedBytes, nil
+	}
+
+	deleted, err = c.deleteKeys(keys)
+	if err != nil {
+		return matched, 0, 0, err
+	}
+	c.dropShared(keys...)
+
+	now := getCurrentTimestamp()
+	for i, key := range keys {
+		c.events.publish(CacheEvent{
+			Type:         EventDelete,
+			CacheKey:     key,
+			LanguageCode: languages[i],
+			Namespace:    namespace,
+			Timestamp:    now,
+		})
+	}
+
+	return matched, deleted, freedBytes, nil
+}
+
+// DeleteByLanguage deletes every entry in namespace for languageCode and returns how many were
+// deleted and their cache keys
+func (c *Cache) DeleteByLanguage(namespace, languageCode string) (int64, []string, error) {
+	rows, err := c.db.Query(`SELECT cache_key FROM audio_cache WHERE namespace = ? AND language_code = ?`, namespace, languageCode)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to query cache: %w", err)
+	}
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			return 0, nil, fmt.Errorf("failed to scan cache entry: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, nil, fmt.Errorf("failed to query cache: %w", err)
+	}
+	if len(keys) == 0 {
+		return 0, nil, nil
+	}
+
+	deleted, err := c.deleteKeys(keys)
+	if err != nil {
+		return 0, nil, err
+	}
+	c.dropShared(keys...)
+
+	now := getCurrentTimestamp()
+	for _, key := range keys {
+		c.events.publish(CacheEvent{
+			Type:         EventDelete,
+			CacheKey:     key,
+			LanguageCode: languageCode,
+			Namespace:    namespace,
+			Timestamp:    now,
+		})
+	}
+	c.sizeMetricsStale.Store(true)
+
+	return deleted, keys, nil
+}
+
+// evictIfNeeded removes entries chosen by the eviction policy if the cache exceeds its size limits.
+// Languages over their quota are evicted first, each within its own entries, so one language
+// can't push out another; then the global limit is enforced across all languages.
 func (c *Cache) evictIfNeeded() {
+	c.evictMu.Lock()
+	defer c.evictMu.Unlock()
+	defer c.sizeMetricsStale.Store(true)
+
+	for lang, quota := range c.languageQuotas {
+		size, err := c.GetLanguageSize(lang)
+		if err != nil {
+			return // Silently fail - this is a background optimization
+		}
+		c.evictDown(lang, size, quota)
+	}
+
+	maxSizeBytes := c.maxSizeBytes.Load()
+	if maxSizeBytes <= 0 {
+		return
+	}
+
 	// Get current cache size
 	var totalSize int64
 	err := c.db.QueryRow(`SELECT COALESCE(SUM(audio_size), 0) FROM audio_cache`).Scan(&totalSize)
 	if err != nil {
 		return // Silently fail - this is a background optimization
 	}
+	c.checkUsageAlert(totalSize)
+	c.evictDown("", totalSize, maxSizeBytes)
+}
 
+// evictDown evicts entries of languageCode ("" = every language) if size exceeds limit
+func (c *Cache) evictDown(languageCode string, size, limit int64) {
 	// If we're under the limit, nothing to do
-	if totalSize <= c.maxSizeBytes {
+	if size <= limit {
 		return
 	}
 
 	// Calculate how much we need to evict (evict down to 90% of max to avoid thrashing)
-	targetSize := int64(float64(c.maxSizeBytes) * 0.9)
-	sizeToEvict := totalSize - targetSize
-
-	log.Printf("Cache size %d bytes exceeds limit %d bytes, evicting %d bytes", totalSize, c.maxSizeBytes, sizeToEvict)
-
-	// Use a subquery to delete the oldest entries efficiently
-	// This deletes all entries until we've freed up enough space
-	result, err := c.db.Exec(`
-		DELETE FROM audio_cache
-		WHERE cache_key IN (
-			SELECT cache_key FROM (
-				SELECT cache_key,
-				       SUM(audio_size) OVER (ORDER BY last_accessed ASC) as cumulative_size
-				FROM audio_cache
-				ORDER BY last_accessed ASC
-			)
-			WHERE cumulative_size <= ?
-		)`, sizeToEvict)
+	targetSize := int64(float64(limit) * 0.9)
+	sizeToEvict := size - targetSize
+
+	logging.Info("Cache: size exceeds limit, evicting", "lang", languageCode, "size", size, "limit", limit, "evicting", sizeToEvict)
+
+	candidates, err := c.evictionPolicy.SelectEvictionCandidates(c.db, languageCode, sizeToEvict)
+	if err != nil {
+		logging.Warn("cache eviction failed", "error", err)
+		return
+	}
+
+	evicted, err := c.deleteKeys(candidates)
+	if err != nil {
+		logging.Warn("cache eviction failed", "error", err)
+		return
+	}
+
+	logging.Info("Cache: evicted entries", "count", evicted)
+}
+
+// GetLanguageSize returns the stored size in bytes of every entry for languageCode
+func (c *Cache) GetLanguageSize(languageCode string) (int64, error) {
+	var size int64
+	err := c.db.QueryRow(
+		`SELECT COALESCE(SUM(audio_size), 0) FROM audio_cache WHERE language_code = ?`,
+		languageCode,
+	).Scan(&size)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get language size: %w", err)
+	}
+	return size, nil
+}
+
+// sizeMetricsInterval is how often the cache size gauges are brought up to date, if entries
+// have changed since. Counting every entry takes a full table scan, too slow for every Put.
+const sizeMetricsInterval = 15 * time.Second
+
+// recordSizeMetricsEvery runs recordSizeMetrics every interval while sizeMetricsStale is set,
+// until stop is closed
+func (c *Cache) recordSizeMetricsEvery(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if c.sizeMetricsStale.Swap(false) {
+				c.recordSizeMetrics()
+			}
+		}
+	}
+}
 
+// recordSizeMetrics updates the cache size and entry count gauges of each language label
+func (c *Cache) recordSizeMetrics() {
+	rows, err := c.db.Query(`SELECT language_code, SUM(audio_size), COUNT(*) FROM audio_cache GROUP BY language_code`)
 	if err != nil {
-		log.Printf("Warning: cache eviction failed: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	// Languages without a label of their own add up under the same one
+	sizes := make(map[string]int64)
+	entries := make(map[string]int64)
+	for rows.Next() {
+		var lang string
+		var size, count int64
+		if err := rows.Scan(&lang, &size, &count); err != nil {
+			return
+		}
+		label := metrics.LanguageLabel(lang)
+		sizes[label] += size
+		entries[label] += count
+	}
+	if rows.Err() != nil {
 		return
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	log.Printf("Evicted %d cache entries", rowsAffected)
+	metrics.CacheSizeBytes.Reset()
+	metrics.CacheEntries.Reset()
+	for label, size := range sizes {
+		metrics.CacheSizeBytes.WithLabelValues(label).Set(float64(size))
+		metrics.CacheEntries.WithLabelValues(label).Set(float64(entries[label]))
+	}
 }
 
-// GetStats returns cache statistics
+// deleteKeys deletes the given cache entries in a single transaction and returns how many were removed
+func (c *Cache) deleteKeys(keys []string) (int64, error) {
+	c.queryMu.RLock()
+	defer c.queryMu.RUnlock()
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	lost, err := c.materializeDependents(tx, keys...)
+	if err != nil {
+		return 0, err
+	}
+	stmt, err := tx.Prepare(`DELETE FROM audio_cache WHERE cache_key = ?`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare delete: %w", err)
+	}
+	defer stmt.Close()
+
+	var deleted int64
+	for _, key := range keys {
+		result, err := stmt.Exec(key)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete %s: %w", key, err)
+		}
+		n, _ := result.RowsAffected()
+		deleted += n
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit eviction: %w", err)
+	}
+	c.dropLost(lost)
+	c.dropHot(keys...)
+	c.dropMemory(keys...)
+	return deleted, nil
+}
+
+// LanguageStat summarizes the cache entries for one language code
+type LanguageStat struct {
+	Count       int64
+	SizeBytes   int64 // Stored (possibly compressed) size of the entries
+	OldestEntry int64 // Creation time of the oldest entry (Unix timestamp)
+	NewestEntry int64 // Creation time of the newest entry (Unix timestamp)
+}
+
+// GetStats returns cache statistics, with a LanguageStat for each language code under
+// "languages"
 func (c *Cache) GetStats() (map[string]interface{}, error) {
+	return c.getStats("TRUE")
+}
+
+// GetNamespaceStats returns the statistics of GetStats for namespace's entries. usage_percent is
+// the share of the size limit they take up.
+func (c *Cache) GetNamespaceStats(namespace string) (map[string]interface{}, error) {
+	return c.getStats("namespace = ?", namespace)
+}
+
+// getStats returns the statistics of GetStats for the entries matching the SQL condition scope
+func (c *Cache) getStats(scope string, scopeArgs ...interface{}) (map[string]interface{}, error) {
 	var count int64
 	var totalSize int64
 
 	err := c.db.QueryRow(
-		`SELECT COUNT(*), COALESCE(SUM(audio_size), 0) FROM audio_cache`,
+		`SELECT COUNT(*), COALESCE(SUM(audio_size), 0) FROM audio_cache WHERE `+scope, scopeArgs...,
 	).Scan(&count, &totalSize)
 
 	if err != nil {
@@ -414,22 +956,110 @@ func (c *Cache) GetStats() (map[string]interface{}, error) {
 	}
 
 	// Add max size info if set
-	if c.maxSizeBytes > 0 {
-		stats["max_size_mb"] = float64(c.maxSizeBytes) / (1024 * 1024)
-		stats["usage_percent"] = (float64(totalSize) / float64(c.maxSizeBytes)) * 100
+	if maxSizeBytes := c.maxSizeBytes.Load(); maxSizeBytes > 0 {
+		stats["max_size_mb"] = float64(maxSizeBytes) / (1024 * 1024)
+		stats["usage_percent"] = (float64(totalSize) / float64(maxSizeBytes)) * 100
+	}
+
+	// Expired entries that the next cleanup will delete
+	var expired int64
+	where, args := c.expiredWhere()
+	err = c.db.QueryRow(`SELECT COUNT(*) FROM audio_cache WHERE (`+where+`) AND `+scope, append(args, scopeArgs...)...).Scan(&expired)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count expired entries: %w", err)
+	}
+	stats["expired_clips"] = expired
+
+	rows, err := c.db.Query(
+		`SELECT language_code, COUNT(*), COALESCE(SUM(audio_size), 0), MIN(created_at), MAX(created_at)
+		 FROM audio_cache WHERE `+scope+` GROUP BY language_code`, scopeArgs...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get language stats: %w", err)
+	}
+	defer rows.Close()
+
+	languages := make(map[string]LanguageStat)
+	for rows.Next() {
+		var lang string
+		var s LanguageStat
+		if err := rows.Scan(&lang, &s.Count, &s.SizeBytes, &s.OldestEntry, &s.NewestEntry); err != nil {
+			return nil, fmt.Errorf("failed to scan language stats: %w", err)
+		}
+		languages[lang] = s
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get language stats: %w", err)
 	}
+	stats["languages"] = languages
 
 	return stats, nil
 }
 
-// Close closes the database connection and cleanup resources
+// Path returns the path to the SQLite database file
+func (c *Cache) Path() string {
+	return c.path
+}
+
+// Ping verifies that the database is accessible
+func (c *Cache) Ping() error {
+	var n int
+	if err := c.db.QueryRow(`SELECT COUNT(*) FROM audio_cache`).Scan(&n); err != nil {
+		return fmt.Errorf("failed to query cache: %w", err)
+	}
+	return nil
+}
+
+// JournalMode returns the SQLite journal mode of the database (e.g. "wal", "delete")
+func (c *Cache) JournalMode() (string, error) {
+	var mode string
+	if err := c.db.QueryRow(`PRAGMA journal_mode`).Scan(&mode); err != nil {
+		return "", fmt.Errorf("failed to query journal mode: %w", err)
+	}
+	return mode, nil
+}
+
+// Subscribe returns a channel of cache events and a function to stop receiving them.
+// The channel is closed on unsubscribe or when watchers are shut down.
+func (c *Cache) Subscribe() (<-chan CacheEvent, func()) {
+	return c.events.subscribe()
+}
+
+// CloseSubscribers closes every event subscription, ending any open watches
+func (c *Cache) CloseSubscribers() {
+	c.events.close()
+}
+
+// Close closes the database connection and cleanup resources. Calling it again does nothing.
 func (c *Cache) Close() error {
+	if !c.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	if c.ttlStop != nil {
+		close(c.ttlStop)
+	}
+	if c.vacuumStop != nil {
+		close(c.vacuumStop)
+	}
+	close(c.metricsStop)
+	c.events.close()
+	c.closeHot()
+	if c.redis != nil {
+		c.redis.Close()
+	}
+	if c.replay != nil {
+		c.replay.close()
+	}
 	if c.encoder != nil {
 		c.encoder.Close()
 	}
 	if c.decoder != nil {
 		c.decoder.Close()
 	}
+	// Flush the WAL into the database file, leaving it empty for the next start
+	if _, err := c.db.Exec(`PRAGMA wal_checkpoint(TRUNCATE)`); err != nil {
+		logging.Warn("failed to checkpoint WAL", "error", err)
+	}
 	return c.db.Close()
 }
 
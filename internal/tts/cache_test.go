@@ -0,0 +1,111 @@
+package tts
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFirstDiff(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      string
+		wantIndex int
+		wantRune  int
+	}{
+		{"equal", "hello", "hello", -1, -1},
+		{"ascii", "hello", "help", 3, 3},
+		{"prefix", "hello", "hello world", 5, 5},
+		{"after multi-byte runes", "naïve café", "naïve cafe", 10, 9},
+		{"inside a multi-byte rune", "café", "cafè", 4, 3},
+		{"wide characters", "日本語です", "日本人です", 6, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FirstDiffIndex(tt.a, tt.b); got != tt.wantIndex {
+				t.Errorf("FirstDiffIndex(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.wantIndex)
+			}
+			if got := FirstDiffRune(tt.a, tt.b); got != tt.wantRune {
+				t.Errorf("FirstDiffRune(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.wantRune)
+			}
+		})
+	}
+}
+
+func TestPutCompressesOnlyCompressibleFormats(t *testing.T) {
+	cache, err := NewCache(filepath.Join(t.TempDir(), "cache.db"), CompressionZstd, 0, 0, LRUEviction{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cache.Close()
+
+	tests := []struct {
+		format          AudioFormat
+		audioData       []byte
+		wantCompression sql.NullString
+	}{
+		{FormatMP3, readTestAudio(t, "en-US"), sql.NullString{String: CompressionZstd, Valid: true}},
+		{FormatWAV16K, (&pcmWAV{sampleRate: 16000, samples: make([]byte, 3200)}).encode(), sql.NullString{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			opts := Options{Format: tt.format}
+			key, err := cache.Put("hello", "en-US", opts, tt.audioData, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var compression sql.NullString
+			if err := cache.db.QueryRow(`SELECT compression FROM audio_cache WHERE cache_key = ?`, key).Scan(&compression); err != nil {
+				t.Fatal(err)
+			}
+			if compression != tt.wantCompression {
+				t.Errorf("compression = %v, want %v", compression, tt.wantCompression)
+			}
+
+			cached, err := cache.Get("hello", "en-US", opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cached == nil || !bytes.Equal(cached.AudioData, tt.audioData) {
+				t.Error("cached audio doesn't round-trip")
+			}
+		})
+	}
+}
+
+func BenchmarkConcurrentGet(b *testing.B) {
+	const entries = 1000
+	audioData := readTestAudio(b, "en-US")
+
+	// Every hit also records its access time in the background, so lookups contend with writes
+	for _, journalMode := range []string{"delete", "wal"} {
+		b.Run("journal="+journalMode, func(b *testing.B) {
+			cache := newTestCache(b)
+			var mode string
+			if err := cache.db.QueryRow(`PRAGMA journal_mode=` + journalMode).Scan(&mode); err != nil || mode != journalMode {
+				b.Fatalf("journal mode = %q, %v, want %s", mode, err, journalMode)
+			}
+			for i := 0; i < entries; i++ {
+				if _, err := cache.Put(fmt.Sprintf("text %d", i), "en-US", Options{}, audioData, ""); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			var next atomic.Int64
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					text := fmt.Sprintf("text %d", next.Add(1)%entries)
+					audio, err := cache.Get(text, "en-US", Options{})
+					if err != nil || audio == nil {
+						b.Errorf("Get(%q) = %v, %v, want the entry", text, audio, err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package tts
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// compactionRetryDelay is how long a scheduled compaction waits when the daemon isn't idle
+const compactionRetryDelay = 10 * time.Minute
+
+// CompactionResult describes a VACUUM of the cache database
+type CompactionResult struct {
+	SizeBefore int64 // Database size in bytes
+	SizeAfter  int64
+	Duration   time.Duration
+}
+
+// databaseSize returns the size of the database in bytes, from its page count
+func (c *Cache) databaseSize() (int64, error) {
+	var pageCount, pageSize int64
+	if err := c.db.QueryRow(`PRAGMA page_count`).Scan(&pageCount); err != nil {
+		return 0, fmt.Errorf("failed to query page count: %w", err)
+	}
+	if err := c.db.QueryRow(`PRAGMA page_size`).Scan(&pageSize); err != nil {
+		return 0, fmt.Errorf("failed to query page size: %w", err)
+	}
+	return pageCount * pageSize, nil
+}
+
+// Compact rebuilds the database with VACUUM, returning the space left by deleted entries to the
+// filesystem. Writers are blocked while it runs.
+func (c *Cache) Compact() (CompactionResult, error) {
+	var result CompactionResult
+	before, err := c.databaseSize()
+	if err != nil {
+		return result, err
+	}
+
+	log.Printf("Cache: compacting, size=%d bytes", before)
+	started := time.Now()
+	if _, err := c.db.Exec(`VACUUM`); err != nil {
+		return result, fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	after, err := c.databaseSize()
+	if err != nil {
+		return result, err
+	}
+
+	result = CompactionResult{SizeBefore: before, SizeAfter: after, Duration: time.Since(started)}
+	log.Printf("Cache: compacted in %s, size=%d bytes (was %d)", result.Duration.Round(time.Millisecond), after, before)
+	return result, nil
+}
+
+// RecordRequest notes that a request arrived, so scheduled compaction waits for a quiet period
+func (s *Service) RecordRequest() {
+	s.lastRequestTime.Store(time.Now().UnixNano())
+}
+
+// Compact compacts the cache database (see Cache.Compact). Only one compaction runs at a time.
+func (s *Service) Compact() (CompactionResult, error) {
+	if !s.compacting.CompareAndSwap(false, true) {
+		return CompactionResult{}, fmt.Errorf("a compaction is already running")
+	}
+	defer s.compacting.Store(false)
+	return s.cache.Compact()
+}
+
+// StartCompactionSchedule compacts the cache database at the times given by a standard cron
+// expression, e.g. "0 3 * * *" for 3 AM daily (local time). A scheduled compaction only starts
+// once no request has arrived for idleThreshold, checking again every 10 minutes until then.
+func (s *Service) StartCompactionSchedule(schedule string, idleThreshold time.Duration) error {
+	c := cron.New()
+	if _, err := c.AddFunc(schedule, func() { s.compactWhenIdle(idleThreshold) }); err != nil {
+		return fmt.Errorf("invalid compaction schedule %q: %w", schedule, err)
+	}
+	c.Start()
+	s.compactionCron = c
+	return nil
+}
+
+// compactWhenIdle compacts the database if no request has arrived for idleThreshold, and
+// otherwise tries again after compactionRetryDelay
+func (s *Service) compactWhenIdle(idleThreshold time.Duration) {
+	if idle := time.Since(time.Unix(0, s.lastRequestTime.Load())); idle < idleThreshold {
+		log.Printf("Cache: last request %s ago, deferring compaction by %s", idle.Round(time.Second), compactionRetryDelay)
+		time.AfterFunc(compactionRetryDelay, func() { s.compactWhenIdle(idleThreshold) })
+		return
+	}
+	if _, err := s.Compact(); err != nil {
+		log.Printf("Warning: scheduled compaction failed: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package tts
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+// Compression algorithms, as stored in the compression column ("" = uncompressed)
+const (
+	CompressionNone = ""
+	CompressionZstd = "zstd"
+	CompressionLZ4  = "lz4" // Faster to decompress than zstd, at a lower ratio
+)
+
+// compress returns audioData compressed with the cache's algorithm, along with the compression
+// column value
+func (c *Cache) compress(audioData []byte) ([]byte, sql.NullString, error) {
+	switch c.compression {
+	case CompressionZstd:
+		if c.encoder == nil {
+			return nil, sql.NullString{}, fmt.Errorf("zstd encoder not initialized")
+		}
+		return c.encoder.EncodeAll(audioData, nil), sql.NullString{String: CompressionZstd, Valid: true}, nil
+	case CompressionLZ4:
+		var buf bytes.Buffer
+		w := lz4.NewWriter(&buf)
+		if _, err := w.Write(audioData); err != nil {
+			return nil, sql.NullString{}, fmt.Errorf("failed to compress audio data: %w", err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, sql.NullString{}, fmt.Errorf("failed to compress audio data: %w", err)
+		}
+		return buf.Bytes(), sql.NullString{String: CompressionLZ4, Valid: true}, nil
+	default:
+		return audioData, sql.NullString{}, nil
+	}
+}
+
+// decompress replaces audio's compressed data with the decompressed audio, if needed. Entries
+// are decompressed by the algorithm they were stored with, whichever is configured now.
+func (c *Cache) decompress(audio *CachedAudio) error {
+	if !audio.Compression.Valid {
+		return nil
+	}
+
+	switch audio.Compression.String {
+	case CompressionZstd:
+		decompressed, err := c.decoder.DecodeAll(audio.AudioData, nil)
+		if err != nil {
+			return fmt.Errorf("failed to decompress audio data: %w", err)
+		}
+		audio.AudioData = decompressed
+	case CompressionLZ4:
+		decompressed, err := io.ReadAll(lz4.NewReader(bytes.NewReader(audio.AudioData)))
+		if err != nil {
+			return fmt.Errorf("failed to decompress audio data: %w", err)
+		}
+		audio.AudioData = decompressed
+	default:
+		return fmt.Errorf("unknown compression %q", audio.Compression.String)
+	}
+	return nil
+}
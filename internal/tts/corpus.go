@@ -0,0 +1,173 @@
+package tts
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// corpusBucketSize is the width in characters of each text length histogram bucket
+const corpusBucketSize = 10
+
+// WordCount is a word and the number of times it occurs
+type WordCount struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// LengthBucket counts texts whose length falls in [MinChars, MaxChars]
+type LengthBucket struct {
+	MinChars int `json:"min_chars"`
+	MaxChars int `json:"max_chars"`
+	Count    int `json:"count"`
+}
+
+// CorpusStats describes the distribution of text stored in the cache
+type CorpusStats struct {
+	TotalTexts      int                    `json:"total_texts"`
+	UniqueWords     int                    `json:"unique_words"`
+	TopWords        map[string][]WordCount `json:"top_words"` // language_code -> most frequent words
+	AvgChars        float64                `json:"avg_chars"`
+	AvgWords        float64                `json:"avg_words"`
+	LengthHistogram []LengthBucket         `json:"length_histogram"`
+	SSMLPercent     float64                `json:"ssml_percent"`
+	NumericPercent  float64                `json:"numeric_percent"`
+	ZipfCoefficient float64                `json:"zipf_coefficient"`
+}
+
+// AnalyzeCorpus reads the cache database at dbPath (read-only) and computes text statistics.
+// topN limits the number of words reported per language.
+func AnalyzeCorpus(dbPath string, topN int) (*CorpusStats, error) {
+	db, err := sql.Open("sqlite3", "file:"+url.PathEscape(dbPath)+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT text, language_code FROM audio_cache`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cache: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &CorpusStats{TopWords: make(map[string][]WordCount)}
+	globalFreq := make(map[string]int)
+	langFreq := make(map[string]map[string]int)
+	buckets := make(map[int]int)
+	var totalChars, totalWords, ssml, numeric int
+
+	for rows.Next() {
+		var text, lang string
+		if err := rows.Scan(&text, &lang); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		stats.TotalTexts++
+		chars := utf8.RuneCountInString(text)
+		totalChars += chars
+		buckets[chars/corpusBucketSize]++
+
+		if strings.HasPrefix(strings.TrimSpace(text), "<speak") {
+			ssml++
+		}
+		if strings.IndexFunc(text, unicode.IsDigit) >= 0 {
+			numeric++
+		}
+
+		if langFreq[lang] == nil {
+			langFreq[lang] = make(map[string]int)
+		}
+		for _, field := range strings.Fields(text) {
+			word := strings.ToLower(strings.TrimFunc(field, unicode.IsPunct))
+			if word == "" {
+				continue
+			}
+			totalWords++
+			globalFreq[word]++
+			langFreq[lang][word]++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	if stats.TotalTexts == 0 {
+		return stats, nil
+	}
+
+	n := float64(stats.TotalTexts)
+	stats.UniqueWords = len(globalFreq)
+	stats.AvgChars = float64(totalChars) / n
+	stats.AvgWords = float64(totalWords) / n
+	stats.SSMLPercent = float64(ssml) / n * 100
+	stats.NumericPercent = float64(numeric) / n * 100
+
+	for lang, freq := range langFreq {
+		words := sortedWordCounts(freq)
+		if len(words) > topN {
+			words = words[:topN]
+		}
+		stats.TopWords[lang] = words
+	}
+
+	keys := make([]int, 0, len(buckets))
+	for k := range buckets {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	for _, k := range keys {
+		stats.LengthHistogram = append(stats.LengthHistogram, LengthBucket{
+			MinChars: k * corpusBucketSize,
+			MaxChars: (k+1)*corpusBucketSize - 1,
+			Count:    buckets[k],
+		})
+	}
+
+	stats.ZipfCoefficient = zipfCoefficient(sortedWordCounts(globalFreq))
+	return stats, nil
+}
+
+// sortedWordCounts returns the words of freq ordered by descending count, then alphabetically
+func sortedWordCounts(freq map[string]int) []WordCount {
+	words := make([]WordCount, 0, len(freq))
+	for word, count := range freq {
+		words = append(words, WordCount{Word: word, Count: count})
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if words[i].Count != words[j].Count {
+			return words[i].Count > words[j].Count
+		}
+		return words[i].Word < words[j].Word
+	})
+	return words
+}
+
+// zipfCoefficient fits log(frequency) = c - s*log(rank) by least squares and returns s.
+// Natural language text typically has s close to 1.
+func zipfCoefficient(ranked []WordCount) float64 {
+	if len(ranked) < 2 {
+		return 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, wc := range ranked {
+		x := math.Log(float64(i + 1))
+		y := math.Log(float64(wc.Count))
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	n := float64(len(ranked))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return -(n*sumXY - sumX*sumY) / denominator
+}
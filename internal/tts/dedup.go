@@ -0,0 +1,80 @@
+package tts
+
+import (
+	"sync"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/metrics"
+)
+
+// dedupLogSize is how many recent deduplication events are kept
+const dedupLogSize = 1000
+
+// DedupEvent records a synthesis that was shared by concurrent requests for the same cache key
+type DedupEvent struct {
+	Timestamp       int64
+	CacheKey        string
+	WaiterCount     int // Requests that waited instead of calling Azure themselves
+	SynthesisTimeMs int64
+}
+
+// DedupStats summarizes how many Azure calls request deduplication has saved
+type DedupStats struct {
+	TotalEvents        int64
+	TotalWaitersSaved  int64
+	AvgSynthesisTimeMs float64
+	RecentEvents       []DedupEvent // Oldest first
+}
+
+// dedupLog keeps running totals and the most recent deduplication events
+type dedupLog struct {
+	mu               sync.Mutex
+	recent           *ringBuffer[DedupEvent]
+	totalEvents      int64
+	totalWaiters     int64
+	totalSynthesisMs int64
+}
+
+func newDedupLog() *dedupLog {
+	return &dedupLog{recent: newRingBuffer[DedupEvent](dedupLogSize)}
+}
+
+// record logs a shared synthesis
+func (l *dedupLog) record(cacheKey string, waiters int, synthesisTime time.Duration) {
+	event := DedupEvent{
+		Timestamp:       getCurrentTimestamp(),
+		CacheKey:        cacheKey,
+		WaiterCount:     waiters,
+		SynthesisTimeMs: synthesisTime.Milliseconds(),
+	}
+
+	l.mu.Lock()
+	l.recent.push(event)
+	l.totalEvents++
+	l.totalWaiters += int64(waiters)
+	l.totalSynthesisMs += event.SynthesisTimeMs
+	l.mu.Unlock()
+
+	metrics.DedupWaitersSaved.Add(float64(waiters))
+}
+
+// stats returns the totals and a copy of the recent events
+func (l *dedupLog) stats() DedupStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := DedupStats{
+		TotalEvents:       l.totalEvents,
+		TotalWaitersSaved: l.totalWaiters,
+		RecentEvents:      l.recent.slice(),
+	}
+	if l.totalEvents > 0 {
+		stats.AvgSynthesisTimeMs = float64(l.totalSynthesisMs) / float64(l.totalEvents)
+	}
+	return stats
+}
+
+// DedupStats reports how many Azure calls deduplication of concurrent requests has saved
+func (s *Service) DedupStats() DedupStats {
+	return s.dedup.stats()
+}
@@ -0,0 +1,122 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitForWaiters waits until n requests are waiting on in-flight fetches
+func waitForWaiters(t *testing.T, service *Service, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		service.inFlightMu.Lock()
+		waiters := 0
+		for _, flight := range service.inFlight {
+			waiters += flight.waiters
+		}
+		service.inFlightMu.Unlock()
+		if waiters == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d requests waiting, want %d", waiters, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestConcurrentRequestsShareOneSynthesis(t *testing.T) {
+	const requests = 20
+
+	provider := newMockProvider(t)
+	release := make(chan struct{})
+	audio := readTestAudio(t, "en-US")
+	provider.audio = func(text, languageCode string) ([]byte, error) {
+		<-release
+		return audio, nil
+	}
+	service := NewService(newTestCache(t), provider)
+
+	var wg sync.WaitGroup
+	results := make([][]byte, requests)
+	errs := make([]error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _, _, errs[i] = service.GetAudio(context.Background(), "Hello there", "en-US", Options{}, false)
+		}(i)
+	}
+	// Hold the synthesis until every other request has joined it
+	waitForWaiters(t, service, requests-1)
+	close(release)
+	wg.Wait()
+
+	for i := range results {
+		if errs[i] != nil {
+			t.Fatalf("request %d: %v", i, errs[i])
+		}
+		if !bytes.Equal(results[i], audio) {
+			t.Errorf("request %d got different audio", i)
+		}
+	}
+	if got := provider.calls.Load(); got != 1 {
+		t.Errorf("provider called %d times, want 1", got)
+	}
+
+	stats := service.DedupStats()
+	if stats.TotalEvents != 1 || len(stats.RecentEvents) != 1 {
+		t.Fatalf("%d dedup events logged (%d recent), want 1", stats.TotalEvents, len(stats.RecentEvents))
+	}
+	if stats.TotalWaitersSaved != requests-1 || stats.RecentEvents[0].WaiterCount != requests-1 {
+		t.Errorf("waiters saved = %d (event: %d), want %d", stats.TotalWaitersSaved, stats.RecentEvents[0].WaiterCount, requests-1)
+	}
+	if key := GenerateCacheKey("Hello there", "en-US", Options{}); stats.RecentEvents[0].CacheKey != key {
+		t.Errorf("event cache key = %q, want %q", stats.RecentEvents[0].CacheKey, key)
+	}
+
+	// A later request is a cache hit, and isn't a dedup event
+	if _, _, cached, err := service.GetAudio(context.Background(), "Hello there", "en-US", Options{}, false); err != nil || !cached {
+		t.Errorf("follow-up request cached=%v err=%v, want a cache hit", cached, err)
+	}
+	if got := service.DedupStats().TotalEvents; got != 1 {
+		t.Errorf("%d dedup events after a cache hit, want 1", got)
+	}
+}
+
+func TestSingleRequestIsNotADedupEvent(t *testing.T) {
+	service := NewService(newTestCache(t), newMockProvider(t))
+	if _, _, _, err := service.GetAudio(context.Background(), "Hello", "en-US", Options{}, false); err != nil {
+		t.Fatal(err)
+	}
+	if stats := service.DedupStats(); stats.TotalEvents != 0 {
+		t.Errorf("%d dedup events for a single request, want 0", stats.TotalEvents)
+	}
+}
+
+func TestRingBuffer(t *testing.T) {
+	tests := []struct {
+		pushes int
+		want   []int
+	}{
+		{0, nil},
+		{2, []int{0, 1}},
+		{3, []int{0, 1, 2}},
+		{4, []int{1, 2, 3}},
+		{7, []int{4, 5, 6}},
+	}
+	for _, tt := range tests {
+		buffer := newRingBuffer[int](3)
+		for i := 0; i < tt.pushes; i++ {
+			buffer.push(i)
+		}
+		if got := buffer.slice(); !slices.Equal(got, tt.want) {
+			t.Errorf("after %d pushes: %v, want %v", tt.pushes, got, tt.want)
+		}
+	}
+}
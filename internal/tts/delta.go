@@ -0,0 +1,304 @@
+package tts
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"com.biesnecker/tts-daemon/internal/logging"
+)
+
+// deltaBlockSize is the length of the base blocks ComputeDelta looks for in the target. Shorter
+// blocks find more matches but make the index larger.
+const deltaBlockSize = 32
+
+// deltaPrime is the multiplier of the rolling hash
+const deltaPrime = 16777619
+
+// Delta operations
+const (
+	deltaCopy   = 0 // Followed by the offset and length of a run of the base
+	deltaInsert = 1 // Followed by a length and that many literal bytes
+)
+
+// ComputeDelta returns a patch that turns base into target (see ApplyDelta). Blocks of the base
+// are found in the target with a rolling hash, rsync-style, and copied; everything else is
+// stored literally, so the patch is about as large as target when the two have little in common.
+func ComputeDelta(base, target []byte) []byte {
+	delta := binary.AppendUvarint(nil, uint64(len(target)))
+	insert := func(literal []byte) {
+		if len(literal) > 0 {
+			delta = append(delta, deltaInsert)
+			delta = binary.AppendUvarint(delta, uint64(len(literal)))
+			delta = append(delta, literal...)
+		}
+	}
+
+	if len(base) < deltaBlockSize || len(target) < deltaBlockSize {
+		insert(target)
+		return delta
+	}
+
+	// Index the base's aligned blocks, keeping the first of identical ones
+	index := make(map[uint32]int, len(base)/deltaBlockSize)
+	for off := 0; off+deltaBlockSize <= len(base); off += deltaBlockSize {
+		h := blockHash(base[off : off+deltaBlockSize])
+		if _, ok := index[h]; !ok {
+			index[h] = off
+		}
+	}
+
+	// deltaPrime^(deltaBlockSize-1), to remove the byte leaving the window
+	var outFactor uint32 = 1
+	for i := 1; i < deltaBlockSize; i++ {
+		outFactor *= deltaPrime
+	}
+
+	pos, literalStart := 0, 0
+	h := blockHash(target[:deltaBlockSize])
+	for pos+deltaBlockSize <= len(target) {
+		if off, ok := index[h]; ok && bytes.Equal(base[off:off+deltaBlockSize], target[pos:pos+deltaBlockSize]) {
+			// Extend the match backwards over the pending literal, then forwards as far as it goes
+			for off > 0 && pos > literalStart && base[off-1] == target[pos-1] {
+				off--
+				pos--
+			}
+			n := deltaBlockSize
+			for off+n < len(base) && pos+n < len(target) && base[off+n] == target[pos+n] {
+				n++
+			}
+
+			insert(target[literalStart:pos])
+			delta = append(delta, deltaCopy)
+			delta = binary.AppendUvarint(delta, uint64(off))
+			delta = binary.AppendUvarint(delta, uint64(n))
+			pos += n
+			literalStart = pos
+			if pos+deltaBlockSize <= len(target) {
+				h = blockHash(target[pos : pos+deltaBlockSize])
+			}
+			continue
+		}
+
+		if pos+deltaBlockSize < len(target) {
+			h = (h-uint32(target[pos])*outFactor)*deltaPrime + uint32(target[pos+deltaBlockSize])
+		}
+		pos++
+	}
+	insert(target[literalStart:])
+	return delta
+}
+
+// blockHash is the rolling hash of a block, as ComputeDelta updates it byte by byte
+func blockHash(block []byte) uint32 {
+	var h uint32
+	for _, b := range block {
+		h = h*deltaPrime + uint32(b)
+	}
+	return h
+}
+
+// ApplyDelta reconstructs the target a delta from ComputeDelta was made for, given its base
+func ApplyDelta(base, delta []byte) ([]byte, error) {
+	size, n := binary.Uvarint(delta)
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid delta header")
+	}
+	delta = delta[n:]
+
+	// The header comes from the delta itself, so it only sizes the buffer up to a sane bound
+	target := make([]byte, 0, min(size, uint64(len(base)+len(delta))))
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+		switch op {
+		case deltaCopy:
+			off, n1 := binary.Uvarint(delta)
+			if n1 <= 0 {
+				return nil, fmt.Errorf("invalid delta copy offset")
+			}
+			length, n2 := binary.Uvarint(delta[n1:])
+			if n2 <= 0 {
+				return nil, fmt.Errorf("invalid delta copy length")
+			}
+			delta = delta[n1+n2:]
+			if off > uint64(len(base)) || length > uint64(len(base))-off {
+				return nil, fmt.Errorf("delta copies past the end of the base")
+			}
+			target = append(target, base[off:off+length]...)
+		case deltaInsert:
+			length, n := binary.Uvarint(delta)
+			if n <= 0 || length > uint64(len(delta)-n) {
+				return nil, fmt.Errorf("invalid delta insert")
+			}
+			target = append(target, delta[n:n+int(length)]...)
+			delta = delta[n+int(length):]
+		default:
+			return nil, fmt.Errorf("unknown delta operation %d", op)
+		}
+	}
+
+	if uint64(len(target)) != size {
+		return nil, fmt.Errorf("delta produced %d bytes, expected %d", len(target), size)
+	}
+	return target, nil
+}
+
+// SetDeltaCompression enables storing an entry as a delta against an entry already cached for
+// the same text and language with other options (e.g. another voice or format), when the delta
+// is smaller than the entry would be on its own. Deleting or replacing the base entry stores the
+// entries made against it whole again.
+func (c *Cache) SetDeltaCompression(enabled bool) error {
+	if enabled {
+		_, err := c.db.Exec(`CREATE INDEX IF NOT EXISTS idx_language_text ON audio_cache(language_code, text)`)
+		if err != nil {
+			return fmt.Errorf("failed to create text index: %w", err)
+		}
+	}
+	c.deltaCompression = enabled
+	return nil
+}
+
+// deltaBase returns the key and decompressed audio of an entry that an entry for text under
+// cacheKey in namespace can be stored as a delta against, or "" if there is none. Entries that
+// are deltas themselves aren't used as bases, and neither are other namespaces' entries, whose
+// deletion would otherwise rewrite namespace's.
+func (c *Cache) deltaBase(cacheKey, namespace, text, languageCode string) (string, []byte, error) {
+	var base CachedAudio
+	err := c.db.QueryRow(
+		`SELECT cache_key, audio_data, compression FROM audio_cache
+		 WHERE namespace = ? AND language_code = ? AND text = ? AND cache_key != ? AND delta_base_key IS NULL LIMIT 1`,
+		namespace, languageCode, text, cacheKey,
+	).Scan(&base.CacheKey, &base.AudioData, &base.Compression)
+	if err == sql.ErrNoRows {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find delta base: %w", err)
+	}
+	if err := c.decompress(&base); err != nil {
+		return "", nil, err
+	}
+	return base.CacheKey, base.AudioData, nil
+}
+
+// applyStoredDelta replaces audio's data with the audio reconstructed from the delta it was
+// stored as, if it was
+func (c *Cache) applyStoredDelta(audio *CachedAudio) error {
+	if !audio.deltaBaseKey.Valid {
+		return nil
+	}
+
+	var base CachedAudio
+	err := c.db.QueryRow(
+		`SELECT audio_data, compression FROM audio_cache WHERE cache_key = ? AND delta_base_key IS NULL`,
+		audio.deltaBaseKey.String,
+	).Scan(&base.AudioData, &base.Compression)
+	if err != nil {
+		return fmt.Errorf("failed to read delta base %s: %w", audio.deltaBaseKey.String, err)
+	}
+	if err := c.decompress(&base); err != nil {
+		return err
+	}
+
+	reconstructed, err := ApplyDelta(base.AudioData, audio.deltaData)
+	if err != nil {
+		return fmt.Errorf("failed to apply delta: %w", err)
+	}
+	audio.AudioData = reconstructed
+	audio.deltaBaseKey = sql.NullString{}
+	audio.deltaData = nil
+	return nil
+}
+
+// materializeDependents stores the entries that are deltas against any of baseKeys whole again,
+// within tx, so that they survive their base being deleted or replaced. Entries that are among
+// baseKeys themselves are left alone. Their audio doesn't change, so copies of it held in memory
+// stay valid. Entries that can't be reconstructed, because their base or delta is corrupt, are
+// deleted instead and their deletion events returned, to be passed to dropLost once tx is
+// committed.
+func (c *Cache) materializeDependents(tx *sql.Tx, baseKeys ...string) (lost []CacheEvent, err error) {
+	deleting := make(map[string]bool, len(baseKeys))
+	for _, key := range baseKeys {
+		deleting[key] = true
+	}
+
+	for _, baseKey := range baseKeys {
+		type dependent struct {
+			cacheKey, languageCode, namespace string
+			deltaData                         []byte
+		}
+		rows, err := tx.Query(`SELECT cache_key, language_code, namespace, delta_data FROM audio_cache WHERE delta_base_key = ?`, baseKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find entries stored against %s: %w", baseKey, err)
+		}
+		var dependents []dependent
+		for rows.Next() {
+			var d dependent
+			if err := rows.Scan(&d.cacheKey, &d.languageCode, &d.namespace, &d.deltaData); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to find entries stored against %s: %w", baseKey, err)
+			}
+			if !deleting[d.cacheKey] {
+				dependents = append(dependents, d)
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to find entries stored against %s: %w", baseKey, err)
+		}
+		if len(dependents) == 0 {
+			continue
+		}
+
+		var base CachedAudio
+		err = tx.QueryRow(`SELECT audio_data, compression FROM audio_cache WHERE cache_key = ?`, baseKey).Scan(&base.AudioData, &base.Compression)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read delta base %s: %w", baseKey, err)
+		}
+		baseErr := c.decompress(&base)
+		for _, d := range dependents {
+			audioData, err := ApplyDelta(base.AudioData, d.deltaData)
+			if baseErr != nil || err != nil {
+				logging.Warn("deleting entry stored against a corrupt delta", "key", d.cacheKey, "base", baseKey, "error", errors.Join(baseErr, err))
+				if _, err := tx.Exec(`DELETE FROM audio_cache WHERE cache_key = ?`, d.cacheKey); err != nil {
+					return nil, fmt.Errorf("failed to delete %s: %w", d.cacheKey, err)
+				}
+				lost = append(lost, CacheEvent{
+					Type:         EventDelete,
+					CacheKey:     d.cacheKey,
+					LanguageCode: d.languageCode,
+					Namespace:    d.namespace,
+				})
+				continue
+			}
+			dataToStore, compression, err := c.encodeForStorage(audioData, !isWAV(audioData))
+			if err != nil {
+				return nil, err
+			}
+			_, err = tx.Exec(
+				`UPDATE audio_cache SET audio_data = ?, audio_size = ?, compression = ?, delta_base_key = NULL, delta_data = NULL
+				 WHERE cache_key = ?`,
+				dataToStore, len(dataToStore), compression, d.cacheKey,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to store %s whole: %w", d.cacheKey, err)
+			}
+		}
+	}
+	return lost, nil
+}
+
+// dropLost removes the entries materializeDependents deleted from the in-memory caches and
+// publishes their deletion. Their copies in Redis or S3, if any, are kept, as they are whole.
+func (c *Cache) dropLost(lost []CacheEvent) {
+	now := getCurrentTimestamp()
+	for _, event := range lost {
+		c.dropHot(event.CacheKey)
+		c.dropMemory(event.CacheKey)
+		event.Timestamp = now
+		c.events.publish(event)
+	}
+}
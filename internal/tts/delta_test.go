@@ -0,0 +1,247 @@
+package tts
+
+import (
+	"bytes"
+	"database/sql"
+	"math/rand/v2"
+	"path/filepath"
+	"testing"
+)
+
+// randomAudio returns n bytes of incompressible stand-in audio, the same for the same seed
+func randomAudio(seed uint64, n int) []byte {
+	r := rand.New(rand.NewPCG(seed, seed))
+	audioData := make([]byte, n)
+	for i := range audioData {
+		audioData[i] = byte(r.Uint32())
+	}
+	return audioData
+}
+
+// edited returns audioData with edit written over it at offset
+func edited(audioData []byte, offset int, edit []byte) []byte {
+	audioData = bytes.Clone(audioData)
+	copy(audioData[offset:], edit)
+	return audioData
+}
+
+func TestDeltaRoundTrip(t *testing.T) {
+	base := randomAudio(1, 20000)
+	tests := []struct {
+		name     string
+		base     []byte
+		target   []byte
+		maxDelta int // Largest acceptable delta, 0 for no limit
+	}{
+		{"both empty", nil, nil, 0},
+		{"empty base", nil, base[:100], 0},
+		{"empty target", base, nil, 0},
+		{"shorter than a block", base[:10], base[5:20], 0},
+		{"identical", base, base, 100},
+		{"edited in the middle", base, edited(base, 9000, []byte("a different word")), 200},
+		{"inserted", base, append(append(bytes.Clone(base[:5000]), "inserted"...), base[5000:]...), 200},
+		{"removed", base, append(bytes.Clone(base[:5000]), base[5100:]...), 200},
+		{"appended", base, append(bytes.Clone(base), randomAudio(2, 300)...), 500},
+		{"truncated", base, base[:12345], 100},
+		{"unaligned", base, base[7:], 100},
+		{"repeated", base[:1000], bytes.Repeat(base[:1000], 5), 200},
+		{"unrelated", base, randomAudio(3, 20000), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta := ComputeDelta(tt.base, tt.target)
+			got, err := ApplyDelta(tt.base, delta)
+			if err != nil {
+				t.Fatalf("ApplyDelta: %v", err)
+			}
+			if !bytes.Equal(got, tt.target) {
+				t.Errorf("ApplyDelta reconstructed %d bytes that differ from the %d byte target", len(got), len(tt.target))
+			}
+			if tt.maxDelta > 0 && len(delta) > tt.maxDelta {
+				t.Errorf("delta is %d bytes, want at most %d", len(delta), tt.maxDelta)
+			}
+		})
+	}
+}
+
+func TestApplyDeltaRejectsInvalidDeltas(t *testing.T) {
+	base := randomAudio(1, 1000)
+	delta := ComputeDelta(base, edited(base, 500, []byte("edit")))
+	longer := randomAudio(2, 1000)
+
+	tests := []struct {
+		name  string
+		delta []byte
+	}{
+		{"empty", nil},
+		{"truncated", delta[:len(delta)-1]},
+		{"copy past the end", ComputeDelta(append(bytes.Clone(base), longer...), longer)},
+		{"unknown operation", append(bytes.Clone(delta), 7)},
+		{"wrong size", append([]byte{0xFF, 0x01}, delta[2:]...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ApplyDelta(base, tt.delta); err == nil {
+				t.Error("ApplyDelta succeeded")
+			}
+		})
+	}
+}
+
+// deltaBaseKey returns the key of the entry cacheKey is stored as a delta against, or "" if it
+// is stored whole
+func deltaBaseKey(t *testing.T, cache *Cache, cacheKey string) string {
+	t.Helper()
+	var baseKey sql.NullString
+	if err := cache.db.QueryRow(`SELECT delta_base_key FROM audio_cache WHERE cache_key = ?`, cacheKey).Scan(&baseKey); err != nil {
+		t.Fatalf("reading %s: %v", cacheKey, err)
+	}
+	return baseKey.String
+}
+
+// putDeltaPair caches baseAudio and targetAudio for the same text with different voices, the
+// second as a delta against the first, and returns their keys
+func putDeltaPair(t *testing.T, cache *Cache, baseAudio, targetAudio []byte) (baseKey, targetKey string) {
+	t.Helper()
+	baseKey, err := cache.Put("Hello world", "en-US", Options{}, baseAudio, "en-US-AriaNeural")
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetKey, err = cache.Put("Hello world", "en-US", Options{Voice: "en-US-GuyNeural"}, targetAudio, "en-US-GuyNeural")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := deltaBaseKey(t, cache, targetKey); got != baseKey {
+		t.Fatalf("second entry is stored against %q, want a delta against %s", got, baseKey)
+	}
+	return baseKey, targetKey
+}
+
+// wantAudio checks that key is cached with audioData
+func wantAudio(t *testing.T, cache *Cache, key string, audioData []byte) {
+	t.Helper()
+	audio, err := cache.GetByKey(key)
+	if err != nil {
+		t.Fatalf("GetByKey(%s): %v", key, err)
+	}
+	if audio == nil {
+		t.Fatalf("%s isn't cached", key)
+	}
+	if !bytes.Equal(audio.AudioData, audioData) {
+		t.Errorf("%s has %d bytes of audio, want its original %d", key, len(audio.AudioData), len(audioData))
+	}
+}
+
+func TestDeltaCompressionRoundTrip(t *testing.T) {
+	for _, compression := range []string{CompressionNone, CompressionZstd} {
+		t.Run("compression="+compression, func(t *testing.T) {
+			policy, _ := NewEvictionPolicy("lru")
+			cache, err := NewCache(filepath.Join(t.TempDir(), "cache.db"), compression, 0, 0, policy, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cache.Close()
+			if err := cache.SetDeltaCompression(true); err != nil {
+				t.Fatal(err)
+			}
+
+			baseAudio := randomAudio(1, 20000)
+			targetAudio := edited(baseAudio, 9000, []byte("a different word"))
+			baseKey, targetKey := putDeltaPair(t, cache, baseAudio, targetAudio)
+			wantAudio(t, cache, baseKey, baseAudio)
+			wantAudio(t, cache, targetKey, targetAudio)
+
+			audio, err := cache.Get("Hello world", "en-US", Options{Voice: "en-US-GuyNeural"})
+			if err != nil || audio == nil || !bytes.Equal(audio.AudioData, targetAudio) {
+				t.Errorf("Get = %v, %v, want the reconstructed audio", audio, err)
+			}
+		})
+	}
+}
+
+func TestDeltaDependentsOutliveTheirBase(t *testing.T) {
+	baseAudio := randomAudio(1, 20000)
+	targetAudio := edited(baseAudio, 9000, []byte("a different word"))
+
+	tests := []struct {
+		name       string
+		removeBase func(cache *Cache, baseKey string) error
+	}{
+		{"deleted", func(cache *Cache, baseKey string) error {
+			_, _, err := cache.Delete("Hello world", "en-US", Options{})
+			return err
+		}},
+		{"deleted by key", func(cache *Cache, baseKey string) error {
+			_, err := cache.DeleteKey("", baseKey)
+			return err
+		}},
+		{"evicted", func(cache *Cache, baseKey string) error {
+			_, err := cache.deleteKeys([]string{baseKey})
+			return err
+		}},
+		{"replaced", func(cache *Cache, baseKey string) error {
+			_, err := cache.Put("Hello world", "en-US", Options{}, randomAudio(2, 20000), "")
+			return err
+		}},
+		{"re-synthesized", func(cache *Cache, baseKey string) error {
+			return cache.replaceAudio(baseKey, true, randomAudio(2, 20000), "")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newTestCache(t)
+			if err := cache.SetDeltaCompression(true); err != nil {
+				t.Fatal(err)
+			}
+			baseKey, targetKey := putDeltaPair(t, cache, baseAudio, targetAudio)
+			events, unsubscribe := cache.Subscribe()
+			defer unsubscribe()
+
+			if err := tt.removeBase(cache, baseKey); err != nil {
+				t.Fatal(err)
+			}
+			wantAudio(t, cache, targetKey, targetAudio)
+			if got := deltaBaseKey(t, cache, targetKey); got != "" {
+				t.Errorf("the entry is still stored against %s", got)
+			}
+			unsubscribe()
+			for event := range events {
+				if event.CacheKey == targetKey {
+					t.Errorf("got a %s event for the entry stored against the base", event.Type)
+				}
+			}
+		})
+	}
+}
+
+func TestDeltaDependentOfCorruptBaseIsDeleted(t *testing.T) {
+	cache := newTestCache(t)
+	if err := cache.SetDeltaCompression(true); err != nil {
+		t.Fatal(err)
+	}
+	baseAudio := randomAudio(1, 20000)
+	baseKey, targetKey := putDeltaPair(t, cache, baseAudio, edited(baseAudio, 9000, []byte("a different word")))
+	if _, err := cache.db.Exec(`UPDATE audio_cache SET delta_data = X'FF' WHERE cache_key = ?`, targetKey); err != nil {
+		t.Fatal(err)
+	}
+	events, unsubscribe := cache.Subscribe()
+	defer unsubscribe()
+
+	// The entry can't be reconstructed without its base, so it goes too, announced like any deletion
+	if _, err := cache.DeleteCorrupt([]string{baseKey}); err != nil {
+		t.Fatal(err)
+	}
+	if audio, err := cache.GetByKey(targetKey); err != nil || audio != nil {
+		t.Errorf("GetByKey(%s) = %v, %v, want it deleted", targetKey, audio, err)
+	}
+	unsubscribe()
+	deleted := make(map[string]bool)
+	for event := range events {
+		if event.Type == EventDelete {
+			deleted[event.CacheKey] = true
+		}
+	}
+	if !deleted[baseKey] || !deleted[targetKey] {
+		t.Errorf("delete events for %v, want both %s and %s", deleted, baseKey, targetKey)
+	}
+}
@@ -0,0 +1,165 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Diagnostic check statuses, ordered from best to worst
+const (
+	StatusPass = "pass"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+)
+
+// inFlightWarnThreshold is the number of concurrent Azure fetches above which diagnostics warn
+const inFlightWarnThreshold = 100
+
+// minFreeDiskPercent is the minimum free disk space at the database path before diagnostics fail
+const minFreeDiskPercent = 10.0
+
+// CheckResult is the outcome of a single diagnostic check
+type CheckResult struct {
+	Name     string
+	Status   string
+	Message  string
+	Duration time.Duration
+}
+
+// WorstStatus returns the most severe status among the given results
+func WorstStatus(results []CheckResult) string {
+	rank := map[string]int{StatusPass: 0, StatusWarn: 1, StatusFail: 2}
+	worst := StatusPass
+	for _, r := range results {
+		if rank[r.Status] > rank[worst] {
+			worst = r.Status
+		}
+	}
+	return worst
+}
+
+// RunDiagnostics runs all subsystem checks and returns their results
+func (s *Service) RunDiagnostics(ctx context.Context) []CheckResult {
+	checks := []struct {
+		name string
+		fn   func(ctx context.Context) (string, string)
+	}{
+		{"sqlite", s.checkSQLite},
+		{"azure_api", s.checkAzureAPI},
+		{"rate_limiter", s.checkRateLimiter},
+		{"disk_space", s.checkDiskSpace},
+		{"in_flight", s.checkInFlight},
+		{"voices", s.checkVoices},
+		{"synthesis", s.checkSynthesisPaused},
+	}
+
+	results := make([]CheckResult, 0, len(checks))
+	for _, check := range checks {
+		start := time.Now()
+		status, message := check.fn(ctx)
+		results = append(results, CheckResult{
+			Name:     check.name,
+			Status:   status,
+			Message:  message,
+			Duration: time.Since(start),
+		})
+	}
+	return results
+}
+
+// checkSQLite verifies the database is accessible and running in WAL mode
+func (s *Service) checkSQLite(ctx context.Context) (string, string) {
+	if err := s.cache.Ping(); err != nil {
+		return StatusFail, err.Error()
+	}
+
+	mode, err := s.cache.JournalMode()
+	if err != nil {
+		return StatusFail, err.Error()
+	}
+	if !strings.EqualFold(mode, "wal") {
+		return StatusWarn, fmt.Sprintf("database accessible, but journal mode is %q (expected wal)", mode)
+	}
+	return StatusPass, "database accessible, WAL mode enabled"
+}
+
+// checkAzureAPI verifies the Azure voice list endpoint is reachable
+func (s *Service) checkAzureAPI(ctx context.Context) (string, string) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := s.provider.Ping(ctx); err != nil {
+		return StatusFail, err.Error()
+	}
+	return StatusPass, "voice list endpoint reachable"
+}
+
+// checkRateLimiter reports whether the rate limiter has capacity available
+func (s *Service) checkRateLimiter(ctx context.Context) (string, string) {
+	tokens := s.provider.RateLimiterTokens()
+	if tokens < 1 {
+		return StatusWarn, fmt.Sprintf("rate limiter exhausted (%.2f tokens available)", tokens)
+	}
+	return StatusPass, fmt.Sprintf("%.2f tokens available", tokens)
+}
+
+// checkDiskSpace verifies there is enough free space for the database to grow
+func (s *Service) checkDiskSpace(ctx context.Context) (string, string) {
+	free, total, err := diskUsage(filepath.Dir(s.cache.Path()))
+	if err != nil {
+		return StatusWarn, err.Error()
+	}
+	if total == 0 {
+		return StatusWarn, "filesystem reports zero size"
+	}
+
+	percentFree := float64(free) / float64(total) * 100
+	message := fmt.Sprintf("%.1f%% free (%.2fGB of %.2fGB)",
+		percentFree, float64(free)/(1<<30), float64(total)/(1<<30))
+	if percentFree < minFreeDiskPercent {
+		return StatusFail, message
+	}
+	return StatusPass, message
+}
+
+// checkInFlight reports the number of Azure fetches currently in progress
+func (s *Service) checkInFlight(ctx context.Context) (string, string) {
+	count := s.InFlightCount()
+	message := fmt.Sprintf("%d in-flight requests (threshold %d)", count, inFlightWarnThreshold)
+	if count >= inFlightWarnThreshold {
+		return StatusWarn, message
+	}
+	return StatusPass, message
+}
+
+// checkVoices verifies every configured custom voice is offered by Azure
+func (s *Service) checkVoices(ctx context.Context) (string, string) {
+	missing := s.provider.MissingCustomVoices()
+	if len(missing) == 0 {
+		return StatusPass, "all configured voices available"
+	}
+
+	entries := make([]string, 0, len(missing))
+	for locale, voice := range missing {
+		entries = append(entries, fmt.Sprintf("%s -> %s", locale, voice))
+	}
+	sort.Strings(entries)
+	return StatusFail, fmt.Sprintf("configured voices not found: %s", strings.Join(entries, ", "))
+}
+
+// checkSynthesisPaused warns while synthesis is paused for maintenance
+func (s *Service) checkSynthesisPaused(ctx context.Context) (string, string) {
+	paused, reason, since := s.SynthesisPaused()
+	if !paused {
+		return StatusPass, "synthesis enabled"
+	}
+	message := fmt.Sprintf("paused for %s", time.Since(since).Round(time.Second))
+	if reason != "" {
+		message += ": " + reason
+	}
+	return StatusWarn, message
+}
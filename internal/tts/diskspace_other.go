@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package tts
+
+import "fmt"
+
+// diskUsage is not supported on this platform
+func diskUsage(path string) (free, total uint64, err error) {
+	return 0, 0, fmt.Errorf("disk usage not supported on this platform")
+}
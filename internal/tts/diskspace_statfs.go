@@ -0,0 +1,20 @@
+//go:build linux || darwin
+
+// Statfs_t's field types differ between the other unix platforms (and aix and solaris have no
+// Statfs), so they use diskspace_other.go
+
+package tts
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// diskUsage returns the free and total bytes of the filesystem containing path
+func diskUsage(path string) (free, total uint64, err error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, fmt.Errorf("statfs failed: %w", err)
+	}
+	return stat.Bavail * uint64(stat.Bsize), stat.Blocks * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,78 @@
+package tts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/decoder"
+	"github.com/gopxl/beep"
+	"github.com/gopxl/beep/wav"
+)
+
+// AudioDuration returns the playback length of MP3, WAV or OGG Opus audio data
+func AudioDuration(audioData []byte) (time.Duration, error) {
+	if isOgg(audioData) {
+		return oggOpusDuration(audioData)
+	}
+
+	var streamer beep.StreamSeekCloser
+	var format beep.Format
+	var err error
+
+	if isWAV(audioData) {
+		streamer, format, err = wav.Decode(bytes.NewReader(audioData))
+	} else {
+		streamer, format, err = decoder.MP3(audioData)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode audio: %w", err)
+	}
+	defer streamer.Close()
+
+	return format.SampleRate.D(streamer.Len()), nil
+}
+
+// oggOpusDuration reads the length of OGG Opus audio from the granule position of its last page,
+// which counts 48kHz samples including the encoder's pre-skip given in the OpusHead header
+func oggOpusDuration(audioData []byte) (time.Duration, error) {
+	const pageHeaderSize = 27
+
+	head := bytes.Index(audioData, []byte("OpusHead"))
+	if head < 0 || len(audioData) < head+12 {
+		return 0, fmt.Errorf("failed to decode audio: no OpusHead header")
+	}
+	preSkip := int64(binary.LittleEndian.Uint16(audioData[head+10:]))
+
+	// The page whose header and segments run exactly to the end of the data is the last one;
+	// anything else matching "OggS" is inside a packet
+	for end := len(audioData); end > 0; {
+		start := bytes.LastIndex(audioData[:end], []byte("OggS"))
+		if start < 0 {
+			break
+		}
+		end = start
+		if len(audioData)-start < pageHeaderSize {
+			continue
+		}
+		segments := int(audioData[start+26])
+		if len(audioData)-start < pageHeaderSize+segments {
+			continue
+		}
+		size := pageHeaderSize + segments
+		for _, n := range audioData[start+pageHeaderSize : start+pageHeaderSize+segments] {
+			size += int(n)
+		}
+		if start+size != len(audioData) {
+			continue
+		}
+
+		samples := int64(binary.LittleEndian.Uint64(audioData[start+6:])) - preSkip
+		if samples < 0 {
+			samples = 0
+		}
+		return time.Duration(samples) * time.Second / 48000, nil
+	}
+	return 0, fmt.Errorf("failed to decode audio: no complete OGG page")
+}
@@ -0,0 +1,116 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+// testMP3 returns frames MPEG-1 Layer III frames of 44.1kHz 128kbps silence. Each frame holds
+// 1152 samples, and with zeroed side information decodes to silence.
+func testMP3(frames int) []byte {
+	const frameSize = 144 * 128000 / 44100 // 417 bytes, without padding
+	frame := make([]byte, frameSize)
+	copy(frame, []byte{0xFF, 0xFB, 0x90, 0xC4}) // Sync, MPEG-1, Layer III, no CRC, 128kbps, 44.1kHz, mono
+	return bytes.Repeat(frame, frames)
+}
+
+// oggPage returns an OGG page holding packet, which must be shorter than 255 bytes
+func oggPage(granule uint64, sequence uint32, packet []byte) []byte {
+	page := make([]byte, 27, 28+len(packet))
+	copy(page, "OggS")
+	binary.LittleEndian.PutUint64(page[6:], granule)
+	binary.LittleEndian.PutUint32(page[18:], sequence)
+	page[26] = 1 // One segment
+	page = append(page, byte(len(packet)))
+	return append(page, packet...)
+}
+
+// testOggOpus returns OGG Opus audio whose last page ends at granule, with the given pre-skip
+func testOggOpus(preSkip uint16, granule uint64) []byte {
+	head := make([]byte, 19)
+	copy(head, "OpusHead")
+	head[8] = 1 // Version
+	head[9] = 1 // Channels
+	binary.LittleEndian.PutUint16(head[10:], preSkip)
+	binary.LittleEndian.PutUint32(head[12:], 48000)
+
+	var audio []byte
+	audio = append(audio, oggPage(0, 0, head)...)
+	audio = append(audio, oggPage(0, 1, []byte("OpusTags\x00\x00\x00\x00\x00\x00\x00\x00"))...)
+	// The packet contains "OggS", which mustn't be mistaken for the start of the last page
+	audio = append(audio, oggPage(granule/2, 2, []byte("xxOggSxx"))...)
+	return append(audio, oggPage(granule, 3, []byte{0xF8, 0xFF, 0xFE})...)
+}
+
+func TestAudioDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		audioData []byte
+		want      time.Duration
+	}{
+		{"mp3, 100 frames", testMP3(100), 100 * 1152 * time.Second / 44100},
+		{"mp3, 1 frame", testMP3(1), 1152 * time.Second / 44100},
+		{"wav, 1 second", (&pcmWAV{sampleRate: 16000, samples: make([]byte, 2*16000)}).encode(), time.Second},
+		{"wav, 250ms", (&pcmWAV{sampleRate: 16000, samples: make([]byte, 2*4000)}).encode(), 250 * time.Millisecond},
+		{"ogg opus, 1 second after pre-skip", testOggOpus(312, 48000+312), time.Second},
+		{"ogg opus, shorter than the pre-skip", testOggOpus(312, 100), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AudioDuration(tt.audioData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// MP3 lengths are rounded to whole samples
+			if diff := got - tt.want; diff < -time.Millisecond || diff > time.Millisecond {
+				t.Errorf("AudioDuration = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAudioDurationErrors(t *testing.T) {
+	truncatedOgg := testOggOpus(312, 48312)
+	tests := []struct {
+		name      string
+		audioData []byte
+	}{
+		{"empty", nil},
+		{"garbage", []byte("definitely not audio")},
+		{"wav cut mid-header", (&pcmWAV{sampleRate: 16000, samples: make([]byte, 2*100)}).encode()[:20]},
+		{"ogg without OpusHead", oggPage(48000, 0, []byte("OpusTags"))},
+		{"ogg cut mid-page", truncatedOgg[:len(truncatedOgg)-1]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d, err := AudioDuration(tt.audioData); err == nil {
+				t.Errorf("AudioDuration = %v, want an error", d)
+			}
+		})
+	}
+}
+
+func TestSynthesizeEphemeralSkipsCache(t *testing.T) {
+	cache := newTestCache(t)
+	provider := newMockProvider(t)
+	service := NewService(cache, provider)
+
+	for i := 0; i < 2; i++ {
+		audioData, err := service.SynthesizeEphemeral(context.Background(), "Hello", "en-US", Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(audioData, readTestAudio(t, "en-US")) {
+			t.Error("ephemeral audio isn't the provider's audio")
+		}
+	}
+	if got := provider.calls.Load(); got != 2 {
+		t.Errorf("provider called %d times, want 2 (ephemeral audio is never reused)", got)
+	}
+	if cached, err := cache.Get("Hello", "en-US", Options{}); err != nil || cached != nil {
+		t.Errorf("ephemeral audio was cached (%v, %v)", cached, err)
+	}
+}
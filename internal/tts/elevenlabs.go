@@ -0,0 +1,268 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// elevenLabsAPI is the base URL of the ElevenLabs API
+const elevenLabsAPI = "https://api.elevenlabs.io/v1"
+
+// ElevenLabsVoice is a voice from the ElevenLabs voice list
+type ElevenLabsVoice struct {
+	VoiceID string `json:"voice_id"`
+	Name    string `json:"name"`
+}
+
+// ElevenLabsClient is a Provider backed by the ElevenLabs API. Its voices speak any language the
+// model supports, so the voice for each language comes from the configuration rather than the
+// voice list.
+type ElevenLabsClient struct {
+	apiKey         string
+	modelID        string
+	defaultVoiceID string
+	rateLimiter    *rate.Limiter
+	httpClient     *http.Client
+	customVoices   map[string]string // Voice IDs by language code from the configuration
+	voiceCache     map[string]string // The custom voices the account has, from the last FetchVoiceList
+	voices         []ElevenLabsVoice // Full voice list from the last FetchVoiceList
+	voiceCacheMu   sync.RWMutex      // Protects voiceCache and voices
+}
+
+// NewElevenLabsClient creates an ElevenLabs client with rate limiting. defaultVoiceID is used for
+// languages without a voice in customVoices.
+func NewElevenLabsClient(apiKey, modelID, defaultVoiceID string, maxQPS float64, customVoices map[string]string) *ElevenLabsClient {
+	return &ElevenLabsClient{
+		apiKey:         apiKey,
+		modelID:        modelID,
+		defaultVoiceID: defaultVoiceID,
+		rateLimiter:    rate.NewLimiter(rate.Limit(maxQPS), 1),
+		httpClient:     &http.Client{},
+		customVoices:   customVoices,
+		voiceCache:     make(map[string]string),
+	}
+}
+
+// FetchVoiceList implements Provider. The locale -> voice mappings are the configured ones whose
+// voice is in the account's list.
+func (e *ElevenLabsClient) FetchVoiceList(ctx context.Context) error {
+	voices, err := e.listVoices(ctx)
+	if err != nil {
+		return err
+	}
+
+	available := make(map[string]bool, len(voices))
+	for _, voice := range voices {
+		available[voice.VoiceID] = true
+	}
+	voiceCache := make(map[string]string, len(e.customVoices))
+	for locale, voiceID := range e.customVoices {
+		if available[voiceID] {
+			voiceCache[locale] = voiceID
+		}
+	}
+
+	e.voiceCacheMu.Lock()
+	e.voices = voices
+	e.voiceCache = voiceCache
+	e.voiceCacheMu.Unlock()
+
+	log.Printf("Loaded %d voices from ElevenLabs, %d mapped to languages", len(voices), len(voiceCache))
+	return nil
+}
+
+// listVoices requests the account's voice list
+func (e *ElevenLabsClient) listVoices(ctx context.Context) ([]ElevenLabsVoice, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", elevenLabsAPI+"/voices", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("xi-api-key", e.apiKey)
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("ElevenLabs API error (status %d): %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result struct {
+		Voices []ElevenLabsVoice `json:"voices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Voices, nil
+}
+
+// ListVoices implements Provider. The voices have no locale.
+func (e *ElevenLabsClient) ListVoices(ctx context.Context) ([]Voice, error) {
+	e.voiceCacheMu.RLock()
+	defer e.voiceCacheMu.RUnlock()
+
+	voices := make([]Voice, 0, len(e.voices))
+	for _, voice := range e.voices {
+		voices = append(voices, Voice{Name: voice.Name, DisplayName: voice.Name, ShortName: voice.VoiceID})
+	}
+	return voices, nil
+}
+
+// VoiceCount implements Provider
+func (e *ElevenLabsClient) VoiceCount() int {
+	e.voiceCacheMu.RLock()
+	defer e.voiceCacheMu.RUnlock()
+	return len(e.voices)
+}
+
+// Ping implements Provider by requesting the voice list
+func (e *ElevenLabsClient) Ping(ctx context.Context) error {
+	_, err := e.listVoices(ctx)
+	return err
+}
+
+// RateLimiterTokens implements Provider
+func (e *ElevenLabsClient) RateLimiterTokens() float64 {
+	return e.rateLimiter.Tokens()
+}
+
+// RateLimiter implements Provider
+func (e *ElevenLabsClient) RateLimiter() *rate.Limiter {
+	return e.rateLimiter
+}
+
+// MissingCustomVoices implements Provider
+func (e *ElevenLabsClient) MissingCustomVoices() map[string]string {
+	e.voiceCacheMu.RLock()
+	defer e.voiceCacheMu.RUnlock()
+
+	missing := make(map[string]string)
+	for locale, voiceID := range e.customVoices {
+		if _, ok := e.voiceCache[locale]; !ok {
+			missing[locale] = voiceID
+		}
+	}
+	return missing
+}
+
+// VoiceName implements Provider with the mapped voice for the language or its base language (see
+// selectVoice), or the default voice
+func (e *ElevenLabsClient) VoiceName(languageCode string) (string, error) {
+	e.voiceCacheMu.RLock()
+	defer e.voiceCacheMu.RUnlock()
+
+	if voice, err := selectVoice(languageCode, nil, e.voiceCache); err == nil {
+		return voice, nil
+	}
+	if e.defaultVoiceID != "" {
+		return e.defaultVoiceID, nil
+	}
+	return "", fmt.Errorf("no voice configured for language code: %s", languageCode)
+}
+
+// DefaultVoices implements Provider with the mapped voices
+func (e *ElevenLabsClient) DefaultVoices() map[string]string {
+	e.voiceCacheMu.RLock()
+	defer e.voiceCacheMu.RUnlock()
+
+	voices := make(map[string]string, len(e.voiceCache))
+	for locale, voice := range e.voiceCache {
+		voices[locale] = voice
+	}
+	return voices
+}
+
+// elevenLabsMP3Bitrates are the MP3 bitrates ElevenLabs offers, all at 44.1kHz
+var elevenLabsMP3Bitrates = map[string]bool{"32k": true, "64k": true, "96k": true, "128k": true, "192k": true}
+
+// elevenLabsOutputFormat returns the output_format parameter for the options' format. ElevenLabs
+// MP3 is always 44.1kHz, so only the bitrate of the MP3 quality is honored.
+func elevenLabsOutputFormat(opts Options) (string, error) {
+	switch opts.Format {
+	case FormatMP3:
+		bitrate := opts.mp3Quality().bitrate
+		if !elevenLabsMP3Bitrates[bitrate] {
+			return "", fmt.Errorf("ElevenLabs doesn't support %s MP3 audio", bitrate)
+		}
+		return "mp3_44100_" + strings.TrimSuffix(bitrate, "k"), nil
+	case FormatWAV16K:
+		return "pcm_16000", nil
+	default:
+		return "", fmt.Errorf("ElevenLabs doesn't support %s audio", opts.Format)
+	}
+}
+
+// Synthesize implements Provider
+func (e *ElevenLabsClient) Synthesize(ctx context.Context, text, languageCode string, opts Options) ([]byte, error) {
+	if opts.SSML {
+		return nil, fmt.Errorf("ElevenLabs doesn't support SSML input")
+	}
+	outputFormat, err := elevenLabsOutputFormat(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter error: %w", err)
+	}
+
+	locale := voiceLocale(languageCode, opts)
+	voiceID, err := voiceFor(e, languageCode, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voice for language %s: %w", locale, err)
+	}
+
+	// ElevenLabs reads break tags in plain text, so the text isn't escaped
+	body, err := json.Marshal(map[string]string{
+		"text":     insertBreaks(text, opts),
+		"model_id": e.modelID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/text-to-speech/%s?output_format=%s", elevenLabsAPI, voiceID, outputFormat)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("xi-api-key", e.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("ElevenLabs API error (status %d): %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	audioData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(audioData) == 0 {
+		return nil, fmt.Errorf("synthesis produced no audio data")
+	}
+
+	// PCM audio comes without a header
+	if opts.Format == FormatWAV16K {
+		audioData = (&pcmWAV{sampleRate: 16000, samples: audioData}).encode()
+	}
+	return audioData, nil
+}
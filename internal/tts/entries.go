@@ -0,0 +1,200 @@
+package tts
+
+import (
+	"database/sql"
+	"fmt"
+	"unicode/utf8"
+)
+
+// CacheEntryInfo describes a cache entry without its audio
+type CacheEntryInfo struct {
+	CacheKey     string
+	Text         string
+	LanguageCode string
+	AudioSize    int64 // Stored (possibly compressed) size in bytes
+	CreatedAt    int64
+	HitCount     int64
+	LastAccessed int64
+	VoiceName    string // "" if it wasn't recorded
+	Namespace    string // "" = the default namespace
+}
+
+// ListEntries returns up to limit entries in namespace ordered by cache key,
+// starting after afterKey ("" for the first page). Entries can be limited to one language, to
+// texts starting with textPrefix, to those tagged with tag and to those created at or after
+// sinceUnix (0 = no limit).
+func (c *Cache) ListEntries(namespace, languageCode, textPrefix, tag string, sinceUnix int64, afterKey string, limit int) ([]CacheEntryInfo, error) {
+	query := `SELECT cache_key, text, language_code, audio_size, created_at, hit_count,
+		COALESCE(last_accessed, created_at), COALESCE(voice_name, ''), namespace
+		FROM audio_cache WHERE namespace = ? AND cache_key > ? AND created_at >= ?`
+	queryArgs := []interface{}{namespace, afterKey, sinceUnix}
+	if languageCode != "" {
+		query += ` AND language_code = ?`
+		queryArgs = append(queryArgs, languageCode)
+	}
+	if textPrefix != "" {
+		// substr counts characters, and unlike LIKE needs no escaping of % and _
+		query += ` AND substr(text, 1, ?) = ?`
+		queryArgs = append(queryArgs, utf8.RuneCountInString(textPrefix), textPrefix)
+	}
+	if tag != "" {
+		query += ` AND cache_key IN (SELECT cache_key FROM audio_cache_tags WHERE tag = ?)`
+		queryArgs = append(queryArgs, tag)
+	}
+	query += ` ORDER BY cache_key LIMIT ?`
+	queryArgs = append(queryArgs, limit)
+
+	rows, err := c.db.Query(query, queryArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cache entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []CacheEntryInfo
+	for rows.Next() {
+		var e CacheEntryInfo
+		if err := rows.Scan(&e.CacheKey, &e.Text, &e.LanguageCode, &e.AudioSize, &e.CreatedAt, &e.HitCount, &e.LastAccessed, &e.VoiceName, &e.Namespace); err != nil {
+			return nil, fmt.Errorf("failed to scan cache entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// GetByKey returns the entry stored under cacheKey, or nil if there is none. Unlike Get it
+// doesn't count as a cache hit.
+func (c *Cache) GetByKey(cacheKey string) (*CachedAudio, error) {
+	c.queryMu.RLock()
+	defer c.queryMu.RUnlock()
+
+	var audio CachedAudio
+	err := c.db.QueryRow(
+		`SELECT cache_key, text, language_code, audio_data, compression, created_at, last_accessed,
+		 COALESCE(voice_name, ''), COALESCE(format, ''), namespace, COALESCE(expires_at, 0), delta_base_key, delta_data
+		 FROM audio_cache WHERE cache_key = ?`,
+		cacheKey,
+	).Scan(
+		&audio.CacheKey,
+		&audio.Text,
+		&audio.LanguageCode,
+		&audio.AudioData,
+		&audio.Compression,
+		&audio.CreatedAt,
+		&audio.LastAccessed,
+		&audio.VoiceName,
+		&audio.Format,
+		&audio.Namespace,
+		&audio.ExpiresAt,
+		&audio.deltaBaseKey,
+		&audio.deltaData,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cache: %w", err)
+	}
+
+	if err := c.decompress(&audio); err != nil {
+		return nil, err
+	}
+	if err := c.applyStoredDelta(&audio); err != nil {
+		return nil, err
+	}
+	return &audio, nil
+}
+
+// DeleteKey removes the entry stored under cacheKey in namespace, reporting whether there was one
+func (c *Cache) DeleteKey(namespace, cacheKey string) (bool, error) {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var languageCode string
+	err = tx.QueryRow(`SELECT language_code FROM audio_cache WHERE cache_key = ? AND namespace = ?`, cacheKey, namespace).Scan(&languageCode)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to delete from cache: %w", err)
+	}
+	lost, err := c.materializeDependents(tx, cacheKey)
+	if err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(`DELETE FROM audio_cache WHERE cache_key = ?`, cacheKey); err != nil {
+		return false, fmt.Errorf("failed to delete from cache: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to delete from cache: %w", err)
+	}
+	c.dropLost(lost)
+	c.dropHot(cacheKey)
+	c.dropMemory(cacheKey)
+	c.dropShared(cacheKey)
+
+	c.events.publish(CacheEvent{
+		Type:         EventDelete,
+		CacheKey:     cacheKey,
+		LanguageCode: languageCode,
+		Namespace:    namespace,
+		Timestamp:    getCurrentTimestamp(),
+	})
+	return true, nil
+}
+
+// HasKey reports whether an entry is stored under cacheKey
+func (c *Cache) HasKey(cacheKey string) (bool, error) {
+	var exists bool
+	err := c.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM audio_cache WHERE cache_key = ?)`, cacheKey).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to query cache: %w", err)
+	}
+	return exists, nil
+}
+
+// PutWithKey stores audio under an existing cache key in namespace, such as one copied from
+// another daemon. The key is kept as-is because the options it was generated with aren't known.
+func (c *Cache) PutWithKey(cacheKey, namespace, text, languageCode string, audioData []byte) error {
+	c.putShared(&CachedAudio{
+		CacheKey:     cacheKey,
+		Text:         text,
+		LanguageCode: languageCode,
+		AudioData:    audioData,
+		CreatedAt:    getCurrentTimestamp(),
+	}, !isWAV(audioData))
+	return c.putEntry(cacheKey, namespace, text, languageCode, "", !isWAV(audioData), audioData, "", 0)
+}
+
+// ListCacheEntries returns a page of the cache entries in namespace (see Cache.ListEntries)
+func (s *Service) ListCacheEntries(namespace, languageCode, textPrefix, tag string, sinceUnix int64, afterKey string, limit int) ([]CacheEntryInfo, error) {
+	return s.cache.ListEntries(namespace, languageCode, textPrefix, tag, sinceUnix, afterKey, limit)
+}
+
+// GetCacheEntry returns the entry stored under cacheKey in namespace, or nil if there is none
+func (s *Service) GetCacheEntry(namespace, cacheKey string) (*CachedAudio, error) {
+	audio, err := s.cache.GetByKey(cacheKey)
+	if err != nil || audio == nil || audio.Namespace != namespace {
+		return nil, err
+	}
+	return audio, nil
+}
+
+// DeleteCacheEntry deletes the entry stored under cacheKey in namespace, reporting whether there
+// was one
+func (s *Service) DeleteCacheEntry(namespace, cacheKey string) (bool, error) {
+	return s.cache.DeleteKey(namespace, cacheKey)
+}
+
+// HasCacheEntry reports whether an entry is stored under cacheKey
+func (s *Service) HasCacheEntry(cacheKey string) (bool, error) {
+	return s.cache.HasKey(cacheKey)
+}
+
+// ImportCacheEntry stores audio copied from another cache under its original key in namespace
+func (s *Service) ImportCacheEntry(cacheKey, namespace, text, languageCode string, audioData []byte) error {
+	return s.cache.PutWithKey(cacheKey, namespace, text, languageCode, audioData)
+}
@@ -0,0 +1,88 @@
+package tts
+
+import (
+	"sync"
+)
+
+// Cache event types
+const (
+	EventPut    = "put"
+	EventDelete = "delete"
+)
+
+// CacheEvent describes a single change to the cache
+type CacheEvent struct {
+	Type         string
+	CacheKey     string
+	LanguageCode string
+	Namespace    string // "" = the default namespace
+	AudioSize    int64
+	Timestamp    int64
+}
+
+// eventSubscriberBuffer is how many events a slow subscriber can fall behind before events are dropped
+const eventSubscriberBuffer = 64
+
+// eventBroadcaster fans cache events out to any number of subscribers
+type eventBroadcaster struct {
+	mu          sync.Mutex
+	subscribers map[chan CacheEvent]struct{}
+	closed      bool
+}
+
+func newEventBroadcaster() *eventBroadcaster {
+	return &eventBroadcaster{
+		subscribers: make(map[chan CacheEvent]struct{}),
+	}
+}
+
+// subscribe registers a new subscriber. The returned channel is closed when the subscriber
+// unsubscribes or the broadcaster is closed.
+func (b *eventBroadcaster) subscribe() (<-chan CacheEvent, func()) {
+	ch := make(chan CacheEvent, eventSubscriberBuffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	b.subscribers[ch] = struct{}{}
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+	return ch, unsubscribe
+}
+
+// publish sends the event to every subscriber without blocking; subscribers whose buffer is
+// full miss the event
+func (b *eventBroadcaster) publish(event CacheEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// close closes every subscriber channel and rejects new subscriptions
+func (b *eventBroadcaster) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,86 @@
+package tts
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// EvictionPolicy decides which cache entries are removed when the cache exceeds its size limit
+type EvictionPolicy interface {
+	// SelectEvictionCandidates returns the cache keys to delete to free roughly bytesToFree bytes,
+	// choosing only entries for languageCode unless it is ""
+	SelectEvictionCandidates(db *sql.DB, languageCode string, bytesToFree int64) ([]string, error)
+}
+
+// LRUEviction evicts the least recently accessed entries first
+type LRUEviction struct{}
+
+// SelectEvictionCandidates implements EvictionPolicy
+func (LRUEviction) SelectEvictionCandidates(db *sql.DB, languageCode string, bytesToFree int64) ([]string, error) {
+	return selectInOrder(db, "last_accessed ASC", languageCode, bytesToFree)
+}
+
+// LFUEviction evicts the entries with the fewest cache hits first, breaking ties by recency
+type LFUEviction struct{}
+
+// SelectEvictionCandidates implements EvictionPolicy
+func (LFUEviction) SelectEvictionCandidates(db *sql.DB, languageCode string, bytesToFree int64) ([]string, error) {
+	return selectInOrder(db, "hit_count ASC, last_accessed ASC", languageCode, bytesToFree)
+}
+
+// FIFOEviction evicts the oldest entries first, regardless of how they are used
+type FIFOEviction struct{}
+
+// SelectEvictionCandidates implements EvictionPolicy
+func (FIFOEviction) SelectEvictionCandidates(db *sql.DB, languageCode string, bytesToFree int64) ([]string, error) {
+	return selectInOrder(db, "created_at ASC", languageCode, bytesToFree)
+}
+
+// NewEvictionPolicy returns the eviction policy with the given config name ("lru", "lfu" or "fifo")
+func NewEvictionPolicy(name string) (EvictionPolicy, error) {
+	switch name {
+	case "", "lru":
+		return LRUEviction{}, nil
+	case "lfu":
+		return LFUEviction{}, nil
+	case "fifo":
+		return FIFOEviction{}, nil
+	default:
+		return nil, fmt.Errorf("unknown eviction policy %q (use lru, lfu or fifo)", name)
+	}
+}
+
+// selectInOrder walks entries in the given order and returns keys until their combined size
+// reaches bytesToFree, considering only languageCode's entries unless it is "". Ties (timestamps
+// have one second resolution) are broken by insertion order.
+func selectInOrder(db *sql.DB, orderBy, languageCode string, bytesToFree int64) ([]string, error) {
+	rows, err := db.Query(fmt.Sprintf(`
+		SELECT cache_key FROM (
+			SELECT cache_key, audio_size,
+			       SUM(audio_size) OVER (ORDER BY %s, rowid ASC) as cumulative_size
+			FROM audio_cache
+			WHERE ? = '' OR language_code = ?
+		)
+		WHERE cumulative_size - audio_size < ?`, orderBy), languageCode, languageCode, bytesToFree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select eviction candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan eviction candidate: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// SetMaxSize changes the size limit of the cache (0 = unlimited), evicting entries right away
+// if it is now over the limit
+func (c *Cache) SetMaxSize(maxSizeMB int64) {
+	c.maxSizeBytes.Store(max(maxSizeMB, 0) * 1024 * 1024)
+	go c.evictIfNeeded()
+}
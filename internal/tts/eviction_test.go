@@ -0,0 +1,193 @@
+package tts
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// insertEntry adds a bare cache row with the given eviction attributes
+func insertEntry(t testing.TB, db *sql.DB, key, languageCode string, size, createdAt, lastAccessed, hits int64) {
+	t.Helper()
+	_, err := db.Exec(
+		`INSERT INTO audio_cache (cache_key, text, language_code, audio_data, audio_size, created_at, last_accessed, hit_count)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		key, key, languageCode, []byte{0}, size, createdAt, lastAccessed, hits)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEvictionPolicies(t *testing.T) {
+	cache := newTestCache(t)
+	// Arguments: key, language, size, created_at, last_accessed, hit_count
+	insertEntry(t, cache.db, "a", "en-US", 100, 1, 30, 5)
+	insertEntry(t, cache.db, "b", "en-US", 100, 2, 10, 1)
+	insertEntry(t, cache.db, "c", "en-US", 100, 3, 20, 0)
+	insertEntry(t, cache.db, "d", "de-DE", 100, 4, 5, 0)
+	insertEntry(t, cache.db, "e", "en-US", 100, 5, 10, 1) // Ties with b except for insertion order
+
+	tests := []struct {
+		policy       string
+		languageCode string
+		bytesToFree  int64
+		want         []string
+	}{
+		{"lru", "", 150, []string{"d", "b"}},
+		{"lru", "en-US", 250, []string{"b", "e", "c"}},
+		{"lfu", "", 150, []string{"d", "c"}},
+		{"lfu", "en-US", 200, []string{"c", "b"}},
+		{"fifo", "", 150, []string{"a", "b"}},
+		{"fifo", "de-DE", 1000, []string{"d"}},
+		{"lru", "", 0, nil},
+		{"lru", "fr-FR", 100, nil},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%s/%d", tt.policy, tt.languageCode, tt.bytesToFree), func(t *testing.T) {
+			policy, err := NewEvictionPolicy(tt.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := policy.SelectEvictionCandidates(cache.db, tt.languageCode, tt.bytesToFree)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("candidates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEvictionPolicyUnknown(t *testing.T) {
+	if _, err := NewEvictionPolicy("random"); err == nil {
+		t.Error("NewEvictionPolicy(\"random\") succeeded, want an error")
+	}
+}
+
+func BenchmarkEvictionPolicies(b *testing.B) {
+	const entries = 10000
+	const entrySize = 1000
+
+	cache := newTestCache(b)
+	tx, err := cache.db.Begin()
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < entries; i++ {
+		_, err := tx.Exec(
+			`INSERT INTO audio_cache (cache_key, text, language_code, audio_data, audio_size, created_at, last_accessed, hit_count)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			fmt.Sprintf("key%d", i), fmt.Sprintf("text %d", i), []string{"en-US", "de-DE"}[i%2],
+			[]byte{0}, entrySize, int64(i), int64((i*7919)%entries), int64(i%13))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		b.Fatal(err)
+	}
+
+	// Free a tenth of the cache, as a typical eviction after a burst of writes would
+	for _, name := range []string{"lru", "lfu", "fifo"} {
+		b.Run(name, func(b *testing.B) {
+			policy, err := NewEvictionPolicy(name)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for i := 0; i < b.N; i++ {
+				keys, err := policy.SelectEvictionCandidates(cache.db, "", entries*entrySize/10)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(keys) != entries/10 {
+					b.Fatalf("selected %d candidates, want %d", len(keys), entries/10)
+				}
+			}
+		})
+	}
+}
+
+func TestLanguageQuotas(t *testing.T) {
+	const entrySize = 100 * 1024
+	const quotaBytes = 1024 * 1024
+
+	tests := []struct {
+		name        string
+		entries     map[string]int // Entries of entrySize per language
+		wantEntries map[string]int // Entries left after eviction
+	}{
+		{
+			name:    "both over quota",
+			entries: map[string]int{"en-US": 15, "fr-FR": 12, "de-DE": 20},
+			// Each is evicted down to 90% of its own quota: 9 entries of 100KB fit in 921.6KB
+			wantEntries: map[string]int{"en-US": 9, "fr-FR": 9, "de-DE": 20},
+		},
+		{
+			name:        "one over quota, one under",
+			entries:     map[string]int{"en-US": 15, "fr-FR": 8, "de-DE": 20},
+			wantEntries: map[string]int{"en-US": 9, "fr-FR": 8, "de-DE": 20},
+		},
+		{
+			name:        "both under quota",
+			entries:     map[string]int{"en-US": 10, "fr-FR": 5},
+			wantEntries: map[string]int{"en-US": 10, "fr-FR": 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// de-DE has no quota, and there is no global limit
+			cache, err := NewCache(filepath.Join(t.TempDir(), "cache.db"), CompressionNone, 0, 0, LRUEviction{},
+				map[string]int{"en-US": 1, "fr-FR": 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cache.Close()
+
+			// Each language's entries are accessed in order, so LRU evicts its first ones
+			accessed := int64(0)
+			for lang, n := range tt.entries {
+				for i := 0; i < n; i++ {
+					accessed++
+					insertEntry(t, cache.db, fmt.Sprintf("%s-%02d", lang, i), lang, entrySize, 1, accessed, 0)
+				}
+			}
+			cache.evictIfNeeded()
+
+			for lang, want := range tt.wantEntries {
+				var keys []string
+				rows, err := cache.db.Query(`SELECT cache_key FROM audio_cache WHERE language_code = ? ORDER BY cache_key`, lang)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for rows.Next() {
+					var key string
+					rows.Scan(&key)
+					keys = append(keys, key)
+				}
+				rows.Close()
+
+				if len(keys) != want {
+					t.Errorf("%s has %d entries, want %d", lang, len(keys), want)
+				}
+				// The survivors are the most recently used of the language
+				if first := fmt.Sprintf("%s-%02d", lang, tt.entries[lang]-want); len(keys) > 0 && keys[0] != first {
+					t.Errorf("%s's oldest remaining entry is %s, want %s", lang, keys[0], first)
+				}
+
+				size, err := cache.GetLanguageSize(lang)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if size != int64(want*entrySize) {
+					t.Errorf("GetLanguageSize(%s) = %d, want %d", lang, size, want*entrySize)
+				}
+				if tt.entries[lang] > want && size > quotaBytes {
+					t.Errorf("%s is still over its quota", lang)
+				}
+			}
+		})
+	}
+}
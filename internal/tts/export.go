@@ -0,0 +1,109 @@
+package tts
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// exportMetadata is the JSON sidecar written next to each entry's audio by Export
+type exportMetadata struct {
+	Text         string   `json:"text"`
+	LanguageCode string   `json:"language_code"`
+	CreatedAt    int64    `json:"created_at"`
+	Namespace    string   `json:"namespace,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	ExpiresAt    int64    `json:"expires_at,omitempty"`
+}
+
+// Export writes every unexpired entry in namespace to w as a zip archive holding <cache key>.<extension>
+// with the decoded audio and <cache key>.json with its metadata. The audio isn't deflated when
+// compression is enabled, as the formats cached compressed don't shrink any further.
+func (c *Cache) Export(w io.Writer, namespace string) error {
+	rows, err := c.db.Query(`SELECT cache_key FROM audio_cache WHERE namespace = ? ORDER BY cache_key`, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to query cache: %w", err)
+	}
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan cache entry: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to query cache: %w", err)
+	}
+
+	audioMethod := zip.Deflate
+	if c.compression != CompressionNone {
+		audioMethod = zip.Store
+	}
+
+	archive := zip.NewWriter(w)
+	for _, key := range keys {
+		audio, err := c.GetByKey(key)
+		if err != nil {
+			return fmt.Errorf("failed to read entry %s: %w", key, err)
+		}
+		if audio == nil || c.expired(audio.CreatedAt, audio.ExpiresAt) {
+			continue // Deleted since the keys were listed, or expired
+		}
+
+		modified := time.Unix(audio.CreatedAt, 0)
+		f, err := archive.CreateHeader(&zip.FileHeader{Name: key + exportExtension(audio), Method: audioMethod, Modified: modified})
+		if err != nil {
+			return fmt.Errorf("failed to write entry %s: %w", key, err)
+		}
+		if _, err := f.Write(audio.AudioData); err != nil {
+			return fmt.Errorf("failed to write entry %s: %w", key, err)
+		}
+
+		tags, err := c.Tags(key)
+		if err != nil {
+			return fmt.Errorf("failed to read entry %s: %w", key, err)
+		}
+		f, err = archive.CreateHeader(&zip.FileHeader{Name: key + ".json", Method: zip.Deflate, Modified: modified})
+		if err != nil {
+			return fmt.Errorf("failed to write entry %s: %w", key, err)
+		}
+		if err := json.NewEncoder(f).Encode(exportMetadata{
+			Text:         audio.Text,
+			LanguageCode: audio.LanguageCode,
+			CreatedAt:    audio.CreatedAt,
+			Namespace:    audio.Namespace,
+			Tags:         tags,
+			ExpiresAt:    audio.ExpiresAt,
+		}); err != nil {
+			return fmt.Errorf("failed to write entry %s: %w", key, err)
+		}
+	}
+	return archive.Close()
+}
+
+// exportExtension returns the file extension for an entry's audio in an export archive
+func exportExtension(audio *CachedAudio) string {
+	switch {
+	case isWAV(audio.AudioData):
+		return ".wav"
+	case isOgg(audio.AudioData):
+		return ".ogg"
+	case audio.Format == FormatOpus24K.String():
+		return ".opus"
+	default:
+		return ".mp3"
+	}
+}
+
+// ExportCache writes namespace's entries to w as a zip archive (see Cache.Export)
+func (s *Service) ExportCache(w io.Writer, namespace string) error {
+	if err := s.cache.Export(w, namespace); err != nil {
+		return fmt.Errorf("cache export failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package tts
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+)
+
+// AudioFingerprint identifies audio by its content: the SHA-256 of the uncompressed bytes, so the
+// same clip has the same fingerprint however it is stored
+func AudioFingerprint(audioData []byte) [32]byte {
+	return sha256.Sum256(audioData)
+}
+
+// encodeFingerprint returns the audio_fingerprint column value for fingerprint
+func encodeFingerprint(fingerprint [32]byte) string {
+	return hex.EncodeToString(fingerprint[:])
+}
+
+// SetFingerprintDedup sets whether Put shares audio already cached under a different key. When
+// the synthesized audio is byte-for-byte identical to an existing entry, such as the same text
+// synthesized by another daemon instance and imported, or a phrasing that normalizes differently
+// but sounds the same, Put records the new key as an alias of the existing entry and returns the
+// existing key instead of storing a second copy. Get follows aliases, so both texts are cache
+// hits from then on, served by the one entry. The alias goes when the entry is deleted or
+// evicted.
+func (c *Cache) SetFingerprintDedup(enabled bool) {
+	c.fingerprintDedup = enabled
+}
+
+// BuildFingerprintIndex computes the fingerprint of entries cached before fingerprints were
+// recorded
+func (c *Cache) BuildFingerprintIndex() error {
+	rows, err := c.db.Query(`SELECT cache_key FROM audio_cache WHERE audio_fingerprint IS NULL`)
+	if err != nil {
+		return fmt.Errorf("failed to query entries without fingerprint: %w", err)
+	}
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan cache entry: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to query entries without fingerprint: %w", err)
+	}
+
+	// Entries are read one at a time so large caches aren't loaded into memory at once
+	for _, key := range keys {
+		audio, err := c.GetByKey(key)
+		if err != nil {
+			return fmt.Errorf("entry %s: %w", key, err)
+		}
+		if audio == nil {
+			continue // Evicted or deleted since the query
+		}
+		_, err = c.db.Exec(`UPDATE audio_cache SET audio_fingerprint = ? WHERE cache_key = ?`,
+			encodeFingerprint(AudioFingerprint(audio.AudioData)), key)
+		if err != nil {
+			return fmt.Errorf("failed to store fingerprint: %w", err)
+		}
+	}
+	return nil
+}
+
+// FindByFingerprint returns an entry in namespace whose audio has the given fingerprint, or nil if
+// there is none. Entries cached before fingerprints were recorded are only found after
+// BuildFingerprintIndex has run.
+func (c *Cache) FindByFingerprint(fingerprint [32]byte, namespace string) (*CachedAudio, error) {
+	key, err := c.keyForFingerprint(fingerprint, namespace, "")
+	if err != nil || key == "" {
+		return nil, err
+	}
+	return c.GetByKey(key)
+}
+
+// keyForFingerprint returns the key of the oldest entry in namespace other than excludeKey whose
+// audio has the given fingerprint, or "" if there is none
+func (c *Cache) keyForFingerprint(fingerprint [32]byte, namespace, excludeKey string) (string, error) {
+	var key string
+	err := c.db.QueryRow(
+		`SELECT cache_key FROM audio_cache WHERE audio_fingerprint = ? AND namespace = ? AND cache_key != ?
+		 ORDER BY created_at ASC LIMIT 1`,
+		encodeFingerprint(fingerprint), namespace, excludeKey,
+	).Scan(&key)
+
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to query fingerprint: %w", err)
+	}
+	return key, nil
+}
+
+// putAlias records cacheKey as a key for the audio stored under targetKey
+func (c *Cache) putAlias(cacheKey, targetKey string) error {
+	_, err := c.db.Exec(`INSERT OR REPLACE INTO audio_cache_aliases (cache_key, target_key) VALUES (?, ?)`, cacheKey, targetKey)
+	if err != nil {
+		return fmt.Errorf("failed to store alias: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package tts
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFingerprintDedupSharesAudio(t *testing.T) {
+	cache := newTestCache(t)
+	cache.SetFingerprintDedup(true)
+	audioData := testMP3(10)
+
+	firstKey, err := cache.Put("Hello there", "en-US", Options{}, audioData, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A different phrasing that happens to sound the same
+	secondKey, err := cache.Put("Hello world", "en-US", Options{}, audioData, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secondKey != firstKey {
+		t.Errorf("second Put returned %s, want the existing key %s", secondKey, firstKey)
+	}
+
+	var blobs int
+	if err := cache.db.QueryRow(`SELECT COUNT(*) FROM audio_cache`).Scan(&blobs); err != nil {
+		t.Fatal(err)
+	}
+	if blobs != 1 {
+		t.Errorf("%d entries stored, want 1", blobs)
+	}
+
+	for _, text := range []string{"Hello there", "Hello world"} {
+		audio, err := cache.Get(text, "en-US", Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if audio == nil {
+			t.Errorf("Get(%q) missed", text)
+			continue
+		}
+		if audio.CacheKey != firstKey || !bytes.Equal(audio.AudioData, audioData) {
+			t.Errorf("Get(%q) = entry %s with %d bytes, want %s with %d", text, audio.CacheKey, len(audio.AudioData), firstKey, len(audioData))
+		}
+	}
+
+	// Different audio gets an entry of its own
+	otherKey, err := cache.Put("Guten Tag", "de-DE", Options{}, testMP3(20), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otherKey == firstKey {
+		t.Error("different audio was deduplicated")
+	}
+
+	// The alias goes with the entry it points to
+	if _, deleted, err := cache.Delete("Hello there", "en-US", Options{}); err != nil || !deleted {
+		t.Fatalf("Delete = %v, %v, want the entry deleted", deleted, err)
+	}
+	audio, err := cache.Get("Hello world", "en-US", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if audio != nil {
+		t.Error("the alias still hits after its entry was deleted")
+	}
+}
+
+func TestFingerprintDedupAliasReplacedByOwnEntry(t *testing.T) {
+	cache := newTestCache(t)
+	cache.SetFingerprintDedup(true)
+	audioData := testMP3(10)
+
+	if _, err := cache.Put("Hello there", "en-US", Options{}, audioData, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.Put("Hello world", "en-US", Options{}, audioData, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Once the alias's text is cached with audio of its own, it no longer follows the alias
+	otherAudio := testMP3(20)
+	aliasKey, err := cache.Put("Hello world", "en-US", Options{}, otherAudio, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	audio, err := cache.Get("Hello world", "en-US", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if audio == nil || audio.CacheKey != aliasKey || !bytes.Equal(audio.AudioData, otherAudio) {
+		t.Errorf("Get returned %+v, want the entry's own audio under %s", audio, aliasKey)
+	}
+
+	// Deleting the alias without audio of its own leaves the entry in place
+	if _, err := cache.Put("Hi there", "en-US", Options{}, audioData, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, deleted, err := cache.Delete("Hi there", "en-US", Options{}); err != nil || !deleted {
+		t.Fatalf("Delete = %v, %v, want the alias deleted", deleted, err)
+	}
+	if audio, err := cache.Get("Hi there", "en-US", Options{}); err != nil || audio != nil {
+		t.Errorf("Get after deleting the alias = %v, %v, want a miss", audio, err)
+	}
+	if audio, err := cache.Get("Hello there", "en-US", Options{}); err != nil || audio == nil {
+		t.Errorf("Get of the aliased entry = %v, %v, want a hit", audio, err)
+	}
+}
@@ -0,0 +1,287 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	texttospeech "cloud.google.com/go/texttospeech/apiv1"
+	"cloud.google.com/go/texttospeech/apiv1/texttospeechpb"
+	"golang.org/x/time/rate"
+	"google.golang.org/api/option"
+)
+
+// gcloudVoiceTiers ranks the Google voice types chosen as a locale's default, best first. Other
+// types (Studio, Journey, Chirp, ...) don't all accept SSML, so they're only used when set in
+// gcloud.voices.
+var gcloudVoiceTiers = []string{"Neural2", "Wavenet", "Standard"}
+
+// GCloudClient is a Provider backed by Google Cloud Text-to-Speech
+type GCloudClient struct {
+	client       *texttospeech.Client
+	rateLimiter  *rate.Limiter
+	customVoices map[string]string // Custom voice mappings (overrides)
+	voiceCache   map[string]string // Cached locale -> voice mappings from Google
+	voices       []*texttospeechpb.Voice
+	voiceCacheMu sync.RWMutex // Protects voiceCache and voices
+}
+
+// NewGCloudClient creates a Google Cloud TTS client authenticated with the service account key
+// in credentialsFile ("" = application default credentials). region selects a regional
+// endpoint such as "eu" or "us" ("" = the global endpoint).
+func NewGCloudClient(ctx context.Context, credentialsFile, region string, maxQPS float64, customVoices map[string]string) (*GCloudClient, error) {
+	var opts []option.ClientOption
+	if credentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(credentialsFile))
+	}
+	if region != "" {
+		opts = append(opts, option.WithEndpoint(region+"-texttospeech.googleapis.com:443"))
+	}
+
+	client, err := texttospeech.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Google Cloud TTS client: %w", err)
+	}
+
+	return &GCloudClient{
+		client:       client,
+		rateLimiter:  rate.NewLimiter(rate.Limit(maxQPS), 1),
+		customVoices: customVoices,
+		voiceCache:   make(map[string]string),
+	}, nil
+}
+
+// Close closes the connection to Google
+func (g *GCloudClient) Close() error {
+	return g.client.Close()
+}
+
+// FetchVoiceList implements Provider. Each locale's default voice is its best tier's first
+// female voice, or its first voice of that tier without one.
+func (g *GCloudClient) FetchVoiceList(ctx context.Context) error {
+	resp, err := g.client.ListVoices(ctx, &texttospeechpb.ListVoicesRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list Google voices: %w", err)
+	}
+
+	type choice struct {
+		name   string
+		tier   int
+		female bool
+	}
+	best := make(map[string]choice)
+	for _, voice := range resp.Voices {
+		tier := gcloudVoiceTier(voice.Name)
+		if tier < 0 {
+			continue
+		}
+		female := voice.SsmlGender == texttospeechpb.SsmlVoiceGender_FEMALE
+		for _, locale := range voice.LanguageCodes {
+			current, exists := best[locale]
+			if !exists || tier < current.tier || (tier == current.tier && female && !current.female) {
+				best[locale] = choice{voice.Name, tier, female}
+			}
+		}
+	}
+
+	voiceCache := make(map[string]string, len(best))
+	for locale, c := range best {
+		voiceCache[locale] = c.name
+	}
+
+	g.voiceCacheMu.Lock()
+	g.voices = resp.Voices
+	g.voiceCache = voiceCache
+	g.voiceCacheMu.Unlock()
+
+	log.Printf("Loaded %d voices from Google covering %d locales", len(resp.Voices), len(voiceCache))
+	return nil
+}
+
+// gcloudVoiceTier returns the index in gcloudVoiceTiers of a voice's type, or -1 if it isn't one
+func gcloudVoiceTier(voiceName string) int {
+	for i, tier := range gcloudVoiceTiers {
+		if strings.Contains(voiceName, "-"+tier+"-") {
+			return i
+		}
+	}
+	return -1
+}
+
+// ListVoices implements Provider, listing a voice under its first language
+func (g *GCloudClient) ListVoices(ctx context.Context) ([]Voice, error) {
+	g.voiceCacheMu.RLock()
+	defer g.voiceCacheMu.RUnlock()
+
+	voices := make([]Voice, 0, len(g.voices))
+	for _, voice := range g.voices {
+		v := Voice{
+			Name:            voice.Name,
+			ShortName:       voice.Name,
+			Gender:          gcloudGender(voice.SsmlGender),
+			SampleRateHertz: strconv.Itoa(int(voice.NaturalSampleRateHertz)),
+		}
+		if len(voice.LanguageCodes) > 0 {
+			v.Locale = voice.LanguageCodes[0]
+		}
+		if tier := gcloudVoiceTier(voice.Name); tier >= 0 {
+			v.VoiceType = gcloudVoiceTiers[tier]
+		}
+		voices = append(voices, v)
+	}
+	return voices, nil
+}
+
+// gcloudGender returns the gender of a Google voice as Azure names it
+func gcloudGender(gender texttospeechpb.SsmlVoiceGender) string {
+	switch gender {
+	case texttospeechpb.SsmlVoiceGender_FEMALE:
+		return "Female"
+	case texttospeechpb.SsmlVoiceGender_MALE:
+		return "Male"
+	case texttospeechpb.SsmlVoiceGender_NEUTRAL:
+		return "Neutral"
+	default:
+		return ""
+	}
+}
+
+// VoiceCount implements Provider
+func (g *GCloudClient) VoiceCount() int {
+	g.voiceCacheMu.RLock()
+	defer g.voiceCacheMu.RUnlock()
+	return len(g.voices)
+}
+
+// Ping implements Provider by listing the voices of one language
+func (g *GCloudClient) Ping(ctx context.Context) error {
+	_, err := g.client.ListVoices(ctx, &texttospeechpb.ListVoicesRequest{LanguageCode: "en-US"})
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	return nil
+}
+
+// RateLimiterTokens implements Provider
+func (g *GCloudClient) RateLimiterTokens() float64 {
+	return g.rateLimiter.Tokens()
+}
+
+// RateLimiter implements Provider
+func (g *GCloudClient) RateLimiter() *rate.Limiter {
+	return g.rateLimiter
+}
+
+// MissingCustomVoices implements Provider
+func (g *GCloudClient) MissingCustomVoices() map[string]string {
+	g.voiceCacheMu.RLock()
+	defer g.voiceCacheMu.RUnlock()
+
+	available := make(map[string]bool, len(g.voices))
+	for _, voice := range g.voices {
+		available[voice.Name] = true
+	}
+
+	missing := make(map[string]string)
+	for locale, voice := range g.customVoices {
+		if !available[voice] {
+			missing[locale] = voice
+		}
+	}
+	return missing
+}
+
+// VoiceName implements Provider (see selectVoice)
+func (g *GCloudClient) VoiceName(languageCode string) (string, error) {
+	g.voiceCacheMu.RLock()
+	defer g.voiceCacheMu.RUnlock()
+	return selectVoice(languageCode, g.customVoices, g.voiceCache)
+}
+
+// DefaultVoices implements Provider
+func (g *GCloudClient) DefaultVoices() map[string]string {
+	g.voiceCacheMu.RLock()
+	defer g.voiceCacheMu.RUnlock()
+
+	voices := make(map[string]string, len(g.voiceCache))
+	for locale, voice := range g.voiceCache {
+		voices[locale] = voice
+	}
+	return voices
+}
+
+// Synthesize implements Provider
+func (g *GCloudClient) Synthesize(ctx context.Context, text, languageCode string, opts Options) ([]byte, error) {
+	if opts.SSML {
+		return nil, fmt.Errorf("Google Cloud TTS doesn't support SSML input")
+	}
+	audioConfig, err := gcloudAudioConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter error: %w", err)
+	}
+
+	locale := voiceLocale(languageCode, opts)
+	voiceName, err := voiceFor(g, languageCode, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voice for language %s: %w", locale, err)
+	}
+
+	resp, err := g.client.SynthesizeSpeech(ctx, &texttospeechpb.SynthesizeSpeechRequest{
+		Input: &texttospeechpb.SynthesisInput{
+			InputSource: &texttospeechpb.SynthesisInput_Ssml{
+				Ssml: "<speak>" + insertBreaks(escapeXML(text), opts) + "</speak>",
+			},
+		},
+		Voice: &texttospeechpb.VoiceSelectionParams{
+			LanguageCode: gcloudVoiceLanguage(voiceName, locale),
+			Name:         voiceName,
+		},
+		AudioConfig: audioConfig,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Google API error: %w", err)
+	}
+
+	if len(resp.AudioContent) == 0 {
+		return nil, fmt.Errorf("synthesis produced no audio data")
+	}
+	return resp.AudioContent, nil
+}
+
+// gcloudAudioConfig returns the Google audio encoding for the options' format. Google encodes
+// MP3 at 32kbps, so only the sample rate of the MP3 quality is honored.
+func gcloudAudioConfig(opts Options) (*texttospeechpb.AudioConfig, error) {
+	switch opts.Format {
+	case FormatMP3:
+		return &texttospeechpb.AudioConfig{
+			AudioEncoding:   texttospeechpb.AudioEncoding_MP3,
+			SampleRateHertz: int32(opts.mp3Quality().sampleRateHz),
+		}, nil
+	case FormatWAV16K:
+		// LINEAR16 audio comes with a WAV header
+		return &texttospeechpb.AudioConfig{AudioEncoding: texttospeechpb.AudioEncoding_LINEAR16, SampleRateHertz: 16000}, nil
+	case FormatOggOpus48K:
+		return &texttospeechpb.AudioConfig{AudioEncoding: texttospeechpb.AudioEncoding_OGG_OPUS, SampleRateHertz: 48000}, nil
+	case FormatOggOpus24K:
+		return &texttospeechpb.AudioConfig{AudioEncoding: texttospeechpb.AudioEncoding_OGG_OPUS, SampleRateHertz: 24000}, nil
+	default:
+		return nil, fmt.Errorf("Google Cloud TTS doesn't support %s audio", opts.Format)
+	}
+}
+
+// gcloudVoiceLanguage returns the language code a Google voice name starts with, such as "en-US"
+// for "en-US-Neural2-F", or fallback if it doesn't look like one
+func gcloudVoiceLanguage(voiceName, fallback string) string {
+	parts := strings.SplitN(voiceName, "-", 3)
+	if len(parts) < 3 {
+		return fallback
+	}
+	return parts[0] + "-" + parts[1]
+}
@@ -0,0 +1,73 @@
+package tts
+
+import (
+	"fmt"
+	"time"
+)
+
+// HeatmapBucket counts cache entries last accessed in one time-of-day interval (UTC)
+type HeatmapBucket struct {
+	HourOfDay     int
+	MinuteOfHour  int   // Start of the interval within the hour
+	AccessCount   int64 // Entries last accessed in the interval
+	CacheHitCount int64 // Of those, entries served from the cache at least once
+}
+
+// AccessHeatmap buckets entries by the time of day (UTC) they were last accessed, over entries
+// accessed in the last daysBack days. granularityMinutes must divide an hour; every interval of
+// the day is returned, in order, including empty ones.
+//
+// Only the most recent access of each entry is recorded, so this shows when the cache is in use
+// rather than counting every access.
+func (c *Cache) AccessHeatmap(granularityMinutes, daysBack int) ([]HeatmapBucket, error) {
+	if granularityMinutes <= 0 || 60%granularityMinutes != 0 {
+		return nil, fmt.Errorf("granularity must divide an hour: %d minutes", granularityMinutes)
+	}
+	if daysBack <= 0 {
+		return nil, fmt.Errorf("days back must be positive: %d", daysBack)
+	}
+
+	interval := int64(granularityMinutes * 60)
+	since := time.Now().Add(-time.Duration(daysBack) * 24 * time.Hour).Unix()
+
+	rows, err := c.db.Query(
+		`SELECT (last_accessed % 86400) / ?, COUNT(*), SUM(CASE WHEN hit_count > 0 THEN 1 ELSE 0 END)
+		 FROM audio_cache WHERE last_accessed >= ?
+		 GROUP BY 1`,
+		interval, since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query access times: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := make([]HeatmapBucket, 24*60/granularityMinutes)
+	for i := range buckets {
+		start := i * granularityMinutes
+		buckets[i].HourOfDay = start / 60
+		buckets[i].MinuteOfHour = start % 60
+	}
+
+	for rows.Next() {
+		var index int
+		var accesses, hits int64
+		if err := rows.Scan(&index, &accesses, &hits); err != nil {
+			return nil, fmt.Errorf("failed to scan access times: %w", err)
+		}
+		if index < 0 || index >= len(buckets) {
+			continue // Negative timestamps
+		}
+		buckets[index].AccessCount = accesses
+		buckets[index].CacheHitCount = hits
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query access times: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// AccessHeatmap returns when cache entries were last accessed (see Cache.AccessHeatmap)
+func (s *Service) AccessHeatmap(granularityMinutes, daysBack int) ([]HeatmapBucket, error) {
+	return s.cache.AccessHeatmap(granularityMinutes, daysBack)
+}
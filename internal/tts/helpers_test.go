@@ -0,0 +1,74 @@
+package tts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testAudioDir holds the recorded audio served by the mock provider
+const testAudioDir = "../../testdata/mock_audio"
+
+// newTestCache returns an empty, uncompressed cache without a size limit in a temporary
+// directory, closed when the test ends
+func newTestCache(t testing.TB) *Cache {
+	t.Helper()
+	policy, err := NewEvictionPolicy("lru")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := NewCache(filepath.Join(t.TempDir(), "cache.db"), CompressionNone, 0, 0, policy, nil)
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+	t.Cleanup(func() { cache.Close() })
+	return cache
+}
+
+// readTestAudio returns the recorded MP3 for languageCode
+func readTestAudio(t testing.TB, languageCode string) []byte {
+	t.Helper()
+	audioData, err := os.ReadFile(filepath.Join(testAudioDir, languageCode+".mp3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return audioData
+}
+
+// mockProvider is a Provider for Service tests. It serves the mock client's recorded audio, or
+// what audio returns when it is set, after delay, and counts its synthesis calls.
+type mockProvider struct {
+	*MockAzureClient
+	delay time.Duration
+	audio func(text, languageCode string) ([]byte, error)
+	calls atomic.Int32
+}
+
+// newMockProvider returns a mockProvider with the mock client's voices loaded
+func newMockProvider(t testing.TB) *mockProvider {
+	t.Helper()
+	client := NewMockAzureClient(testAudioDir, 1000, nil)
+	if err := client.FetchVoiceList(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return &mockProvider{MockAzureClient: client}
+}
+
+// Synthesize implements Provider
+func (p *mockProvider) Synthesize(ctx context.Context, text, languageCode string, opts Options) ([]byte, error) {
+	p.calls.Add(1)
+	if p.delay > 0 {
+		select {
+		case <-time.After(p.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if p.audio != nil {
+		return p.audio(text, languageCode)
+	}
+	return p.MockAzureClient.Synthesize(ctx, text, languageCode, opts)
+}
@@ -0,0 +1,131 @@
+package tts
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// hitRateSeconds is the longest window hit rates are reported for, with one bucket per second
+const hitRateSeconds = 5 * 60
+
+// insufficientData replaces the 1-minute hit rate in GetCacheStats until the daemon has been
+// running for a minute
+const insufficientData = "insufficient data"
+
+// HitRate is the share of GetAudio requests served from the cache, from 0 to 1 (0 without
+// requests)
+type HitRate struct {
+	OneMinute   float64
+	FiveMinutes float64
+	Total       float64 // Since the daemon started
+}
+
+// hitBucket counts the hits and misses of one second
+type hitBucket struct {
+	second atomic.Int64 // Unix time the counts are for
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// hitCounter counts cache hits and misses in a ring of per-second buckets covering the last
+// hitRateSeconds, using atomics only so that concurrent requests don't contend for a lock. A
+// bucket is reused by resetting it when a request arrives in a later second, so counts recorded
+// around that moment can be lost; the rates are approximate.
+type hitCounter struct {
+	buckets [hitRateSeconds]hitBucket
+	hits    atomic.Int64
+	misses  atomic.Int64
+}
+
+// record counts a request at Unix time now
+func (h *hitCounter) record(now int64, hit bool) {
+	b := &h.buckets[now%hitRateSeconds]
+	if second := b.second.Load(); second != now && b.second.CompareAndSwap(second, now) {
+		b.hits.Store(0)
+		b.misses.Store(0)
+	}
+	if hit {
+		b.hits.Add(1)
+		h.hits.Add(1)
+	} else {
+		b.misses.Add(1)
+		h.misses.Add(1)
+	}
+}
+
+// rates returns the hit rates as of Unix time now
+func (h *hitCounter) rates(now int64) HitRate {
+	var hits1m, misses1m, hits5m, misses5m int64
+	for i := range h.buckets {
+		b := &h.buckets[i]
+		age := now - b.second.Load()
+		if age < 0 || age >= hitRateSeconds {
+			continue
+		}
+		hits, misses := b.hits.Load(), b.misses.Load()
+		hits5m += hits
+		misses5m += misses
+		if age < 60 {
+			hits1m += hits
+			misses1m += misses
+		}
+	}
+	return HitRate{
+		OneMinute:   hitRate(hits1m, misses1m),
+		FiveMinutes: hitRate(hits5m, misses5m),
+		Total:       hitRate(h.hits.Load(), h.misses.Load()),
+	}
+}
+
+// hitRate returns hits as a share of all requests, or 0 without any
+func hitRate(hits, misses int64) float64 {
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
+
+// hitRates tracks cache hit rates overall and for each language code
+type hitRates struct {
+	started   time.Time
+	total     hitCounter
+	languages sync.Map // Language code -> *hitCounter
+}
+
+func newHitRates() *hitRates {
+	return &hitRates{started: time.Now()}
+}
+
+// record counts a request for languageCode that was served from the cache (hit) or not
+func (r *hitRates) record(languageCode string, hit bool) {
+	now := time.Now().Unix()
+	r.total.record(now, hit)
+
+	counter, ok := r.languages.Load(languageCode)
+	if !ok {
+		counter, _ = r.languages.LoadOrStore(languageCode, &hitCounter{})
+	}
+	counter.(*hitCounter).record(now, hit)
+}
+
+// addTo adds the hit rates to stats as returned by GetCacheStats: hit_rate_1m, hit_rate_5m and
+// hit_rate_total overall, and a HitRate for each language code under "language_hit_rates"
+func (r *hitRates) addTo(stats map[string]interface{}) {
+	now := time.Now().Unix()
+	total := r.total.rates(now)
+	if time.Since(r.started) < time.Minute {
+		stats["hit_rate_1m"] = insufficientData
+	} else {
+		stats["hit_rate_1m"] = total.OneMinute
+	}
+	stats["hit_rate_5m"] = total.FiveMinutes
+	stats["hit_rate_total"] = total.Total
+
+	languages := make(map[string]HitRate)
+	r.languages.Range(func(key, value any) bool {
+		languages[key.(string)] = value.(*hitCounter).rates(now)
+		return true
+	})
+	stats["language_hit_rates"] = languages
+}
@@ -0,0 +1,334 @@
+package tts
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/metrics"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Hot cache backends
+const (
+	HotCacheMemory = "memory"
+	HotCacheBolt   = "bbolt"
+)
+
+const (
+	// hotCacheWindow is how long a hot entry has to reach the threshold again to stay hot
+	hotCacheWindow = 24 * time.Hour
+
+	// hotCacheCheckInterval is how often hot entries are checked for demotion
+	hotCacheCheckInterval = time.Hour
+)
+
+// hotBucket is the bbolt bucket holding hot entries
+var hotBucket = []byte("entries")
+
+// hotStore holds copies of frequently accessed entries, with their audio decompressed, so they
+// can be served without querying SQLite
+type hotStore interface {
+	get(cacheKey string) (*CachedAudio, error) // nil if the entry isn't hot
+	put(audio *CachedAudio) error
+	remove(cacheKey string) error
+	keys() ([]string, error)
+	close() error
+}
+
+// hotEntry tracks how often a hot entry has been served since its window started
+type hotEntry struct {
+	hits  atomic.Int64
+	since time.Time
+}
+
+// hotCache is the second cache level in front of SQLite (see Cache.SetHotCache)
+type hotCache struct {
+	store     hotStore
+	threshold int // Accesses per day to promote an entry, and to keep it
+
+	mu      sync.Mutex
+	entries map[string]*hotEntry
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// SetHotCache adds a second cache level that keeps entries accessed more than threshold times a
+// day (by hit count since they were cached) in memory or, with the bbolt backend, in a bbolt file
+// next to the database. A hot entry that isn't accessed threshold times within a day is demoted
+// again; checks run hourly, each entry's day starting when it was promoted.
+func (c *Cache) SetHotCache(backend string, threshold int) error {
+	var store hotStore
+	switch backend {
+	case HotCacheMemory:
+		store = &memoryHotStore{}
+	case HotCacheBolt:
+		db, err := bolt.Open(c.path+".hot", 0600, &bolt.Options{Timeout: time.Second})
+		if err != nil {
+			return fmt.Errorf("failed to open hot cache: %w", err)
+		}
+		err = db.Update(func(tx *bolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(hotBucket)
+			return err
+		})
+		if err != nil {
+			db.Close()
+			return fmt.Errorf("failed to create hot cache bucket: %w", err)
+		}
+		store = &boltHotStore{db: db}
+	default:
+		return fmt.Errorf("unknown hot cache backend %q (expected %s or %s)", backend, HotCacheMemory, HotCacheBolt)
+	}
+	if threshold <= 0 {
+		store.close()
+		return fmt.Errorf("hot cache threshold must be positive: %d", threshold)
+	}
+
+	hot := &hotCache{
+		store:     store,
+		threshold: threshold,
+		entries:   make(map[string]*hotEntry),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	// Entries kept from the last run start a new window, unless they were deleted meanwhile
+	keys, err := store.keys()
+	if err != nil {
+		store.close()
+		return err
+	}
+	now := time.Now()
+	for _, key := range keys {
+		exists, err := c.HasKey(key)
+		if err != nil {
+			store.close()
+			return err
+		}
+		if !exists {
+			store.remove(key)
+			continue
+		}
+		hot.entries[key] = &hotEntry{since: now}
+	}
+
+	c.hot = hot
+	go c.runHotCacheChecks()
+	log.Printf("Cache: %s hot cache enabled, threshold=%d accesses/day, %d entries", backend, threshold, len(hot.entries))
+	return nil
+}
+
+// getHot returns the hot copy of the entry stored under cacheKey, or nil
+func (c *Cache) getHot(cacheKey string) *CachedAudio {
+	c.hot.mu.Lock()
+	entry, ok := c.hot.entries[cacheKey]
+	c.hot.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	audio, err := c.hot.store.get(cacheKey)
+	if err != nil {
+		log.Printf("Warning: hot cache lookup failed: %v", err)
+		return nil
+	}
+	if audio != nil {
+		entry.hits.Add(1)
+		metrics.HotCacheHits.Inc()
+	}
+	return audio
+}
+
+// recordHotAccess updates an entry's access time and hit count like updateLastAccessed, and
+// promotes it to the hot cache if it has been accessed often enough since it was cached
+func (c *Cache) recordHotAccess(audio CachedAudio, timestamp int64) {
+	var hitCount, createdAt int64
+	err := c.db.QueryRow(
+		`UPDATE audio_cache SET last_accessed = ?, hit_count = hit_count + 1 WHERE cache_key = ?
+		 RETURNING hit_count, created_at`,
+		timestamp, audio.CacheKey,
+	).Scan(&hitCount, &createdAt)
+	if err != nil {
+		return // A background optimization, like updateLastAccessed
+	}
+
+	days := max(1, float64(timestamp-createdAt)/86400)
+	if float64(hitCount)/days < float64(c.hot.threshold) {
+		return
+	}
+
+	c.hot.mu.Lock()
+	defer c.hot.mu.Unlock()
+	if _, ok := c.hot.entries[audio.CacheKey]; ok {
+		return
+	}
+	audio.Compression.Valid = false // The hot copy is stored decompressed
+	if err := c.hot.store.put(&audio); err != nil {
+		log.Printf("Warning: failed to promote %s to the hot cache: %v", audio.CacheKey, err)
+		return
+	}
+	c.hot.entries[audio.CacheKey] = &hotEntry{since: time.Now()}
+}
+
+// dropHot removes entries from the hot cache, e.g. because they were deleted or replaced
+func (c *Cache) dropHot(keys ...string) {
+	if c.hot == nil {
+		return
+	}
+	c.hot.mu.Lock()
+	defer c.hot.mu.Unlock()
+	for _, key := range keys {
+		if _, ok := c.hot.entries[key]; !ok {
+			continue
+		}
+		delete(c.hot.entries, key)
+		if err := c.hot.store.remove(key); err != nil {
+			log.Printf("Warning: failed to remove %s from the hot cache: %v", key, err)
+		}
+	}
+}
+
+// runHotCacheChecks demotes hot entries that weren't accessed threshold times in their last day
+func (c *Cache) runHotCacheChecks() {
+	defer close(c.hot.done)
+	ticker := time.NewTicker(hotCacheCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.hot.stop:
+			return
+		}
+
+		now := time.Now()
+		var cold []string
+		c.hot.mu.Lock()
+		for key, entry := range c.hot.entries {
+			if now.Sub(entry.since) < hotCacheWindow {
+				continue
+			}
+			if entry.hits.Load() < int64(c.hot.threshold) {
+				cold = append(cold, key)
+				continue
+			}
+			entry.hits.Store(0)
+			entry.since = now
+		}
+		c.hot.mu.Unlock()
+
+		if len(cold) > 0 {
+			c.dropHot(cold...)
+			log.Printf("Cache: demoted %d entries from the hot cache", len(cold))
+		}
+	}
+}
+
+// closeHot stops the demotion checks and closes the hot cache's store
+func (c *Cache) closeHot() {
+	if c.hot == nil {
+		return
+	}
+	close(c.hot.stop)
+	<-c.hot.done
+	c.hot.store.close()
+}
+
+// memoryHotStore keeps hot entries in memory; they are lost when the daemon stops
+type memoryHotStore struct {
+	entries sync.Map // cache key -> *CachedAudio
+}
+
+func (m *memoryHotStore) get(cacheKey string) (*CachedAudio, error) {
+	audio, ok := m.entries.Load(cacheKey)
+	if !ok {
+		return nil, nil
+	}
+	copied := *audio.(*CachedAudio)
+	return &copied, nil
+}
+
+func (m *memoryHotStore) put(audio *CachedAudio) error {
+	copied := *audio
+	copied.AudioData = bytes.Clone(audio.AudioData) // Callers may still be using the original
+	m.entries.Store(audio.CacheKey, &copied)
+	return nil
+}
+
+func (m *memoryHotStore) remove(cacheKey string) error {
+	m.entries.Delete(cacheKey)
+	return nil
+}
+
+func (m *memoryHotStore) keys() ([]string, error) {
+	var keys []string
+	m.entries.Range(func(key, _ any) bool {
+		keys = append(keys, key.(string))
+		return true
+	})
+	return keys, nil
+}
+
+func (m *memoryHotStore) close() error {
+	return nil
+}
+
+// boltHotStore keeps hot entries in a bbolt file, so they stay hot across restarts
+type boltHotStore struct {
+	db *bolt.DB
+}
+
+func (b *boltHotStore) get(cacheKey string) (*CachedAudio, error) {
+	var audio *CachedAudio
+	err := b.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(hotBucket).Get([]byte(cacheKey))
+		if value == nil {
+			return nil
+		}
+		audio = &CachedAudio{}
+		return gob.NewDecoder(bytes.NewReader(value)).Decode(audio)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hot entry: %w", err)
+	}
+	return audio, nil
+}
+
+func (b *boltHotStore) put(audio *CachedAudio) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(audio); err != nil {
+		return fmt.Errorf("failed to encode hot entry: %w", err)
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(hotBucket).Put([]byte(audio.CacheKey), buf.Bytes())
+	})
+}
+
+func (b *boltHotStore) remove(cacheKey string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(hotBucket).Delete([]byte(cacheKey))
+	})
+}
+
+func (b *boltHotStore) keys() ([]string, error) {
+	var keys []string
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(hotBucket).ForEach(func(key, _ []byte) error {
+			keys = append(keys, string(key))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hot entries: %w", err)
+	}
+	return keys, nil
+}
+
+func (b *boltHotStore) close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,256 @@
+package tts
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// zstdMagic starts every zstd frame
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// importEntry is an entry of an archive being imported
+type importEntry struct {
+	audio    *zip.File
+	metadata exportMetadata
+	hasMeta  bool
+}
+
+// Import adds the entries of an archive written by Export, keeping entries that are already
+// cached, and returns how many it imported and how many it skipped as already cached. Every
+// entry's metadata is checked, including that it belongs to namespace, before anything is
+// imported. Audio is stored compressed or not as this cache is configured, whichever way it was
+// archived.
+func (c *Cache) Import(r io.Reader, namespace string) (imported, skipped int, err error) {
+	// Reading a zip archive needs random access, so it is spooled to a file first
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".import-*.zip")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read archive: %w", err)
+	}
+	archive, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	entries, keys, err := readImportEntries(archive)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, key := range keys {
+		if entries[key].metadata.Namespace != namespace {
+			return 0, 0, fmt.Errorf("entry %s belongs to namespace %q, not %q", key, entries[key].metadata.Namespace, namespace)
+		}
+	}
+
+	for _, key := range keys {
+		entry := entries[key]
+		audioData, err := readZipFile(entry.audio)
+		if err != nil {
+			return imported, skipped, fmt.Errorf("failed to read %s: %w", entry.audio.Name, err)
+		}
+		if bytes.HasPrefix(audioData, zstdMagic) {
+			if audioData, err = c.decoder.DecodeAll(audioData, nil); err != nil {
+				return imported, skipped, fmt.Errorf("failed to decompress %s: %w", entry.audio.Name, err)
+			}
+		}
+
+		added, err := c.importEntry(key, entry.metadata, audioData)
+		if err != nil {
+			return imported, skipped, err
+		}
+		if added {
+			imported++
+		} else {
+			skipped++
+		}
+	}
+
+	if imported > 0 {
+		c.sizeMetricsStale.Store(true)
+		if c.maxSizeBytes.Load() > 0 || len(c.languageQuotas) > 0 {
+			c.evictIfNeeded()
+		}
+	}
+	return imported, skipped, nil
+}
+
+// readImportEntries pairs up the audio and metadata files of an archive, checking every
+// metadata file, and returns the entries along with their cache keys in archive order
+func readImportEntries(archive *zip.Reader) (map[string]*importEntry, []string, error) {
+	entries := make(map[string]*importEntry)
+	var keys []string
+	entryFor := func(key string) *importEntry {
+		entry, ok := entries[key]
+		if !ok {
+			entry = &importEntry{}
+			entries[key] = entry
+			keys = append(keys, key)
+		}
+		return entry
+	}
+
+	for _, f := range archive.File {
+		ext := filepath.Ext(f.Name)
+		key := strings.TrimSuffix(f.Name, ext)
+		if decoded, err := hex.DecodeString(key); err != nil || len(decoded) != 32 {
+			return nil, nil, fmt.Errorf("%s is not named after a cache key", f.Name)
+		}
+
+		switch ext {
+		case ".json":
+			data, err := readZipFile(f)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+			}
+			metadata, err := parseExportMetadata(data)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid metadata in %s: %w", f.Name, err)
+			}
+			entry := entryFor(key)
+			entry.metadata, entry.hasMeta = metadata, true
+		case ".mp3", ".wav", ".ogg", ".opus":
+			entryFor(key).audio = f
+		default:
+			return nil, nil, fmt.Errorf("unexpected file %s in archive", f.Name)
+		}
+	}
+
+	for _, key := range keys {
+		if entries[key].audio == nil {
+			return nil, nil, fmt.Errorf("entry %s has no audio", key)
+		}
+		if !entries[key].hasMeta {
+			return nil, nil, fmt.Errorf("entry %s has no metadata", key)
+		}
+	}
+	return entries, keys, nil
+}
+
+// parseExportMetadata decodes and checks an entry's metadata file
+func parseExportMetadata(data []byte) (exportMetadata, error) {
+	var metadata exportMetadata
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&metadata); err != nil {
+		return metadata, err
+	}
+	if metadata.Text == "" {
+		return metadata, fmt.Errorf("text is required")
+	}
+	if metadata.LanguageCode == "" {
+		return metadata, fmt.Errorf("language_code is required")
+	}
+	if metadata.CreatedAt <= 0 {
+		return metadata, fmt.Errorf("created_at must be a positive Unix timestamp")
+	}
+	return metadata, nil
+}
+
+// readZipFile returns the contents of a file in a zip archive
+func readZipFile(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// importEntry stores an imported entry under cacheKey unless one is already stored there, and
+// reports whether it did
+func (c *Cache) importEntry(cacheKey string, metadata exportMetadata, audioData []byte) (bool, error) {
+	now := getCurrentTimestamp()
+	stats := ComputeTextStats(metadata.Text)
+
+	dataToStore, compression, err := c.encodeForStorage(audioData, !isWAV(audioData))
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		`INSERT OR IGNORE INTO audio_cache
+		 (cache_key, text, language_code, audio_data, audio_size, compression, created_at, last_accessed,
+		  minhash, audio_fingerprint, word_count, char_count, namespace, expires_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		cacheKey,
+		metadata.Text,
+		metadata.LanguageCode,
+		dataToStore,
+		len(dataToStore),
+		compression,
+		metadata.CreatedAt,
+		now,
+		encodeMinHash(MinHash(metadata.Text, minhashSize)),
+		encodeFingerprint(AudioFingerprint(audioData)),
+		stats.WordCount,
+		stats.CharCount,
+		metadata.Namespace,
+		sql.NullInt64{Int64: metadata.ExpiresAt, Valid: metadata.ExpiresAt != 0},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to insert into cache: %w", err)
+	}
+	added, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to insert into cache: %w", err)
+	}
+	if added == 0 {
+		return false, nil // Already cached
+	}
+	if err := insertTags(tx, cacheKey, metadata.Tags); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to insert into cache: %w", err)
+	}
+
+	if c.replay != nil {
+		c.replay.append(ReplayRecord{
+			CacheKey:     cacheKey,
+			LanguageCode: metadata.LanguageCode,
+			Text:         metadata.Text,
+			AudioSize:    uint32(len(audioData)),
+			Timestamp:    now,
+		})
+	}
+
+	c.events.publish(CacheEvent{
+		Type:         EventPut,
+		CacheKey:     cacheKey,
+		LanguageCode: metadata.LanguageCode,
+		Namespace:    metadata.Namespace,
+		AudioSize:    int64(len(audioData)),
+		Timestamp:    now,
+	})
+	return true, nil
+}
+
+// ImportCache adds the entries of an archive of namespace's entries written by ExportCache (see
+// Cache.Import)
+func (s *Service) ImportCache(r io.Reader, namespace string) (imported, skipped int, err error) {
+	imported, skipped, err = s.cache.Import(r, namespace)
+	if err != nil {
+		return imported, skipped, fmt.Errorf("cache import failed: %w", err)
+	}
+	return imported, skipped, nil
+}
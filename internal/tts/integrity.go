@@ -0,0 +1,187 @@
+package tts
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// CacheEntryRef identifies a cached text
+type CacheEntryRef struct {
+	Text         string
+	LanguageCode string
+}
+
+// CollisionGroup lists every entry stored under the same cache key
+type CollisionGroup struct {
+	CacheKey string
+	Entries  []CacheEntryRef
+}
+
+// KeyMismatch is an entry whose stored key doesn't match the key computed from its text
+// with any combination of synthesis options
+type KeyMismatch struct {
+	CacheKey     string
+	Text         string
+	LanguageCode string
+	ExpectedKey  string // Key computed with default options
+}
+
+// CollisionReport returns every cache key shared by more than one entry in namespace. cache_key
+// is the table's primary key, so a non-empty report means the schema or INSERT OR REPLACE logic
+// is broken.
+func (c *Cache) CollisionReport(namespace string) ([]CollisionGroup, error) {
+	rows, err := c.db.Query(`
+		SELECT cache_key, text, language_code FROM audio_cache
+		WHERE namespace = ? AND cache_key IN (
+			SELECT cache_key FROM audio_cache WHERE namespace = ? GROUP BY cache_key HAVING COUNT(*) > 1
+		)
+		ORDER BY cache_key`, namespace, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cache keys: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []CollisionGroup
+	for rows.Next() {
+		var key string
+		var entry CacheEntryRef
+		if err := rows.Scan(&key, &entry.Text, &entry.LanguageCode); err != nil {
+			return nil, fmt.Errorf("failed to scan cache entry: %w", err)
+		}
+		if len(groups) == 0 || groups[len(groups)-1].CacheKey != key {
+			groups = append(groups, CollisionGroup{CacheKey: key})
+		}
+		groups[len(groups)-1].Entries = append(groups[len(groups)-1].Entries, entry)
+	}
+	return groups, rows.Err()
+}
+
+// VerifyKeys recomputes the cache key of every entry in namespace from its text and language and
+// returns the entries whose stored key doesn't match, along with the number of entries checked
+func (c *Cache) VerifyKeys(namespace string) (int64, []KeyMismatch, error) {
+	rows, err := c.db.Query(`SELECT cache_key, text, language_code, voice_name FROM audio_cache WHERE namespace = ?`, namespace)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to query cache entries: %w", err)
+	}
+	defer rows.Close()
+
+	var checked int64
+	var mismatches []KeyMismatch
+	for rows.Next() {
+		var key, text, lang string
+		var voiceName sql.NullString
+		if err := rows.Scan(&key, &text, &lang, &voiceName); err != nil {
+			return 0, nil, fmt.Errorf("failed to scan cache entry: %w", err)
+		}
+		checked++
+
+		// The options an entry was synthesized with aren't stored, so accept any of them
+		if _, matched := optionsForKey(key, namespace, text, lang, voiceName.String); !matched {
+			mismatches = append(mismatches, KeyMismatch{
+				CacheKey:     key,
+				Text:         text,
+				LanguageCode: lang,
+				ExpectedKey:  GenerateCacheKey(text, lang, Options{Namespace: namespace}),
+			})
+		}
+	}
+	return checked, mismatches, rows.Err()
+}
+
+// verifySampleSize is how many randomly chosen entries Verify decodes
+const verifySampleSize = 1000
+
+// maxIntegrityErrors is how many problems PRAGMA integrity_check reports at most
+const maxIntegrityErrors = 100
+
+// Verify checks the database file for corruption, e.g. after a power outage: it runs SQLite's
+// integrity check, returning the problems it reports (none if the file is intact), and then
+// decodes a random sample of entries, returning the keys of those whose audio can't be
+// decompressed or rebuilt from its delta, along with how many it decoded
+func (c *Cache) Verify() (checked int64, integrityErrors, corruptKeys []string, err error) {
+	rows, err := c.db.Query(fmt.Sprintf(`PRAGMA integrity_check(%d)`, maxIntegrityErrors))
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to run integrity check: %w", err)
+	}
+	for rows.Next() {
+		var message string
+		if err := rows.Scan(&message); err != nil {
+			rows.Close()
+			return 0, nil, nil, fmt.Errorf("failed to scan integrity check result: %w", err)
+		}
+		if message != "ok" {
+			integrityErrors = append(integrityErrors, message)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to run integrity check: %w", err)
+	}
+
+	rows, err = c.db.Query(
+		`SELECT cache_key, audio_data, compression, delta_base_key, delta_data FROM audio_cache
+		 ORDER BY RANDOM() LIMIT ?`,
+		verifySampleSize,
+	)
+	if err != nil {
+		return 0, integrityErrors, nil, fmt.Errorf("failed to sample cache entries: %w", err)
+	}
+	var sample []CachedAudio
+	for rows.Next() {
+		var audio CachedAudio
+		if err := rows.Scan(&audio.CacheKey, &audio.AudioData, &audio.Compression, &audio.deltaBaseKey, &audio.deltaData); err != nil {
+			rows.Close()
+			return 0, integrityErrors, nil, fmt.Errorf("failed to scan cache entry: %w", err)
+		}
+		sample = append(sample, audio)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, integrityErrors, nil, fmt.Errorf("failed to sample cache entries: %w", err)
+	}
+
+	// Decoded after the query is done, since deltas read their base
+	for i := range sample {
+		audio := &sample[i]
+		if err := c.decompress(audio); err != nil {
+			corruptKeys = append(corruptKeys, audio.CacheKey)
+			continue
+		}
+		if err := c.applyStoredDelta(audio); err != nil || len(audio.AudioData) == 0 {
+			corruptKeys = append(corruptKeys, audio.CacheKey)
+		}
+	}
+	return int64(len(sample)), integrityErrors, corruptKeys, nil
+}
+
+// DeleteCorrupt deletes entries Verify found to be corrupt, returning how many it deleted. Their
+// copies in Redis or S3, if any, are kept, as they are presumably intact.
+func (c *Cache) DeleteCorrupt(keys []string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	var events []CacheEvent
+	for _, key := range keys {
+		event := CacheEvent{Type: EventDelete, CacheKey: key}
+		err := c.db.QueryRow(`SELECT language_code, namespace FROM audio_cache WHERE cache_key = ?`, key).Scan(&event.LanguageCode, &event.Namespace)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read entry %s: %w", key, err)
+		}
+		events = append(events, event)
+	}
+	deleted, err := c.deleteKeys(keys)
+	if err != nil {
+		return 0, err
+	}
+
+	now := getCurrentTimestamp()
+	for _, event := range events {
+		event.Timestamp = now
+		c.events.publish(event)
+	}
+	c.sizeMetricsStale.Store(true)
+	return deleted, nil
+}
@@ -0,0 +1,70 @@
+package tts
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// languageFamilies maps locales and languages to their closest relative (see LanguageFamilyTree)
+//
+//go:embed langfamily.json
+var languageFamilies []byte
+
+// LanguageFamilyTree links each locale or language to the closest related one, e.g. pt-AO to
+// pt-MZ, pt-MZ to pt-BR, pt-BR to pt-PT and pt-PT to pt. A language without a voice is spoken
+// by the first voice found walking up from it.
+type LanguageFamilyTree struct {
+	parents map[string]string
+}
+
+// LoadLanguageFamilyTree parses the tree embedded in the binary
+func LoadLanguageFamilyTree() (*LanguageFamilyTree, error) {
+	var parents map[string]string
+	if err := json.Unmarshal(languageFamilies, &parents); err != nil {
+		return nil, fmt.Errorf("failed to parse language families: %w", err)
+	}
+	return &LanguageFamilyTree{parents: parents}, nil
+}
+
+// Relatives returns the locales and languages related to languageCode, closest first. A locale
+// missing from the tree starts from its base language, e.g. oc-FR from oc.
+func (t *LanguageFamilyTree) Relatives(languageCode string) []string {
+	node := languageCode
+	if _, ok := t.parents[node]; !ok {
+		base, _, found := strings.Cut(languageCode, "-")
+		if !found {
+			return nil
+		}
+		node = base
+	}
+
+	var relatives []string
+	seen := map[string]bool{languageCode: true}
+	for {
+		parent, ok := t.parents[node]
+		if !ok || seen[parent] {
+			return relatives
+		}
+		seen[parent] = true
+		relatives = append(relatives, parent)
+		node = parent
+	}
+}
+
+// SetLanguageFamilyFallback sets whether a language without a voice or a fallback chain is
+// spoken by the closest related language with a voice (see LanguageFamilyTree) before falling
+// back to en-US
+func (s *Service) SetLanguageFamilyFallback(enabled bool) error {
+	if !enabled {
+		s.familyTree = nil
+		return nil
+	}
+	tree, err := LoadLanguageFamilyTree()
+	if err != nil {
+		return err
+	}
+	s.familyTree = tree
+	return nil
+}
@@ -0,0 +1,63 @@
+package tts
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLanguageFamilyFallback(t *testing.T) {
+	tree, err := LoadLanguageFamilyTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The mock has voices for en-US, en-GB, fr-FR, es-ES, es-MX, de-DE, it-IT, ja-JP and zh-CN
+	service := NewService(newTestCache(t), newMockProvider(t))
+	if err := service.SetLanguageFamilyFallback(true); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		languageCode  string
+		wantRelatives []string
+		wantLocale    string // Locale whose mock voice is used
+	}{
+		{"pt-AO", []string{"pt-MZ", "pt-BR", "pt-PT", "pt"}, "en-US"}, // Angolan Portuguese, no Portuguese voice
+		{"gl-ES", []string{"pt-PT", "pt"}, "en-US"},                   // Galician, from its base language
+		{"oc-FR", []string{"ca-ES", "es-ES", "es"}, "es-ES"},          // Occitan, through Catalan
+		{"wa", []string{"fr-BE", "fr-FR", "fr"}, "fr-FR"},             // Walloon, through Belgian French
+		{"als", []string{"de-CH", "de-DE", "de"}, "de-DE"},            // Alemannic, through Swiss German
+		{"fo", []string{"is-IS", "nb-NO", "da-DK", "da"}, "en-US"},    // Faroese, through the other West Nordic language
+		{"sco", []string{"en-GB", "en-US", "en"}, "en-GB"},            // Scots
+		{"scn", []string{"it-IT", "it"}, "it-IT"},                     // Sicilian
+		{"yue", []string{"zh-HK", "zh-CN", "zh"}, "zh-CN"},            // Cantonese, through Hong Kong Chinese
+		{"ceb", []string{"fil-PH", "fil"}, "en-US"},                   // Cebuano, through Filipino
+		{"xx-YY", nil, "en-US"},                                       // Unknown language
+	}
+	for _, tt := range tests {
+		t.Run(tt.languageCode, func(t *testing.T) {
+			if got := tree.Relatives(tt.languageCode); !slices.Equal(got, tt.wantRelatives) {
+				t.Errorf("Relatives(%s) = %v, want %v", tt.languageCode, got, tt.wantRelatives)
+			}
+			if got := service.EffectiveLocale(tt.languageCode); got != tt.wantLocale {
+				t.Errorf("EffectiveLocale(%s) = %s, want %s", tt.languageCode, got, tt.wantLocale)
+			}
+		})
+	}
+}
+
+func TestLanguageFamilyTreeIsAcyclic(t *testing.T) {
+	tree, err := LoadLanguageFamilyTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for languageCode := range tree.parents {
+		relatives := tree.Relatives(languageCode)
+		if slices.Contains(relatives, languageCode) {
+			t.Errorf("%s is its own relative: %v", languageCode, relatives)
+		}
+		// A chain that loops would end at a language with a parent
+		if last := relatives[len(relatives)-1]; tree.parents[last] != "" {
+			t.Errorf("the chain for %s ends at %s, which has a parent", languageCode, last)
+		}
+	}
+}
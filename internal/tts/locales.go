@@ -0,0 +1,92 @@
+package tts
+
+import (
+	"fmt"
+	"sort"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// LocaleInfo describes a locale the provider has a voice for, the cache has entries for, or both
+type LocaleInfo struct {
+	Locale        string
+	DisplayName   string // English name, e.g. "Canadian French" ("" if unknown)
+	HasVoice      bool   // The provider has a voice of its own for the locale
+	DefaultVoice  string // The provider's default voice for the locale ("" without one)
+	CachedEntries int64
+	CachedBytes   int64 // Stored (possibly compressed) size of the entries
+}
+
+// localeUsage is the number and stored size of cache entries for one language code
+type localeUsage struct {
+	entries, bytes int64
+}
+
+// languageUsage returns the number and stored size of entries for each language code in namespace
+func (c *Cache) languageUsage(namespace string) (map[string]localeUsage, error) {
+	rows, err := c.db.Query(`SELECT language_code, COUNT(*), SUM(audio_size) FROM audio_cache WHERE namespace = ? GROUP BY language_code`, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query languages: %w", err)
+	}
+	defer rows.Close()
+
+	usage := make(map[string]localeUsage)
+	for rows.Next() {
+		var lang string
+		var u localeUsage
+		if err := rows.Scan(&lang, &u.entries, &u.bytes); err != nil {
+			return nil, fmt.Errorf("failed to scan language: %w", err)
+		}
+		usage[lang] = u
+	}
+	return usage, rows.Err()
+}
+
+// ListLocales merges the locales the provider has voices for with the language codes of
+// namespace's cache entries, sorted by locale. withVoice keeps only locales with a voice and
+// withCache only those with cached entries.
+func (s *Service) ListLocales(namespace string, withVoice, withCache bool) ([]LocaleInfo, error) {
+	usage, err := s.cache.languageUsage(namespace)
+	if err != nil {
+		return nil, err
+	}
+	voices := s.provider.DefaultVoices()
+
+	locales := make(map[string]bool, len(voices)+len(usage))
+	for locale := range voices {
+		locales[locale] = true
+	}
+	for locale := range usage {
+		locales[locale] = true
+	}
+
+	var infos []LocaleInfo
+	for locale := range locales {
+		voice, hasVoice := voices[locale]
+		u, hasCache := usage[locale]
+		if (withVoice && !hasVoice) || (withCache && !hasCache) {
+			continue
+		}
+		infos = append(infos, LocaleInfo{
+			Locale:        locale,
+			DisplayName:   localeDisplayName(locale),
+			HasVoice:      hasVoice,
+			DefaultVoice:  voice,
+			CachedEntries: u.entries,
+			CachedBytes:   u.bytes,
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Locale < infos[j].Locale })
+	return infos, nil
+}
+
+// localeDisplayName returns the English name of a locale, or "" if it isn't a known language tag
+func localeDisplayName(locale string) string {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return ""
+	}
+	return display.English.Tags().Name(tag)
+}
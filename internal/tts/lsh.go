@@ -0,0 +1,252 @@
+package tts
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Near-duplicate detection settings
+const (
+	minhashSize  = 8 // Hash values stored per entry
+	minhashBands = 4 // Entries sharing all values of any band are compared
+	minhashRows  = minhashSize / minhashBands
+	shingleSize  = 3 // Characters per shingle
+)
+
+// minhashSeeds holds the seeds of the hash functions. They are generated from a fixed seed so
+// signatures stored in the database stay comparable across restarts.
+var minhashSeeds = func() []uint64 {
+	r := rand.New(rand.NewSource(0x7a5e1f))
+	seeds := make([]uint64, 64)
+	for i := range seeds {
+		seeds[i] = r.Uint64()
+	}
+	return seeds
+}()
+
+// MinHash returns the MinHash signature of text over its character 3-grams, using numHashes
+// hash functions (at most 64). Case, punctuation and spacing are ignored, so differently
+// punctuated phrasings of the same sentence get the same signature. The fraction of equal
+// values in two signatures estimates the Jaccard similarity of the texts' 3-gram sets.
+func MinHash(text string, numHashes int) []uint64 {
+	if numHashes > len(minhashSeeds) {
+		numHashes = len(minhashSeeds)
+	}
+
+	signature := make([]uint64, numHashes)
+	for i := range signature {
+		signature[i] = ^uint64(0)
+	}
+
+	for _, shingle := range shingles(text) {
+		h := fnv.New64a()
+		h.Write([]byte(shingle))
+		base := h.Sum64()
+		for i := range signature {
+			if v := mix64(base ^ minhashSeeds[i]); v < signature[i] {
+				signature[i] = v
+			}
+		}
+	}
+	return signature
+}
+
+// shingles returns the distinct character 3-grams of text after lowercasing it and reducing
+// punctuation and whitespace to single spaces. Text shorter than a shingle is one shingle.
+func shingles(text string) []string {
+	text = strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return unicode.IsPunct(r) || unicode.IsSpace(r)
+	}), " ")
+
+	runes := []rune(text)
+	if len(runes) <= shingleSize {
+		return []string{text}
+	}
+
+	seen := make(map[string]bool)
+	var out []string
+	for i := 0; i+shingleSize <= len(runes); i++ {
+		s := string(runes[i : i+shingleSize])
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// mix64 is the splitmix64 finalizer, used to turn one hash into many independent ones
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// encodeMinHash formats the stored part of a signature for the minhash column
+func encodeMinHash(signature []uint64) string {
+	parts := make([]string, 0, minhashSize)
+	for _, v := range signature[:minhashSize] {
+		parts = append(parts, strconv.FormatUint(v, 16))
+	}
+	return strings.Join(parts, " ")
+}
+
+// decodeMinHash parses a minhash column value
+func decodeMinHash(s string) ([]uint64, error) {
+	parts := strings.Fields(s)
+	if len(parts) != minhashSize {
+		return nil, fmt.Errorf("expected %d minhash values, got %d", minhashSize, len(parts))
+	}
+	signature := make([]uint64, len(parts))
+	for i, p := range parts {
+		v, err := strconv.ParseUint(p, 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minhash value %q: %w", p, err)
+		}
+		signature[i] = v
+	}
+	return signature, nil
+}
+
+// minhashSimilarity estimates the Jaccard similarity of two signatures
+func minhashSimilarity(a, b []uint64) float64 {
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
+
+// backfillMinHashes computes the minhash of entries stored before the column existed
+func (c *Cache) backfillMinHashes() error {
+	rows, err := c.db.Query(`SELECT cache_key, text FROM audio_cache WHERE minhash IS NULL`)
+	if err != nil {
+		return fmt.Errorf("failed to query entries without minhash: %w", err)
+	}
+	pending := make(map[string]string)
+	for rows.Next() {
+		var key, text string
+		if err := rows.Scan(&key, &text); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan cache entry: %w", err)
+		}
+		pending[key] = text
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to query entries without minhash: %w", err)
+	}
+
+	for key, text := range pending {
+		_, err := c.db.Exec(`UPDATE audio_cache SET minhash = ? WHERE cache_key = ?`, encodeMinHash(MinHash(text, minhashSize)), key)
+		if err != nil {
+			return fmt.Errorf("failed to store minhash: %w", err)
+		}
+	}
+	return nil
+}
+
+// FindNearDuplicates returns groups of entries in namespace and the same language whose texts
+// have an estimated Jaccard similarity above threshold, such as "Hello, how are you?" and "hello
+// how are you". Candidates are entries that share a band of their minhash signature; with 8
+// stored values the estimate moves in steps of 1/8.
+func (c *Cache) FindNearDuplicates(namespace string, threshold float64) ([][]CacheEntryInfo, error) {
+	if err := c.backfillMinHashes(); err != nil {
+		return nil, err
+	}
+
+	rows, err := c.db.Query(
+		`SELECT cache_key, text, language_code, audio_size, created_at, hit_count, minhash
+		 FROM audio_cache WHERE namespace = ? ORDER BY cache_key`, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cache entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []CacheEntryInfo
+	var signatures [][]uint64
+	for rows.Next() {
+		var e CacheEntryInfo
+		var minhash string
+		if err := rows.Scan(&e.CacheKey, &e.Text, &e.LanguageCode, &e.AudioSize, &e.CreatedAt, &e.HitCount, &minhash); err != nil {
+			return nil, fmt.Errorf("failed to scan cache entry: %w", err)
+		}
+		e.Namespace = namespace
+		signature, err := decodeMinHash(minhash)
+		if err != nil {
+			return nil, fmt.Errorf("entry %s: %w", e.CacheKey, err)
+		}
+		entries = append(entries, e)
+		signatures = append(signatures, signature)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query cache entries: %w", err)
+	}
+
+	// Bucket entries by language and band, then compare the entries within each bucket
+	buckets := make(map[string][]int)
+	for i, signature := range signatures {
+		for band := 0; band < minhashBands; band++ {
+			key := fmt.Sprintf("%s|%d|%x", entries[i].LanguageCode, band, signature[band*minhashRows:(band+1)*minhashRows])
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+
+	// Union-find over similar pairs
+	parent := make([]int, len(entries))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for _, bucket := range buckets {
+		for a := 0; a < len(bucket); a++ {
+			for b := a + 1; b < len(bucket); b++ {
+				i, j := bucket[a], bucket[b]
+				if find(i) != find(j) && minhashSimilarity(signatures[i], signatures[j]) > threshold {
+					parent[find(j)] = find(i)
+				}
+			}
+		}
+	}
+
+	// Collect groups in cache key order, dropping entries with no near duplicate
+	members := make(map[int][]CacheEntryInfo)
+	var roots []int
+	for i := range entries {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], entries[i])
+	}
+
+	var groups [][]CacheEntryInfo
+	for _, root := range roots {
+		if len(members[root]) > 1 {
+			groups = append(groups, members[root])
+		}
+	}
+	return groups, nil
+}
+
+// FindNearDuplicates returns groups of namespace's cache entries with nearly identical text (see
+// Cache.FindNearDuplicates)
+func (s *Service) FindNearDuplicates(namespace string, threshold float64) ([][]CacheEntryInfo, error) {
+	return s.cache.FindNearDuplicates(namespace, threshold)
+}
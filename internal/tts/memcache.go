@@ -0,0 +1,57 @@
+package tts
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"com.biesnecker/tts-daemon/internal/metrics"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// SetMemoryCache keeps the entries most recently read or written, up to entries of them, in
+// memory with their audio decoded, so lookups for them don't query SQLite. The memory tier is
+// checked before the hot cache and doesn't count toward the cache size limit.
+func (c *Cache) SetMemoryCache(entries int) error {
+	memory, err := lru.New[string, *CachedAudio](entries)
+	if err != nil {
+		return fmt.Errorf("invalid memory cache size %d: %w", entries, err)
+	}
+	c.memory = memory
+	log.Printf("Cache: memory cache enabled, %d entries", entries)
+	return nil
+}
+
+// getMemory returns the memory tier's copy of the entry stored under cacheKey, or nil
+func (c *Cache) getMemory(cacheKey string) *CachedAudio {
+	audio, ok := c.memory.Get(cacheKey)
+	if !ok {
+		return nil
+	}
+	metrics.MemoryCacheHits.Inc()
+	copied := *audio
+	return &copied
+}
+
+// putMemory adds a copy of a decoded entry to the memory tier, if it is enabled
+func (c *Cache) putMemory(audio *CachedAudio) {
+	if c.memory == nil {
+		return
+	}
+	copied := *audio
+	copied.AudioData = bytes.Clone(audio.AudioData) // Callers may still be using the original
+	copied.Compression = sql.NullString{}
+	copied.deltaBaseKey, copied.deltaData = sql.NullString{}, nil
+	c.memory.Add(audio.CacheKey, &copied)
+}
+
+// dropMemory removes entries from the memory tier, e.g. because they were deleted or replaced
+func (c *Cache) dropMemory(keys ...string) {
+	if c.memory == nil {
+		return
+	}
+	for _, key := range keys {
+		c.memory.Remove(key)
+	}
+}
@@ -0,0 +1,125 @@
+package tts
+
+import (
+	"testing"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// hasLanguageSeries reports whether collector has a series labelled with languageCode
+func hasLanguageSeries(t *testing.T, collector prometheus.Collector, languageCode string) bool {
+	t.Helper()
+	registry := prometheus.NewPedanticRegistry()
+	if err := registry.Register(collector); err != nil {
+		t.Fatal(err)
+	}
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "language" && label.GetValue() == languageCode {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func TestRequestMetricsLanguageLabels(t *testing.T) {
+	provider := newMockProvider(t)
+	recorded := readTestAudio(t, "en-US")
+	provider.audio = func(text, languageCode string) ([]byte, error) { return recorded, nil }
+	service := NewService(newTestCache(t), provider)
+	service.SetLanguageFallbackChains(map[string][]string{"pt-AO": {"es-ES"}})
+
+	tests := []struct {
+		languageCode string
+		wantLabel    string
+	}{
+		{"de-DE", "de-DE"},                      // A voice of its own
+		{"pt-AO", "pt-AO"},                      // A fallback chain
+		{"zz-Made-Up-1", metrics.OtherLanguage}, // Neither
+		{"de-de", metrics.OtherLanguage},        // Labels match exactly
+	}
+	for _, tt := range tests {
+		t.Run(tt.languageCode, func(t *testing.T) {
+			counter := metrics.Requests.WithLabelValues(SourceAzure, tt.wantLabel, "ok")
+			before := testutil.ToFloat64(counter)
+			if _, _, _, err := service.GetAudio(t.Context(), "Hello", tt.languageCode, Options{}, false); err != nil {
+				t.Fatal(err)
+			}
+			if got := testutil.ToFloat64(counter) - before; got != 1 {
+				t.Errorf("tts_requests_total{language=%q} went up by %v, want 1", tt.wantLabel, got)
+			}
+			if tt.wantLabel != tt.languageCode && hasLanguageSeries(t, metrics.Requests, tt.languageCode) {
+				t.Errorf("tts_requests_total has a series for %q", tt.languageCode)
+			}
+		})
+	}
+}
+
+func TestCacheSizeMetrics(t *testing.T) {
+	cache := newTestCache(t)
+	service := NewService(cache, newMockProvider(t))
+	defer service.Close()
+
+	entries := []struct {
+		text, languageCode string
+		size               int
+	}{
+		{"Hello", "en-US", 1000},
+		{"Goodbye", "en-US", 500},
+		{"Bonjour", "fr-FR", 700},
+		{"Hej", "sv-SE", 300},       // No voice, so it's labelled other
+		{"Hola", "zz-Made-Up", 200}, // Adds up with sv-SE
+	}
+	for _, entry := range entries {
+		if _, err := cache.Put(entry.text, entry.languageCode, Options{}, make([]byte, entry.size), ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !cache.sizeMetricsStale.Load() {
+		t.Fatal("Put didn't mark the size gauges stale")
+	}
+
+	// The gauges are recomputed on the next tick
+	stop := make(chan struct{})
+	defer close(stop)
+	go cache.recordSizeMetricsEvery(10*time.Millisecond, stop)
+	deadline := time.Now().Add(5 * time.Second)
+	for testutil.ToFloat64(metrics.CacheEntries.WithLabelValues(metrics.OtherLanguage)) != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("the size gauges weren't recomputed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if cache.sizeMetricsStale.Load() {
+		t.Error("the size gauges are still stale after being recomputed")
+	}
+
+	want := map[string]struct{ size, entries float64 }{
+		"en-US":               {1500, 2},
+		"fr-FR":               {700, 1},
+		metrics.OtherLanguage: {500, 2},
+	}
+	for label, w := range want {
+		if got := testutil.ToFloat64(metrics.CacheSizeBytes.WithLabelValues(label)); got != w.size {
+			t.Errorf("tts_cache_size_bytes{language=%q} = %v, want %v", label, got, w.size)
+		}
+		if got := testutil.ToFloat64(metrics.CacheEntries.WithLabelValues(label)); got != w.entries {
+			t.Errorf("tts_cache_entries_total{language=%q} = %v, want %v", label, got, w.entries)
+		}
+	}
+	for _, languageCode := range []string{"sv-SE", "zz-Made-Up"} {
+		if hasLanguageSeries(t, metrics.CacheSizeBytes, languageCode) || hasLanguageSeries(t, metrics.CacheEntries, languageCode) {
+			t.Errorf("the cache gauges have a series for %q", languageCode)
+		}
+	}
+}
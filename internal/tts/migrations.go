@@ -0,0 +1,174 @@
+package tts
+
+import (
+	"embed"
+	"fmt"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// migrationFiles holds the schema migrations, named "<version>_<description>.sql". A schema
+// change is a new file with the next version; applied files must not be edited.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// legacyMigrations is how many migrations databases created before schema_migrations existed
+// may already have applied, up to the columns they added
+const legacyMigrations = 16
+
+// addColumnPattern matches the columns a migration adds
+var addColumnPattern = regexp.MustCompile(`(?i)ALTER\s+TABLE\s+(\w+)\s+ADD\s+COLUMN\s+(\w+)`)
+
+// migration is a versioned schema change
+type migration struct {
+	version int
+	name    string
+	script  string
+}
+
+// loadMigrations returns the embedded migrations in version order
+func loadMigrations() ([]migration, error) {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var migrations []migration
+	for _, entry := range entries {
+		name := entry.Name()
+		prefix, _, ok := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s isn't named <version>_<description>.sql", name)
+		}
+		script, err := migrationFiles.ReadFile(path.Join("migrations", name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", name, err)
+		}
+		migrations = append(migrations, migration{version: version, name: name, script: string(script)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].version == migrations[i-1].version {
+			return nil, fmt.Errorf("migrations %s and %s have the same version", migrations[i-1].name, migrations[i].name)
+		}
+	}
+	return migrations, nil
+}
+
+// migrate applies the migrations the database doesn't have yet, each in its own transaction,
+// recording them in schema_migrations
+func (c *Cache) migrate() error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	// A database with entries but no schema_migrations table predates it; its schema was
+	// upgraded by checking for each column instead
+	var legacy bool
+	row := c.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='audio_cache'
+		AND NOT EXISTS (SELECT 1 FROM sqlite_master WHERE type='table' AND name='schema_migrations')`)
+	if err := row.Scan(&legacy); err != nil {
+		return fmt.Errorf("failed to check for schema_migrations table: %w", err)
+	}
+
+	_, err = c.db.Exec(`
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at INTEGER NOT NULL
+	);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	applied := make(map[int]bool)
+	rows, err := c.db.Query(`SELECT version FROM schema_migrations`)
+	if err != nil {
+		return fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		applied[version] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+
+	if legacy {
+		log.Printf("Cache: recording the existing schema in schema_migrations")
+	}
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+		run := true
+		if legacy && m.version <= legacyMigrations {
+			if run, err = c.missingColumns(m.script); err != nil {
+				return err
+			}
+		}
+		if err := c.applyMigration(m, run); err != nil {
+			return err
+		}
+		if !legacy && len(applied) > 0 {
+			log.Printf("Cache: applied schema migration %s", m.name)
+		}
+	}
+	return nil
+}
+
+// missingColumns reports whether any column a migration adds is missing. Migrations that don't
+// add columns only create things if they don't exist, so they always run.
+func (c *Cache) missingColumns(script string) (bool, error) {
+	matches := addColumnPattern.FindAllStringSubmatch(script, -1)
+	if len(matches) == 0 {
+		return true, nil
+	}
+	for _, match := range matches {
+		var exists bool
+		row := c.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name=?`, match[1], match[2])
+		if err := row.Scan(&exists); err != nil {
+			return false, fmt.Errorf("failed to check for %s column: %w", match[2], err)
+		}
+		if !exists {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// applyMigration runs a migration's script, unless run is false because a legacy database
+// already has its changes, and records it as applied
+func (c *Cache) applyMigration(m migration, run bool) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if run {
+		if _, err := tx.Exec(m.script); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", m.name, err)
+		}
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, m.version, getCurrentTimestamp()); err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", m.name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %s: %w", m.name, err)
+	}
+	return nil
+}
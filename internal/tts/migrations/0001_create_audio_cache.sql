@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS audio_cache (
+	cache_key TEXT PRIMARY KEY,
+	text TEXT NOT NULL,
+	language_code TEXT NOT NULL,
+	audio_data BLOB NOT NULL,
+	audio_size INTEGER NOT NULL,
+	created_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_language_code ON audio_cache(language_code);
+CREATE INDEX IF NOT EXISTS idx_created_at ON audio_cache(created_at);
@@ -0,0 +1,4 @@
+-- Algorithm the audio is compressed with, NULL for uncompressed
+ALTER TABLE audio_cache ADD COLUMN compression TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_compression ON audio_cache(compression);
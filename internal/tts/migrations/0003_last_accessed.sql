@@ -0,0 +1,6 @@
+-- Used by LRU eviction; existing entries count as last accessed when they were cached
+ALTER TABLE audio_cache ADD COLUMN last_accessed INTEGER;
+
+UPDATE audio_cache SET last_accessed = created_at WHERE last_accessed IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_last_accessed ON audio_cache(last_accessed);
@@ -0,0 +1,4 @@
+-- Used by LFU eviction
+ALTER TABLE audio_cache ADD COLUMN hit_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_hit_count ON audio_cache(hit_count);
@@ -0,0 +1,2 @@
+-- Set by ResynthesizeAll
+ALTER TABLE audio_cache ADD COLUMN resynth_in_progress BOOLEAN NOT NULL DEFAULT 0;
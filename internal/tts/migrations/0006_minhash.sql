@@ -0,0 +1,2 @@
+-- Used by FindNearDuplicates; existing entries are filled in on first use
+ALTER TABLE audio_cache ADD COLUMN minhash TEXT;
@@ -0,0 +1,2 @@
+-- NULL for entries cached before voices were recorded
+ALTER TABLE audio_cache ADD COLUMN voice_name TEXT;
@@ -0,0 +1,2 @@
+-- NULL for entries cached before formats were recorded, or copied from another daemon
+ALTER TABLE audio_cache ADD COLUMN format TEXT;
@@ -0,0 +1,4 @@
+-- NULL until BuildFingerprintIndex runs for entries cached before fingerprints were recorded
+ALTER TABLE audio_cache ADD COLUMN audio_fingerprint TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_audio_fingerprint ON audio_cache(audio_fingerprint);
@@ -0,0 +1,3 @@
+-- NULL for entries cached before text statistics were recorded
+ALTER TABLE audio_cache ADD COLUMN word_count INTEGER;
+ALTER TABLE audio_cache ADD COLUMN char_count INTEGER;
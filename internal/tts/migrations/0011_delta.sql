@@ -0,0 +1,7 @@
+-- NULL for entries stored whole, see SetDeltaCompression
+ALTER TABLE audio_cache ADD COLUMN delta_base_key TEXT;
+ALTER TABLE audio_cache ADD COLUMN delta_data BLOB;
+
+-- Entries stored as deltas are stored whole again before their base is deleted or replaced
+-- (see Cache.materializeDependents), which looks them up by base
+CREATE INDEX IF NOT EXISTS idx_delta_base_key ON audio_cache(delta_base_key);
@@ -0,0 +1,15 @@
+-- Changes of the default voice for a locale, and the last seen default voices they are
+-- computed against
+CREATE TABLE IF NOT EXISTS voice_change_history (
+	locale TEXT NOT NULL,
+	old_voice TEXT NOT NULL,
+	new_voice TEXT NOT NULL,
+	detected_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_voice_change_locale ON voice_change_history(locale, detected_at);
+
+CREATE TABLE IF NOT EXISTS voice_defaults (
+	locale TEXT PRIMARY KEY,
+	voice TEXT NOT NULL
+);
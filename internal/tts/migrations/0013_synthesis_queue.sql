@@ -0,0 +1,13 @@
+-- Asynchronous synthesis jobs, see EnqueueSynthesis
+CREATE TABLE IF NOT EXISTS synthesis_queue (
+	id TEXT PRIMARY KEY,
+	text TEXT NOT NULL,
+	language_code TEXT NOT NULL,
+	priority INTEGER NOT NULL DEFAULT 0,
+	status TEXT NOT NULL,
+	created_at INTEGER NOT NULL,
+	completed_at INTEGER,
+	error_message TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_queue_pending ON synthesis_queue(status, priority, created_at);
@@ -0,0 +1,4 @@
+-- Entries cached before namespaces belong to the default namespace
+ALTER TABLE audio_cache ADD COLUMN namespace TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_namespace_language ON audio_cache(namespace, language_code);
@@ -0,0 +1,14 @@
+-- User-defined entry tags. The trigger removes an entry's tags when it is deleted or evicted; a
+-- replaced row (INSERT OR REPLACE) doesn't fire it, so an entry keeps its tags when it is
+-- re-synthesized.
+CREATE TABLE IF NOT EXISTS audio_cache_tags (
+	cache_key TEXT NOT NULL,
+	tag TEXT NOT NULL,
+	PRIMARY KEY (cache_key, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_audio_cache_tags_tag ON audio_cache_tags(tag);
+
+CREATE TRIGGER IF NOT EXISTS delete_entry_tags AFTER DELETE ON audio_cache BEGIN
+	DELETE FROM audio_cache_tags WHERE cache_key = OLD.cache_key;
+END;
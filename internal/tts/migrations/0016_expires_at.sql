@@ -0,0 +1,4 @@
+-- NULL for entries that follow the cache's TTL, see Options.TTLSeconds
+ALTER TABLE audio_cache ADD COLUMN expires_at INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_expires_at ON audio_cache(expires_at);
@@ -0,0 +1,13 @@
+-- Keys whose audio is identical to another entry's, stored once under target_key (see
+-- SetFingerprintDedup). The trigger removes the aliases of an entry when it is deleted or
+-- evicted; a replaced row (INSERT OR REPLACE) doesn't fire it, so they survive re-synthesis.
+CREATE TABLE IF NOT EXISTS audio_cache_aliases (
+	cache_key TEXT PRIMARY KEY,
+	target_key TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audio_cache_aliases_target_key ON audio_cache_aliases(target_key);
+
+CREATE TRIGGER IF NOT EXISTS delete_entry_aliases AFTER DELETE ON audio_cache BEGIN
+	DELETE FROM audio_cache_aliases WHERE target_key = OLD.cache_key;
+END;
@@ -0,0 +1,202 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// mockVoices is the voice list reported by MockAzureClient
+var mockVoices = []Voice{
+	{Name: "Microsoft Server Speech Text to Speech Voice (en-US, AriaNeural)", DisplayName: "Aria", ShortName: "en-US-AriaNeural", Gender: "Female", Locale: "en-US", VoiceType: "Neural", Status: "GA"},
+	{Name: "Microsoft Server Speech Text to Speech Voice (en-US, GuyNeural)", DisplayName: "Guy", ShortName: "en-US-GuyNeural", Gender: "Male", Locale: "en-US", VoiceType: "Neural", Status: "GA"},
+	{Name: "Microsoft Server Speech Text to Speech Voice (en-GB, SoniaNeural)", DisplayName: "Sonia", ShortName: "en-GB-SoniaNeural", Gender: "Female", Locale: "en-GB", VoiceType: "Neural", Status: "GA"},
+	{Name: "Microsoft Server Speech Text to Speech Voice (fr-FR, DeniseNeural)", DisplayName: "Denise", ShortName: "fr-FR-DeniseNeural", Gender: "Female", Locale: "fr-FR", VoiceType: "Neural", Status: "GA"},
+	{Name: "Microsoft Server Speech Text to Speech Voice (es-ES, ElviraNeural)", DisplayName: "Elvira", ShortName: "es-ES-ElviraNeural", Gender: "Female", Locale: "es-ES", VoiceType: "Neural", Status: "GA"},
+	{Name: "Microsoft Server Speech Text to Speech Voice (es-MX, DaliaNeural)", DisplayName: "Dalia", ShortName: "es-MX-DaliaNeural", Gender: "Female", Locale: "es-MX", VoiceType: "Neural", Status: "GA"},
+	{Name: "Microsoft Server Speech Text to Speech Voice (de-DE, KatjaNeural)", DisplayName: "Katja", ShortName: "de-DE-KatjaNeural", Gender: "Female", Locale: "de-DE", VoiceType: "Neural", Status: "GA"},
+	{Name: "Microsoft Server Speech Text to Speech Voice (it-IT, ElsaNeural)", DisplayName: "Elsa", ShortName: "it-IT-ElsaNeural", Gender: "Female", Locale: "it-IT", VoiceType: "Neural", Status: "GA"},
+	{Name: "Microsoft Server Speech Text to Speech Voice (ja-JP, NanamiNeural)", DisplayName: "Nanami", ShortName: "ja-JP-NanamiNeural", Gender: "Female", Locale: "ja-JP", VoiceType: "Neural", Status: "GA"},
+	{Name: "Microsoft Server Speech Text to Speech Voice (zh-CN, XiaoxiaoNeural)", DisplayName: "Xiaoxiao", ShortName: "zh-CN-XiaoxiaoNeural", Gender: "Female", Locale: "zh-CN", VoiceType: "Neural", Status: "GA"},
+}
+
+// MockAzureClient is a Provider that returns pre-recorded audio instead of calling Azure, so the
+// daemon can run without credentials. Audio for a language is read from <audioDir>/<language>.mp3
+// (or .wav, .opus or .ogg for the other output formats), whatever the text.
+type MockAzureClient struct {
+	audioDir     string
+	rateLimiter  *rate.Limiter
+	customVoices map[string]string
+	voices       []Voice
+	voicesMu     sync.RWMutex // Protects customVoices and voices
+}
+
+// NewMockAzureClient creates a mock client serving audio from audioDir
+func NewMockAzureClient(audioDir string, maxQPS float64, customVoices map[string]string) *MockAzureClient {
+	return &MockAzureClient{
+		audioDir:     audioDir,
+		rateLimiter:  rate.NewLimiter(rate.Limit(maxQPS), 1),
+		customVoices: customVoices,
+	}
+}
+
+// FetchVoiceList implements Provider with a fixed set of test voices
+func (m *MockAzureClient) FetchVoiceList(ctx context.Context) error {
+	m.voicesMu.Lock()
+	defer m.voicesMu.Unlock()
+	m.voices = mockVoices
+	return nil
+}
+
+// ListVoices implements Provider
+func (m *MockAzureClient) ListVoices(ctx context.Context) ([]Voice, error) {
+	m.voicesMu.RLock()
+	defer m.voicesMu.RUnlock()
+	return append([]Voice(nil), m.voices...), nil
+}
+
+// VoiceCount implements Provider
+func (m *MockAzureClient) VoiceCount() int {
+	m.voicesMu.RLock()
+	defer m.voicesMu.RUnlock()
+	return len(m.voices)
+}
+
+// Ping implements Provider by checking that the audio directory exists
+func (m *MockAzureClient) Ping(ctx context.Context) error {
+	if _, err := os.Stat(m.audioDir); err != nil {
+		return fmt.Errorf("mock audio directory: %w", err)
+	}
+	return nil
+}
+
+// RateLimiterTokens implements Provider
+func (m *MockAzureClient) RateLimiterTokens() float64 {
+	return m.rateLimiter.Tokens()
+}
+
+// RateLimiter implements Provider
+func (m *MockAzureClient) RateLimiter() *rate.Limiter {
+	return m.rateLimiter
+}
+
+// MissingCustomVoices implements Provider against the test voices
+func (m *MockAzureClient) MissingCustomVoices() map[string]string {
+	m.voicesMu.RLock()
+	defer m.voicesMu.RUnlock()
+
+	available := make(map[string]bool, len(m.voices))
+	for _, voice := range m.voices {
+		available[voice.ShortName] = true
+	}
+
+	missing := make(map[string]string)
+	for locale, voice := range m.customVoices {
+		if !available[voice] {
+			missing[locale] = voice
+		}
+	}
+	return missing
+}
+
+// SetCustomVoices implements CustomVoiceProvider
+func (m *MockAzureClient) SetCustomVoices(customVoices map[string]string) {
+	m.voicesMu.Lock()
+	defer m.voicesMu.Unlock()
+	m.customVoices = customVoices
+}
+
+// VoiceName implements Provider, preferring a custom voice and then the first test voice for
+// the language or its base language
+func (m *MockAzureClient) VoiceName(languageCode string) (string, error) {
+	m.voicesMu.RLock()
+	voice, ok := m.customVoices[languageCode]
+	m.voicesMu.RUnlock()
+	if ok {
+		return voice, nil
+	}
+	voices := m.DefaultVoices()
+	if voice, ok := voices[languageCode]; ok {
+		return voice, nil
+	}
+	if len(languageCode) > 2 && languageCode[2] == '-' {
+		if voice, ok := voices[languageCode[:2]]; ok {
+			return voice, nil
+		}
+	}
+	return "", fmt.Errorf("no voice available for language code: %s", languageCode)
+}
+
+// DefaultVoices implements Provider with the first test voice for each locale
+func (m *MockAzureClient) DefaultVoices() map[string]string {
+	m.voicesMu.RLock()
+	defer m.voicesMu.RUnlock()
+
+	voices := make(map[string]string)
+	for _, voice := range m.voices {
+		if _, ok := voices[voice.Locale]; !ok {
+			voices[voice.Locale] = voice.ShortName
+		}
+	}
+	return voices
+}
+
+// Synthesize implements Provider by returning the recorded audio for the language
+func (m *MockAzureClient) Synthesize(ctx context.Context, text, languageCode string, opts Options) ([]byte, error) {
+	if err := m.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter error: %w", err)
+	}
+	return m.recordedAudio(languageCode, opts)
+}
+
+// SynthesizeBatch implements BatchProvider by repeating the recorded WAV audio for the language
+// once per text, separated by gap of silence
+func (m *MockAzureClient) SynthesizeBatch(ctx context.Context, texts []string, languageCode string, opts Options, gap time.Duration) ([]byte, error) {
+	if err := m.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter error: %w", err)
+	}
+
+	audioData, err := m.recordedAudio(languageCode, opts)
+	if err != nil {
+		return nil, err
+	}
+	wav, err := parsePCMWAV(audioData)
+	if err != nil {
+		return nil, fmt.Errorf("mock batches need WAV audio: %w", err)
+	}
+
+	silent := make([]byte, 2*int(gap.Seconds()*float64(wav.sampleRate)))
+	combined := &pcmWAV{sampleRate: wav.sampleRate}
+	for i := range texts {
+		if i > 0 {
+			combined.samples = append(combined.samples, silent...)
+		}
+		combined.samples = append(combined.samples, wav.samples...)
+	}
+	return combined.encode(), nil
+}
+
+// recordedAudio reads the recorded audio for the language in opts.Format
+func (m *MockAzureClient) recordedAudio(languageCode string, opts Options) ([]byte, error) {
+	ext := ".mp3"
+	switch opts.Format {
+	case FormatWAV16K:
+		ext = ".wav"
+	case FormatOpus24K:
+		ext = ".opus"
+	case FormatOggOpus48K, FormatOggOpus24K:
+		ext = ".ogg"
+	}
+	path := filepath.Join(m.audioDir, voiceLocale(languageCode, opts)+ext)
+
+	audioData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no mock audio for %s: %w", languageCode, err)
+	}
+	return audioData, nil
+}
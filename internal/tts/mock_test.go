@@ -0,0 +1,76 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestMockAzureClient(t *testing.T) {
+	client := newMockProvider(t).MockAzureClient
+	if got := client.VoiceCount(); got != 10 {
+		t.Errorf("mock has %d voices, want 10", got)
+	}
+	if err := client.Ping(context.Background()); err != nil {
+		t.Errorf("Ping: %v", err)
+	}
+
+	tests := []struct {
+		languageCode string
+		wantVoice    string
+		wantAudio    string // Recorded audio file's language, "" for an error
+	}{
+		{"en-US", "en-US-AriaNeural", "en-US"},
+		{"en-GB", "en-GB-SoniaNeural", "en-GB"},
+		{"ja-JP", "ja-JP-NanamiNeural", "ja-JP"},
+		{"zh-CN", "zh-CN-XiaoxiaoNeural", "zh-CN"},
+		{"ko-KR", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.languageCode, func(t *testing.T) {
+			voice, err := client.VoiceName(tt.languageCode)
+			if tt.wantVoice == "" {
+				if err == nil {
+					t.Errorf("VoiceName = %q, want an error", voice)
+				}
+			} else if voice != tt.wantVoice {
+				t.Errorf("VoiceName = %q (%v), want %q", voice, err, tt.wantVoice)
+			}
+
+			audioData, err := client.Synthesize(context.Background(), "any text at all", tt.languageCode, Options{})
+			if tt.wantAudio == "" {
+				if err == nil {
+					t.Error("Synthesize succeeded without recorded audio")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(audioData, readTestAudio(t, tt.wantAudio)) {
+				t.Errorf("Synthesize returned audio other than %s.mp3", tt.wantAudio)
+			}
+		})
+	}
+}
+
+func TestMockAzureClientMissingDirectory(t *testing.T) {
+	client := NewMockAzureClient(t.TempDir()+"/missing", 10, nil)
+	if err := client.Ping(context.Background()); err == nil {
+		t.Error("Ping succeeded without the audio directory")
+	}
+}
+
+func TestMockAzureClientCustomVoices(t *testing.T) {
+	client := NewMockAzureClient(testAudioDir, 10, map[string]string{"en-US": "en-US-GuyNeural", "fr-FR": "fr-FR-NobodyNeural"})
+	if err := client.FetchVoiceList(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if voice, _ := client.VoiceName("en-US"); voice != "en-US-GuyNeural" {
+		t.Errorf("VoiceName(en-US) = %q, want the custom voice", voice)
+	}
+	missing := client.MissingCustomVoices()
+	if len(missing) != 1 || missing["fr-FR"] != "fr-FR-NobodyNeural" {
+		t.Errorf("MissingCustomVoices = %v, want only fr-FR", missing)
+	}
+}
@@ -0,0 +1,39 @@
+package tts
+
+import (
+	"fmt"
+	"time"
+)
+
+// MP3Silence returns at least d of silent MP3 frames in the same format as the reference MP3
+// audio, so it can be appended to or between streams in that format. Each frame is the
+// reference's first frame header followed by zeros, which decodes to silence.
+func MP3Silence(reference []byte, d time.Duration) ([]byte, error) {
+	frame := reference[skipID3v2(reference):]
+	length, err := mp3FrameLength(frame)
+	if err != nil {
+		return nil, fmt.Errorf("reference is not MP3 audio: %w", err)
+	}
+
+	header := [4]byte{frame[0], frame[1], frame[2] &^ 0x02, frame[3]} // Without padding
+	if frame[2]&0x02 != 0 {
+		length--
+	}
+	header[1] |= 0x01 // No CRC follows the header
+
+	version := header[1] >> 3 & 0x03
+	sampleRate := mpegSampleRates[version][header[2]>>2&0x03]
+	samplesPerFrame := 1152
+	if version != 3 {
+		samplesPerFrame = 576 // MPEG-2 and 2.5
+	}
+
+	frameDuration := time.Duration(samplesPerFrame) * time.Second / time.Duration(sampleRate)
+	frames := int((d + frameDuration - 1) / frameDuration)
+
+	silence := make([]byte, frames*length)
+	for i := 0; i < frames; i++ {
+		copy(silence[i*length:], header[:])
+	}
+	return silence, nil
+}
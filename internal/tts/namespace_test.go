@@ -0,0 +1,120 @@
+package tts
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// putInNamespaces caches texts in en-US in each of namespaces
+func putInNamespaces(t *testing.T, cache *Cache, namespaces []string, texts ...string) {
+	t.Helper()
+	for _, namespace := range namespaces {
+		for _, text := range texts {
+			if _, err := cache.Put(text, "en-US", Options{Namespace: namespace}, readTestAudio(t, "en-US"), ""); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestCacheWideOperationsAreScopedToNamespaces(t *testing.T) {
+	cache := newTestCache(t)
+	putInNamespaces(t, cache, []string{"team-a", "team-b"}, "Hello, how are you?")
+	putInNamespaces(t, cache, []string{"team-b"}, "hello how are you", "Goodbye")
+
+	if count, err := cache.CountEntries("team-a", "en-US"); err != nil || count != 1 {
+		t.Errorf("CountEntries(team-a) = %d, %v, want 1", count, err)
+	}
+	if checked, _, err := cache.VerifyKeys("team-a"); err != nil || checked != 1 {
+		t.Errorf("VerifyKeys(team-a) checked %d entries (%v), want 1", checked, err)
+	}
+	if stats, err := cache.GetNamespaceStats("team-a"); err != nil || stats["total_clips"] != int64(1) {
+		t.Errorf("GetNamespaceStats(team-a) = %v, %v, want 1 clip", stats["total_clips"], err)
+	}
+	if usage, err := cache.languageUsage("team-a"); err != nil || usage["en-US"].entries != 1 {
+		t.Errorf("languageUsage(team-a) = %v, %v, want 1 en-US entry", usage, err)
+	}
+	if groups, err := cache.FindNearDuplicates("team-a", 0.5); err != nil || len(groups) != 0 {
+		t.Errorf("FindNearDuplicates(team-a) = %v, %v, want no groups", groups, err)
+	}
+	groups, err := cache.FindNearDuplicates("team-b", 0.5)
+	if err != nil || len(groups) != 1 || len(groups[0]) != 2 {
+		t.Fatalf("FindNearDuplicates(team-b) = %v, %v, want team B's two greetings", groups, err)
+	}
+	for _, entry := range groups[0] {
+		if entry.Namespace != "team-b" {
+			t.Errorf("FindNearDuplicates(team-b) returned an entry of %q", entry.Namespace)
+		}
+	}
+
+	if matched, deleted, _, err := cache.DeletePattern("team-a", "%how are you%", "", false); err != nil || matched != 1 || deleted != 1 {
+		t.Errorf("DeletePattern(team-a) = %d matched, %d deleted, %v, want 1", matched, deleted, err)
+	}
+	if count, err := cache.CountEntries("team-b", "en-US"); err != nil || count != 3 {
+		t.Errorf("after deleting team A's entries team B has %d (%v), want 3", count, err)
+	}
+}
+
+func TestExportAndImportAreScopedToNamespaces(t *testing.T) {
+	cache := newTestCache(t)
+	putInNamespaces(t, cache, []string{"team-a", "team-b"}, "Hello")
+	putInNamespaces(t, cache, []string{"team-b"}, "Goodbye")
+
+	var archive bytes.Buffer
+	if err := cache.Export(&archive, "team-b"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The archive holds team B's entries, so another namespace can't import it
+	imported, _, err := newTestCache(t).Import(bytes.NewReader(archive.Bytes()), "team-a")
+	if err == nil || !strings.Contains(err.Error(), `belongs to namespace "team-b"`) || imported != 0 {
+		t.Errorf("importing team B's entries into team-a = %d, %v, want them refused", imported, err)
+	}
+
+	dest := newTestCache(t)
+	imported, skipped, err := dest.Import(bytes.NewReader(archive.Bytes()), "team-b")
+	if err != nil || imported != 2 || skipped != 0 {
+		t.Fatalf("Import(team-b) = %d imported, %d skipped, %v, want 2 imported", imported, skipped, err)
+	}
+	if count, err := dest.CountEntries("team-a", "en-US"); err != nil || count != 0 {
+		t.Errorf("the export of team-b brought %d (%v) of team A's entries, want none", count, err)
+	}
+}
+
+func TestEventsRecordTheirNamespace(t *testing.T) {
+	cache := newTestCache(t)
+	events, unsubscribe := cache.Subscribe()
+	defer unsubscribe()
+
+	putInNamespaces(t, cache, []string{"team-b"}, "Hello")
+	select {
+	case event := <-events:
+		if event.Type != EventPut || event.Namespace != "team-b" {
+			t.Errorf("event = %+v, want a put in team-b", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no event for the put")
+	}
+}
+
+func TestDeltasAreStoredWithinANamespace(t *testing.T) {
+	cache := newTestCache(t)
+	if err := cache.SetDeltaCompression(true); err != nil {
+		t.Fatal(err)
+	}
+	baseAudio := randomAudio(1, 20000)
+	if _, err := cache.Put("Hello world", "en-US", Options{Namespace: "team-a"}, baseAudio, "en-US-AriaNeural"); err != nil {
+		t.Fatal(err)
+	}
+	targetAudio := edited(baseAudio, 9000, []byte("a different word"))
+	key, err := cache.Put("Hello world", "en-US", Options{Namespace: "team-b", Voice: "en-US-GuyNeural"}, targetAudio, "en-US-GuyNeural")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base := deltaBaseKey(t, cache, key); base != "" {
+		t.Errorf("team B's entry is stored as a delta against %s of team-a", base)
+	}
+	wantAudio(t, cache, key, targetAudio)
+}
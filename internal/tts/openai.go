@@ -0,0 +1,232 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Defaults for the OpenAI provider
+const (
+	DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+	DefaultOpenAIModel   = "tts-1"
+	DefaultOpenAIVoice   = "alloy"
+)
+
+// OpenAIClient is a Provider backed by OpenAI's /audio/speech endpoint. OpenAI voices speak every
+// language, so one voice is used for all of them.
+type OpenAIClient struct {
+	baseURL      string // API base such as https://api.openai.com/v1, possibly with a query
+	apiKey       string
+	model        string // tts-1 or tts-1-hd
+	voice        string // alloy, echo, fable, onyx, nova or shimmer
+	rateLimiter  *rate.Limiter
+	httpClient   *http.Client
+	voiceCache   map[string]string // Just the anyVoiceLocale entry once FetchVoiceList has run
+	voiceCacheMu sync.RWMutex
+}
+
+// NewOpenAIClient creates an OpenAI TTS client with rate limiting. baseURL may point at an Azure
+// OpenAI deployment or a proxy instead of OpenAI; "" selects the defaults for it, model and voice.
+func NewOpenAIClient(baseURL, apiKey, model, voice string, maxQPS float64) *OpenAIClient {
+	if baseURL == "" {
+		baseURL = DefaultOpenAIBaseURL
+	}
+	if model == "" {
+		model = DefaultOpenAIModel
+	}
+	if voice == "" {
+		voice = DefaultOpenAIVoice
+	}
+	return &OpenAIClient{
+		baseURL:     baseURL,
+		apiKey:      apiKey,
+		model:       model,
+		voice:       voice,
+		rateLimiter: rate.NewLimiter(rate.Limit(maxQPS), 1),
+		httpClient:  &http.Client{},
+		voiceCache:  make(map[string]string),
+	}
+}
+
+// FetchVoiceList implements Provider. OpenAI has no voice list to fetch, so it just maps every
+// language to the configured voice.
+func (o *OpenAIClient) FetchVoiceList(ctx context.Context) error {
+	o.voiceCacheMu.Lock()
+	o.voiceCache = map[string]string{anyVoiceLocale: o.voice}
+	o.voiceCacheMu.Unlock()
+	return nil
+}
+
+// openAIVoices are the voices of the tts-1 models
+var openAIVoices = []string{"alloy", "echo", "fable", "onyx", "nova", "shimmer"}
+
+// ListVoices implements Provider with OpenAI's voices, which have no locale
+func (o *OpenAIClient) ListVoices(ctx context.Context) ([]Voice, error) {
+	voices := make([]Voice, 0, len(openAIVoices))
+	for _, name := range openAIVoices {
+		voices = append(voices, Voice{Name: name, ShortName: name})
+	}
+	return voices, nil
+}
+
+// VoiceCount implements Provider
+func (o *OpenAIClient) VoiceCount() int {
+	o.voiceCacheMu.RLock()
+	defer o.voiceCacheMu.RUnlock()
+	return len(o.voiceCache)
+}
+
+// Ping implements Provider. Any answer but an authentication or server error counts, since
+// deployments and proxies don't all serve the same endpoints.
+func (o *OpenAIClient) Ping(ctx context.Context) error {
+	endpoint, err := o.endpoint("models")
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	o.setAuth(req)
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden || resp.StatusCode >= 500 {
+		return fmt.Errorf("OpenAI API error (status %d)", resp.StatusCode)
+	}
+	return nil
+}
+
+// RateLimiterTokens implements Provider
+func (o *OpenAIClient) RateLimiterTokens() float64 {
+	return o.rateLimiter.Tokens()
+}
+
+// RateLimiter implements Provider
+func (o *OpenAIClient) RateLimiter() *rate.Limiter {
+	return o.rateLimiter
+}
+
+// MissingCustomVoices implements Provider; there are no custom voice mappings
+func (o *OpenAIClient) MissingCustomVoices() map[string]string {
+	return map[string]string{}
+}
+
+// VoiceName implements Provider (see selectVoice)
+func (o *OpenAIClient) VoiceName(languageCode string) (string, error) {
+	o.voiceCacheMu.RLock()
+	defer o.voiceCacheMu.RUnlock()
+	return selectVoice(languageCode, nil, o.voiceCache)
+}
+
+// DefaultVoices implements Provider. OpenAI voices have no locale, so there are none.
+func (o *OpenAIClient) DefaultVoices() map[string]string {
+	return map[string]string{}
+}
+
+// openAIResponseFormat returns the response_format for the options' format. OpenAI chooses the
+// MP3 quality itself, and its WAV audio is 24kHz, so the 16kHz WAV format isn't available.
+func openAIResponseFormat(opts Options) (string, error) {
+	switch opts.Format {
+	case FormatMP3:
+		return "mp3", nil
+	case FormatOggOpus48K:
+		return "opus", nil
+	default:
+		return "", fmt.Errorf("OpenAI doesn't support %s audio", opts.Format)
+	}
+}
+
+// Synthesize implements Provider
+func (o *OpenAIClient) Synthesize(ctx context.Context, text, languageCode string, opts Options) ([]byte, error) {
+	if opts.SSML {
+		return nil, fmt.Errorf("OpenAI doesn't support SSML input")
+	}
+	responseFormat, err := openAIResponseFormat(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := o.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter error: %w", err)
+	}
+
+	locale := voiceLocale(languageCode, opts)
+	voice, err := voiceFor(o, languageCode, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voice for language %s: %w", locale, err)
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"model":           o.model,
+		"input":           text,
+		"voice":           voice,
+		"response_format": responseFormat,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	endpoint, err := o.endpoint("audio/speech")
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	o.setAuth(req)
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("OpenAI API error (status %d): %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	audioData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(audioData) == 0 {
+		return nil, fmt.Errorf("synthesis produced no audio data")
+	}
+	return audioData, nil
+}
+
+// endpoint returns the URL of an API path under the base URL, keeping the base URL's query
+// (Azure OpenAI deployments need an api-version parameter)
+func (o *OpenAIClient) endpoint(path string) (string, error) {
+	base, err := url.Parse(o.baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid OpenAI base URL: %w", err)
+	}
+	return base.JoinPath(path).String(), nil
+}
+
+// setAuth adds the API key to a request, both as OpenAI expects it and as the api-key header
+// Azure OpenAI expects
+func (o *OpenAIClient) setAuth(req *http.Request) {
+	if o.apiKey == "" {
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	req.Header.Set("api-key", o.apiKey)
+}
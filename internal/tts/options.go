@@ -0,0 +1,312 @@
+package tts
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// AudioFormat is the encoding of synthesized audio
+type AudioFormat int
+
+const (
+	FormatMP3    AudioFormat = iota // 16kHz 128kbps mono MP3 (default)
+	FormatWAV16K                    // 16kHz 16-bit mono PCM in a RIFF/WAV container
+	FormatOpus24K                   // 24kHz 48kbps mono Opus frames without a container
+	FormatOggOpus48K                // 48kHz mono Opus in an OGG container
+	FormatOggOpus24K                // 24kHz mono Opus in an OGG container, the smallest format for speech
+)
+
+// String returns the format's name as used in cache keys and logs
+func (f AudioFormat) String() string {
+	switch f {
+	case FormatWAV16K:
+		return "wav-16k"
+	case FormatOpus24K:
+		return "opus-24k"
+	case FormatOggOpus48K:
+		return "ogg-opus-48k"
+	case FormatOggOpus24K:
+		return "ogg-opus-24k"
+	default:
+		return "mp3"
+	}
+}
+
+// azureOutputFormat returns the X-Microsoft-OutputFormat header value for the format
+func (f AudioFormat) azureOutputFormat() string {
+	switch f {
+	case FormatWAV16K:
+		return "riff-16khz-16bit-mono-pcm"
+	case FormatOpus24K:
+		return "audio-24khz-16bit-48kbps-mono-opus"
+	case FormatOggOpus48K:
+		return "ogg-48khz-16bit-mono-opus"
+	case FormatOggOpus24K:
+		return "ogg-24khz-16bit-mono-opus"
+	default:
+		return "audio-16khz-128kbitrate-mono-mp3"
+	}
+}
+
+// Default MP3 quality; MP3 entries cached at it keep the keys they had before it was configurable
+const (
+	DefaultMP3Bitrate      = "128k"
+	DefaultMP3SampleRateHz = 16000
+)
+
+// mp3Quality is a sample rate and bitrate Azure can synthesize MP3 audio at
+type mp3Quality struct {
+	sampleRateHz int
+	bitrate      string
+}
+
+// mp3OutputFormats lists the supported MP3 qualities with their X-Microsoft-OutputFormat header
+// values. Azure has no 8kHz MP3 format and offers only some bitrates at each sample rate.
+var mp3OutputFormats = []struct {
+	quality mp3Quality
+	header  string
+}{
+	{mp3Quality{16000, "32k"}, "audio-16khz-32kbitrate-mono-mp3"},
+	{mp3Quality{16000, "64k"}, "audio-16khz-64kbitrate-mono-mp3"},
+	{mp3Quality{16000, "128k"}, "audio-16khz-128kbitrate-mono-mp3"},
+	{mp3Quality{24000, "48k"}, "audio-24khz-48kbitrate-mono-mp3"},
+	{mp3Quality{24000, "96k"}, "audio-24khz-96kbitrate-mono-mp3"},
+	{mp3Quality{24000, "160k"}, "audio-24khz-160kbitrate-mono-mp3"},
+	{mp3Quality{48000, "96k"}, "audio-48khz-96kbitrate-mono-mp3"},
+	{mp3Quality{48000, "192k"}, "audio-48khz-192kbitrate-mono-mp3"},
+}
+
+// ValidateMP3Quality checks that Azure can synthesize MP3 audio at sampleRateHz and bitrate
+// (0 and "" are the defaults)
+func ValidateMP3Quality(sampleRateHz int, bitrate string) error {
+	_, err := Options{MP3SampleRateHz: sampleRateHz, MP3Bitrate: bitrate}.azureOutputFormat()
+	return err
+}
+
+// mp3Quality returns the MP3 quality of the options, with the defaults filled in
+func (o Options) mp3Quality() mp3Quality {
+	q := mp3Quality{sampleRateHz: o.MP3SampleRateHz, bitrate: o.MP3Bitrate}
+	if q.sampleRateHz == 0 {
+		q.sampleRateHz = DefaultMP3SampleRateHz
+	}
+	if q.bitrate == "" {
+		q.bitrate = DefaultMP3Bitrate
+	}
+	return q
+}
+
+// azureOutputFormat returns the X-Microsoft-OutputFormat header value for the options' format
+// and, for MP3, its quality
+func (o Options) azureOutputFormat() (string, error) {
+	if o.Format != FormatMP3 {
+		return o.Format.azureOutputFormat(), nil
+	}
+
+	q := o.mp3Quality()
+	var supported []string
+	for _, f := range mp3OutputFormats {
+		if f.quality == q {
+			return f.header, nil
+		}
+		supported = append(supported, fmt.Sprintf("%s at %d Hz", f.quality.bitrate, f.quality.sampleRateHz))
+	}
+	return "", fmt.Errorf("unsupported MP3 quality %s at %d Hz (supported: %s)", q.bitrate, q.sampleRateHz, strings.Join(supported, ", "))
+}
+
+// audioFormats lists every supported format
+var audioFormats = []AudioFormat{FormatMP3, FormatWAV16K, FormatOpus24K, FormatOggOpus48K, FormatOggOpus24K}
+
+// compressible reports whether cached audio in this format should be zstd compressed
+func (f AudioFormat) compressible() bool {
+	return f != FormatWAV16K
+}
+
+// isWAV reports whether audioData starts with a RIFF/WAVE header
+func isWAV(audioData []byte) bool {
+	return len(audioData) >= 12 && string(audioData[0:4]) == "RIFF" && string(audioData[8:12]) == "WAVE"
+}
+
+// isOgg reports whether audioData starts with an OGG page header
+func isOgg(audioData []byte) bool {
+	return len(audioData) >= 4 && string(audioData[0:4]) == "OggS"
+}
+
+// Options controls how text is turned into audio. Any option that changes the synthesized
+// audio must also be reflected in the cache key, so each combination is cached separately.
+type Options struct {
+	InjectBreaks    bool        // Insert a short pause after sentence-ending punctuation
+	BreakAtNewlines bool        // Turn line breaks and blank lines into pauses
+	Format          AudioFormat // Encoding requested from Azure
+	MP3Bitrate      string      // MP3 only: bitrate such as "64k" ("" = DefaultMP3Bitrate)
+	MP3SampleRateHz int         // MP3 only: sample rate (0 = DefaultMP3SampleRateHz)
+	VoiceLocale     string      // Locale whose voice is used when the language has none ("" = its own)
+	Voice           string      // Voice to use instead of the language's, e.g. "en-US-GuyNeural"
+	SSML            bool        // Text is an SSML document to synthesize as written (Azure only)
+	Namespace       string      // Tenant whose cache the entry belongs to ("" = the default namespace)
+
+	// Punctuation restoration rewrites the text itself before it is cached, so it needs no
+	// cache key variant
+	RestorePunctuation  bool   // Add punctuation to unpunctuated text (see RestorePunctuation)
+	PunctuationLanguage string // Language whose rules are used ("" = the request's language)
+
+	// Tags label the stored entry for grouping (see Cache.DeleteByTag) and don't change the
+	// audio, so they aren't part of the cache key either
+	Tags []string
+
+	// TTLSeconds is how long the stored entry lives, overriding the cache's TTL (0 = the cache's
+	// TTL, negative = it never expires). It only applies when the entry is stored, not to
+	// requests answered from the cache.
+	TTLSeconds int64
+}
+
+// expiresAt returns the expires_at of an entry stored at now with the options (0 = per the TTL)
+func (o Options) expiresAt(now int64) int64 {
+	switch {
+	case o.TTLSeconds == 0:
+		return 0
+	case o.TTLSeconds < 0:
+		return neverExpires
+	default:
+		return now + o.TTLSeconds
+	}
+}
+
+// variant returns the cache key suffix for the options, or "" for the defaults so that
+// existing cache entries keep their keys
+func (o Options) variant() string {
+	var parts []string
+	if o.InjectBreaks {
+		parts = append(parts, "breaks")
+	}
+	if o.BreakAtNewlines {
+		parts = append(parts, "newline-breaks")
+	}
+	if o.Format != FormatMP3 {
+		parts = append(parts, o.Format.String())
+	} else if q := o.mp3Quality(); q != (mp3Quality{DefaultMP3SampleRateHz, DefaultMP3Bitrate}) {
+		parts = append(parts, fmt.Sprintf("mp3-%dhz-%s", q.sampleRateHz, q.bitrate))
+	}
+	if o.VoiceLocale != "" {
+		parts = append(parts, "voice="+o.VoiceLocale)
+	}
+	if o.Voice != "" {
+		parts = append(parts, "voice-name="+o.Voice)
+	}
+	if o.SSML {
+		parts = append(parts, "ssml")
+	}
+	return strings.Join(parts, ",")
+}
+
+// allOptions returns every combination of options that can appear in a cache key, with each of
+// voiceLocales as the fallback voice locale besides none. It must be kept in sync with variant
+// when options are added.
+func allOptions(voiceLocales ...string) []Options {
+	// Each format once, with MP3 at every quality
+	var formats []Options
+	for _, format := range audioFormats {
+		if format != FormatMP3 {
+			formats = append(formats, Options{Format: format})
+			continue
+		}
+		for _, f := range mp3OutputFormats {
+			formats = append(formats, Options{MP3SampleRateHz: f.quality.sampleRateHz, MP3Bitrate: f.quality.bitrate})
+		}
+	}
+
+	var all []Options
+	for _, voiceLocale := range append([]string{""}, voiceLocales...) {
+		for _, format := range formats {
+			for _, injectBreaks := range []bool{false, true} {
+				for _, breakAtNewlines := range []bool{false, true} {
+					opts := format
+					opts.InjectBreaks = injectBreaks
+					opts.BreakAtNewlines = breakAtNewlines
+					opts.VoiceLocale = voiceLocale
+					all = append(all, opts)
+				}
+			}
+		}
+	}
+
+	// SSML documents carry their own pauses
+	for _, format := range formats {
+		opts := format
+		opts.SSML = true
+		all = append(all, opts)
+	}
+	return all
+}
+
+// optionsForKey returns the options that produce cacheKey for text and languageCode. The options
+// an entry was synthesized with aren't stored, so they are recovered by trying every combination.
+// voiceName is the voice recorded for the entry ("" if unknown); its locale is tried as the
+// fallback voice locale, and the voice itself as an explicitly requested voice. namespace is the
+// entry's namespace.
+func optionsForKey(cacheKey, namespace, text, languageCode, voiceName string) (Options, bool) {
+	var voiceLocales []string
+	if locale := localeOfVoice(voiceName); locale != "" && locale != languageCode {
+		voiceLocales = append(voiceLocales, locale)
+	}
+	for _, opts := range allOptions(voiceLocales...) {
+		opts.Namespace = namespace
+		if GenerateCacheKey(text, languageCode, opts) == cacheKey {
+			return opts, true
+		}
+	}
+	if voiceName != "" {
+		for _, opts := range allOptions() {
+			opts.Voice = voiceName
+			opts.Namespace = namespace
+			if GenerateCacheKey(text, languageCode, opts) == cacheKey {
+				return opts, true
+			}
+		}
+	}
+	return Options{}, false
+}
+
+// normalizeForKey normalizes text for the cache key. When newlines become pauses they change
+// the audio, so the line structure is preserved instead of being collapsed into spaces. SSML is
+// used as-is, since any change to it can change the audio.
+func normalizeForKey(text string, opts Options) string {
+	if opts.SSML {
+		return text
+	}
+	if !opts.BreakAtNewlines {
+		return NormalizeText(text)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.ToLower(strings.Join(strings.Fields(line), " "))
+	}
+	text = strings.Trim(strings.Join(lines, "\n"), "\n")
+
+	return strings.TrimRightFunc(text, func(r rune) bool {
+		return unicode.IsPunct(r) || unicode.IsSpace(r)
+	})
+}
+
+var (
+	paragraphBreakPattern       = regexp.MustCompile(`\n[ \t]*\n\s*`)
+	sentenceEndPattern          = regexp.MustCompile(`([.!?])(\s|$)`)
+	// Full-width punctuation ends a sentence without a following space, as in Chinese and Japanese
+	fullWidthSentenceEndPattern = regexp.MustCompile(`[。！？]`)
+)
+
+// insertBreaks adds SSML <break> elements to XML-escaped text according to opts
+func insertBreaks(escaped string, opts Options) string {
+	if opts.BreakAtNewlines {
+		escaped = strings.ReplaceAll(escaped, "\r\n", "\n")
+		escaped = paragraphBreakPattern.ReplaceAllString(escaped, `<break time="500ms"/>`)
+		escaped = strings.ReplaceAll(escaped, "\n", `<break time="200ms"/>`)
+	}
+	if opts.InjectBreaks {
+		escaped = sentenceEndPattern.ReplaceAllString(escaped, `$1<break time="100ms"/>$2`)
+		escaped = fullWidthSentenceEndPattern.ReplaceAllString(escaped, `$0<break time="100ms"/>`)
+	}
+	return escaped
+}
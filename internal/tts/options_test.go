@@ -0,0 +1,191 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInsertBreaks(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		opts Options
+		want string
+	}{
+		{
+			name: "disabled",
+			text: "One. Two\nThree\n\nFour",
+			want: "One. Two\nThree\n\nFour",
+		},
+		{
+			name: "sentence ends",
+			text: "One. Two! Three? Four",
+			opts: Options{InjectBreaks: true},
+			want: `One.<break time="100ms"/> Two!<break time="100ms"/> Three?<break time="100ms"/> Four`,
+		},
+		{
+			name: "sentence end at the end of the text",
+			text: "Done.",
+			opts: Options{InjectBreaks: true},
+			want: `Done.<break time="100ms"/>`,
+		},
+		{
+			name: "decimal point isn't a sentence end",
+			text: "It costs 3.50 today",
+			opts: Options{InjectBreaks: true},
+			want: "It costs 3.50 today",
+		},
+		{
+			name: "CJK sentence ends",
+			text: "你好。再见！",
+			opts: Options{InjectBreaks: true},
+			want: `你好。<break time="100ms"/>再见！<break time="100ms"/>`,
+		},
+		{
+			name: "newlines",
+			text: "apple\nbanana\n\ncherry",
+			opts: Options{BreakAtNewlines: true},
+			want: `apple<break time="200ms"/>banana<break time="500ms"/>cherry`,
+		},
+		{
+			name: "blank line with spaces and CRLF",
+			text: "apple\r\n  \r\nbanana",
+			opts: Options{BreakAtNewlines: true},
+			want: `apple<break time="500ms"/>banana`,
+		},
+		{
+			name: "both",
+			text: "First line.\nSecond line.",
+			opts: Options{InjectBreaks: true, BreakAtNewlines: true},
+			want: `First line.<break time="200ms"/>Second line.<break time="100ms"/>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := insertBreaks(tt.text, tt.opts); got != tt.want {
+				t.Errorf("insertBreaks(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsertBreaksAfterEscaping(t *testing.T) {
+	// Breaks are inserted into escaped text, and mustn't themselves be escaped
+	got := insertBreaks(escapeXML("Tom & Jerry. <Fin>"), Options{InjectBreaks: true})
+	want := `Tom &amp; Jerry.<break time="100ms"/> &lt;Fin&gt;`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if err := ValidateSSML(`<speak version="1.0" xml:lang="en-US">` + got + "</speak>"); err != nil {
+		t.Errorf("SSML with breaks is invalid: %v", err)
+	}
+}
+
+func TestBreakOptionsCacheSeparately(t *testing.T) {
+	text := "One.\nTwo."
+	keys := make(map[string]Options)
+	for _, opts := range []Options{
+		{},
+		{InjectBreaks: true},
+		{BreakAtNewlines: true},
+		{InjectBreaks: true, BreakAtNewlines: true},
+	} {
+		key := GenerateCacheKey(text, "en-US", opts)
+		if other, ok := keys[key]; ok {
+			t.Errorf("options %+v and %+v share the cache key %s", opts, other, key)
+		}
+		keys[key] = opts
+	}
+
+	// With newline breaks the line structure changes the audio, so it changes the key
+	flat := GenerateCacheKey("One. Two.", "en-US", Options{BreakAtNewlines: true})
+	if flat == GenerateCacheKey(text, "en-US", Options{BreakAtNewlines: true}) {
+		t.Error("texts that differ only in newlines share a key with break_at_newlines")
+	}
+	if GenerateCacheKey("one.  two", "en-US", Options{}) != GenerateCacheKey(text, "en-US", Options{}) {
+		t.Error("texts that differ only in newlines and case have different keys without breaks")
+	}
+}
+
+func TestAzureOutputFormatHeader(t *testing.T) {
+	client, server := newMockAzure(t, testTone(100*time.Millisecond))
+
+	// Every configurable bitrate at every configurable sample rate; Azure has formats for some
+	supported := map[mp3Quality]string{
+		{16000, "32k"}:  "audio-16khz-32kbitrate-mono-mp3",
+		{16000, "64k"}:  "audio-16khz-64kbitrate-mono-mp3",
+		{16000, "128k"}: "audio-16khz-128kbitrate-mono-mp3",
+		{24000, "48k"}:  "audio-24khz-48kbitrate-mono-mp3",
+		{24000, "96k"}:  "audio-24khz-96kbitrate-mono-mp3",
+		{24000, "160k"}: "audio-24khz-160kbitrate-mono-mp3",
+		{48000, "96k"}:  "audio-48khz-96kbitrate-mono-mp3",
+		{48000, "192k"}: "audio-48khz-192kbitrate-mono-mp3",
+	}
+	keys := make(map[string]mp3Quality)
+	for _, sampleRateHz := range []int{8000, 16000, 24000, 48000} {
+		for _, bitrate := range []string{"32k", "48k", "64k", "96k", "128k", "160k", "192k"} {
+			q := mp3Quality{sampleRateHz, bitrate}
+			t.Run(fmt.Sprintf("%dhz-%s", sampleRateHz, bitrate), func(t *testing.T) {
+				opts := Options{MP3SampleRateHz: sampleRateHz, MP3Bitrate: bitrate}
+				sent := len(server.formats)
+				_, err := client.Synthesize(context.Background(), "Hello", "en-US", opts)
+
+				want, ok := supported[q]
+				if !ok {
+					if err == nil || !strings.Contains(err.Error(), "unsupported MP3 quality") {
+						t.Errorf("Synthesize = %v, want an unsupported quality error", err)
+					}
+					if ValidateMP3Quality(sampleRateHz, bitrate) == nil {
+						t.Error("ValidateMP3Quality accepts the quality")
+					}
+					if len(server.formats) != sent {
+						t.Error("a request was sent for an unsupported quality")
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := server.formats[len(server.formats)-1]; got != want {
+					t.Errorf("X-Microsoft-OutputFormat = %q, want %q", got, want)
+				}
+
+				// Each quality is cached separately
+				key := GenerateCacheKey("Hello", "en-US", opts)
+				if other, ok := keys[key]; ok {
+					t.Errorf("shares its cache key with %+v", other)
+				}
+				keys[key] = q
+			})
+		}
+	}
+
+	// The defaults, which keep the key MP3 entries had before the quality was configurable, and
+	// the other formats
+	tests := []struct {
+		opts Options
+		want string
+	}{
+		{Options{}, "audio-16khz-128kbitrate-mono-mp3"},
+		{Options{MP3Bitrate: "64k"}, "audio-16khz-64kbitrate-mono-mp3"},
+		{Options{MP3SampleRateHz: 24000, MP3Bitrate: "96k"}, "audio-24khz-96kbitrate-mono-mp3"},
+		{Options{Format: FormatWAV16K, MP3Bitrate: "32k"}, "riff-16khz-16bit-mono-pcm"},
+		{Options{Format: FormatOpus24K}, "audio-24khz-16bit-48kbps-mono-opus"},
+		{Options{Format: FormatOggOpus48K}, "ogg-48khz-16bit-mono-opus"},
+		{Options{Format: FormatOggOpus24K}, "ogg-24khz-16bit-mono-opus"},
+	}
+	for _, tt := range tests {
+		if _, err := client.Synthesize(context.Background(), "Hello", "en-US", tt.opts); err != nil {
+			t.Fatal(err)
+		}
+		if got := server.formats[len(server.formats)-1]; got != tt.want {
+			t.Errorf("X-Microsoft-OutputFormat for %+v = %q, want %q", tt.opts, got, tt.want)
+		}
+	}
+	if GenerateCacheKey("Hello", "en-US", Options{}) != GenerateCacheKey("Hello", "en-US", Options{MP3SampleRateHz: 16000, MP3Bitrate: "128k"}) {
+		t.Error("the explicit default quality has a different cache key than the default")
+	}
+}
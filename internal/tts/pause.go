@@ -0,0 +1,60 @@
+package tts
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSynthesisPaused is returned instead of calling Azure while synthesis is paused
+var ErrSynthesisPaused = errors.New("synthesis is paused")
+
+// PauseSynthesis stops requests from reaching Azure, for example during an Azure maintenance
+// window. Cached audio is still served. It reports whether synthesis was already paused, in
+// which case only the reason is updated.
+func (s *Service) PauseSynthesis(reason string) (wasPaused bool) {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+
+	wasPaused = s.paused.Load()
+	s.pauseReason = reason
+	if !wasPaused {
+		s.pausedAt = time.Now()
+		s.paused.Store(true)
+	}
+	return wasPaused
+}
+
+// ResumeSynthesis lets requests reach Azure again. It reports whether synthesis was paused and
+// for how long.
+func (s *Service) ResumeSynthesis() (wasPaused bool, pausedFor time.Duration) {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+
+	if !s.paused.Load() {
+		return false, 0
+	}
+	s.paused.Store(false)
+	pausedFor = time.Since(s.pausedAt)
+	s.pauseReason = ""
+	s.pausedAt = time.Time{}
+	return true, pausedFor
+}
+
+// SynthesisPaused reports whether synthesis is paused, why and since when
+func (s *Service) SynthesisPaused() (paused bool, reason string, since time.Time) {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+	return s.paused.Load(), s.pauseReason, s.pausedAt
+}
+
+// checkPaused returns ErrSynthesisPaused (with the reason) if synthesis is paused
+func (s *Service) checkPaused() error {
+	if !s.paused.Load() {
+		return nil
+	}
+	if _, reason, _ := s.SynthesisPaused(); reason != "" {
+		return fmt.Errorf("%w: %s", ErrSynthesisPaused, reason)
+	}
+	return ErrSynthesisPaused
+}
@@ -0,0 +1,270 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/polly"
+	"github.com/aws/aws-sdk-go-v2/service/polly/types"
+	"golang.org/x/time/rate"
+)
+
+// PollyClient is a Provider backed by Amazon Polly
+type PollyClient struct {
+	client       *polly.Client
+	rateLimiter  *rate.Limiter
+	customVoices map[string]string       // Custom voice mappings (overrides)
+	voiceCache   map[string]string       // Cached locale -> voice ID mappings from Polly
+	voiceEngines map[string]types.Engine // Engine each voice is synthesized with
+	voices       []Voice                 // Voices from the last FetchVoiceList that can be used
+	voiceCacheMu sync.RWMutex            // Protects voiceCache, voiceEngines and voices
+}
+
+// NewPollyClient creates a Polly client with credentials from the standard AWS chain
+// (environment, shared config and credentials files, instance roles). region overrides the
+// chain's region when set.
+func NewPollyClient(ctx context.Context, region string, maxQPS float64, customVoices map[string]string) (*PollyClient, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("no AWS region configured (set polly.region or AWS_REGION)")
+	}
+
+	return &PollyClient{
+		client:       polly.NewFromConfig(cfg),
+		rateLimiter:  rate.NewLimiter(rate.Limit(maxQPS), 1),
+		customVoices: customVoices,
+		voiceCache:   make(map[string]string),
+		voiceEngines: make(map[string]types.Engine),
+	}, nil
+}
+
+// FetchVoiceList implements Provider. Each locale's default voice is a neural voice where
+// Polly has one and a standard voice otherwise, preferring female voices.
+func (p *PollyClient) FetchVoiceList(ctx context.Context) error {
+	var voices []types.Voice
+	input := &polly.DescribeVoicesInput{}
+	for {
+		resp, err := p.client.DescribeVoices(ctx, input)
+		if err != nil {
+			return fmt.Errorf("failed to list Polly voices: %w", err)
+		}
+		voices = append(voices, resp.Voices...)
+		if resp.NextToken == nil {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+
+	type choice struct {
+		id             string
+		neural, female bool
+	}
+	best := make(map[string]choice)
+	voiceEngines := make(map[string]types.Engine, len(voices))
+	var usable []Voice
+	for _, voice := range voices {
+		engine := pollyEngine(voice)
+		if engine == "" {
+			continue
+		}
+		voiceEngines[string(voice.Id)] = engine
+		usable = append(usable, Voice{
+			Name:      aws.ToString(voice.Name),
+			ShortName: string(voice.Id),
+			Gender:    string(voice.Gender),
+			Locale:    string(voice.LanguageCode),
+			VoiceType: pollyVoiceType(engine),
+		})
+
+		c := choice{string(voice.Id), engine == types.EngineNeural, voice.Gender == types.GenderFemale}
+		locale := string(voice.LanguageCode)
+		current, exists := best[locale]
+		if !exists || (c.neural && !current.neural) || (c.neural == current.neural && c.female && !current.female) {
+			best[locale] = c
+		}
+	}
+
+	voiceCache := make(map[string]string, len(best))
+	for locale, c := range best {
+		voiceCache[locale] = c.id
+	}
+
+	p.voiceCacheMu.Lock()
+	p.voiceCache = voiceCache
+	p.voiceEngines = voiceEngines
+	p.voices = usable
+	p.voiceCacheMu.Unlock()
+
+	log.Printf("Loaded %d voices from Polly covering %d locales", len(voices), len(voiceCache))
+	return nil
+}
+
+// pollyEngine returns the engine a voice is synthesized with: neural if it supports it, else
+// standard, or "" if it supports neither
+func pollyEngine(voice types.Voice) types.Engine {
+	var standard bool
+	for _, engine := range voice.SupportedEngines {
+		switch engine {
+		case types.EngineNeural:
+			return types.EngineNeural
+		case types.EngineStandard:
+			standard = true
+		}
+	}
+	if standard {
+		return types.EngineStandard
+	}
+	return ""
+}
+
+// pollyVoiceType returns the VoiceType of a voice synthesized with engine, as Azure names them
+func pollyVoiceType(engine types.Engine) string {
+	if engine == types.EngineNeural {
+		return "Neural"
+	}
+	return "Standard"
+}
+
+// ListVoices implements Provider
+func (p *PollyClient) ListVoices(ctx context.Context) ([]Voice, error) {
+	p.voiceCacheMu.RLock()
+	defer p.voiceCacheMu.RUnlock()
+	return append([]Voice(nil), p.voices...), nil
+}
+
+// VoiceCount implements Provider
+func (p *PollyClient) VoiceCount() int {
+	p.voiceCacheMu.RLock()
+	defer p.voiceCacheMu.RUnlock()
+	return len(p.voices)
+}
+
+// Ping implements Provider by listing the voices of one language
+func (p *PollyClient) Ping(ctx context.Context) error {
+	_, err := p.client.DescribeVoices(ctx, &polly.DescribeVoicesInput{LanguageCode: types.LanguageCodeEnUs})
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	return nil
+}
+
+// RateLimiterTokens implements Provider
+func (p *PollyClient) RateLimiterTokens() float64 {
+	return p.rateLimiter.Tokens()
+}
+
+// RateLimiter implements Provider
+func (p *PollyClient) RateLimiter() *rate.Limiter {
+	return p.rateLimiter
+}
+
+// MissingCustomVoices implements Provider
+func (p *PollyClient) MissingCustomVoices() map[string]string {
+	p.voiceCacheMu.RLock()
+	defer p.voiceCacheMu.RUnlock()
+
+	missing := make(map[string]string)
+	for locale, voice := range p.customVoices {
+		if _, ok := p.voiceEngines[voice]; !ok {
+			missing[locale] = voice
+		}
+	}
+	return missing
+}
+
+// VoiceName implements Provider (see selectVoice)
+func (p *PollyClient) VoiceName(languageCode string) (string, error) {
+	p.voiceCacheMu.RLock()
+	defer p.voiceCacheMu.RUnlock()
+	return selectVoice(languageCode, p.customVoices, p.voiceCache)
+}
+
+// DefaultVoices implements Provider
+func (p *PollyClient) DefaultVoices() map[string]string {
+	p.voiceCacheMu.RLock()
+	defer p.voiceCacheMu.RUnlock()
+
+	voices := make(map[string]string, len(p.voiceCache))
+	for locale, voice := range p.voiceCache {
+		voices[locale] = voice
+	}
+	return voices
+}
+
+// Synthesize implements Provider. Polly picks the MP3 bitrate itself, so only the sample
+// rate of the MP3 quality is honored.
+func (p *PollyClient) Synthesize(ctx context.Context, text, languageCode string, opts Options) ([]byte, error) {
+	if opts.SSML {
+		return nil, fmt.Errorf("Polly doesn't support SSML input")
+	}
+	var outputFormat types.OutputFormat
+	var sampleRate int
+	switch opts.Format {
+	case FormatMP3:
+		outputFormat, sampleRate = types.OutputFormatMp3, opts.mp3Quality().sampleRateHz
+	case FormatWAV16K:
+		outputFormat, sampleRate = types.OutputFormatPcm, 16000
+	case FormatOggOpus48K:
+		outputFormat, sampleRate = types.OutputFormatOggOpus, 48000
+	case FormatOggOpus24K:
+		outputFormat, sampleRate = types.OutputFormatOggOpus, 24000
+	default:
+		return nil, fmt.Errorf("Polly doesn't support %s audio", opts.Format)
+	}
+
+	if err := p.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter error: %w", err)
+	}
+
+	locale := voiceLocale(languageCode, opts)
+	voiceName, err := voiceFor(p, languageCode, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voice for language %s: %w", locale, err)
+	}
+	p.voiceCacheMu.RLock()
+	engine, ok := p.voiceEngines[voiceName]
+	p.voiceCacheMu.RUnlock()
+	if !ok {
+		engine = types.EngineStandard
+	}
+
+	resp, err := p.client.SynthesizeSpeech(ctx, &polly.SynthesizeSpeechInput{
+		Engine:       engine,
+		OutputFormat: outputFormat,
+		SampleRate:   aws.String(strconv.Itoa(sampleRate)),
+		Text:         aws.String("<speak>" + insertBreaks(escapeXML(text), opts) + "</speak>"),
+		TextType:     types.TextTypeSsml,
+		VoiceId:      types.VoiceId(voiceName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Polly API error: %w", err)
+	}
+	defer resp.AudioStream.Close()
+
+	audioData, err := io.ReadAll(resp.AudioStream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(audioData) == 0 {
+		return nil, fmt.Errorf("synthesis produced no audio data")
+	}
+
+	// Polly's PCM audio has no header
+	if opts.Format == FormatWAV16K {
+		audioData = (&pcmWAV{sampleRate: uint32(sampleRate), samples: audioData}).encode()
+	}
+	return audioData, nil
+}
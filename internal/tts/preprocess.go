@@ -0,0 +1,136 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/metrics"
+	"com.biesnecker/tts-daemon/internal/tracing"
+)
+
+// Defaults for OpenAI preprocessing
+const (
+	DefaultPreprocessEndpoint = "https://api.openai.com/v1/chat/completions"
+	DefaultPreprocessModel    = "gpt-4o-mini"
+)
+
+// preprocessPrompt is the system prompt sent with the text to rewrite
+const preprocessPrompt = "Rewrite the following for natural text-to-speech pronunciation, expanding abbreviations and URLs:"
+
+// preprocessTimeout bounds a single chat/completions call
+const preprocessTimeout = 30 * time.Second
+
+// OpenAIPreprocessor rewrites text with an OpenAI chat model before it is synthesized, so that
+// URLs, abbreviations and code are spoken naturally
+type OpenAIPreprocessor struct {
+	Endpoint string // chat/completions URL ("" = DefaultPreprocessEndpoint)
+	APIKey   string
+	Model    string // "" = DefaultPreprocessModel
+}
+
+// chatMessage is a message of a chat/completions request or response
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatRequest is the body of a chat/completions request
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+}
+
+// chatResponse is the part of a chat/completions response the preprocessor reads
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// Preprocess returns the model's rewrite of text
+func (p *OpenAIPreprocessor) Preprocess(ctx context.Context, text string) (string, error) {
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultPreprocessEndpoint
+	}
+	model := p.Model
+	if model == "" {
+		model = DefaultPreprocessModel
+	}
+
+	body, err := json.Marshal(chatRequest{
+		Model: model,
+		Messages: []chatMessage{
+			{Role: "system", Content: preprocessPrompt},
+			{Role: "user", Content: text},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, preprocessTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.APIKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("chat/completions returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	var result chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(result.Choices) == 0 || strings.TrimSpace(result.Choices[0].Message.Content) == "" {
+		return "", fmt.Errorf("chat/completions returned no text")
+	}
+	return strings.TrimSpace(result.Choices[0].Message.Content), nil
+}
+
+// SetTextPreprocessor rewrites text with p before it is sent to the provider. Entries are still
+// cached (and looked up) under the original text, so the cache doesn't depend on the model's
+// output. nil disables preprocessing.
+func (s *Service) SetTextPreprocessor(p *OpenAIPreprocessor) {
+	s.preprocessor = p
+}
+
+// synthesisText returns the text to send to the provider for text, which is text itself unless
+// a preprocessor is set and text isn't SSML. If preprocessing fails the original text is
+// synthesized.
+func (s *Service) synthesisText(ctx context.Context, text string, opts Options) string {
+	if s.preprocessor == nil || opts.SSML {
+		return text
+	}
+
+	started := time.Now()
+	ctx, span := tracing.Start(ctx, "text_preprocess")
+	rewritten, err := s.preprocessor.Preprocess(ctx, text)
+	endSpan(span, err)
+	metrics.PreprocessDuration.Observe(time.Since(started).Seconds())
+	if err != nil {
+		log.Printf("Warning: text preprocessing failed, synthesizing the original text: %v", err)
+		return text
+	}
+	return rewritten
+}
@@ -0,0 +1,107 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/time/rate"
+)
+
+// Voice is a voice offered by a provider. The fields are those of Azure's voice list, which it
+// decodes; other providers fill in the ones they have.
+type Voice struct {
+	Name            string   `json:"Name"`
+	DisplayName     string   `json:"DisplayName"`
+	ShortName       string   `json:"ShortName"` // What requests and voice mappings name the voice by
+	Gender          string   `json:"Gender"`
+	Locale          string   `json:"Locale"` // "" for voices that speak any language
+	VoiceType       string   `json:"VoiceType"`
+	Status          string   `json:"Status"`
+	WordsPerMinute  string   `json:"WordsPerMinute"`
+	SampleRateHertz string   `json:"SampleRateHertz"`
+	StyleList       []string `json:"StyleList,omitempty"`
+}
+
+// Provider synthesizes speech. AzureClient, GCloudClient, PollyClient, ElevenLabsClient and
+// OpenAIClient are the real implementations; MockAzureClient serves pre-recorded audio for
+// development without credentials.
+type Provider interface {
+	// FetchVoiceList loads the voices available for synthesis
+	FetchVoiceList(ctx context.Context) error
+	// ListVoices returns the voices loaded by the last FetchVoiceList
+	ListVoices(ctx context.Context) ([]Voice, error)
+	// VoiceCount returns the number of voices loaded by the last FetchVoiceList
+	VoiceCount() int
+	// Ping checks that the provider is reachable
+	Ping(ctx context.Context) error
+	// RateLimiterTokens returns the number of requests that can be made immediately
+	RateLimiterTokens() float64
+	// RateLimiter returns the limiter that synthesis requests wait on
+	RateLimiter() *rate.Limiter
+	// MissingCustomVoices returns the configured voice mappings the provider doesn't offer
+	MissingCustomVoices() map[string]string
+	// VoiceName returns the voice used to synthesize languageCode
+	VoiceName(languageCode string) (string, error)
+	// DefaultVoices returns the provider's default voice for each locale
+	DefaultVoices() map[string]string
+	// Synthesize returns audio for text in opts.Format (MP3 by default)
+	Synthesize(ctx context.Context, text, languageCode string, opts Options) ([]byte, error)
+}
+
+// CustomVoiceProvider is implemented by providers whose custom voice mappings can be replaced
+// while they are in use
+type CustomVoiceProvider interface {
+	Provider
+	SetCustomVoices(customVoices map[string]string)
+}
+
+// anyVoiceLocale is the voice cache key of a voice that is used for every language
+const anyVoiceLocale = "*"
+
+// selectVoice picks the voice for a language code from the configured custom voices and the
+// provider's default voice for each locale (voiceCache).
+// Priority order:
+// 1. Custom voice exact match (e.g., es-MX in config)
+// 2. Provider cache exact match (e.g., es-MX from the voice list)
+// 3. Custom voice base language (e.g., es in config as fallback)
+// 4. Provider cache base language (e.g., es from the voice list as fallback)
+// 5. Provider cache wildcard (anyVoiceLocale), for providers whose voices have no locale
+func selectVoice(languageCode string, customVoices, voiceCache map[string]string) (string, error) {
+	// 1. Check custom voice mapping for exact match
+	if voice, ok := customVoices[languageCode]; ok {
+		return voice, nil
+	}
+
+	// 2. Check the provider's voices for exact match
+	if voice, ok := voiceCache[languageCode]; ok {
+		return voice, nil
+	}
+
+	// Extract base language for fallback checks
+	if len(languageCode) > 2 && languageCode[2] == '-' {
+		baseLanguage := languageCode[:2]
+
+		// 3. Check custom voice mapping for base language
+		if voice, ok := customVoices[baseLanguage]; ok {
+			return voice, nil
+		}
+
+		// 4. Check the provider's voices for base language
+		if voice, ok := voiceCache[baseLanguage]; ok {
+			return voice, nil
+		}
+	}
+
+	// 5. Check for a voice that speaks any language
+	if voice, ok := voiceCache[anyVoiceLocale]; ok {
+		return voice, nil
+	}
+
+	// If voice cache is empty, it means FetchVoiceList hasn't been called
+	if len(voiceCache) == 0 {
+		return "", fmt.Errorf("voice cache not initialized - call FetchVoiceList first")
+	}
+
+	// No matching voice found
+	return "", fmt.Errorf("no voice available for language code: %s", languageCode)
+}
@@ -0,0 +1,104 @@
+package tts
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// punctuationWindow is roughly how many words a restored sentence may run before it is ended
+// at the next clause boundary
+const punctuationWindow = 20
+
+// minClauseWords is how many words must precede a conjunction before a comma is inserted, so short
+// phrases such as "salt and pepper" are left alone
+const minClauseWords = 3
+
+var (
+	// conjunctions get a comma before them (or end the sentence once it has grown past the window)
+	conjunctions = map[string]bool{"and": true, "but": true, "or": true, "because": true}
+
+	// clauseStarters are words that commonly begin a new clause in spoken English
+	clauseStarters = map[string]bool{
+		"i": true, "we": true, "you": true, "he": true, "she": true, "they": true,
+		"so": true, "then": true, "now": true, "anyway": true,
+	}
+
+	// unsupportedPunctuationLanguages records languages already warned about
+	unsupportedPunctuationLanguages sync.Map
+)
+
+// RestorePunctuation adds commas and periods to unpunctuated text, such as speech recognition
+// output, so that it is delivered with natural pauses. Text that already contains sentence-ending
+// punctuation is returned unchanged. Only English is supported; other languages are returned
+// unchanged.
+func RestorePunctuation(text, languageCode string) (string, error) {
+	if !utf8.ValidString(text) {
+		return "", fmt.Errorf("text is not valid UTF-8")
+	}
+
+	if !strings.HasPrefix(strings.ToLower(languageCode), "en") {
+		if _, warned := unsupportedPunctuationLanguages.LoadOrStore(languageCode, true); !warned {
+			log.Printf("Warning: punctuation restoration is not supported for %s, text is left unchanged", languageCode)
+		}
+		return text, nil
+	}
+
+	if strings.ContainsAny(text, ".!?") {
+		return text, nil
+	}
+
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return text, nil
+	}
+
+	out := make([]string, 0, len(words))
+	sentenceWords, clauseWords := 0, 0
+	for _, word := range words {
+		// Never punctuate straight after a conjunction or clause starter ("and. then", "then. i")
+		if n := len(out); n > 0 && !endsWithPunct(out[n-1]) && !isBoundaryWord(bareWord(out[n-1])) {
+			lower := bareWord(word)
+			switch {
+			case sentenceWords >= punctuationWindow && isBoundaryWord(lower):
+				out[n-1] += "."
+				sentenceWords, clauseWords = 0, 0
+			case conjunctions[lower] && clauseWords >= minClauseWords:
+				out[n-1] += ","
+				clauseWords = 0
+			}
+		}
+
+		out = append(out, word)
+		sentenceWords++
+		clauseWords++
+		if endsWithPunct(word) {
+			clauseWords = 0
+		}
+	}
+
+	if last := len(out) - 1; !endsWithPunct(out[last]) {
+		out[last] += "."
+	}
+
+	return strings.Join(out, " "), nil
+}
+
+// endsWithPunct reports whether word ends with a punctuation mark
+func endsWithPunct(word string) bool {
+	r, _ := utf8.DecodeLastRuneInString(word)
+	return unicode.IsPunct(r)
+}
+
+// bareWord returns word in lower case without surrounding punctuation
+func bareWord(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, unicode.IsPunct))
+}
+
+// isBoundaryWord reports whether word is a conjunction or clause starter
+func isBoundaryWord(word string) bool {
+	return conjunctions[word] || clauseStarters[word]
+}
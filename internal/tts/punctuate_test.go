@@ -0,0 +1,107 @@
+package tts
+
+import "testing"
+
+func TestRestorePunctuation(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		languageCode string
+		want         string
+	}{
+		{
+			name:         "commas before conjunctions",
+			text:         "i went to the store and bought some milk but they were out of bread",
+			languageCode: "en-US",
+			want:         "i went to the store, and bought some milk, but they were out of bread.",
+		},
+		{
+			name:         "short phrases keep their conjunctions bare",
+			text:         "salt and pepper",
+			languageCode: "en-US",
+			want:         "salt and pepper.",
+		},
+		{
+			name:         "long run-on is split into sentences",
+			text:         "so we started the meeting late because the projector was broken and nobody could find the cable then we decided to just talk through the slides without it and i think it went fine anyway",
+			languageCode: "en-GB",
+			want:         "so we started the meeting late, because the projector was broken, and nobody could find the cable then we decided to just talk through the slides without it. and i think it went fine anyway.",
+		},
+		{
+			name:         "alternatives",
+			text:         "we tried the first option or the second option and neither worked",
+			languageCode: "en-US",
+			want:         "we tried the first option, or the second option, and neither worked.",
+		},
+		{
+			name:         "full stop added at the end",
+			text:         "hello world",
+			languageCode: "en-US",
+			want:         "hello world.",
+		},
+		{
+			name:         "extra whitespace from the recognizer is collapsed",
+			text:         "  okay  see you\ttomorrow ",
+			languageCode: "en-US",
+			want:         "okay see you tomorrow.",
+		},
+		{
+			name:         "already punctuated text is unchanged",
+			text:         "Already punctuated. leave it and go",
+			languageCode: "en-US",
+			want:         "Already punctuated. leave it and go",
+		},
+		{
+			name:         "blank text is unchanged",
+			text:         "   ",
+			languageCode: "en-US",
+			want:         "   ",
+		},
+		{
+			name:         "other languages are unchanged",
+			text:         "ich bin nach hause gegangen und habe gegessen aber nicht viel",
+			languageCode: "de-DE",
+			want:         "ich bin nach hause gegangen und habe gegessen aber nicht viel",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RestorePunctuation(tt.text, tt.languageCode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("RestorePunctuation(%q) =\n%q, want\n%q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestorePunctuationInvalidUTF8(t *testing.T) {
+	if _, err := RestorePunctuation("bad \xff text", "en-US"); err == nil {
+		t.Error("RestorePunctuation accepted invalid UTF-8")
+	}
+}
+
+func TestPrepareText(t *testing.T) {
+	const asr = "turn left at the light and then keep going"
+	tests := []struct {
+		name         string
+		text         string
+		languageCode string
+		opts         Options
+		want         string
+	}{
+		{"disabled", asr, "en-US", Options{}, asr},
+		{"enabled", asr, "en-US", Options{RestorePunctuation: true}, "turn left at the light, and then keep going."},
+		{"language override", asr, "fr-FR", Options{RestorePunctuation: true, PunctuationLanguage: "en"}, "turn left at the light, and then keep going."},
+		{"SSML is never rewritten", "<speak>" + asr + "</speak>", "en-US", Options{RestorePunctuation: true, SSML: true}, "<speak>" + asr + "</speak>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prepareText(tt.text, tt.languageCode, tt.opts); got != tt.want {
+				t.Errorf("prepareText = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package tts
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Synthesis job states
+const (
+	JobPending    = "pending"
+	JobProcessing = "processing"
+	JobDone       = "done"
+	JobFailed     = "failed"
+)
+
+// Job is a queued asynchronous synthesis request
+type Job struct {
+	ID           string
+	Text         string
+	LanguageCode string
+	Priority     int
+	Status       string
+	CreatedAt    int64
+	CompletedAt  int64 // 0 until the job is done or failed
+	ErrorMessage string
+}
+
+// resetInterruptedJobs returns jobs left processing by a previous run to the queue, so they are
+// run again
+func (c *Cache) resetInterruptedJobs() error {
+	if _, err := c.db.Exec(`UPDATE synthesis_queue SET status = ? WHERE status = ?`, JobPending, JobProcessing); err != nil {
+		return fmt.Errorf("failed to reset interrupted jobs: %w", err)
+	}
+
+	return nil
+}
+
+// newJobID returns a random (version 4) UUID
+func newJobID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// EnqueueJob adds a pending synthesis job and returns its ID
+func (c *Cache) EnqueueJob(text, languageCode string, priority int) (string, error) {
+	id, err := newJobID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate job id: %w", err)
+	}
+
+	_, err = c.db.Exec(
+		`INSERT INTO synthesis_queue (id, text, language_code, priority, status, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		id, text, languageCode, priority, JobPending, getCurrentTimestamp(),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	return id, nil
+}
+
+// claimNextJob marks the highest priority pending job (oldest first) as processing and returns it,
+// or nil if the queue is empty. Jobs enqueued in the same second are taken in insertion order.
+func (c *Cache) claimNextJob() (*Job, error) {
+	var job Job
+	err := c.db.QueryRow(
+		`UPDATE synthesis_queue SET status = ?
+		 WHERE id = (
+			SELECT id FROM synthesis_queue WHERE status = ?
+			ORDER BY priority DESC, created_at ASC, rowid ASC LIMIT 1
+		 )
+		 RETURNING id, text, language_code, priority, created_at`,
+		JobProcessing, JobPending,
+	).Scan(&job.ID, &job.Text, &job.LanguageCode, &job.Priority, &job.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+
+	job.Status = JobProcessing
+	return &job, nil
+}
+
+// finishJob records the outcome of a processed job
+func (c *Cache) finishJob(id string, jobErr error) error {
+	status := JobDone
+	var errorMessage sql.NullString
+	if jobErr != nil {
+		status = JobFailed
+		errorMessage = sql.NullString{String: jobErr.Error(), Valid: true}
+	}
+
+	_, err := c.db.Exec(
+		`UPDATE synthesis_queue SET status = ?, completed_at = ?, error_message = ? WHERE id = ?`,
+		status, getCurrentTimestamp(), errorMessage, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update job %s: %w", id, err)
+	}
+	return nil
+}
+
+// PriorityUpdate sets a new priority for a queued job
+type PriorityUpdate struct {
+	JobID       string
+	NewPriority int
+}
+
+// ReorderJobs changes the priority of pending jobs in a single transaction. Jobs that don't exist
+// or are no longer pending are left alone and returned in notFound. The worker picks jobs by
+// priority on every poll, so the new order applies from the next poll.
+func (c *Cache) ReorderJobs(updates []PriorityUpdate) (updated int, notFound []string, err error) {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`UPDATE synthesis_queue SET priority = ? WHERE id = ? AND status = ?`)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to prepare update: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, u := range updates {
+		result, err := stmt.Exec(u.NewPriority, u.JobID, JobPending)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to update job %s: %w", u.JobID, err)
+		}
+		n, _ := result.RowsAffected()
+		if n == 0 {
+			notFound = append(notFound, u.JobID)
+			continue
+		}
+		updated++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, nil, fmt.Errorf("failed to commit reorder: %w", err)
+	}
+	return updated, notFound, nil
+}
+
+// GetJob returns the job with the given ID, or nil if there is none
+func (c *Cache) GetJob(id string) (*Job, error) {
+	var job Job
+	var completedAt sql.NullInt64
+	var errorMessage sql.NullString
+	err := c.db.QueryRow(
+		`SELECT id, text, language_code, priority, status, created_at, completed_at, error_message
+		 FROM synthesis_queue WHERE id = ?`,
+		id,
+	).Scan(&job.ID, &job.Text, &job.LanguageCode, &job.Priority, &job.Status, &job.CreatedAt, &completedAt, &errorMessage)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job: %w", err)
+	}
+
+	job.CompletedAt = completedAt.Int64
+	job.ErrorMessage = errorMessage.String
+	return &job, nil
+}
+
+// EnqueueSynthesis queues text for synthesis by the background worker and returns the job ID
+func (s *Service) EnqueueSynthesis(text, languageCode string, priority int) (string, error) {
+	if err := checkLanguageCode(languageCode); err != nil {
+		return "", err
+	}
+	return s.cache.EnqueueJob(text, languageCode, priority)
+}
+
+// ReorderQueue changes the priority of pending jobs (see Cache.ReorderJobs)
+func (s *Service) ReorderQueue(updates []PriorityUpdate) (updated int, notFound []string, err error) {
+	return s.cache.ReorderJobs(updates)
+}
+
+// GetJob returns a queued job by ID, or nil if it doesn't exist
+func (s *Service) GetJob(id string) (*Job, error) {
+	return s.cache.GetJob(id)
+}
+
+// StartQueueWorker starts a background worker that polls the synthesis queue every interval and
+// processes pending jobs one at a time with opts. It stops when the service is closed.
+func (s *Service) StartQueueWorker(interval time.Duration, opts Options) {
+	s.workerStop = make(chan struct{})
+	s.workerDone = make(chan struct{})
+
+	go func() {
+		defer close(s.workerDone)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.workerStop:
+				return
+			case <-ticker.C:
+				s.drainQueue(opts)
+			}
+		}
+	}()
+}
+
+// drainQueue processes pending jobs until the queue is empty or the worker is stopped
+func (s *Service) drainQueue(opts Options) {
+	for {
+		select {
+		case <-s.workerStop:
+			return
+		default:
+		}
+
+		// Jobs stay pending while synthesis is paused
+		if s.paused.Load() {
+			return
+		}
+
+		job, err := s.cache.claimNextJob()
+		if err != nil {
+			log.Printf("Warning: synthesis queue: %v", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+
+		_, _, cached, jobErr := s.GetAudio(context.Background(), job.Text, job.LanguageCode, opts, false)
+		if err := s.cache.finishJob(job.ID, jobErr); err != nil {
+			log.Printf("Warning: synthesis queue: %v", err)
+		}
+
+		if jobErr != nil {
+			log.Printf("Queue: job %s failed: %v", job.ID, jobErr)
+		} else {
+			log.Printf("Queue: job %s done, lang=%s, cached=%v", job.ID, job.LanguageCode, cached)
+		}
+	}
+}
+
+// stopQueueWorker stops the background worker, if running, and waits for the current job to finish
+func (s *Service) stopQueueWorker() {
+	if s.workerStop == nil {
+		return
+	}
+	close(s.workerStop)
+	<-s.workerDone
+	s.workerStop = nil
+}
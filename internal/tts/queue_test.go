@@ -0,0 +1,133 @@
+package tts
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReorderJobs(t *testing.T) {
+	tests := []struct {
+		name         string
+		priorities   []int       // Initial priority of jobs 0..n-1, enqueued in order
+		updates      map[int]int // Job index to new priority
+		missing      []string    // IDs of jobs that don't exist, also updated
+		wantOrder    []int       // Job indexes in processing order
+		wantUpdated  int
+		wantNotFound int
+	}{
+		{
+			name:       "no updates keeps FIFO order",
+			priorities: []int{0, 0, 0},
+			wantOrder:  []int{0, 1, 2},
+		},
+		{
+			name:        "promote the last job",
+			priorities:  []int{0, 0, 0},
+			updates:     map[int]int{2: 10},
+			wantOrder:   []int{2, 0, 1},
+			wantUpdated: 1,
+		},
+		{
+			name:        "demote the first job",
+			priorities:  []int{5, 5, 5},
+			updates:     map[int]int{0: -1},
+			wantOrder:   []int{1, 2, 0},
+			wantUpdated: 1,
+		},
+		{
+			name:        "reverse the queue",
+			priorities:  []int{1, 2, 3},
+			updates:     map[int]int{0: 3, 2: 1},
+			wantOrder:   []int{0, 1, 2},
+			wantUpdated: 2,
+		},
+		{
+			name:         "unknown jobs are reported",
+			priorities:   []int{0, 0},
+			updates:      map[int]int{1: 1},
+			missing:      []string{"no-such-job"},
+			wantOrder:    []int{1, 0},
+			wantUpdated:  1,
+			wantNotFound: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newTestCache(t)
+			var ids []string
+			for _, priority := range tt.priorities {
+				id, err := cache.EnqueueJob("job", "en-US", priority)
+				if err != nil {
+					t.Fatal(err)
+				}
+				ids = append(ids, id)
+			}
+
+			var updates []PriorityUpdate
+			for i, priority := range tt.updates {
+				updates = append(updates, PriorityUpdate{JobID: ids[i], NewPriority: priority})
+			}
+			for _, id := range tt.missing {
+				updates = append(updates, PriorityUpdate{JobID: id, NewPriority: 100})
+			}
+			updated, notFound, err := cache.ReorderJobs(updates)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated != tt.wantUpdated || len(notFound) != tt.wantNotFound {
+				t.Errorf("updated %d with %d not found, want %d and %d", updated, len(notFound), tt.wantUpdated, tt.wantNotFound)
+			}
+			if !slices.Equal(notFound, tt.missing) {
+				t.Errorf("not found = %v, want %v", notFound, tt.missing)
+			}
+
+			var order []int
+			for {
+				job, err := cache.claimNextJob()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if job == nil {
+					break
+				}
+				order = append(order, slices.Index(ids, job.ID))
+			}
+			if !slices.Equal(order, tt.wantOrder) {
+				t.Errorf("processing order = %v, want %v", order, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestReorderJobsSkipsClaimedJobs(t *testing.T) {
+	cache := newTestCache(t)
+	first, err := cache.EnqueueJob("first", "en-US", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := cache.EnqueueJob("second", "en-US", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job, err := cache.claimNextJob(); err != nil || job.ID != first {
+		t.Fatalf("claimed %v (%v), want %s", job, err, first)
+	}
+
+	// The processing job can't be reprioritized, but the pending one can in the same call
+	updated, notFound, err := cache.ReorderJobs([]PriorityUpdate{{first, 9}, {second, 9}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated != 1 || !slices.Equal(notFound, []string{first}) {
+		t.Errorf("updated %d, not found %v; want 1 and [%s]", updated, notFound, first)
+	}
+	for id, want := range map[string]int{first: 0, second: 9} {
+		job, err := cache.GetJob(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.Priority != want {
+			t.Errorf("job %s has priority %d, want %d", id, job.Priority, want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package tts
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimitStatus describes the provider's rate limiter at one moment
+type RateLimitStatus struct {
+	CurrentTokens      float64
+	MaxTokens          float64 // Burst size
+	RatePerSecond      float64
+	NextTokenAvailable time.Duration // 0 if a request can be made immediately
+	DailyCharsUsed     int           // Characters sent to the provider today (UTC), by every kind of request
+}
+
+// RateLimitStatus reports how much capacity the provider's rate limiter has, without using any
+// of it
+func (s *Service) RateLimitStatus() RateLimitStatus {
+	limiter := s.provider.RateLimiter()
+	now := time.Now()
+
+	// Reserve().Delay() would give the same wait, but cancelling a reservation that was satisfied
+	// immediately doesn't return its token, so the wait is worked out from the token count
+	tokens := limiter.TokensAt(now)
+	status := RateLimitStatus{
+		CurrentTokens:  tokens,
+		MaxTokens:      float64(limiter.Burst()),
+		RatePerSecond:  float64(limiter.Limit()),
+		DailyCharsUsed: s.charsToday.Used(),
+	}
+	if tokens < 1 && limiter.Limit() > 0 {
+		status.NextTokenAvailable = time.Duration((1 - tokens) / float64(limiter.Limit()) * float64(time.Second))
+	}
+	return status
+}
+
+// WaitForCapacity blocks until the rate limiter has a token available, returning how long it
+// waited. The token isn't taken, so another request may use it first.
+func (s *Service) WaitForCapacity(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	for {
+		delay := s.RateLimitStatus().NextTokenAvailable
+		if delay <= 0 {
+			return time.Since(start), nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return time.Since(start), ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package tts
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRateLimitStatusCountsEverySynthesis(t *testing.T) {
+	service := NewService(newTestCache(t), newMockProvider(t))
+	ctx := context.Background()
+
+	if _, _, _, err := service.GetAudio(ctx, "Hello", "en-US", Options{}, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := service.GetAudio(ctx, "Hello", "en-US", Options{}, false); err != nil {
+		t.Fatal(err) // Cached, so nothing is sent
+	}
+	if _, err := service.SynthesizeEphemeral(ctx, "Grüße", "en-US", Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := service.RateLimitStatus().DailyCharsUsed; got != 10 {
+		t.Errorf("DailyCharsUsed = %d, want 10 (5 synthesized for the cache, 5 ephemeral)", got)
+	}
+}
@@ -0,0 +1,52 @@
+package tts
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"com.biesnecker/tts-daemon/internal/metrics"
+)
+
+// Sources of the audio GetAudio returns, as passed to MetricsRecorder.RecordRequest
+const (
+	SourceCache = "cache"
+	SourceAzure = "azure" // Synthesized by the provider, whichever it is
+)
+
+// MetricsRecorder receives the Service's request metrics. The default records them in the
+// Prometheus metrics (metrics.PrometheusRecorder); see SetMetricsRecorder.
+type MetricsRecorder interface {
+	// RecordRequest is called when GetAudio returns audio from source, or err. Requests
+	// rejected before the cache lookup (such as invalid SSML) aren't recorded.
+	RecordRequest(source, languageCode string, err error, duration time.Duration)
+
+	// RecordProviderCall is called after each synthesis call to the provider
+	RecordProviderCall(err error)
+}
+
+// SetMetricsRecorder replaces the recorder the Service reports its metrics to
+func (s *Service) SetMetricsRecorder(r MetricsRecorder) {
+	s.recorder = r
+}
+
+// synthesize calls the provider, recording the call and the characters sent
+func (s *Service) synthesize(ctx context.Context, text, languageCode string, opts Options) ([]byte, error) {
+	s.charsToday.Consume(utf8.RuneCountInString(text)) // Unlimited, so it never fails
+	audioData, err := s.provider.Synthesize(ctx, text, languageCode, opts)
+	s.recorder.RecordProviderCall(err)
+	return audioData, err
+}
+
+// setMetricsLanguages gives each locale the provider has a voice for, and each language with a
+// fallback chain, a language label of its own in the metrics (see metrics.SetLanguages)
+func (s *Service) setMetricsLanguages() {
+	languages := slices.Collect(maps.Keys(s.provider.DefaultVoices()))
+	languages = slices.AppendSeq(languages, maps.Keys(s.fallbackChains))
+	metrics.SetLanguages(languages)
+	if s.cache != nil {
+		s.cache.sizeMetricsStale.Store(true)
+	}
+}
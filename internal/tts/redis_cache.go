@@ -0,0 +1,204 @@
+package tts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/metrics"
+	"github.com/redis/go-redis/v9"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// redisTimeout bounds each Redis call, so a slow Redis doesn't hold up lookups that SQLite or
+// the provider can answer
+const redisTimeout = 2 * time.Second
+
+// RedisCache stores cache entries in Redis, so daemons behind a load balancer share them
+type RedisCache struct {
+	client    *redis.Client
+	namespace string
+}
+
+// redisEntry is a cache entry as stored in Redis, encoded with msgpack
+type redisEntry struct {
+	Text         string `msgpack:"text"`
+	LanguageCode string `msgpack:"language_code"`
+	AudioData    []byte `msgpack:"audio_data"`
+	Compression  string `msgpack:"compression,omitempty"` // As in the compression column ("" = uncompressed)
+	CreatedAt    int64  `msgpack:"created_at"`
+	VoiceName    string `msgpack:"voice_name,omitempty"`
+	Format       string `msgpack:"format,omitempty"`
+	ExpiresAt    int64  `msgpack:"expires_at,omitempty"`
+}
+
+// NewRedisCache connects to the Redis server at url (redis://[user:password@]host:port/db, or
+// rediss:// for TLS). Entries are stored under "<namespace>:<cache key>".
+func NewRedisCache(url, namespace string) (*RedisCache, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis at %s: %w", opts.Addr, err)
+	}
+
+	return &RedisCache{client: client, namespace: namespace}, nil
+}
+
+// key returns the Redis key of the entry stored under cacheKey
+func (r *RedisCache) key(cacheKey string) string {
+	return r.namespace + ":" + cacheKey
+}
+
+// Get returns the entry stored under cacheKey, with its audio as stored, or nil if there is none
+func (r *RedisCache) Get(ctx context.Context, cacheKey string) (*CachedAudio, error) {
+	data, err := r.client.Get(ctx, r.key(cacheKey)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from Redis: %w", err)
+	}
+
+	var entry redisEntry
+	if err := msgpack.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to decode Redis entry %s: %w", cacheKey, err)
+	}
+	return &CachedAudio{
+		CacheKey:     cacheKey,
+		Text:         entry.Text,
+		LanguageCode: entry.LanguageCode,
+		AudioData:    entry.AudioData,
+		Compression:  sql.NullString{String: entry.Compression, Valid: entry.Compression != ""},
+		CreatedAt:    entry.CreatedAt,
+		LastAccessed: entry.CreatedAt,
+		VoiceName:    entry.VoiceName,
+		Format:       entry.Format,
+		ExpiresAt:    entry.ExpiresAt,
+	}, nil
+}
+
+// Put stores audio, whose AudioData is compressed as its Compression says, replacing any entry
+// under the same key. It expires after ttl (0 = never).
+func (r *RedisCache) Put(ctx context.Context, audio *CachedAudio, ttl time.Duration) error {
+	data, err := msgpack.Marshal(&redisEntry{
+		Text:         audio.Text,
+		LanguageCode: audio.LanguageCode,
+		AudioData:    audio.AudioData,
+		Compression:  audio.Compression.String,
+		CreatedAt:    audio.CreatedAt,
+		VoiceName:    audio.VoiceName,
+		Format:       audio.Format,
+		ExpiresAt:    audio.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode Redis entry %s: %w", audio.CacheKey, err)
+	}
+	if err := r.client.Set(ctx, r.key(audio.CacheKey), data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to write to Redis: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the entries stored under the given cache keys
+func (r *RedisCache) Delete(ctx context.Context, cacheKeys ...string) error {
+	if len(cacheKeys) == 0 {
+		return nil
+	}
+	keys := make([]string, len(cacheKeys))
+	for i, cacheKey := range cacheKeys {
+		keys[i] = r.key(cacheKey)
+	}
+	if err := r.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete from Redis: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection to Redis
+func (r *RedisCache) Close() error {
+	return r.client.Close()
+}
+
+// SetRedis shares entries with other daemons through Redis, as a second level behind SQLite:
+// entries are written to both, and lookups that miss SQLite are answered from Redis when
+// another daemon cached the entry, copying it into SQLite (see getShared). Deleting an entry deletes it from
+// Redis too, while evictions and expired entry cleanups only affect SQLite; entries expire in
+// Redis after their own TTL or the cache's, if one is set (see SetTTL).
+func (c *Cache) SetRedis(redisCache *RedisCache) {
+	c.redis = redisCache
+}
+
+// getRedis looks up an entry that isn't in SQLite in Redis, returning nil if it isn't there
+// either. Lookup failures are logged, so the entry is synthesized instead.
+func (c *Cache) getRedis(cacheKey string) *CachedAudio {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	audio, err := c.redis.Get(ctx, cacheKey)
+	if err != nil {
+		log.Printf("Warning: Redis lookup failed: %v", err)
+		return nil
+	}
+	if audio == nil || c.expired(audio.CreatedAt, audio.ExpiresAt) {
+		return nil
+	}
+	if err := c.decompress(audio); err != nil {
+		log.Printf("Warning: Redis entry %s: %v", cacheKey, err)
+		return nil
+	}
+	metrics.RedisCacheHits.Inc()
+	return audio
+}
+
+// putRedis writes an entry to Redis, if it is enabled. Failures are logged rather than returned,
+// since the entry is still cached in SQLite.
+func (c *Cache) putRedis(audio *CachedAudio, compressible bool) {
+	if c.redis == nil {
+		return
+	}
+	stored := *audio
+	var err error
+	stored.AudioData, stored.Compression, err = c.encodeForStorage(audio.AudioData, compressible)
+	if err != nil {
+		log.Printf("Warning: failed to write %s to Redis: %v", audio.CacheKey, err)
+		return
+	}
+
+	// Entries with their own lifetime expire in Redis when they do in SQLite
+	ttl := c.ttl
+	switch {
+	case audio.ExpiresAt == neverExpires:
+		ttl = 0
+	case audio.ExpiresAt != 0:
+		if ttl = time.Duration(audio.ExpiresAt-getCurrentTimestamp()) * time.Second; ttl <= 0 {
+			return // Already expired
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := c.redis.Put(ctx, &stored, ttl); err != nil {
+		log.Printf("Warning: failed to write %s to Redis: %v", audio.CacheKey, err)
+	}
+}
+
+// dropRedis deletes entries from Redis, if it is enabled
+func (c *Cache) dropRedis(keys ...string) {
+	if c.redis == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := c.redis.Delete(ctx, keys...); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// speechRegions lists the Azure regions that offer Speech Services
+var speechRegions = []string{
+	"australiaeast", "brazilsouth", "canadacentral", "centralindia", "centralus",
+	"eastasia", "eastus", "eastus2", "francecentral", "germanywestcentral",
+	"japaneast", "japanwest", "koreacentral", "northcentralus", "northeurope",
+	"norwayeast", "qatarcentral", "southafricanorth", "southcentralus", "southeastasia",
+	"swedencentral", "switzerlandnorth", "switzerlandwest", "uaenorth", "uksouth",
+	"westcentralus", "westeurope", "westus", "westus2", "westus3",
+}
+
+// regionTokenURL is the token endpoint used to test a key against a region
+var regionTokenURL = "https://%s.api.cognitive.microsoft.com/sts/v1.0/issueToken"
+
+// AutoDetectRegion finds the region the subscription key belongs to and uses it for later
+// requests. Speech keys are only accepted by their own region's endpoints (the Azure management
+// API needs Azure AD credentials rather than a Speech key), so every Speech region's token
+// endpoint is tried and the one that accepts the key wins. It must be called before the client
+// is used.
+func (a *AzureClient) AutoDetectRegion(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tokenURL := regionTokenURL // Read once, as checks may still be running after this returns
+	found := make(chan string, len(speechRegions))
+	var wg sync.WaitGroup
+	for _, region := range speechRegions {
+		wg.Add(1)
+		go func(region string) {
+			defer wg.Done()
+			if a.keyWorksInRegion(ctx, fmt.Sprintf(tokenURL, region)) {
+				found <- region
+			}
+		}(region)
+	}
+	go func() {
+		wg.Wait()
+		close(found)
+	}()
+
+	region, ok := <-found
+	if !ok {
+		if err := ctx.Err(); err != nil {
+			return "", fmt.Errorf("region detection interrupted: %w; set azure.region in the config file", err)
+		}
+		return "", fmt.Errorf("the subscription key was not accepted in any known Speech region; set azure.region in the config file to the region shown on the resource's \"Keys and Endpoint\" page")
+	}
+
+	a.region = region
+	return region, nil
+}
+
+// keyWorksInRegion reports whether a region's token endpoint, tokenURL, accepts the subscription key
+func (a *AzureClient) keyWorksInRegion(ctx context.Context, tokenURL string) bool {
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Ocp-Apim-Subscription-Key", a.subscriptionKey)
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
+
+// Region returns the region requests are sent to
+func (a *AzureClient) Region() string {
+	return a.region
+}
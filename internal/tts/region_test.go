@@ -0,0 +1,85 @@
+package tts
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// mockTokenEndpoints serves every region's token endpoint, accepting key only in keyRegion, and
+// points regionTokenURL at it for the duration of the test
+func mockTokenEndpoints(t *testing.T, key, keyRegion string) *atomic.Int32 {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		region := strings.Split(strings.Trim(r.URL.Path, "/"), "/")[0]
+		if r.Method != http.MethodPost || r.Header.Get("Ocp-Apim-Subscription-Key") != key || region != keyRegion {
+			http.Error(w, "Access denied due to invalid subscription key", http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("token"))
+	}))
+	t.Cleanup(server.Close)
+
+	original := regionTokenURL
+	regionTokenURL = server.URL + "/%s/sts/v1.0/issueToken"
+	t.Cleanup(func() { regionTokenURL = original })
+	return &requests
+}
+
+func TestAutoDetectRegion(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		keyRegion  string // Region the mock accepts the key in
+		wantRegion string // "" for an error
+	}{
+		{"key from westeurope", "secret", "westeurope", "westeurope"},
+		{"key from the last listed region", "secret", "westus3", "westus3"},
+		{"key accepted nowhere", "wrong", "westeurope", ""},
+		{"region the client doesn't know", "secret", "marsnorth", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := mockTokenEndpoints(t, "secret", tt.keyRegion)
+			client := NewAzureClient(tt.key, "", 10, nil)
+
+			region, err := client.AutoDetectRegion(context.Background())
+			if tt.wantRegion == "" {
+				if err == nil {
+					t.Fatalf("detected %q, want an error", region)
+				}
+				if !strings.Contains(err.Error(), "set azure.region") {
+					t.Errorf("error %q doesn't explain how to set the region", err)
+				}
+				if got := requests.Load(); int(got) != len(speechRegions) {
+					t.Errorf("tried %d regions, want all %d", got, len(speechRegions))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if region != tt.wantRegion || client.Region() != tt.wantRegion {
+				t.Errorf("detected %q, client uses %q; want %q", region, client.Region(), tt.wantRegion)
+			}
+		})
+	}
+}
+
+func TestAutoDetectRegionCancelled(t *testing.T) {
+	mockTokenEndpoints(t, "secret", "westeurope")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewAzureClient("secret", "", 10, nil)
+	if _, err := client.AutoDetectRegion(ctx); err == nil || !strings.Contains(err.Error(), "interrupted") {
+		t.Errorf("error = %v, want an interrupted detection", err)
+	}
+	if client.Region() != "" {
+		t.Errorf("client region = %q after a failed detection, want none", client.Region())
+	}
+}
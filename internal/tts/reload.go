@@ -0,0 +1,27 @@
+package tts
+
+import "golang.org/x/time/rate"
+
+// SetMaxQPS changes how many requests per second the provider may make, taking effect for the
+// requests waiting on its rate limiter too
+func (s *Service) SetMaxQPS(maxQPS float64) {
+	limiter := s.provider.RateLimiter()
+	limiter.SetLimit(rate.Limit(maxQPS))
+	limiter.SetBurst(1)
+}
+
+// SetCustomVoices replaces the provider's custom voice mappings, returning false if it doesn't
+// support changing them (see CustomVoiceProvider). Entries cached with the previous voices stay
+// cached until they are re-synthesized.
+func (s *Service) SetCustomVoices(customVoices map[string]string) bool {
+	provider := s.provider
+	if batcher, ok := provider.(*RequestBatcher); ok {
+		provider = batcher.BatchProvider
+	}
+	voiceProvider, ok := provider.(CustomVoiceProvider)
+	if !ok {
+		return false
+	}
+	voiceProvider.SetCustomVoices(customVoices)
+	return true
+}
@@ -0,0 +1,242 @@
+package tts
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReplayRecord is one cached synthesis recorded in the replay log. The log holds everything needed
+// to synthesize the cache again except the audio itself.
+type ReplayRecord struct {
+	CacheKey     string
+	LanguageCode string
+	Text         string
+	AudioSize    uint32
+	Timestamp    int64 // Unix timestamp the entry was cached
+}
+
+// Options returns the synthesis options the record's cache key was generated with, or the
+// defaults if they can't be recovered
+func (r ReplayRecord) Options() Options {
+	opts, _ := optionsForKey(r.CacheKey, "", r.Text, r.LanguageCode, "")
+	return opts
+}
+
+// replayLog appends a record of every cache put to a file, rotating it at a maximum size
+type replayLog struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64 // 0 = never rotate
+	file     *os.File
+	size     int64
+}
+
+// SetReplayLog starts recording every cache put to the log file at path, rotating it (renaming it
+// with a timestamp suffix and starting a new file) when it would exceed maxMB (0 = never)
+func (c *Cache) SetReplayLog(path string, maxMB int64) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open replay log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat replay log: %w", err)
+	}
+
+	c.replay = &replayLog{
+		path:     path,
+		maxBytes: maxMB * 1024 * 1024,
+		file:     file,
+		size:     info.Size(),
+	}
+	return nil
+}
+
+// encodeReplayRecord returns the binary form of a record:
+// cache_key[32] text_length(uint16) language_len(uint8) language_code text audio_size(uint32) timestamp(int64),
+// with integers in little-endian order
+func encodeReplayRecord(r ReplayRecord) ([]byte, error) {
+	key, err := hex.DecodeString(r.CacheKey)
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("cache key %q is not a SHA-256 hex digest", r.CacheKey)
+	}
+	if len(r.Text) > math.MaxUint16 {
+		return nil, fmt.Errorf("text is too long to record (%d bytes)", len(r.Text))
+	}
+	if len(r.LanguageCode) > math.MaxUint8 {
+		return nil, fmt.Errorf("language code is too long to record (%d bytes)", len(r.LanguageCode))
+	}
+
+	buf := make([]byte, 0, 32+2+1+len(r.LanguageCode)+len(r.Text)+4+8)
+	buf = append(buf, key...)
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(r.Text)))
+	buf = append(buf, uint8(len(r.LanguageCode)))
+	buf = append(buf, r.LanguageCode...)
+	buf = append(buf, r.Text...)
+	buf = binary.LittleEndian.AppendUint32(buf, r.AudioSize)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(r.Timestamp))
+	return buf, nil
+}
+
+// append writes a record, rotating the file first if it would grow past the limit
+func (l *replayLog) append(r ReplayRecord) {
+	buf, err := encodeReplayRecord(r)
+	if err != nil {
+		log.Printf("Warning: replay log: skipping %s: %v", r.CacheKey, err)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.maxBytes > 0 && l.size > 0 && l.size+int64(len(buf)) > l.maxBytes {
+		if err := l.rotate(); err != nil {
+			log.Printf("Warning: replay log rotation failed: %v", err)
+		}
+	}
+
+	n, err := l.file.Write(buf)
+	l.size += int64(n)
+	if err != nil {
+		log.Printf("Warning: replay log write failed: %v", err)
+	}
+}
+
+// rotate renames the current file with a timestamp suffix and starts a new one
+func (l *replayLog) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	rotated := l.path + "." + time.Now().UTC().Format("20060102T150405.000000000")
+	if err := os.Rename(l.path, rotated); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	l.file = file
+	l.size = 0
+	log.Printf("Replay log rotated to %s", rotated)
+	return nil
+}
+
+// close closes the log file
+func (l *replayLog) close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// ReplayLogFiles returns the rotated log files for logPath, oldest first, followed by logPath
+// itself if it exists
+func ReplayLogFiles(logPath string) ([]string, error) {
+	rotated, err := filepath.Glob(logPath + ".*")
+	if err != nil {
+		return nil, err
+	}
+	// Rotated names end in a fixed-width UTC timestamp, so they sort chronologically
+	sort.Strings(rotated)
+
+	if _, err := os.Stat(logPath); err == nil {
+		rotated = append(rotated, logPath)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return rotated, nil
+}
+
+// ReadReplayLog calls fn for every record in the log file at path, in the order they were written
+func ReadReplayLog(path string, fn func(ReplayRecord) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open replay log: %w", err)
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+	for {
+		var header [35]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("%s: truncated record: %w", path, err)
+		}
+		textLen := int(binary.LittleEndian.Uint16(header[32:34]))
+		langLen := int(header[34])
+
+		body := make([]byte, langLen+textLen+12)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return fmt.Errorf("%s: truncated record: %w", path, err)
+		}
+
+		record := ReplayRecord{
+			CacheKey:     hex.EncodeToString(header[:32]),
+			LanguageCode: string(body[:langLen]),
+			Text:         string(body[langLen : langLen+textLen]),
+			AudioSize:    binary.LittleEndian.Uint32(body[langLen+textLen:]),
+			Timestamp:    int64(binary.LittleEndian.Uint64(body[langLen+textLen+4:])),
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+// ReplayLog replays every record of the log at logPath and its rotated files. Records for which
+// exists reports true are skipped; the rest are passed to synthesize, which should put the text
+// back in the cache (for example by calling Service.GetAudio with the record's options).
+// Replaying stops at the first error.
+func ReplayLog(logPath string, exists func(cacheKey string) (bool, error), synthesize func(ReplayRecord) error) (replayed, skipped int, err error) {
+	files, err := ReplayLogFiles(logPath)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to find replay logs: %w", err)
+	}
+	if len(files) == 0 {
+		return 0, 0, fmt.Errorf("no replay log found at %s", logPath)
+	}
+
+	// The same entry may have been put more than once
+	seen := make(map[string]bool)
+	for _, file := range files {
+		err := ReadReplayLog(file, func(record ReplayRecord) error {
+			if seen[record.CacheKey] {
+				return nil
+			}
+			seen[record.CacheKey] = true
+
+			present, err := exists(record.CacheKey)
+			if err != nil {
+				return err
+			}
+			if present {
+				skipped++
+				return nil
+			}
+
+			if err := synthesize(record); err != nil {
+				return fmt.Errorf("failed to replay %s: %w", record.CacheKey, err)
+			}
+			replayed++
+			return nil
+		})
+		if err != nil {
+			return replayed, skipped, err
+		}
+	}
+	return replayed, skipped, nil
+}
@@ -0,0 +1,188 @@
+package tts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ResynthesizeProgress reports one entry processed by ResynthesizeAll and the running totals
+type ResynthesizeProgress struct {
+	Index         int64 // 1-based position of the entry
+	Total         int64 // Entries for the language when the job started
+	CacheKey      string
+	Duration      time.Duration // Time spent synthesizing and storing the entry
+	Resynthesized int64
+	Failed        int64
+	Err           error // Why this entry failed, if it did
+}
+
+// CountEntries returns the number of cache entries in namespace for languageCode
+func (c *Cache) CountEntries(namespace, languageCode string) (int64, error) {
+	var count int64
+	err := c.db.QueryRow(`SELECT COUNT(*) FROM audio_cache WHERE namespace = ? AND language_code = ?`, namespace, languageCode).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count cache entries: %w", err)
+	}
+	return count, nil
+}
+
+// setResynthInProgress flags or clears an entry as being re-synthesized
+func (c *Cache) setResynthInProgress(cacheKey string, inProgress bool) error {
+	_, err := c.db.Exec(`UPDATE audio_cache SET resynth_in_progress = ? WHERE cache_key = ?`, inProgress, cacheKey)
+	if err != nil {
+		return fmt.Errorf("failed to update resynth_in_progress: %w", err)
+	}
+	return nil
+}
+
+// replaceAudio stores new audio for an existing entry and clears its resynth_in_progress flag.
+// Unlike Put it keeps the entry's creation time and hit count.
+func (c *Cache) replaceAudio(cacheKey string, compressible bool, audioData []byte, voiceName string) error {
+	dataToStore, compression, err := c.encodeForStorage(audioData, compressible)
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Deltas stored against the old audio wouldn't reconstruct anything from the new audio
+	lost, err := c.materializeDependents(tx, cacheKey)
+	if err != nil {
+		return err
+	}
+
+	var languageCode, namespace string
+	err = tx.QueryRow(
+		`UPDATE audio_cache SET audio_data = ?, audio_size = ?, compression = ?, voice_name = ?, audio_fingerprint = ?,
+		 resynth_in_progress = 0, delta_base_key = NULL, delta_data = NULL WHERE cache_key = ? RETURNING language_code, namespace`,
+		dataToStore, len(dataToStore), compression, sql.NullString{String: voiceName, Valid: voiceName != ""},
+		encodeFingerprint(AudioFingerprint(audioData)), cacheKey,
+	).Scan(&languageCode, &namespace)
+	if err != nil {
+		return fmt.Errorf("failed to update cache entry: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to update cache entry: %w", err)
+	}
+	c.dropLost(lost)
+	c.dropHot(cacheKey)
+	c.dropMemory(cacheKey)
+	c.dropShared(cacheKey) // Other daemons keep their own copies of the old audio
+
+	c.events.publish(CacheEvent{
+		Type:         EventPut,
+		CacheKey:     cacheKey,
+		LanguageCode: languageCode,
+		Namespace:    namespace,
+		AudioSize:    int64(len(audioData)),
+		Timestamp:    getCurrentTimestamp(),
+	})
+
+	if c.maxSizeBytes.Load() > 0 || len(c.languageQuotas) > 0 {
+		go c.evictIfNeeded()
+	}
+
+	return nil
+}
+
+// ResynthesizeAll synthesizes every cache entry in namespace for languageCode again, replacing
+// the cached audio, for example after Azure updates a voice model. Entries are read batchSize at
+// a time and synthesized at most qps times per second (0 = only the client's own limit).
+// progress is called after each entry; if it returns an error the job stops with that error.
+// When ctx is cancelled the job stops cleanly and the totals so far are returned.
+func (s *Service) ResynthesizeAll(ctx context.Context, namespace, languageCode string, batchSize int, qps float64, progress func(ResynthesizeProgress) error) (ResynthesizeProgress, error) {
+	var stats ResynthesizeProgress
+
+	total, err := s.cache.CountEntries(namespace, languageCode)
+	if err != nil {
+		return stats, err
+	}
+	stats.Total = total
+
+	limit := rate.Inf
+	if qps > 0 {
+		limit = rate.Limit(qps)
+	}
+	limiter := rate.NewLimiter(limit, 1)
+
+	afterKey := ""
+	for {
+		entries, err := s.cache.ListEntries(namespace, languageCode, "", "", 0, afterKey, batchSize)
+		if err != nil {
+			return stats, err
+		}
+
+		for _, entry := range entries {
+			if err := limiter.Wait(ctx); err != nil {
+				return stats, nil // Cancelled
+			}
+
+			stats.Index++
+			stats.CacheKey = entry.CacheKey
+			started := time.Now()
+			stats.Err = s.resynthesizeEntry(ctx, entry)
+			stats.Duration = time.Since(started)
+
+			if ctx.Err() != nil {
+				stats.Index-- // The entry wasn't finished
+				return stats, nil
+			}
+			if errors.Is(stats.Err, ErrSynthesisPaused) {
+				stats.Index--
+				return stats, stats.Err
+			}
+			if stats.Err != nil {
+				stats.Failed++
+			} else {
+				stats.Resynthesized++
+			}
+
+			if err := progress(stats); err != nil {
+				return stats, err
+			}
+		}
+
+		if len(entries) < batchSize {
+			return stats, nil
+		}
+		afterKey = entries[len(entries)-1].CacheKey
+	}
+}
+
+// resynthesizeEntry replaces the audio of a single entry, flagging it while the synthesis runs
+func (s *Service) resynthesizeEntry(ctx context.Context, entry CacheEntryInfo) error {
+	recordedVoice, err := s.cache.voiceNameForKey(entry.CacheKey)
+	if err != nil {
+		return err
+	}
+	opts, ok := optionsForKey(entry.CacheKey, entry.Namespace, entry.Text, entry.LanguageCode, recordedVoice)
+	if !ok {
+		return fmt.Errorf("cache key doesn't match its text with any options")
+	}
+
+	if err := s.checkPaused(); err != nil {
+		return err
+	}
+
+	if err := s.cache.setResynthInProgress(entry.CacheKey, true); err != nil {
+		return err
+	}
+
+	audioData, err := s.synthesize(ctx, s.synthesisText(ctx, entry.Text, opts), entry.LanguageCode, opts)
+	if err != nil {
+		s.cache.setResynthInProgress(entry.CacheKey, false)
+		return fmt.Errorf("synthesis failed: %w", err)
+	}
+
+	voiceName, _ := voiceFor(s.provider, entry.LanguageCode, opts)
+	return s.cache.replaceAudio(entry.CacheKey, opts.Format.compressible(), audioData, voiceName)
+}
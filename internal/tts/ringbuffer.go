@@ -0,0 +1,32 @@
+package tts
+
+// ringBuffer holds the most recent items up to a fixed capacity, overwriting the oldest.
+// It is not safe for concurrent use.
+type ringBuffer[T any] struct {
+	items []T
+	next  int // Index the next item is written to
+	full  bool
+}
+
+func newRingBuffer[T any](capacity int) *ringBuffer[T] {
+	return &ringBuffer[T]{items: make([]T, capacity)}
+}
+
+// push adds an item, overwriting the oldest one if the buffer is full
+func (r *ringBuffer[T]) push(item T) {
+	r.items[r.next] = item
+	r.next = (r.next + 1) % len(r.items)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// slice returns a copy of the items, oldest first
+func (r *ringBuffer[T]) slice() []T {
+	if !r.full {
+		return append([]T(nil), r.items[:r.next]...)
+	}
+	out := make([]T, 0, len(r.items))
+	out = append(out, r.items[r.next:]...)
+	return append(out, r.items[:r.next]...)
+}
@@ -0,0 +1,223 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/metrics"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+const (
+	// s3Timeout bounds each S3 call
+	s3Timeout = 10 * time.Second
+
+	// s3MaxTextMetadata is the longest escaped text stored in an object's metadata; S3 allows
+	// 2 KB of user metadata per object, so longer texts are left out
+	s3MaxTextMetadata = 1536
+
+	// s3MaxDeleteKeys is how many objects one DeleteObjects call can delete
+	s3MaxDeleteKeys = 1000
+)
+
+// S3Cache stores cache entries as objects in an S3 bucket, or one of an S3-compatible service
+// such as MinIO, for archival and sharing between regions
+type S3Cache struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Cache connects to bucket with credentials from the standard AWS chain (environment,
+// shared config and credentials files, instance roles). region overrides the chain's region when
+// set, and endpoint replaces the AWS endpoint, for S3-compatible services. Entries are stored
+// at "<prefix>/<cache key>", or "<cache key>" without a prefix.
+func NewS3Cache(ctx context.Context, bucket, prefix, region, endpoint string) (*S3Cache, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("no AWS region configured (set database.s3_region or AWS_REGION)")
+	}
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+			o.UsePathStyle = true // S3-compatible services rarely support virtual-hosted buckets
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, s3Timeout)
+	defer cancel()
+	if _, err := client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucket)}); err != nil {
+		return nil, fmt.Errorf("failed to access S3 bucket %s: %w", bucket, err)
+	}
+
+	return &S3Cache{client: client, bucket: bucket, prefix: strings.Trim(prefix, "/")}, nil
+}
+
+// key returns the object key of the entry stored under cacheKey
+func (s *S3Cache) key(cacheKey string) string {
+	if s.prefix == "" {
+		return cacheKey
+	}
+	return s.prefix + "/" + cacheKey
+}
+
+// Get returns the entry stored under cacheKey, or nil if there is none. Its Text is only set if
+// it was short enough to be stored.
+func (s *S3Cache) Get(ctx context.Context, cacheKey string) (*CachedAudio, error) {
+	resp, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(cacheKey)),
+	})
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from S3: %w", err)
+	}
+	defer resp.Body.Close()
+
+	audioData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read S3 object %s: %w", cacheKey, err)
+	}
+
+	// Metadata keys come back lowercased
+	text, _ := url.QueryUnescape(resp.Metadata["text"])
+	createdAt, _ := strconv.ParseInt(resp.Metadata["created_at"], 10, 64)
+	expiresAt, _ := strconv.ParseInt(resp.Metadata["expires_at"], 10, 64)
+	return &CachedAudio{
+		CacheKey:     cacheKey,
+		Text:         text,
+		LanguageCode: resp.Metadata["language_code"],
+		AudioData:    audioData,
+		CreatedAt:    createdAt,
+		LastAccessed: createdAt,
+		VoiceName:    resp.Metadata["voice_name"],
+		Format:       resp.Metadata["format"],
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// Put stores audio, uncompressed, replacing any object under the same key. The text is stored
+// URL-escaped, since metadata can only hold ASCII.
+func (s *S3Cache) Put(ctx context.Context, audio *CachedAudio) error {
+	metadata := map[string]string{
+		"language_code": audio.LanguageCode,
+		"created_at":    strconv.FormatInt(audio.CreatedAt, 10),
+	}
+	if text := url.QueryEscape(audio.Text); len(text) <= s3MaxTextMetadata {
+		metadata["text"] = text
+	}
+	if audio.VoiceName != "" {
+		metadata["voice_name"] = audio.VoiceName
+	}
+	if audio.Format != "" {
+		metadata["format"] = audio.Format
+	}
+	if audio.ExpiresAt != 0 {
+		metadata["expires_at"] = strconv.FormatInt(audio.ExpiresAt, 10)
+	}
+
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(s.key(audio.CacheKey)),
+		Body:     bytes.NewReader(audio.AudioData),
+		Metadata: metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write to S3: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the objects stored under the given cache keys
+func (s *S3Cache) Delete(ctx context.Context, cacheKeys ...string) error {
+	for start := 0; start < len(cacheKeys); start += s3MaxDeleteKeys {
+		batch := cacheKeys[start:min(start+s3MaxDeleteKeys, len(cacheKeys))]
+		objects := make([]types.ObjectIdentifier, len(batch))
+		for i, cacheKey := range batch {
+			objects[i] = types.ObjectIdentifier{Key: aws.String(s.key(cacheKey))}
+		}
+
+		resp, err := s.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(s.bucket),
+			Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete from S3: %w", err)
+		}
+		if len(resp.Errors) > 0 {
+			return fmt.Errorf("failed to delete %d objects from S3, first: %s", len(resp.Errors), aws.ToString(resp.Errors[0].Message))
+		}
+	}
+	return nil
+}
+
+// SetS3 archives entries in S3 behind SQLite (and Redis, if set): entries are written to both,
+// and lookups that miss SQLite are answered from S3, copying the entry into SQLite. Deleting an
+// entry deletes its object too, while evictions and expired entry cleanups only affect SQLite.
+func (c *Cache) SetS3(s3Cache *S3Cache) {
+	c.s3 = s3Cache
+}
+
+// getS3 looks up an entry that isn't in SQLite in S3, returning nil if it isn't there either.
+// Lookup failures are logged, so the entry is synthesized instead.
+func (c *Cache) getS3(cacheKey string) *CachedAudio {
+	ctx, cancel := context.WithTimeout(context.Background(), s3Timeout)
+	defer cancel()
+	audio, err := c.s3.Get(ctx, cacheKey)
+	if err != nil {
+		log.Printf("Warning: S3 lookup failed: %v", err)
+		return nil
+	}
+	if audio == nil || c.expired(audio.CreatedAt, audio.ExpiresAt) {
+		return nil
+	}
+	metrics.S3CacheHits.Inc()
+	return audio
+}
+
+// putS3 writes an entry to S3, if it is enabled. Failures are logged rather than returned, since
+// the entry is still cached in SQLite.
+func (c *Cache) putS3(audio *CachedAudio) {
+	if c.s3 == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s3Timeout)
+	defer cancel()
+	if err := c.s3.Put(ctx, audio); err != nil {
+		log.Printf("Warning: failed to write %s to S3: %v", audio.CacheKey, err)
+	}
+}
+
+// dropS3 deletes entries from S3, if it is enabled
+func (c *Cache) dropS3(keys ...string) {
+	if c.s3 == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s3Timeout)
+	defer cancel()
+	if err := c.s3.Delete(ctx, keys...); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
@@ -1,9 +1,22 @@
 package tts
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"regexp"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/logging"
+	"com.biesnecker/tts-daemon/internal/metrics"
+	"com.biesnecker/tts-daemon/internal/tracing"
+
+	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // inFlightFetch tracks an ongoing fetch operation
@@ -13,50 +26,127 @@ type inFlightFetch struct {
 	cacheKey  string
 	cached    bool
 	err       error
+	waiters   int // Requests waiting on this fetch besides the one performing it (guarded by inFlightMu)
 }
 
 // Service provides TTS functionality with caching
 type Service struct {
-	cache       *Cache
-	azureClient *AzureClient
+	cache    *Cache
+	provider Provider
+	recorder MetricsRecorder
+	hitRates *hitRates
+
+	// Characters sent to the provider today, never limited (see RateLimitStatus)
+	charsToday *DailyBudget
 
 	// In-flight fetch tracking to deduplicate concurrent requests
 	inFlightMu sync.Mutex
 	inFlight   map[string]*inFlightFetch
+	dedup      *dedupLog
+
+	// Maintenance pause (see PauseSynthesis)
+	paused      atomic.Bool
+	pauseMu     sync.Mutex // Guards pauseReason and pausedAt
+	pauseReason string
+	pausedAt    time.Time
+
+	// Locales tried for languages without a voice (see SetLanguageFallbackChains)
+	fallbackChains map[string][]string
+
+	// Related languages tried after the fallback chains (see SetLanguageFamilyFallback)
+	familyTree *LanguageFamilyTree
+
+	// Rewrites text before synthesis (see SetTextPreprocessor)
+	preprocessor *OpenAIPreprocessor
+
+	// Whether synthesized MP3 audio is checked before caching (see SetAudioValidation)
+	validateAudio bool
+
+	// Synthesis queue worker (see StartQueueWorker)
+	workerStop chan struct{}
+	workerDone chan struct{}
+
+	// Closed to stop the periodic voice list refresh (see StartVoiceRefresh)
+	refreshStop chan struct{}
+
+	// Told the outcome of every voice list refresh (see SetVoiceRefreshHandler)
+	voiceRefreshHandler func(err error)
+
+	// Database compaction (see StartCompactionSchedule)
+	compactionCron  *cron.Cron
+	compacting      atomic.Bool
+	lastRequestTime atomic.Int64 // Unix nanoseconds (see RecordRequest)
 }
 
 // NewService creates a new TTS service
-func NewService(cache *Cache, azureClient *AzureClient) *Service {
-	return &Service{
-		cache:       cache,
-		azureClient: azureClient,
-		inFlight:    make(map[string]*inFlightFetch),
+func NewService(cache *Cache, provider Provider) *Service {
+	s := &Service{
+		cache:      cache,
+		provider:   provider,
+		recorder:   metrics.PrometheusRecorder{},
+		hitRates:   newHitRates(),
+		charsToday: NewDailyBudget(0),
+		inFlight:   make(map[string]*inFlightFetch),
+		dedup:      newDedupLog(),
 	}
+	s.setMetricsLanguages()
+	return s
 }
 
 // GetAudio retrieves audio for the given text and language
 // It first checks the cache (unless force is true), and if not found, fetches from Azure
 // Concurrent requests for the same text/language will wait on the same fetch operation
-func (s *Service) GetAudio(text, languageCode string, forceRefresh bool) (audioData []byte, cacheKey string, cached bool, err error) {
+func (s *Service) GetAudio(ctx context.Context, text, languageCode string, opts Options, forceRefresh bool) (audioData []byte, cacheKey string, cached bool, err error) {
+	if err := checkLanguageCode(languageCode); err != nil {
+		return nil, "", false, err
+	}
+	if err := checkSSML(text, opts); err != nil {
+		return nil, "", false, err
+	}
+	text = prepareText(text, languageCode, opts)
+	opts = s.withVoiceFallback(languageCode, opts)
+
+	requestStarted := time.Now()
+	source := SourceCache
+	defer func() {
+		s.recorder.RecordRequest(source, languageCode, err, time.Since(requestStarted))
+		if err == nil {
+			s.hitRates.record(languageCode, source == SourceCache)
+		}
+	}()
+
 	// Try to get from cache first (unless force refresh is requested)
 	if !forceRefresh {
-		cachedAudio, err := s.cache.Get(text, languageCode)
+		_, span := tracing.Start(ctx, "cache_lookup")
+		cachedAudio, err := s.cache.Get(text, languageCode, opts)
+		span.SetAttributes(attribute.Bool("cache.hit", cachedAudio != nil))
+		endSpan(span, err)
 		if err != nil {
 			return nil, "", false, fmt.Errorf("cache lookup failed: %w", err)
 		}
 
 		if cachedAudio != nil {
+			if err := s.cache.AddTags(cachedAudio.CacheKey, opts.Tags); err != nil {
+				logging.Warn("tagging failed", "key", cachedAudio.CacheKey, "error", err)
+			}
 			return cachedAudio.AudioData, cachedAudio.CacheKey, true, nil
 		}
 	}
 
-	// Cache miss - check if there's already an in-flight fetch for this item
-	key := GenerateCacheKey(text, languageCode)
+	// Cache miss - Azure must not be called while synthesis is paused
+	source = SourceAzure
+	if err := s.checkPaused(); err != nil {
+		return nil, "", false, err
+	}
+
+	// Check if there's already an in-flight fetch for this item
+	key := GenerateCacheKey(text, languageCode, opts)
 
 	// Check for existing in-flight fetch
 	s.inFlightMu.Lock()
 	if flight, exists := s.inFlight[key]; exists {
 		// Another goroutine is already fetching this, wait for it
+		flight.waiters++
 		s.inFlightMu.Unlock()
 		<-flight.done
 		return flight.audioData, flight.cacheKey, flight.cached, flight.err
@@ -69,16 +159,30 @@ func (s *Service) GetAudio(text, languageCode string, forceRefresh bool) (audioD
 	s.inFlight[key] = flight
 	s.inFlightMu.Unlock()
 
-	// Perform the fetch (outside the lock)
-	audioData, err = s.azureClient.SynthesizeToMP3(text, languageCode)
+	// Perform the fetch (outside the lock). Waiters share the result, so the caller cancelling
+	// must not abort it.
+	started := time.Now()
+	synthCtx, span := tracing.Start(context.WithoutCancel(ctx), "azure_synthesis")
+	span.SetAttributes(attribute.String("tts.language", languageCode), attribute.Int("tts.text_length", len(text)))
+	audioData, err = s.synthesize(synthCtx, s.synthesisText(synthCtx, text, opts), languageCode, opts)
+	endSpan(span, err)
+	if err == nil && s.validateAudio && opts.Format == FormatMP3 {
+		if err = ValidateMP3(audioData); err != nil {
+			metrics.InvalidAudio.Inc()
+			logging.Warn("Azure returned invalid audio", "lang", languageCode, "error", err)
+		}
+	}
 	if err != nil {
 		flight.err = fmt.Errorf("Azure synthesis failed: %w", err)
 	} else {
-		// Store in cache
-		cacheKey, err = s.cache.Put(text, languageCode, audioData)
+		// Store in cache, noting the voice so entries made before a voice change can be found
+		voiceName, _ := voiceFor(s.provider, languageCode, opts)
+		_, span := tracing.Start(ctx, "cache_put")
+		cacheKey, err = s.cache.Put(text, languageCode, opts, audioData, voiceName)
+		endSpan(span, err)
 		if err != nil {
 			// Don't fail the request if caching fails, just log the error
-			log.Printf("Warning: caching failed: %v", err)
+			logging.Warn("caching failed", "key", key, "error", err)
 			cacheKey = key
 		}
 
@@ -90,15 +194,79 @@ func (s *Service) GetAudio(text, languageCode string, forceRefresh bool) (audioD
 	// Remove from in-flight map and signal completion
 	s.inFlightMu.Lock()
 	delete(s.inFlight, key)
+	waiters := flight.waiters
 	s.inFlightMu.Unlock()
 	close(flight.done)
 
+	if waiters > 0 && flight.err == nil {
+		s.dedup.record(key, waiters, time.Since(started))
+	}
+
 	return flight.audioData, flight.cacheKey, flight.cached, flight.err
 }
 
+// endSpan records err (if any) on span and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ErrInvalidLanguageCode is returned for language codes with characters other than letters,
+// digits, '-' and '_'. The cache key joins the namespace, language code and text with '/' and
+// ':', so a language code containing them could collide with another request's key.
+var ErrInvalidLanguageCode = errors.New("invalid language code")
+
+// languageCodePattern matches valid language codes, e.g. en-US or zh_Hant_TW
+var languageCodePattern = regexp.MustCompile(`^[A-Za-z0-9_-]*$`)
+
+// checkLanguageCode returns ErrInvalidLanguageCode unless languageCode matches languageCodePattern
+func checkLanguageCode(languageCode string) error {
+	if !languageCodePattern.MatchString(languageCode) {
+		return fmt.Errorf("%w %q (use letters, digits, '-' and '_')", ErrInvalidLanguageCode, languageCode)
+	}
+	return nil
+}
+
+// checkSSML rejects malformed SSML before it reaches the cache or Azure
+func checkSSML(text string, opts Options) error {
+	if !opts.SSML {
+		return nil
+	}
+	if err := ValidateSSML(text); err != nil {
+		return fmt.Errorf("invalid SSML: %w", err)
+	}
+	return nil
+}
+
+// prepareText applies the text rewrites requested by opts. It runs before the cache key is
+// generated, so the rewritten text is what gets cached. SSML is never rewritten.
+func prepareText(text, languageCode string, opts Options) string {
+	if !opts.RestorePunctuation || opts.SSML {
+		return text
+	}
+
+	lang := opts.PunctuationLanguage
+	if lang == "" {
+		lang = languageCode
+	}
+
+	punctuated, err := RestorePunctuation(text, lang)
+	if err != nil {
+		logging.Warn("punctuation restoration failed", "lang", lang, "error", err)
+		return text
+	}
+	return punctuated
+}
+
 // BulkGetAudio retrieves audio for multiple text/language pairs concurrently
 // Returns a slice of results in the same order as the requests
-func (s *Service) BulkGetAudio(requests []struct{ Text, LanguageCode string }, forceRefresh bool) []struct {
+func (s *Service) BulkGetAudio(ctx context.Context, requests []struct {
+	Text, LanguageCode string
+	Options            Options
+}, forceRefresh bool) []struct {
 	AudioData []byte
 	CacheKey  string
 	Cached    bool
@@ -111,41 +279,98 @@ func (s *Service) BulkGetAudio(requests []struct{ Text, LanguageCode string }, f
 		Err       error
 	}, len(requests))
 
+	s.bulkGetAudio(ctx, requests, forceRefresh, 0, func(idx int, audioData []byte, cacheKey string, cached bool, err error) {
+		results[idx].AudioData = audioData
+		results[idx].CacheKey = cacheKey
+		results[idx].Cached = cached
+		results[idx].Err = err
+	})
+
+	return results
+}
+
+// bulkGetAudio fetches the audio for requests with at most concurrency requests in flight
+// (0 = all at once), calling done with each request's index and result as it completes. done
+// may be called concurrently.
+func (s *Service) bulkGetAudio(ctx context.Context, requests []struct {
+	Text, LanguageCode string
+	Options            Options
+}, forceRefresh bool, concurrency int, done func(idx int, audioData []byte, cacheKey string, cached bool, err error)) {
+	if concurrency <= 0 {
+		concurrency = len(requests)
+	}
+	slots := make(chan struct{}, concurrency)
+
 	// Use a WaitGroup to fetch all items concurrently
 	var wg sync.WaitGroup
 	for i, req := range requests {
 		wg.Add(1)
-		go func(idx int, text, lang string) {
+		slots <- struct{}{}
+		go func(idx int, text, lang string, opts Options) {
 			defer wg.Done()
-			audioData, cacheKey, cached, err := s.GetAudio(text, lang, forceRefresh)
-			results[idx].AudioData = audioData
-			results[idx].CacheKey = cacheKey
-			results[idx].Cached = cached
-			results[idx].Err = err
-		}(i, req.Text, req.LanguageCode)
+			defer func() { <-slots }()
+			audioData, cacheKey, cached, err := s.GetAudio(ctx, text, lang, opts, forceRefresh)
+			done(idx, audioData, cacheKey, cached, err)
+		}(i, req.Text, req.LanguageCode, req.Options)
 	}
 	wg.Wait()
+}
 
-	return results
+// SynthesizeEphemeral synthesizes audio directly from Azure without reading or writing the cache
+func (s *Service) SynthesizeEphemeral(ctx context.Context, text, languageCode string, opts Options) ([]byte, error) {
+	if err := checkLanguageCode(languageCode); err != nil {
+		return nil, err
+	}
+	if err := checkSSML(text, opts); err != nil {
+		return nil, err
+	}
+	text = prepareText(text, languageCode, opts)
+	opts = s.withVoiceFallback(languageCode, opts)
+
+	if err := s.checkPaused(); err != nil {
+		return nil, err
+	}
+
+	synthText := s.synthesisText(ctx, text, opts)
+	ctx, span := tracing.Start(ctx, "azure_synthesis")
+	audioData, err := s.synthesize(ctx, synthText, languageCode, opts)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("Azure synthesis failed: %w", err)
+	}
+
+	return audioData, nil
 }
 
 // GetCachedAudio retrieves audio only from cache, without fetching
-func (s *Service) GetCachedAudio(text, languageCode string) (audioData []byte, cacheKey string, found bool, err error) {
-	cachedAudio, err := s.cache.Get(text, languageCode)
+func (s *Service) GetCachedAudio(text, languageCode string, opts Options) (audioData []byte, cacheKey string, found bool, err error) {
+	if err := checkLanguageCode(languageCode); err != nil {
+		return nil, "", false, err
+	}
+	text = prepareText(text, languageCode, opts)
+	opts = s.withVoiceFallback(languageCode, opts)
+
+	cachedAudio, err := s.cache.Get(text, languageCode, opts)
 	if err != nil {
 		return nil, "", false, fmt.Errorf("cache lookup failed: %w", err)
 	}
 
 	if cachedAudio == nil {
-		return nil, GenerateCacheKey(text, languageCode), false, nil
+		return nil, GenerateCacheKey(text, languageCode, opts), false, nil
 	}
 
 	return cachedAudio.AudioData, cachedAudio.CacheKey, true, nil
 }
 
 // DeleteCached removes audio from cache
-func (s *Service) DeleteCached(text, languageCode string) (cacheKey string, deleted bool, err error) {
-	cacheKey, deleted, err = s.cache.Delete(text, languageCode)
+func (s *Service) DeleteCached(text, languageCode string, opts Options) (cacheKey string, deleted bool, err error) {
+	if err := checkLanguageCode(languageCode); err != nil {
+		return "", false, err
+	}
+	text = prepareText(text, languageCode, opts)
+	opts = s.withVoiceFallback(languageCode, opts)
+
+	cacheKey, deleted, err = s.cache.Delete(text, languageCode, opts)
 	if err != nil {
 		return cacheKey, false, fmt.Errorf("cache delete failed: %w", err)
 	}
@@ -153,12 +378,111 @@ func (s *Service) DeleteCached(text, languageCode string) (cacheKey string, dele
 	return cacheKey, deleted, nil
 }
 
-// GetCacheStats returns statistics about the cache
-func (s *Service) GetCacheStats() (map[string]interface{}, error) {
-	return s.cache.GetStats()
+// DeleteByLanguage removes every cache entry in namespace for a language (see
+// Cache.DeleteByLanguage)
+func (s *Service) DeleteByLanguage(namespace, languageCode string) (int64, []string, error) {
+	deleted, keys, err := s.cache.DeleteByLanguage(namespace, languageCode)
+	if err != nil {
+		return 0, nil, fmt.Errorf("cache language delete failed: %w", err)
+	}
+	return deleted, keys, nil
 }
 
-// Close closes the service and releases resources
+// DeleteByTag removes every cache entry in namespace tagged with tag (see Cache.DeleteByTag)
+func (s *Service) DeleteByTag(namespace, tag string) (int64, error) {
+	deleted, err := s.cache.DeleteByTag(namespace, tag)
+	if err != nil {
+		return 0, fmt.Errorf("cache tag delete failed: %w", err)
+	}
+	return deleted, nil
+}
+
+// DeletePattern removes cache entries whose text matches a LIKE pattern (see Cache.DeletePattern)
+func (s *Service) DeletePattern(namespace, pattern, languageCode string, dryRun bool) (matched, deleted, freedBytes int64, err error) {
+	matched, deleted, freedBytes, err = s.cache.DeletePattern(namespace, pattern, languageCode, dryRun)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("cache pattern delete failed: %w", err)
+	}
+
+	return matched, deleted, freedBytes, nil
+}
+
+// VerifyIntegrity checks namespace's entries for duplicate keys and keys that don't match their
+// text
+func (s *Service) VerifyIntegrity(namespace string) (checked int64, collisions []CollisionGroup, mismatches []KeyMismatch, err error) {
+	collisions, err = s.cache.CollisionReport(namespace)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("collision check failed: %w", err)
+	}
+
+	checked, mismatches, err = s.cache.VerifyKeys(namespace)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("key verification failed: %w", err)
+	}
+
+	return checked, collisions, mismatches, nil
+}
+
+// VerifyCache checks the database for corruption (see Cache.Verify) and, if repair is set,
+// deletes the corrupt entries it found, so they are synthesized again
+func (s *Service) VerifyCache(repair bool) (checked int64, integrityErrors, corruptKeys []string, repaired int64, err error) {
+	checked, integrityErrors, corruptKeys, err = s.cache.Verify()
+	if err != nil {
+		return 0, nil, nil, 0, fmt.Errorf("cache verification failed: %w", err)
+	}
+
+	if repair && len(corruptKeys) > 0 {
+		repaired, err = s.cache.DeleteCorrupt(corruptKeys)
+		if err != nil {
+			return checked, integrityErrors, corruptKeys, 0, fmt.Errorf("failed to delete corrupt entries: %w", err)
+		}
+		logging.Info("Cache: deleted corrupt entries", "count", repaired)
+	}
+	return checked, integrityErrors, corruptKeys, repaired, nil
+}
+
+// InFlightCount returns the number of Azure fetches currently in progress
+func (s *Service) InFlightCount() int {
+	s.inFlightMu.Lock()
+	defer s.inFlightMu.Unlock()
+	return len(s.inFlight)
+}
+
+// WatchCache subscribes to cache changes; call the returned function to unsubscribe
+func (s *Service) WatchCache() (<-chan CacheEvent, func()) {
+	return s.cache.Subscribe()
+}
+
+// StopWatchers ends all cache watches, e.g. before shutting down the server
+func (s *Service) StopWatchers() {
+	s.cache.CloseSubscribers()
+}
+
+// GetCacheStats returns statistics about namespace's cache entries (see
+// Cache.GetNamespaceStats), along with the hit rates of the whole cache (see hitRates.addTo)
+func (s *Service) GetCacheStats(namespace string) (map[string]interface{}, error) {
+	stats, err := s.cache.GetNamespaceStats(namespace)
+	if err != nil {
+		return nil, err
+	}
+	s.hitRates.addTo(stats)
+
+	paused, reason, _ := s.SynthesisPaused()
+	stats["synthesis_paused"] = paused
+	if paused {
+		stats["pause_reason"] = reason
+	}
+	return stats, nil
+}
+
+// Close stops the service's background work. The cache is left open for its owner to close.
 func (s *Service) Close() error {
-	return s.cache.Close()
+	s.stopQueueWorker()
+	if s.refreshStop != nil {
+		close(s.refreshStop)
+	}
+	if s.compactionCron != nil {
+		<-s.compactionCron.Stop().Done() // Let a running compaction finish
+	}
+	return nil
 }
@@ -0,0 +1,209 @@
+package tts
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestGetAudioCachesSyntheses(t *testing.T) {
+	provider := newMockProvider(t)
+	cache := newTestCache(t)
+	service := NewService(cache, provider)
+	recorded := readTestAudio(t, "fr-FR")
+
+	// Requests run in order against the same service
+	tests := []struct {
+		name         string
+		text         string
+		opts         Options
+		forceRefresh bool
+		wantCached   bool
+		wantCalls    int32 // Provider calls so far
+	}{
+		{"miss synthesizes", "Bonjour", Options{}, false, false, 1},
+		{"repeat is cached", "Bonjour", Options{}, false, true, 1},
+		{"other options synthesize", "Bonjour", Options{InjectBreaks: true}, false, false, 2},
+		{"other options are cached", "Bonjour", Options{InjectBreaks: true}, false, true, 2},
+		{"force refresh synthesizes", "Bonjour", Options{}, true, false, 3},
+		{"refreshed entry is cached", "Bonjour", Options{}, false, true, 3},
+		{"other text synthesizes", "Au revoir", Options{}, false, false, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audioData, cacheKey, cached, err := service.GetAudio(t.Context(), tt.text, "fr-FR", tt.opts, tt.forceRefresh)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cached != tt.wantCached {
+				t.Errorf("cached = %v, want %v", cached, tt.wantCached)
+			}
+			if got := provider.calls.Load(); got != tt.wantCalls {
+				t.Errorf("provider called %d times, want %d", got, tt.wantCalls)
+			}
+			if !bytes.Equal(audioData, recorded) {
+				t.Errorf("got %d bytes of audio, want the %d byte recording", len(audioData), len(recorded))
+			}
+			if want := GenerateCacheKey(tt.text, "fr-FR", tt.opts); cacheKey != want {
+				t.Errorf("cache key = %s, want %s", cacheKey, want)
+			}
+
+			// The entry notes the voice it was synthesized with
+			entry, err := cache.GetByKey(cacheKey)
+			if err != nil || entry == nil {
+				t.Fatalf("GetByKey(%s) = %v, %v, want the entry", cacheKey, entry, err)
+			}
+			if entry.VoiceName != "fr-FR-DeniseNeural" {
+				t.Errorf("entry voice = %q, want fr-FR-DeniseNeural", entry.VoiceName)
+			}
+		})
+	}
+}
+
+func TestGetAudioDoesntCacheFailures(t *testing.T) {
+	provider := newMockProvider(t)
+	errUnavailable := errors.New("503 Service Unavailable")
+	provider.audio = func(text, languageCode string) ([]byte, error) { return nil, errUnavailable }
+	cache := newTestCache(t)
+	service := NewService(cache, provider)
+
+	_, _, _, err := service.GetAudio(t.Context(), "Hello", "en-US", Options{}, false)
+	if !errors.Is(err, errUnavailable) {
+		t.Fatalf("GetAudio = %v, want the provider's error", err)
+	}
+	if audio, err := cache.Get("Hello", "en-US", Options{}); err != nil || audio != nil {
+		t.Errorf("the failure was cached (%v, %v)", audio, err)
+	}
+
+	// The next request tries again
+	recorded := readTestAudio(t, "en-US")
+	provider.audio = func(text, languageCode string) ([]byte, error) { return recorded, nil }
+	audioData, _, cached, err := service.GetAudio(t.Context(), "Hello", "en-US", Options{}, false)
+	if err != nil || cached || !bytes.Equal(audioData, recorded) {
+		t.Errorf("retry = %d bytes, cached=%v, err=%v, want a fresh synthesis", len(audioData), cached, err)
+	}
+	if got := provider.calls.Load(); got != 2 {
+		t.Errorf("provider called %d times, want 2", got)
+	}
+}
+
+func TestPausedServiceServesOnlyCachedAudio(t *testing.T) {
+	provider := newMockProvider(t)
+	service := NewService(newTestCache(t), provider)
+	if _, _, _, err := service.GetAudio(t.Context(), "Hello", "en-US", Options{}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	service.PauseSynthesis("maintenance")
+	if _, _, cached, err := service.GetAudio(t.Context(), "Hello", "en-US", Options{}, false); err != nil || !cached {
+		t.Errorf("cached request while paused = cached %v, %v, want a hit", cached, err)
+	}
+	if _, _, _, err := service.GetAudio(t.Context(), "Goodbye", "en-US", Options{}, false); !errors.Is(err, ErrSynthesisPaused) {
+		t.Errorf("miss while paused = %v, want ErrSynthesisPaused", err)
+	}
+	if _, err := service.SynthesizeEphemeral(t.Context(), "Goodbye", "en-US", Options{}); !errors.Is(err, ErrSynthesisPaused) {
+		t.Errorf("ephemeral synthesis while paused = %v, want ErrSynthesisPaused", err)
+	}
+	if got := provider.calls.Load(); got != 1 {
+		t.Errorf("provider called %d times, want only the call before pausing", got)
+	}
+
+	service.ResumeSynthesis()
+	if _, _, cached, err := service.GetAudio(t.Context(), "Goodbye", "en-US", Options{}, false); err != nil || cached {
+		t.Errorf("miss after resuming = cached %v, %v, want a synthesis", cached, err)
+	}
+}
+
+func TestSynthesizeEphemeralBypassesCache(t *testing.T) {
+	provider := newMockProvider(t)
+	cache := newTestCache(t)
+	service := NewService(cache, provider)
+
+	for i := 1; i <= 2; i++ {
+		audioData, err := service.SynthesizeEphemeral(t.Context(), "Hallo", "de-DE", Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(audioData, readTestAudio(t, "de-DE")) {
+			t.Error("ephemeral synthesis didn't return the recording")
+		}
+		if got := provider.calls.Load(); got != int32(i) {
+			t.Errorf("provider called %d times after %d requests, want every request synthesized", got, i)
+		}
+	}
+	if _, _, found, err := service.GetCachedAudio("Hallo", "de-DE", Options{}); err != nil || found {
+		t.Errorf("GetCachedAudio = found %v, %v, want nothing cached", found, err)
+	}
+}
+
+func TestDeleteCachedResynthesizes(t *testing.T) {
+	provider := newMockProvider(t)
+	service := NewService(newTestCache(t), provider)
+	if _, _, _, err := service.GetAudio(t.Context(), "Ciao", "it-IT", Options{}, false); err != nil {
+		t.Fatal(err)
+	}
+	_, _, found, err := service.GetCachedAudio("Ciao", "it-IT", Options{})
+	if err != nil || !found {
+		t.Fatalf("GetCachedAudio = found %v, %v, want the entry", found, err)
+	}
+
+	if _, deleted, err := service.DeleteCached("Ciao", "it-IT", Options{}); err != nil || !deleted {
+		t.Fatalf("DeleteCached = %v, %v, want the entry deleted", deleted, err)
+	}
+	if _, deleted, err := service.DeleteCached("Ciao", "it-IT", Options{}); err != nil || deleted {
+		t.Errorf("second DeleteCached = %v, %v, want nothing deleted", deleted, err)
+	}
+	if _, _, cached, err := service.GetAudio(t.Context(), "Ciao", "it-IT", Options{}, false); err != nil || cached {
+		t.Errorf("request after deleting = cached %v, %v, want a synthesis", cached, err)
+	}
+	if got := provider.calls.Load(); got != 2 {
+		t.Errorf("provider called %d times, want 2", got)
+	}
+}
+
+func TestLanguageCodeCantCollideWithNamespace(t *testing.T) {
+	// The default namespace's keys have no prefix, so a language code that looks like one would
+	// collide with the namespace's key
+	if GenerateCacheKey("Hello", "teamB/en-US", Options{}) != GenerateCacheKey("Hello", "en-US", Options{Namespace: "teamB"}) {
+		t.Fatal("the keys don't collide; the test no longer covers the key format")
+	}
+
+	provider := newMockProvider(t)
+	service := NewService(newTestCache(t), provider)
+	if _, _, _, err := service.GetAudio(t.Context(), "Hello", "en-US", Options{Namespace: "teamB"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, languageCode := range []string{"teamB/en-US", "en-US:hello", "en US", "en-US\n"} {
+		t.Run(languageCode, func(t *testing.T) {
+			if _, _, _, err := service.GetAudio(t.Context(), "Hello", languageCode, Options{}, false); !errors.Is(err, ErrInvalidLanguageCode) {
+				t.Errorf("GetAudio = %v, want ErrInvalidLanguageCode", err)
+			}
+			if _, _, _, err := service.GetCachedAudio("Hello", languageCode, Options{}); !errors.Is(err, ErrInvalidLanguageCode) {
+				t.Errorf("GetCachedAudio = %v, want ErrInvalidLanguageCode", err)
+			}
+			if _, _, err := service.DeleteCached("Hello", languageCode, Options{}); !errors.Is(err, ErrInvalidLanguageCode) {
+				t.Errorf("DeleteCached = %v, want ErrInvalidLanguageCode", err)
+			}
+			if _, err := service.SynthesizeEphemeral(t.Context(), "Hello", languageCode, Options{}); !errors.Is(err, ErrInvalidLanguageCode) {
+				t.Errorf("SynthesizeEphemeral = %v, want ErrInvalidLanguageCode", err)
+			}
+			if _, err := service.EnqueueSynthesis("Hello", languageCode, 0); !errors.Is(err, ErrInvalidLanguageCode) {
+				t.Errorf("EnqueueSynthesis = %v, want ErrInvalidLanguageCode", err)
+			}
+		})
+	}
+
+	// The namespace's entry is untouched
+	if _, _, found, err := service.GetCachedAudio("Hello", "en-US", Options{Namespace: "teamB"}); err != nil || !found {
+		t.Errorf("the namespace's entry = found %v, %v, want it cached", found, err)
+	}
+	if got := provider.calls.Load(); got != 1 {
+		t.Errorf("provider called %d times, want only for the namespace's request", got)
+	}
+	for _, languageCode := range []string{"en-US", "zh_Hant_TW", "yue", ""} {
+		if err := checkLanguageCode(languageCode); err != nil {
+			t.Errorf("checkLanguageCode(%q) = %v, want it valid", languageCode, err)
+		}
+	}
+}
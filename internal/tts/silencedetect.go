@@ -0,0 +1,144 @@
+package tts
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// silenceThreshold is the largest sample amplitude (of 32767) treated as silence
+const silenceThreshold = 128
+
+// pcmWAV is 16-bit mono PCM audio read from a RIFF/WAV file
+type pcmWAV struct {
+	sampleRate uint32
+	samples    []byte // Little-endian 16-bit samples
+}
+
+// parsePCMWAV reads the format and sample data of 16-bit mono PCM WAV audio
+func parsePCMWAV(audioData []byte) (*pcmWAV, error) {
+	if !isWAV(audioData) {
+		return nil, fmt.Errorf("not WAV audio")
+	}
+
+	var wav pcmWAV
+	haveFormat := false
+	for offset := 12; offset+8 <= len(audioData); {
+		id := string(audioData[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(audioData[offset+4:]))
+		body := offset + 8
+		if size < 0 || body+size > len(audioData) {
+			size = len(audioData) - body // Streamed WAV files may have an unset data size
+		}
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, fmt.Errorf("WAV format chunk is too short")
+			}
+			format := binary.LittleEndian.Uint16(audioData[body:])
+			channels := binary.LittleEndian.Uint16(audioData[body+2:])
+			bits := binary.LittleEndian.Uint16(audioData[body+14:])
+			if format != 1 || channels != 1 || bits != 16 {
+				return nil, fmt.Errorf("unsupported WAV format (format=%d, channels=%d, bits=%d)", format, channels, bits)
+			}
+			wav.sampleRate = binary.LittleEndian.Uint32(audioData[body+4:])
+			haveFormat = true
+		case "data":
+			if !haveFormat {
+				return nil, fmt.Errorf("WAV data chunk precedes the format chunk")
+			}
+			wav.samples = audioData[body : body+size&^1]
+			return &wav, nil
+		}
+
+		offset = body + size + size%2 // Chunks are padded to an even size
+	}
+	return nil, fmt.Errorf("WAV audio has no data chunk")
+}
+
+// encode returns the samples as a RIFF/WAV file
+func (w *pcmWAV) encode() []byte {
+	out := make([]byte, 44+len(w.samples))
+	copy(out[0:], "RIFF")
+	binary.LittleEndian.PutUint32(out[4:], uint32(36+len(w.samples)))
+	copy(out[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(out[16:], 16)
+	binary.LittleEndian.PutUint16(out[20:], 1) // PCM
+	binary.LittleEndian.PutUint16(out[22:], 1) // Mono
+	binary.LittleEndian.PutUint32(out[24:], w.sampleRate)
+	binary.LittleEndian.PutUint32(out[28:], w.sampleRate*2)
+	binary.LittleEndian.PutUint16(out[32:], 2)
+	binary.LittleEndian.PutUint16(out[34:], 16)
+	copy(out[36:], "data")
+	binary.LittleEndian.PutUint32(out[40:], uint32(len(w.samples)))
+	copy(out[44:], w.samples)
+	return out
+}
+
+// silence is a run of silent samples, [start, end) in sample indexes
+type silence struct {
+	start, end int
+}
+
+// findSilences returns the runs of at least minSamples consecutive silent samples, in order
+func findSilences(samples []byte, minSamples int) []silence {
+	var silences []silence
+	n := len(samples) / 2
+	runStart := -1
+	for i := 0; i <= n; i++ {
+		quiet := false
+		if i < n {
+			sample := int16(binary.LittleEndian.Uint16(samples[2*i:]))
+			quiet = sample <= silenceThreshold && sample >= -silenceThreshold
+		}
+		switch {
+		case quiet && runStart < 0:
+			runStart = i
+		case !quiet && runStart >= 0:
+			if i-runStart >= minSamples {
+				silences = append(silences, silence{runStart, i})
+			}
+			runStart = -1
+		}
+	}
+	return silences
+}
+
+// splitAtSilences splits WAV audio of parts utterances separated by silent gaps of at least
+// minGap seconds into one WAV file per utterance. The longest parts-1 gaps that aren't at the
+// start or end of the audio are removed, so shorter pauses within an utterance are left alone;
+// it fails if there aren't enough gaps.
+func splitAtSilences(audioData []byte, parts int, minGap float64) ([][]byte, error) {
+	wav, err := parsePCMWAV(audioData)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(wav.samples) / 2
+	var gaps []silence
+	for _, s := range findSilences(wav.samples, int(minGap*float64(wav.sampleRate))) {
+		if s.start > 0 && s.end < total {
+			gaps = append(gaps, s)
+		}
+	}
+	if len(gaps) < parts-1 {
+		return nil, fmt.Errorf("found %d gaps between %d utterances", len(gaps), parts)
+	}
+
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i].end-gaps[i].start > gaps[j].end-gaps[j].start })
+	gaps = gaps[:parts-1]
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i].start < gaps[j].start })
+
+	// Each part keeps a little of the gap on either side so it doesn't start or end abruptly
+	keep := int(wav.sampleRate) / 10
+	segments := make([][]byte, 0, parts)
+	start := 0
+	for _, gap := range append(gaps, silence{total, total}) {
+		end := min(gap.start+keep, gap.end)
+		segment := &pcmWAV{sampleRate: wav.sampleRate, samples: wav.samples[2*start : 2*end]}
+		segments = append(segments, segment.encode())
+		start = max(gap.end-keep, end)
+	}
+	return segments, nil
+}
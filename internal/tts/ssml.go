@@ -0,0 +1,77 @@
+package tts
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// xmlNamespace is the namespace encoding/xml gives attributes with the xml: prefix
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// ValidateSSML checks that ssml is a well-formed document Azure can synthesize as-is: a single
+// <speak> root with version and xml:lang attributes, and a name on every <voice>. It doesn't
+// check the elements against the SSML schema, which Azure reports itself.
+func ValidateSSML(ssml string) error {
+	decoder := xml.NewDecoder(strings.NewReader(ssml))
+	depth := 0
+	roots := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("malformed SSML: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				roots++
+				if roots > 1 {
+					return errors.New("SSML must have a single <speak> root element")
+				}
+				if t.Name.Local != "speak" {
+					return fmt.Errorf("SSML root element must be <speak>, not <%s>", t.Name.Local)
+				}
+				if !hasAttr(t, "version") {
+					return errors.New("<speak> is missing the version attribute")
+				}
+				if !hasAttr(t, "lang") {
+					return errors.New("<speak> is missing the xml:lang attribute")
+				}
+			} else if t.Name.Local == "voice" && !hasAttr(t, "name") {
+				return errors.New("<voice> is missing the name attribute")
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth == 0 && strings.TrimSpace(string(t)) != "" {
+				return errors.New("SSML has text outside the <speak> element")
+			}
+		}
+	}
+
+	if roots == 0 {
+		return errors.New("SSML must have a <speak> root element")
+	}
+	return nil
+}
+
+// hasAttr reports whether element has a non-empty attribute named local. "lang" must carry the
+// xml: prefix.
+func hasAttr(element xml.StartElement, local string) bool {
+	for _, attr := range element.Attr {
+		if attr.Name.Local != local || attr.Value == "" {
+			continue
+		}
+		if local != "lang" || attr.Name.Space == xmlNamespace || attr.Name.Space == "xml" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package tts
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// insertTags tags the entry stored under cacheKey as part of tx, skipping empty tags and ones
+// it already has
+func insertTags(tx *sql.Tx, cacheKey string, tags []string) error {
+	for _, tag := range tags {
+		if tag == "" {
+			continue
+		}
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO audio_cache_tags (cache_key, tag) VALUES (?, ?)`, cacheKey, tag); err != nil {
+			return fmt.Errorf("failed to tag %s: %w", cacheKey, err)
+		}
+	}
+	return nil
+}
+
+// AddTags adds tags to the entry stored under cacheKey, e.g. when a request with tags is
+// answered from the cache
+func (c *Cache) AddTags(cacheKey string, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	tx, err := c.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertTags(tx, cacheKey, tags); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tags: %w", err)
+	}
+	return nil
+}
+
+// Tags returns the tags of the entry stored under cacheKey, in alphabetical order
+func (c *Cache) Tags(cacheKey string) ([]string, error) {
+	rows, err := c.db.Query(`SELECT tag FROM audio_cache_tags WHERE cache_key = ? ORDER BY tag`, cacheKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// DeleteByTag removes every cache entry in namespace tagged with tag, returning how many were
+// deleted
+func (c *Cache) DeleteByTag(namespace, tag string) (int64, error) {
+	rows, err := c.db.Query(
+		`SELECT a.cache_key, a.language_code FROM audio_cache a
+		 JOIN audio_cache_tags t ON t.cache_key = a.cache_key
+		 WHERE t.tag = ? AND a.namespace = ?`,
+		tag, namespace,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query cache: %w", err)
+	}
+
+	var keys []string
+	languages := make(map[string]string)
+	for rows.Next() {
+		var key, languageCode string
+		if err := rows.Scan(&key, &languageCode); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan cache entry: %w", err)
+		}
+		keys = append(keys, key)
+		languages[key] = languageCode
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to query cache: %w", err)
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	deleted, err := c.deleteKeys(keys)
+	if err != nil {
+		return 0, err
+	}
+	c.dropShared(keys...)
+
+	now := getCurrentTimestamp()
+	for _, key := range keys {
+		c.events.publish(CacheEvent{
+			Type:         EventDelete,
+			CacheKey:     key,
+			LanguageCode: languages[key],
+			Namespace:    namespace,
+			Timestamp:    now,
+		})
+	}
+	c.sizeMetricsStale.Store(true)
+
+	return deleted, nil
+}
@@ -0,0 +1,45 @@
+package tts
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// readingWordsPerMinute is the average adult silent reading speed
+const readingWordsPerMinute = 238.0
+
+// TextStats describes a text for clients that display it alongside the audio
+type TextStats struct {
+	CharCount            int
+	WordCount            int
+	SentenceCount        int
+	EstimatedReadingTime time.Duration
+}
+
+// ComputeTextStats returns statistics for text after normalization (see NormalizeText), so the
+// counts match the text that is cached
+func ComputeTextStats(text string) TextStats {
+	normalized := NormalizeText(text)
+	if normalized == "" {
+		return TextStats{}
+	}
+
+	words := strings.Fields(normalized)
+
+	// A sentence ends at a word ending in . ! or ? (so "3.5" doesn't count). Normalization strips
+	// the final punctuation, so the last sentence is counted separately.
+	sentences := 1
+	for _, word := range words[:len(words)-1] {
+		if strings.ContainsAny(word[len(word)-1:], ".!?") {
+			sentences++
+		}
+	}
+
+	return TextStats{
+		CharCount:            utf8.RuneCountInString(normalized),
+		WordCount:            len(words),
+		SentenceCount:        sentences,
+		EstimatedReadingTime: time.Duration(float64(len(words)) / readingWordsPerMinute * float64(time.Minute)),
+	}
+}
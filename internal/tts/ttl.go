@@ -0,0 +1,97 @@
+package tts
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+)
+
+// neverExpires is the expires_at of entries stored with a negative TTLSeconds
+const neverExpires = math.MaxInt64
+
+// SetTTL makes entries older than ttl cache misses, so they are synthesized again with the
+// current voice models, and deletes expired entries every cleanupInterval in the background
+// until the cache is closed. A ttl of 0 disables expiration by age; entries stored with their own
+// lifetime (see Options.TTLSeconds) expire either way.
+func (c *Cache) SetTTL(ttl, cleanupInterval time.Duration) {
+	c.ttl = ttl
+
+	c.ttlStop = make(chan struct{})
+	stop := c.ttlStop
+	go func() {
+		ticker := time.NewTicker(cleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				deleted, err := c.PurgeExpired()
+				if err != nil {
+					log.Printf("Warning: failed to delete expired cache entries: %v", err)
+				} else if deleted > 0 {
+					log.Printf("Cache: deleted %d expired entries", deleted)
+				}
+			}
+		}
+	}()
+}
+
+// expiryCutoff returns the creation time before which entries have expired
+func (c *Cache) expiryCutoff() int64 {
+	return getCurrentTimestamp() - int64(c.ttl/time.Second)
+}
+
+// expired reports whether an entry created at createdAt has expired: at expiresAt if it has its
+// own lifetime (0 = none), otherwise once it has outlived the TTL
+func (c *Cache) expired(createdAt, expiresAt int64) bool {
+	if expiresAt != 0 {
+		return expiresAt <= getCurrentTimestamp()
+	}
+	return c.ttl > 0 && createdAt < c.expiryCutoff()
+}
+
+// expiredWhere returns a WHERE condition matching the entries expired considers expired, and its
+// arguments
+func (c *Cache) expiredWhere() (string, []interface{}) {
+	now := getCurrentTimestamp()
+	if c.ttl <= 0 {
+		return `expires_at <= ?`, []interface{}{now}
+	}
+	return `(expires_at IS NULL AND created_at < ?) OR expires_at <= ?`, []interface{}{c.expiryCutoff(), now}
+}
+
+// PurgeExpired deletes the entries that have expired and returns how many it deleted. Entries
+// stored as deltas against them are stored whole again first.
+func (c *Cache) PurgeExpired() (int64, error) {
+	where, args := c.expiredWhere()
+	rows, err := c.db.Query(`SELECT cache_key FROM audio_cache WHERE `+where, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query expired entries: %w", err)
+	}
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan expired entry: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to query expired entries: %w", err)
+	}
+
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	deleted, err := c.deleteKeys(keys)
+	if err != nil {
+		return 0, err
+	}
+	c.sizeMetricsStale.Store(true)
+	return deleted, nil
+}
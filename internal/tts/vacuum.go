@@ -0,0 +1,112 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// autoVacuumIncremental is the value of PRAGMA auto_vacuum in incremental mode
+const autoVacuumIncremental = 2
+
+// SetIncrementalVacuum returns the pages freed by evictions and deletions to the file system
+// every interval in the background, with PRAGMA incremental_vacuum, until the cache is closed.
+// This needs the database in incremental auto-vacuum mode; one created before that is switched
+// over with a full VACUUM first, which can take a while for a large cache.
+func (c *Cache) SetIncrementalVacuum(interval time.Duration) error {
+	// auto_vacuum applies to the connection that runs the VACUUM
+	conn, err := c.db.Conn(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer conn.Close()
+
+	var mode int
+	if err := conn.QueryRowContext(context.Background(), `PRAGMA auto_vacuum`).Scan(&mode); err != nil {
+		return fmt.Errorf("failed to query auto_vacuum mode: %w", err)
+	}
+	if mode != autoVacuumIncremental {
+		log.Printf("Cache: switching the database to incremental auto-vacuum")
+		if _, err := conn.ExecContext(context.Background(), `PRAGMA auto_vacuum = INCREMENTAL`); err != nil {
+			return fmt.Errorf("failed to set auto_vacuum mode: %w", err)
+		}
+		if _, err := conn.ExecContext(context.Background(), `VACUUM`); err != nil {
+			return fmt.Errorf("failed to vacuum database: %w", err)
+		}
+	}
+
+	c.vacuumStop = make(chan struct{})
+	stop := c.vacuumStop
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if !c.lockQueries(stop) {
+					return
+				}
+				err := c.incrementalVacuum()
+				c.queryMu.Unlock()
+				if err != nil {
+					log.Printf("Warning: incremental vacuum failed: %v", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// lockQueries write-locks queryMu once the lookups and writes in flight finish, returning false
+// if stop was closed meanwhile. New ones wait for the vacuum, so a steady stream of them can't
+// hold it off; none takes the read lock twice, which would deadlock behind the waiting vacuum.
+func (c *Cache) lockQueries(stop chan struct{}) bool {
+	c.queryMu.Lock()
+	select {
+	case <-stop:
+		c.queryMu.Unlock()
+		return false
+	default:
+		return true
+	}
+}
+
+// incrementalVacuum frees the database's unused pages, logging its size before and after. It
+// does nothing if there are none.
+func (c *Cache) incrementalVacuum() error {
+	var freePages int64
+	if err := c.db.QueryRow(`PRAGMA freelist_count`).Scan(&freePages); err != nil {
+		return fmt.Errorf("failed to query free pages: %w", err)
+	}
+	if freePages == 0 {
+		return nil
+	}
+
+	before, err := c.databaseSize()
+	if err != nil {
+		return err
+	}
+	started := time.Now()
+	// The pragma frees pages as its result rows are stepped through, so they must all be read
+	rows, err := c.db.Query(`PRAGMA incremental_vacuum`)
+	if err != nil {
+		return fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	for rows.Next() {
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	after, err := c.databaseSize()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Cache: incremental vacuum freed %d pages in %s, size=%d bytes (was %d)", freePages, time.Since(started).Round(time.Millisecond), after, before)
+	return nil
+}
@@ -0,0 +1,64 @@
+package tts
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVacuumIsntStarvedByLookups(t *testing.T) {
+	cache := newTestCache(t)
+	if _, err := cache.Put("Hello", "en-US", Options{}, readTestAudio(t, "en-US"), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Lookups overlap one another, so the lock is never free between them
+	var stopLookups atomic.Bool
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stopLookups.Load() {
+				if _, err := cache.Get("Hello", "en-US", Options{}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer stopLookups.Store(true)
+	time.Sleep(50 * time.Millisecond)
+
+	locked := make(chan bool)
+	go func() { locked <- cache.lockQueries(make(chan struct{})) }()
+	select {
+	case ok := <-locked:
+		if !ok {
+			t.Fatal("lockQueries gave up without being stopped")
+		}
+		cache.queryMu.Unlock()
+	case <-time.After(5 * time.Second):
+		t.Fatal("the vacuum is still waiting for lookups to stop")
+	}
+}
+
+func TestVacuumStopsWhileWaiting(t *testing.T) {
+	cache := newTestCache(t)
+	cache.queryMu.RLock() // A lookup in flight
+	stop := make(chan struct{})
+	locked := make(chan bool)
+	go func() { locked <- cache.lockQueries(stop) }()
+
+	close(stop)
+	cache.queryMu.RUnlock()
+	if <-locked {
+		t.Error("lockQueries locked the cache after being stopped")
+	}
+	if !cache.queryMu.TryLock() {
+		t.Fatal("lockQueries left the cache locked")
+	}
+	cache.queryMu.Unlock()
+}
@@ -0,0 +1,163 @@
+package tts
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"com.biesnecker/tts-daemon/internal/decoder"
+)
+
+// ErrInvalidAudio is returned when synthesized audio is truncated or corrupt; synthesizing the
+// text again usually succeeds
+var ErrInvalidAudio = errors.New("invalid audio")
+
+const (
+	// minMP3Size is the smallest plausible synthesized MP3; even a single word is several KB
+	minMP3Size = 1024
+
+	// minAudibleFraction is the fraction of samples that must be louder than audibleLevel for
+	// audio not to count as silent
+	minAudibleFraction = 0.001
+)
+
+// audibleLevel is -60 dB relative to full scale
+var audibleLevel = math.Pow(10, -60.0/20)
+
+// Layer III bitrates in kbps by bitrate index, for MPEG-1 and for MPEG-2/2.5
+var (
+	mpeg1L3Bitrates = [15]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320}
+	mpeg2L3Bitrates = [15]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160}
+)
+
+// mpegSampleRates are the sample rates by sample rate index for each MPEG version ID
+// (0 = MPEG-2.5, 2 = MPEG-2, 3 = MPEG-1)
+var mpegSampleRates = map[byte][3]int{
+	0: {11025, 12000, 8000},
+	2: {22050, 24000, 16000},
+	3: {44100, 48000, 32000},
+}
+
+// ValidateMP3 checks that audioData is a complete MP3 stream with audible content. Errors wrap
+// ErrInvalidAudio.
+func ValidateMP3(audioData []byte) error {
+	if len(audioData) <= minMP3Size {
+		return fmt.Errorf("%w: only %d bytes", ErrInvalidAudio, len(audioData))
+	}
+
+	start := skipID3v2(audioData)
+	if _, err := mp3FrameLength(audioData[start:]); err != nil {
+		return fmt.Errorf("%w: no MP3 frame at the start of the audio: %v", ErrInvalidAudio, err)
+	}
+	if err := checkMP3Frames(audioData[start:]); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAudio, err)
+	}
+
+	audible, total, err := countAudibleSamples(audioData)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAudio, err)
+	}
+	if total == 0 || float64(audible) < minAudibleFraction*float64(total) {
+		return fmt.Errorf("%w: audio is silent (%d of %d samples above -60 dB)", ErrInvalidAudio, audible, total)
+	}
+	return nil
+}
+
+// skipID3v2 returns the offset of the audio after any ID3v2 tag at the start of audioData
+func skipID3v2(audioData []byte) int {
+	if len(audioData) < 10 || string(audioData[0:3]) != "ID3" {
+		return 0
+	}
+	// The tag size is stored as four 7-bit bytes and excludes the 10-byte header
+	size := int(audioData[6])<<21 | int(audioData[7])<<14 | int(audioData[8])<<7 | int(audioData[9])
+	return min(10+size, len(audioData))
+}
+
+// mp3FrameLength returns the length in bytes of the Layer III frame whose header starts frame
+func mp3FrameLength(frame []byte) (int, error) {
+	if len(frame) < 4 {
+		return 0, fmt.Errorf("frame header is truncated")
+	}
+	if frame[0] != 0xFF || frame[1]&0xE0 != 0xE0 {
+		return 0, fmt.Errorf("missing frame sync word")
+	}
+
+	version := frame[1] >> 3 & 0x03
+	layer := frame[1] >> 1 & 0x03
+	bitrateIndex := frame[2] >> 4
+	sampleRateIndex := frame[2] >> 2 & 0x03
+	padding := int(frame[2] >> 1 & 0x01)
+
+	sampleRates, ok := mpegSampleRates[version]
+	if !ok {
+		return 0, fmt.Errorf("reserved MPEG version")
+	}
+	if layer != 1 {
+		return 0, fmt.Errorf("not a Layer III frame")
+	}
+	if bitrateIndex == 0 || bitrateIndex == 15 || sampleRateIndex == 3 {
+		return 0, fmt.Errorf("invalid bitrate or sample rate")
+	}
+
+	sampleRate := sampleRates[sampleRateIndex]
+	if version == 3 {
+		return 144*mpeg1L3Bitrates[bitrateIndex]*1000/sampleRate + padding, nil
+	}
+	return 72*mpeg2L3Bitrates[bitrateIndex]*1000/sampleRate + padding, nil
+}
+
+// checkMP3Frames walks the frames of audioData, which must start with a frame header, and checks
+// that the last one isn't cut off
+func checkMP3Frames(audioData []byte) error {
+	end := len(audioData)
+	if end >= 128 && string(audioData[end-128:end-125]) == "TAG" {
+		end -= 128 // ID3v1 tag
+	}
+
+	offset, frames := 0, 0
+	for offset < end {
+		length, err := mp3FrameLength(audioData[offset:end])
+		if err != nil {
+			return fmt.Errorf("frame %d at byte %d: %v", frames+1, offset, err)
+		}
+		if offset+length > end {
+			return fmt.Errorf("last frame is truncated (%d of %d bytes)", end-offset, length)
+		}
+		offset += length
+		frames++
+	}
+	return nil
+}
+
+// countAudibleSamples decodes MP3 audio and counts the samples louder than audibleLevel
+func countAudibleSamples(audioData []byte) (audible, total int, err error) {
+	streamer, _, err := decoder.MP3(audioData)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to decode audio: %w", err)
+	}
+	defer streamer.Close()
+
+	buf := make([][2]float64, 4096)
+	for {
+		n, ok := streamer.Stream(buf)
+		for _, sample := range buf[:n] {
+			if math.Abs(sample[0]) > audibleLevel || math.Abs(sample[1]) > audibleLevel {
+				audible++
+			}
+		}
+		total += n
+		if !ok {
+			break
+		}
+	}
+	if err := streamer.Err(); err != nil {
+		return 0, 0, fmt.Errorf("failed to decode audio: %w", err)
+	}
+	return audible, total, nil
+}
+
+// SetAudioValidation makes GetAudio check synthesized MP3 audio with ValidateMP3 before caching
+// it, failing the request with ErrInvalidAudio instead of caching truncated or silent audio
+func (s *Service) SetAudioValidation(enabled bool) {
+	s.validateAudio = enabled
+}
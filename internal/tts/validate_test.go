@@ -0,0 +1,93 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"com.biesnecker/tts-daemon/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// withHeader returns audioData with its first frame header's second and third bytes replaced
+func withHeader(audioData []byte, b1, b2 byte) []byte {
+	audioData = bytes.Clone(audioData)
+	audioData[1], audioData[2] = b1, b2
+	return audioData
+}
+
+func TestValidateMP3(t *testing.T) {
+	// The mock recordings are silent, so audible audio comes from a short sine tone, which
+	// starts with an ID3v2 tag
+	tone, err := os.ReadFile("../../testdata/tone.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	silent := testMP3(10)
+
+	// Frame 3 is overwritten with data that isn't a frame header
+	corrupt := bytes.Clone(silent)
+	copy(corrupt[2*417:], "not a frame header")
+
+	id3v1 := append([]byte("TAG"), make([]byte, 125)...)
+
+	tests := []struct {
+		name      string
+		audioData []byte
+		wantErr   string // "" if the audio is valid
+	}{
+		{"tone", tone, ""},
+		{"with an ID3v1 tag", append(bytes.Clone(tone), id3v1...), ""},
+		{"empty", nil, "only 0 bytes"},
+		{"too small", testMP3(2), "only 834 bytes"},
+		{"no sync word", bytes.Repeat([]byte("garbage!"), 200), "missing frame sync word"},
+		{"reserved MPEG version", withHeader(silent, 0xEB, 0x90), "reserved MPEG version"},
+		{"layer II", withHeader(silent, 0xFD, 0x90), "not a Layer III frame"},
+		{"free bitrate", withHeader(silent, 0xFB, 0x00), "invalid bitrate or sample rate"},
+		{"bad bitrate", withHeader(silent, 0xFB, 0xF0), "invalid bitrate or sample rate"},
+		{"reserved sample rate", withHeader(silent, 0xFB, 0x9C), "invalid bitrate or sample rate"},
+		{"corrupt frame", corrupt, "frame 3 at byte 834"},
+		{"last frame truncated", tone[:len(tone)-100], "last frame is truncated"},
+		{"silent", silent, "audio is silent"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMP3(tt.audioData)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateMP3 = %v, want valid", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateMP3 = %v, want %q", err, tt.wantErr)
+			}
+			if !errors.Is(err, ErrInvalidAudio) {
+				t.Errorf("error %v doesn't wrap ErrInvalidAudio", err)
+			}
+		})
+	}
+}
+
+func TestGetAudioRejectsInvalidAudio(t *testing.T) {
+	provider := newMockProvider(t)
+	provider.audio = func(text, languageCode string) ([]byte, error) { return testMP3(10), nil }
+	cache := newTestCache(t)
+	service := NewService(cache, provider)
+	service.SetAudioValidation(true)
+
+	before := testutil.ToFloat64(metrics.InvalidAudio)
+	_, _, _, err := service.GetAudio(context.Background(), "Hello", "en-US", Options{}, false)
+	if !errors.Is(err, ErrInvalidAudio) {
+		t.Fatalf("GetAudio = %v, want ErrInvalidAudio", err)
+	}
+	if got := testutil.ToFloat64(metrics.InvalidAudio) - before; got != 1 {
+		t.Errorf("tts_invalid_audio_total went up by %v, want 1", got)
+	}
+	if audio, err := cache.Get("Hello", "en-US", Options{}); err != nil || audio != nil {
+		t.Errorf("invalid audio was cached (%v, %v)", audio, err)
+	}
+}
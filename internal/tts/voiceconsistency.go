@@ -0,0 +1,79 @@
+package tts
+
+import (
+	"fmt"
+	"sort"
+)
+
+// VoiceInconsistency describes a language with cache entries synthesized by a voice other than
+// the one that would be used now, e.g. after a voice mapping in the config changed
+type VoiceInconsistency struct {
+	Locale          string
+	CurrentVoice    string   // "" if the provider no longer has a voice for the language
+	CachedVoices    []string // Every voice recorded for the language's entries, sorted
+	AffectedEntries int64    // Entries not synthesized with CurrentVoice
+}
+
+// cachedVoiceCounts returns the number of entries per recorded voice for each language code, or
+// for just languageCode if it isn't "". Entries cached before voices were recorded are ignored.
+func (c *Cache) cachedVoiceCounts(languageCode string) (map[string]map[string]int64, error) {
+	query := `SELECT language_code, voice_name, COUNT(*) FROM audio_cache WHERE voice_name IS NOT NULL AND voice_name != ''`
+	var queryArgs []interface{}
+	if languageCode != "" {
+		query += ` AND language_code = ?`
+		queryArgs = append(queryArgs, languageCode)
+	}
+	query += ` GROUP BY language_code, voice_name`
+
+	rows, err := c.db.Query(query, queryArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cached voices: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]map[string]int64)
+	for rows.Next() {
+		var lang, voice string
+		var count int64
+		if err := rows.Scan(&lang, &voice, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan cached voice: %w", err)
+		}
+		if counts[lang] == nil {
+			counts[lang] = make(map[string]int64)
+		}
+		counts[lang][voice] = count
+	}
+	return counts, rows.Err()
+}
+
+// CheckVoiceConsistency compares the voices recorded for cached entries with the voice each
+// language is synthesized with now (including any fallback locale), for one language or all of
+// them if languageCode is "". Only languages with entries from another voice are returned,
+// sorted by locale.
+func (s *Service) CheckVoiceConsistency(languageCode string) ([]VoiceInconsistency, error) {
+	counts, err := s.cache.cachedVoiceCounts(languageCode)
+	if err != nil {
+		return nil, err
+	}
+
+	var inconsistencies []VoiceInconsistency
+	for lang, voices := range counts {
+		current, _ := s.provider.VoiceName(voiceLocale(lang, s.withVoiceFallback(lang, Options{})))
+
+		inconsistency := VoiceInconsistency{Locale: lang, CurrentVoice: current}
+		for voice, count := range voices {
+			inconsistency.CachedVoices = append(inconsistency.CachedVoices, voice)
+			if voice != current {
+				inconsistency.AffectedEntries += count
+			}
+		}
+		if inconsistency.AffectedEntries == 0 {
+			continue
+		}
+		sort.Strings(inconsistency.CachedVoices)
+		inconsistencies = append(inconsistencies, inconsistency)
+	}
+
+	sort.Slice(inconsistencies, func(i, j int) bool { return inconsistencies[i].Locale < inconsistencies[j].Locale })
+	return inconsistencies, nil
+}
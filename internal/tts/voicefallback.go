@@ -0,0 +1,118 @@
+package tts
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+// lastResortLocale is used for languages with no voice of their own, no fallback chain and no
+// related language with a voice
+const lastResortLocale = "en-US"
+
+// lastResortLanguages records languages already warned about falling back to lastResortLocale
+var lastResortLanguages sync.Map
+
+// SetLanguageFallbackChains sets the locales tried, in order, for a language the provider has no
+// voice for, keyed by locale or base language, e.g. "pt-AO": ["pt-BR", "pt-PT"]. Languages
+// without a voice or a chain fall back to a related language (see SetLanguageFamilyFallback)
+// and then to en-US.
+func (s *Service) SetLanguageFallbackChains(chains map[string][]string) {
+	s.fallbackChains = chains
+	s.setMetricsLanguages()
+}
+
+// VoiceFallbackLocale returns the locale whose voice is used for languageCode, or "" if the
+// provider has a voice for the language itself (exactly or by its base language)
+func (s *Service) VoiceFallbackLocale(languageCode string) string {
+	if _, err := s.provider.VoiceName(languageCode); err == nil {
+		return ""
+	}
+
+	chain, ok := s.fallbackChains[languageCode]
+	if !ok {
+		if base, _, found := strings.Cut(languageCode, "-"); found {
+			chain = s.fallbackChains[base]
+		}
+	}
+	for _, locale := range chain {
+		if _, err := s.provider.VoiceName(locale); err == nil {
+			return locale
+		}
+	}
+	if s.familyTree != nil {
+		for _, locale := range s.familyTree.Relatives(languageCode) {
+			if _, err := s.provider.VoiceName(locale); err == nil {
+				return locale
+			}
+		}
+	}
+
+	if languageCode == lastResortLocale {
+		return ""
+	}
+	if _, err := s.provider.VoiceName(lastResortLocale); err != nil {
+		return "" // Synthesis reports the missing voice
+	}
+	if _, warned := lastResortLanguages.LoadOrStore(languageCode, true); !warned {
+		log.Printf("Warning: no voice for %s or its fallback locales, using %s", languageCode, lastResortLocale)
+	}
+	return lastResortLocale
+}
+
+// EffectiveLocale returns the locale whose voice is used for languageCode: the language itself,
+// or its fallback locale
+func (s *Service) EffectiveLocale(languageCode string) string {
+	if locale := s.VoiceFallbackLocale(languageCode); locale != "" {
+		return locale
+	}
+	return languageCode
+}
+
+// withVoiceFallback returns opts with the fallback locale for languageCode, which is part of the
+// cache key so that audio spoken by another locale's voice is cached separately
+func (s *Service) withVoiceFallback(languageCode string, opts Options) Options {
+	if opts.Voice != "" {
+		return opts // An explicitly requested voice needs no fallback
+	}
+	opts.VoiceLocale = s.VoiceFallbackLocale(languageCode)
+	return opts
+}
+
+// voiceLocale returns the locale whose voice synthesizes languageCode with opts
+func voiceLocale(languageCode string, opts Options) string {
+	if opts.VoiceLocale != "" {
+		return opts.VoiceLocale
+	}
+	return languageCode
+}
+
+// voiceFor returns the voice that synthesizes languageCode with opts: the requested voice, or the
+// provider's voice for the language or its fallback locale
+func voiceFor(p Provider, languageCode string, opts Options) (string, error) {
+	if opts.Voice != "" {
+		return opts.Voice, nil
+	}
+	return p.VoiceName(voiceLocale(languageCode, opts))
+}
+
+// localeOfVoice returns the locale prefix of an Azure voice short name, such as "pt-BR" for
+// "pt-BR-FranciscaNeural", or "" for an unknown voice
+func localeOfVoice(voiceName string) string {
+	if i := strings.LastIndex(voiceName, "-"); i > 0 {
+		return voiceName[:i]
+	}
+	return ""
+}
+
+// voiceNameForKey returns the voice recorded for the entry stored under cacheKey ("" if unknown)
+func (c *Cache) voiceNameForKey(cacheKey string) (string, error) {
+	var voiceName sql.NullString
+	err := c.db.QueryRow(`SELECT voice_name FROM audio_cache WHERE cache_key = ?`, cacheKey).Scan(&voiceName)
+	if err != nil && err != sql.ErrNoRows {
+		return "", fmt.Errorf("failed to query cache entry: %w", err)
+	}
+	return voiceName.String, nil
+}
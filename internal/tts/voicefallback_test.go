@@ -0,0 +1,102 @@
+package tts
+
+import "testing"
+
+// voiceTableProvider is a mockProvider whose voices are chosen from fixed tables the way the
+// Azure client chooses them, including custom voices for a base language
+type voiceTableProvider struct {
+	*mockProvider
+	customVoices map[string]string
+}
+
+// VoiceName implements Provider
+func (p *voiceTableProvider) VoiceName(languageCode string) (string, error) {
+	return selectVoice(languageCode, p.customVoices, p.DefaultVoices())
+}
+
+func TestVoiceFallbackLocale(t *testing.T) {
+	provider := &voiceTableProvider{
+		mockProvider: newMockProvider(t),
+		customVoices: map[string]string{"pt-PT": "pt-PT-RaquelNeural", "nl": "nl-NL-ColetteNeural"},
+	}
+	chains := map[string][]string{
+		"pt-AO": {"pt-BR", "pt-PT"}, // pt-BR has no voice
+		"ca":    {"es-ES"},          // Keyed by base language
+		"eu-ES": {"xx-XX"},          // No voice anywhere in the chain
+		"co":    {"fr-FR"},          // Takes precedence over the language family
+	}
+
+	tests := []struct {
+		languageCode string
+		family       bool
+		want         string
+	}{
+		{"fr-FR", false, ""},      // Exact locale
+		{"nl-BE", false, ""},      // Base language
+		{"pt-AO", false, "pt-PT"}, // Region variants in order
+		{"ca-ES", false, "es-ES"}, // Base language's chain
+		{"eu-ES", false, "en-US"}, // Chain without voices
+		{"ko-KR", false, "en-US"}, // Last resort
+		{"en-US", false, ""},      // The last resort itself
+		{"lb", false, "en-US"},    // Family fallback disabled
+		{"lb", true, "de-DE"},     // Parent with a voice
+		{"pt-CV", true, "pt-PT"},  // Parent without a chain
+		{"co", true, "fr-FR"},     // Chain before family
+		{"ko-KR", true, "en-US"},  // Not in the family tree
+	}
+	for _, tt := range tests {
+		name := tt.languageCode
+		if tt.family {
+			name += "/family"
+		}
+		t.Run(name, func(t *testing.T) {
+			service := NewService(newTestCache(t), provider)
+			service.SetLanguageFallbackChains(chains)
+			if err := service.SetLanguageFamilyFallback(tt.family); err != nil {
+				t.Fatal(err)
+			}
+			if got := service.VoiceFallbackLocale(tt.languageCode); got != tt.want {
+				t.Errorf("VoiceFallbackLocale(%s) = %q, want %q", tt.languageCode, got, tt.want)
+			}
+			wantLocale := tt.want
+			if wantLocale == "" {
+				wantLocale = tt.languageCode
+			}
+			if got := service.EffectiveLocale(tt.languageCode); got != wantLocale {
+				t.Errorf("EffectiveLocale(%s) = %q, want %q", tt.languageCode, got, wantLocale)
+			}
+		})
+	}
+}
+
+func TestVoiceFallbackIsCachedSeparately(t *testing.T) {
+	provider := &voiceTableProvider{
+		mockProvider: newMockProvider(t),
+		customVoices: map[string]string{"pt-PT": "pt-PT-RaquelNeural"},
+	}
+	audioData := readTestAudio(t, "en-US")
+	provider.audio = func(text, languageCode string) ([]byte, error) { return audioData, nil } // No recording for pt-PT
+	cache := newTestCache(t)
+	service := NewService(cache, provider)
+	service.SetLanguageFallbackChains(map[string][]string{"pt-AO": {"pt-BR", "pt-PT"}})
+
+	_, cacheKey, _, err := service.GetAudio(t.Context(), "Bom dia", "pt-AO", Options{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	voiceName, err := cache.voiceNameForKey(cacheKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if voiceName != "pt-PT-RaquelNeural" {
+		t.Errorf("entry voice = %q, want the fallback locale's voice", voiceName)
+	}
+	if cacheKey == GenerateCacheKey("Bom dia", "pt-AO", Options{}) {
+		t.Error("the fallback locale isn't part of the cache key")
+	}
+
+	// An explicitly requested voice is used as is
+	if opts := service.withVoiceFallback("pt-AO", Options{Voice: "pt-BR-FranciscaNeural"}); opts.VoiceLocale != "" {
+		t.Errorf("VoiceLocale = %q with an explicit voice, want none", opts.VoiceLocale)
+	}
+}
@@ -0,0 +1,134 @@
+package tts
+
+import (
+	"fmt"
+	"sort"
+)
+
+// VoiceChange records that the default voice for a locale changed
+type VoiceChange struct {
+	Locale     string
+	OldVoice   string
+	NewVoice   string
+	DetectedAt int64 // Unix timestamp
+}
+
+// RecordVoiceDefaults compares voices (locale -> default voice) with the voices recorded last
+// time, adds a history row for every locale whose voice changed and stores voices for next time.
+// Locales seen for the first time are recorded without a history row.
+func (c *Cache) RecordVoiceDefaults(voices map[string]string) ([]VoiceChange, error) {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	previous := make(map[string]string)
+	rows, err := tx.Query(`SELECT locale, voice FROM voice_defaults`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query voice defaults: %w", err)
+	}
+	for rows.Next() {
+		var locale, voice string
+		if err := rows.Scan(&locale, &voice); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan voice default: %w", err)
+		}
+		previous[locale] = voice
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query voice defaults: %w", err)
+	}
+
+	locales := make([]string, 0, len(voices))
+	for locale := range voices {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	now := getCurrentTimestamp()
+	var changes []VoiceChange
+	for _, locale := range locales {
+		voice := voices[locale]
+		old, known := previous[locale]
+		if known && old == voice {
+			continue
+		}
+		if known {
+			changes = append(changes, VoiceChange{Locale: locale, OldVoice: old, NewVoice: voice, DetectedAt: now})
+			if _, err := tx.Exec(
+				`INSERT INTO voice_change_history (locale, old_voice, new_voice, detected_at) VALUES (?, ?, ?, ?)`,
+				locale, old, voice, now,
+			); err != nil {
+				return nil, fmt.Errorf("failed to record voice change: %w", err)
+			}
+		}
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO voice_defaults (locale, voice) VALUES (?, ?)`, locale, voice); err != nil {
+			return nil, fmt.Errorf("failed to store voice default: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit voice defaults: %w", err)
+	}
+	return changes, nil
+}
+
+// VoiceChangeHistory returns recorded voice changes, newest first, optionally for one locale
+// ("" = all). limit <= 0 returns every change.
+func (c *Cache) VoiceChangeHistory(locale string, limit int) ([]VoiceChange, error) {
+	query := `SELECT locale, old_voice, new_voice, detected_at FROM voice_change_history`
+	var queryArgs []interface{}
+	if locale != "" {
+		query += ` WHERE locale = ?`
+		queryArgs = append(queryArgs, locale)
+	}
+	query += ` ORDER BY detected_at DESC, rowid DESC`
+	if limit > 0 {
+		query += ` LIMIT ?`
+		queryArgs = append(queryArgs, limit)
+	}
+
+	rows, err := c.db.Query(query, queryArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query voice history: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []VoiceChange
+	for rows.Next() {
+		var vc VoiceChange
+		if err := rows.Scan(&vc.Locale, &vc.OldVoice, &vc.NewVoice, &vc.DetectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan voice change: %w", err)
+		}
+		changes = append(changes, vc)
+	}
+	return changes, rows.Err()
+}
+
+// CountEntriesWithVoice returns the number of cache entries synthesized with voiceName
+func (c *Cache) CountEntriesWithVoice(voiceName string) (int64, error) {
+	var count int64
+	err := c.db.QueryRow(`SELECT COUNT(*) FROM audio_cache WHERE voice_name = ?`, voiceName).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count cache entries: %w", err)
+	}
+	return count, nil
+}
+
+// RecordVoiceChanges compares the provider's current default voices with those seen last time
+// (see Cache.RecordVoiceDefaults). Call it after FetchVoiceList.
+func (s *Service) RecordVoiceChanges() ([]VoiceChange, error) {
+	return s.cache.RecordVoiceDefaults(s.provider.DefaultVoices())
+}
+
+// VoiceChangeHistory returns recorded voice changes (see Cache.VoiceChangeHistory)
+func (s *Service) VoiceChangeHistory(locale string, limit int) ([]VoiceChange, error) {
+	return s.cache.VoiceChangeHistory(locale, limit)
+}
+
+// CountEntriesWithVoice returns the number of cache entries synthesized with voiceName
+func (s *Service) CountEntriesWithVoice(voiceName string) (int64, error) {
+	return s.cache.CountEntriesWithVoice(voiceName)
+}
@@ -0,0 +1,66 @@
+package tts
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"com.biesnecker/tts-daemon/internal/metrics"
+)
+
+// RefreshVoiceList reloads the provider's voice list, so voices added since startup can be used,
+// and records any default voice changes (see RecordVoiceChanges). If loading fails the current
+// voices are kept. It returns the number of voices and of locales with a default voice.
+func (s *Service) RefreshVoiceList(ctx context.Context) (voices, locales int, err error) {
+	err = s.provider.FetchVoiceList(ctx)
+	if s.voiceRefreshHandler != nil {
+		s.voiceRefreshHandler(err)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	metrics.VoiceCacheRefreshes.Inc()
+	s.setMetricsLanguages()
+
+	changes, err := s.RecordVoiceChanges()
+	if err != nil {
+		log.Printf("Warning: failed to record voice changes: %v", err)
+	}
+	for _, change := range changes {
+		log.Printf("Azure: default voice for %s changed from %s to %s", change.Locale, change.OldVoice, change.NewVoice)
+	}
+
+	return s.provider.VoiceCount(), len(s.provider.DefaultVoices()), nil
+}
+
+// SetVoiceRefreshHandler sets a function RefreshVoiceList calls with the result (nil on
+// success) of every attempt to reload the voice list
+func (s *Service) SetVoiceRefreshHandler(handler func(err error)) {
+	s.voiceRefreshHandler = handler
+}
+
+// StartVoiceRefresh refreshes the voice list every interval in the background until the service
+// is closed. Failed refreshes are logged and leave the current voices in place.
+func (s *Service) StartVoiceRefresh(interval time.Duration) {
+	s.refreshStop = make(chan struct{})
+	stop := s.refreshStop
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				voices, locales, err := s.RefreshVoiceList(context.Background())
+				if err != nil {
+					log.Printf("Warning: failed to refresh voice list, keeping the current voices: %v", err)
+					continue
+				}
+				log.Printf("Azure: refreshed voice list, %d voices covering %d locales", voices, locales)
+			}
+		}
+	}()
+}
@@ -0,0 +1,47 @@
+package tts
+
+import (
+	"context"
+	"sync"
+)
+
+// WarmProgress is the running totals of a WarmCache call
+type WarmProgress struct {
+	Total        int
+	Done         int
+	CachedHits   int   // Requests that were already cached
+	AzureFetches int   // Requests synthesized and cached
+	Errors       int   // Requests that failed
+	LastError    error // Error of the request that just completed, if it failed
+}
+
+// WarmCache fetches the audio for requests so that it is cached, with at most concurrency
+// requests in flight, independently of the rate limiter. progress is called with the running
+// totals after each request completes, one call at a time.
+func (s *Service) WarmCache(ctx context.Context, requests []struct {
+	Text, LanguageCode string
+	Options            Options
+}, concurrency int, progress func(WarmProgress)) WarmProgress {
+	var mu sync.Mutex
+	totals := WarmProgress{Total: len(requests)}
+
+	s.bulkGetAudio(ctx, requests, false, concurrency, func(idx int, audioData []byte, cacheKey string, cached bool, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		totals.Done++
+		totals.LastError = err
+		switch {
+		case err != nil:
+			totals.Errors++
+		case cached:
+			totals.CachedHits++
+		default:
+			totals.AzureFetches++
+		}
+		progress(totals)
+	})
+
+	totals.LastError = nil
+	return totals
+}
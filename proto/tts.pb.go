@@ -21,14 +21,136 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// OutputFormat selects the audio format requested from Azure
+type OutputFormat int32
+
+const (
+	OutputFormat_MP3          OutputFormat = 0 // mono MP3, 16kHz 128kbps unless another bitrate or sample rate is requested
+	OutputFormat_WAV_16K      OutputFormat = 1 // 16kHz 16-bit mono PCM in a RIFF/WAV container (uncompressed, no decode cost)
+	OutputFormat_OPUS_24K     OutputFormat = 2 // 24kHz 48kbps mono Opus frames without a container, for WebRTC clients
+	OutputFormat_OGG_OPUS_48K OutputFormat = 3 // 48kHz mono Opus in an OGG container; lower playback latency than MP3
+	OutputFormat_OGG_OPUS_24K OutputFormat = 4 // 24kHz mono Opus in an OGG container; a fraction of the size of MP3 for speech
+)
+
+// Enum value maps for OutputFormat.
+var (
+	OutputFormat_name = map[int32]string{
+		0: "MP3",
+		1: "WAV_16K",
+		2: "OPUS_24K",
+		3: "OGG_OPUS_48K",
+		4: "OGG_OPUS_24K",
+	}
+	OutputFormat_value = map[string]int32{
+		"MP3":          0,
+		"WAV_16K":      1,
+		"OPUS_24K":     2,
+		"OGG_OPUS_48K": 3,
+		"OGG_OPUS_24K": 4,
+	}
+)
+
+func (x OutputFormat) Enum() *OutputFormat {
+	p := new(OutputFormat)
+	*p = x
+	return p
+}
+
+func (x OutputFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutputFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tts_proto_enumTypes[0].Descriptor()
+}
+
+func (OutputFormat) Type() protoreflect.EnumType {
+	return &file_proto_tts_proto_enumTypes[0]
+}
+
+func (x OutputFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutputFormat.Descriptor instead.
+func (OutputFormat) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{0}
+}
+
+// MetricType is the type of a Prometheus metric family
+type MetricType int32
+
+const (
+	MetricType_COUNTER   MetricType = 0
+	MetricType_GAUGE     MetricType = 1
+	MetricType_SUMMARY   MetricType = 2
+	MetricType_UNTYPED   MetricType = 3
+	MetricType_HISTOGRAM MetricType = 4
+)
+
+// Enum value maps for MetricType.
+var (
+	MetricType_name = map[int32]string{
+		0: "COUNTER",
+		1: "GAUGE",
+		2: "SUMMARY",
+		3: "UNTYPED",
+		4: "HISTOGRAM",
+	}
+	MetricType_value = map[string]int32{
+		"COUNTER":   0,
+		"GAUGE":     1,
+		"SUMMARY":   2,
+		"UNTYPED":   3,
+		"HISTOGRAM": 4,
+	}
+)
+
+func (x MetricType) Enum() *MetricType {
+	p := new(MetricType)
+	*p = x
+	return p
+}
+
+func (x MetricType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MetricType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tts_proto_enumTypes[1].Descriptor()
+}
+
+func (MetricType) Type() protoreflect.EnumType {
+	return &file_proto_tts_proto_enumTypes[1]
+}
+
+func (x MetricType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MetricType.Descriptor instead.
+func (MetricType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{1}
+}
+
 // TTSRequest contains the text and language for TTS
 type TTSRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
-	LanguageCode  string                 `protobuf:"bytes,2,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"`  // e.g., "en-US", "fr-FR", "es-ES"
-	ForceRefresh  bool                   `protobuf:"varint,3,opt,name=force_refresh,json=forceRefresh,proto3" json:"force_refresh,omitempty"` // if true, bypass cache and refetch from Azure
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Text             string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	LanguageCode     string                 `protobuf:"bytes,2,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"`                        // e.g., "en-US", "fr-FR", "es-ES"
+	ForceRefresh     bool                   `protobuf:"varint,3,opt,name=force_refresh,json=forceRefresh,proto3" json:"force_refresh,omitempty"`                       // if true, bypass cache and refetch from Azure
+	TempoFactor      float64                `protobuf:"fixed64,4,opt,name=tempo_factor,json=tempoFactor,proto3" json:"tempo_factor,omitempty"`                         // playback tempo applied by the client (0.5-2.0, 0 = 1.0); cached audio is unaffected
+	OutputFormat     OutputFormat           `protobuf:"varint,5,opt,name=output_format,json=outputFormat,proto3,enum=tts.OutputFormat" json:"output_format,omitempty"` // audio format to synthesize and cache
+	IncludeTextStats bool                   `protobuf:"varint,6,opt,name=include_text_stats,json=includeTextStats,proto3" json:"include_text_stats,omitempty"`         // FetchTTS only: return statistics about the text in text_stats
+	Mp3Bitrate       string                 `protobuf:"bytes,7,opt,name=mp3_bitrate,json=mp3Bitrate,proto3" json:"mp3_bitrate,omitempty"`                              // MP3 only: "32k", "48k", "64k", "96k", "128k", "160k" or "192k" (empty = audio.bitrate)
+	Mp3SampleRateHz  int32                  `protobuf:"varint,8,opt,name=mp3_sample_rate_hz,json=mp3SampleRateHz,proto3" json:"mp3_sample_rate_hz,omitempty"`          // MP3 only: 16000, 24000 or 48000 (0 = audio.sample_rate_hz)
+	VoiceName        string                 `protobuf:"bytes,9,opt,name=voice_name,json=voiceName,proto3" json:"voice_name,omitempty"`                                 // Azure voice to use instead of the language's, e.g. "en-US-GuyNeural" (cached separately)
+	IsSsml           bool                   `protobuf:"varint,10,opt,name=is_ssml,json=isSsml,proto3" json:"is_ssml,omitempty"`                                        // text is an SSML document sent to Azure as written (cached under the exact document)
+	Namespace        string                 `protobuf:"bytes,11,opt,name=namespace,proto3" json:"namespace,omitempty"`                                                 // tenant whose cache is used, one of server.namespaces (empty = the default namespace)
+	Tags             []string               `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty"`                                                           // labels added to the entry, e.g. "feature=onboarding", for DeleteByTag and tag_filter (not part of the cache key)
+	TtlSeconds       int64                  `protobuf:"varint,13,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`                            // lifetime of the stored entry, overriding database.ttl_days (0 = database.ttl_days, -1 = never expires)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *TTSRequest) Reset() {
@@ -82,10 +204,81 @@ func (x *TTSRequest) GetForceRefresh() bool {
 	return false
 }
 
+func (x *TTSRequest) GetTempoFactor() float64 {
+	if x != nil {
+		return x.TempoFactor
+	}
+	return 0
+}
+
+func (x *TTSRequest) GetOutputFormat() OutputFormat {
+	if x != nil {
+		return x.OutputFormat
+	}
+	return OutputFormat_MP3
+}
+
+func (x *TTSRequest) GetIncludeTextStats() bool {
+	if x != nil {
+		return x.IncludeTextStats
+	}
+	return false
+}
+
+func (x *TTSRequest) GetMp3Bitrate() string {
+	if x != nil {
+		return x.Mp3Bitrate
+	}
+	return ""
+}
+
+func (x *TTSRequest) GetMp3SampleRateHz() int32 {
+	if x != nil {
+		return x.Mp3SampleRateHz
+	}
+	return 0
+}
+
+func (x *TTSRequest) GetVoiceName() string {
+	if x != nil {
+		return x.VoiceName
+	}
+	return ""
+}
+
+func (x *TTSRequest) GetIsSsml() bool {
+	if x != nil {
+		return x.IsSsml
+	}
+	return false
+}
+
+func (x *TTSRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *TTSRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TTSRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
 // BulkTTSRequest contains multiple TTS requests
 type BulkTTSRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Requests      []*TTSRequest          `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	Adaptive      bool                   `protobuf:"varint,2,opt,name=adaptive,proto3" json:"adaptive,omitempty"` // BulkFetchTTS only: fetch in batches sized to Azure's rate limits instead of all at once
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -127,31 +320,36 @@ func (x *BulkTTSRequest) GetRequests() []*TTSRequest {
 	return nil
 }
 
-// TTSResponse contains the audio data and metadata
-type TTSResponse struct {
+func (x *BulkTTSRequest) GetAdaptive() bool {
+	if x != nil {
+		return x.Adaptive
+	}
+	return false
+}
+
+// WarmCacheRequest lists the texts to cache
+type WarmCacheRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cached        bool                   `protobuf:"varint,1,opt,name=cached,proto3" json:"cached,omitempty"`                        // whether audio was retrieved from cache
-	AudioData     []byte                 `protobuf:"bytes,2,opt,name=audio_data,json=audioData,proto3" json:"audio_data,omitempty"`  // MP3 audio data
-	CacheKey      string                 `protobuf:"bytes,3,opt,name=cache_key,json=cacheKey,proto3" json:"cache_key,omitempty"`     // hash used as cache key
-	AudioSize     int64                  `protobuf:"varint,4,opt,name=audio_size,json=audioSize,proto3" json:"audio_size,omitempty"` // size of audio data in bytes
+	Requests      []*TTSRequest          `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	Concurrency   int32                  `protobuf:"varint,2,opt,name=concurrency,proto3" json:"concurrency,omitempty"` // requests fetched at once (0 = server.warm_concurrency)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TTSResponse) Reset() {
-	*x = TTSResponse{}
+func (x *WarmCacheRequest) Reset() {
+	*x = WarmCacheRequest{}
 	mi := &file_proto_tts_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TTSResponse) String() string {
+func (x *WarmCacheRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TTSResponse) ProtoMessage() {}
+func (*WarmCacheRequest) ProtoMessage() {}
 
-func (x *TTSResponse) ProtoReflect() protoreflect.Message {
+func (x *WarmCacheRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_tts_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -163,61 +361,52 @@ func (x *TTSResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TTSResponse.ProtoReflect.Descriptor instead.
-func (*TTSResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use WarmCacheRequest.ProtoReflect.Descriptor instead.
+func (*WarmCacheRequest) Descriptor() ([]byte, []int) {
 	return file_proto_tts_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *TTSResponse) GetCached() bool {
-	if x != nil {
-		return x.Cached
-	}
-	return false
-}
-
-func (x *TTSResponse) GetAudioData() []byte {
+func (x *WarmCacheRequest) GetRequests() []*TTSRequest {
 	if x != nil {
-		return x.AudioData
+		return x.Requests
 	}
 	return nil
 }
 
-func (x *TTSResponse) GetCacheKey() string {
-	if x != nil {
-		return x.CacheKey
-	}
-	return ""
-}
-
-func (x *TTSResponse) GetAudioSize() int64 {
+func (x *WarmCacheRequest) GetConcurrency() int32 {
 	if x != nil {
-		return x.AudioSize
+		return x.Concurrency
 	}
 	return 0
 }
 
-// BulkTTSResponse contains multiple TTS responses
-type BulkTTSResponse struct {
+// WarmCacheProgress reports the running totals of a WarmCache
+type WarmCacheProgress struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Responses     []*TTSResponse         `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Done          int64                  `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`
+	CachedHits    int64                  `protobuf:"varint,3,opt,name=cached_hits,json=cachedHits,proto3" json:"cached_hits,omitempty"`       // texts that were already cached
+	AzureFetches  int64                  `protobuf:"varint,4,opt,name=azure_fetches,json=azureFetches,proto3" json:"azure_fetches,omitempty"` // texts synthesized and cached
+	Errors        int64                  `protobuf:"varint,5,opt,name=errors,proto3" json:"errors,omitempty"`
+	LastError     string                 `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"` // error of the text that just completed, if it failed
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BulkTTSResponse) Reset() {
-	*x = BulkTTSResponse{}
+func (x *WarmCacheProgress) Reset() {
+	*x = WarmCacheProgress{}
 	mi := &file_proto_tts_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BulkTTSResponse) String() string {
+func (x *WarmCacheProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BulkTTSResponse) ProtoMessage() {}
+func (*WarmCacheProgress) ProtoMessage() {}
 
-func (x *BulkTTSResponse) ProtoReflect() protoreflect.Message {
+func (x *WarmCacheProgress) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_tts_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -229,42 +418,83 @@ func (x *BulkTTSResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BulkTTSResponse.ProtoReflect.Descriptor instead.
-func (*BulkTTSResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use WarmCacheProgress.ProtoReflect.Descriptor instead.
+func (*WarmCacheProgress) Descriptor() ([]byte, []int) {
 	return file_proto_tts_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *BulkTTSResponse) GetResponses() []*TTSResponse {
+func (x *WarmCacheProgress) GetTotal() int64 {
 	if x != nil {
-		return x.Responses
+		return x.Total
 	}
-	return nil
+	return 0
 }
 
-// PlayResponse indicates success/failure of playback
-type PlayResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	WasCached     bool                   `protobuf:"varint,3,opt,name=was_cached,json=wasCached,proto3" json:"was_cached,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *WarmCacheProgress) GetDone() int64 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
 }
 
-func (x *PlayResponse) Reset() {
-	*x = PlayResponse{}
+func (x *WarmCacheProgress) GetCachedHits() int64 {
+	if x != nil {
+		return x.CachedHits
+	}
+	return 0
+}
+
+func (x *WarmCacheProgress) GetAzureFetches() int64 {
+	if x != nil {
+		return x.AzureFetches
+	}
+	return 0
+}
+
+func (x *WarmCacheProgress) GetErrors() int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *WarmCacheProgress) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+// TTSResponse contains the audio data and metadata
+type TTSResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Cached              bool                   `protobuf:"varint,1,opt,name=cached,proto3" json:"cached,omitempty"`                                                       // whether audio was retrieved from cache
+	AudioData           []byte                 `protobuf:"bytes,2,opt,name=audio_data,json=audioData,proto3" json:"audio_data,omitempty"`                                 // audio data in the requested output format
+	CacheKey            string                 `protobuf:"bytes,3,opt,name=cache_key,json=cacheKey,proto3" json:"cache_key,omitempty"`                                    // hash used as cache key
+	AudioSize           int64                  `protobuf:"varint,4,opt,name=audio_size,json=audioSize,proto3" json:"audio_size,omitempty"`                                // size of audio data in bytes
+	TextStats           *TextStats             `protobuf:"bytes,5,opt,name=text_stats,json=textStats,proto3" json:"text_stats,omitempty"`                                 // set when include_text_stats was requested
+	VoiceFallbackLocale string                 `protobuf:"bytes,6,opt,name=voice_fallback_locale,json=voiceFallbackLocale,proto3" json:"voice_fallback_locale,omitempty"` // locale whose voice was used when the language has none of its own
+	EffectiveLocale     string                 `protobuf:"bytes,7,opt,name=effective_locale,json=effectiveLocale,proto3" json:"effective_locale,omitempty"`               // locale whose voice was used (the requested one without a fallback)
+	FromStaleCache      bool                   `protobuf:"varint,8,opt,name=from_stale_cache,json=fromStaleCache,proto3" json:"from_stale_cache,omitempty"`               // FetchWithFallback only: served from the cache without waiting for synthesis
+	Fallback            bool                   `protobuf:"varint,9,opt,name=fallback,proto3" json:"fallback,omitempty"`                                                   // FetchWithFallback only: the audio is the fallback text's
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *TTSResponse) Reset() {
+	*x = TTSResponse{}
 	mi := &file_proto_tts_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlayResponse) String() string {
+func (x *TTSResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayResponse) ProtoMessage() {}
+func (*TTSResponse) ProtoMessage() {}
 
-func (x *PlayResponse) ProtoReflect() protoreflect.Message {
+func (x *TTSResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_tts_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -276,56 +506,100 @@ func (x *PlayResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayResponse.ProtoReflect.Descriptor instead.
-func (*PlayResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use TTSResponse.ProtoReflect.Descriptor instead.
+func (*TTSResponse) Descriptor() ([]byte, []int) {
 	return file_proto_tts_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *PlayResponse) GetSuccess() bool {
+func (x *TTSResponse) GetCached() bool {
 	if x != nil {
-		return x.Success
+		return x.Cached
 	}
 	return false
 }
 
-func (x *PlayResponse) GetMessage() string {
+func (x *TTSResponse) GetAudioData() []byte {
 	if x != nil {
-		return x.Message
+		return x.AudioData
+	}
+	return nil
+}
+
+func (x *TTSResponse) GetCacheKey() string {
+	if x != nil {
+		return x.CacheKey
 	}
 	return ""
 }
 
-func (x *PlayResponse) GetWasCached() bool {
+func (x *TTSResponse) GetAudioSize() int64 {
 	if x != nil {
-		return x.WasCached
+		return x.AudioSize
+	}
+	return 0
+}
+
+func (x *TTSResponse) GetTextStats() *TextStats {
+	if x != nil {
+		return x.TextStats
+	}
+	return nil
+}
+
+func (x *TTSResponse) GetVoiceFallbackLocale() string {
+	if x != nil {
+		return x.VoiceFallbackLocale
+	}
+	return ""
+}
+
+func (x *TTSResponse) GetEffectiveLocale() string {
+	if x != nil {
+		return x.EffectiveLocale
+	}
+	return ""
+}
+
+func (x *TTSResponse) GetFromStaleCache() bool {
+	if x != nil {
+		return x.FromStaleCache
 	}
 	return false
 }
 
-// DeleteResponse indicates success/failure of deletion
-type DeleteResponse struct {
+func (x *TTSResponse) GetFallback() bool {
+	if x != nil {
+		return x.Fallback
+	}
+	return false
+}
+
+// AudioChunk is one piece of the audio streamed by StreamTTS
+type AudioChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	CacheKey      string                 `protobuf:"bytes,3,opt,name=cache_key,json=cacheKey,proto3" json:"cache_key,omitempty"`
+	AudioData     []byte                 `protobuf:"bytes,1,opt,name=audio_data,json=audioData,proto3" json:"audio_data,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`               // position of audio_data in the audio
+	IsLast        bool                   `protobuf:"varint,3,opt,name=is_last,json=isLast,proto3" json:"is_last,omitempty"` // no more chunks follow
+	Cached        bool                   `protobuf:"varint,4,opt,name=cached,proto3" json:"cached,omitempty"`               // whether the audio was retrieved from cache
+	CacheKey      string                 `protobuf:"bytes,5,opt,name=cache_key,json=cacheKey,proto3" json:"cache_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteResponse) Reset() {
-	*x = DeleteResponse{}
+func (x *AudioChunk) Reset() {
+	*x = AudioChunk{}
 	mi := &file_proto_tts_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteResponse) String() string {
+func (x *AudioChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteResponse) ProtoMessage() {}
+func (*AudioChunk) ProtoMessage() {}
 
-func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+func (x *AudioChunk) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_tts_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -337,53 +611,5459 @@ func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
-func (*DeleteResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AudioChunk.ProtoReflect.Descriptor instead.
+func (*AudioChunk) Descriptor() ([]byte, []int) {
 	return file_proto_tts_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *DeleteResponse) GetSuccess() bool {
+func (x *AudioChunk) GetAudioData() []byte {
 	if x != nil {
-		return x.Success
+		return x.AudioData
 	}
-	return false
+	return nil
 }
 
-func (x *DeleteResponse) GetMessage() string {
+func (x *AudioChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *AudioChunk) GetIsLast() bool {
+	if x != nil {
+		return x.IsLast
+	}
+	return false
+}
+
+func (x *AudioChunk) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+func (x *AudioChunk) GetCacheKey() string {
+	if x != nil {
+		return x.CacheKey
+	}
+	return ""
+}
+
+// TextStats describes the normalized text of a request, e.g. for reading progress displays
+type TextStats struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	CharCount              int32                  `protobuf:"varint,1,opt,name=char_count,json=charCount,proto3" json:"char_count,omitempty"`
+	WordCount              int32                  `protobuf:"varint,2,opt,name=word_count,json=wordCount,proto3" json:"word_count,omitempty"`
+	SentenceCount          int32                  `protobuf:"varint,3,opt,name=sentence_count,json=sentenceCount,proto3" json:"sentence_count,omitempty"`
+	EstimatedReadingTimeMs int64                  `protobuf:"varint,4,opt,name=estimated_reading_time_ms,json=estimatedReadingTimeMs,proto3" json:"estimated_reading_time_ms,omitempty"` // at an average adult reading speed of 238 words per minute
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *TextStats) Reset() {
+	*x = TextStats{}
+	mi := &file_proto_tts_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextStats) ProtoMessage() {}
+
+func (x *TextStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextStats.ProtoReflect.Descriptor instead.
+func (*TextStats) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TextStats) GetCharCount() int32 {
+	if x != nil {
+		return x.CharCount
+	}
+	return 0
+}
+
+func (x *TextStats) GetWordCount() int32 {
+	if x != nil {
+		return x.WordCount
+	}
+	return 0
+}
+
+func (x *TextStats) GetSentenceCount() int32 {
+	if x != nil {
+		return x.SentenceCount
+	}
+	return 0
+}
+
+func (x *TextStats) GetEstimatedReadingTimeMs() int64 {
+	if x != nil {
+		return x.EstimatedReadingTimeMs
+	}
+	return 0
+}
+
+// EphemeralResponse contains uncached audio; there is no cache key because nothing is stored
+type EphemeralResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AudioData     []byte                 `protobuf:"bytes,1,opt,name=audio_data,json=audioData,proto3" json:"audio_data,omitempty"`     // audio data in the requested output format
+	DurationMs    int64                  `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"` // playback length of the audio
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EphemeralResponse) Reset() {
+	*x = EphemeralResponse{}
+	mi := &file_proto_tts_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EphemeralResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EphemeralResponse) ProtoMessage() {}
+
+func (x *EphemeralResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EphemeralResponse.ProtoReflect.Descriptor instead.
+func (*EphemeralResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EphemeralResponse) GetAudioData() []byte {
+	if x != nil {
+		return x.AudioData
+	}
+	return nil
+}
+
+func (x *EphemeralResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// FallbackRequest is a TTSRequest that may be answered with cached or fallback audio
+type FallbackRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *TTSRequest            `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`                                    // force_refresh is ignored
+	StaleOkForMs  int64                  `protobuf:"varint,2,opt,name=stale_ok_for_ms,json=staleOkForMs,proto3" json:"stale_ok_for_ms,omitempty"` // how long to wait for synthesis when the text isn't cached (0 = don't wait)
+	FallbackText  string                 `protobuf:"bytes,3,opt,name=fallback_text,json=fallbackText,proto3" json:"fallback_text,omitempty"`      // text whose cached audio is returned when synthesis takes longer (empty = "unavailable")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FallbackRequest) Reset() {
+	*x = FallbackRequest{}
+	mi := &file_proto_tts_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FallbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FallbackRequest) ProtoMessage() {}
+
+func (x *FallbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FallbackRequest.ProtoReflect.Descriptor instead.
+func (*FallbackRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FallbackRequest) GetRequest() *TTSRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *FallbackRequest) GetStaleOkForMs() int64 {
+	if x != nil {
+		return x.StaleOkForMs
+	}
+	return 0
+}
+
+func (x *FallbackRequest) GetFallbackText() string {
+	if x != nil {
+		return x.FallbackText
+	}
+	return ""
+}
+
+// FetchAndSaveRequest is a TTS request plus the file the audio should be written to
+type FetchAndSaveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *TTSRequest            `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	OutputPath    string                 `protobuf:"bytes,2,opt,name=output_path,json=outputPath,proto3" json:"output_path,omitempty"` // absolute path under one of server.allowed_save_directories
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchAndSaveRequest) Reset() {
+	*x = FetchAndSaveRequest{}
+	mi := &file_proto_tts_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchAndSaveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchAndSaveRequest) ProtoMessage() {}
+
+func (x *FetchAndSaveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchAndSaveRequest.ProtoReflect.Descriptor instead.
+func (*FetchAndSaveRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FetchAndSaveRequest) GetRequest() *TTSRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *FetchAndSaveRequest) GetOutputPath() string {
+	if x != nil {
+		return x.OutputPath
+	}
+	return ""
+}
+
+// FetchAndSaveResponse reports where the audio was written
+type FetchAndSaveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Saved         bool                   `protobuf:"varint,1,opt,name=saved,proto3" json:"saved,omitempty"`
+	OutputPath    string                 `protobuf:"bytes,2,opt,name=output_path,json=outputPath,proto3" json:"output_path,omitempty"` // cleaned path the audio was written to
+	AudioSize     int64                  `protobuf:"varint,3,opt,name=audio_size,json=audioSize,proto3" json:"audio_size,omitempty"`   // size of the written file in bytes
+	WasCached     bool                   `protobuf:"varint,4,opt,name=was_cached,json=wasCached,proto3" json:"was_cached,omitempty"`   // whether audio was retrieved from cache
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchAndSaveResponse) Reset() {
+	*x = FetchAndSaveResponse{}
+	mi := &file_proto_tts_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchAndSaveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchAndSaveResponse) ProtoMessage() {}
+
+func (x *FetchAndSaveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchAndSaveResponse.ProtoReflect.Descriptor instead.
+func (*FetchAndSaveResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FetchAndSaveResponse) GetSaved() bool {
+	if x != nil {
+		return x.Saved
+	}
+	return false
+}
+
+func (x *FetchAndSaveResponse) GetOutputPath() string {
+	if x != nil {
+		return x.OutputPath
+	}
+	return ""
+}
+
+func (x *FetchAndSaveResponse) GetAudioSize() int64 {
+	if x != nil {
+		return x.AudioSize
+	}
+	return 0
+}
+
+func (x *FetchAndSaveResponse) GetWasCached() bool {
+	if x != nil {
+		return x.WasCached
+	}
+	return false
+}
+
+// BulkTTSResponse contains multiple TTS responses
+type BulkTTSResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Responses     []*TTSResponse         `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkTTSResponse) Reset() {
+	*x = BulkTTSResponse{}
+	mi := &file_proto_tts_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkTTSResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkTTSResponse) ProtoMessage() {}
+
+func (x *BulkTTSResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkTTSResponse.ProtoReflect.Descriptor instead.
+func (*BulkTTSResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BulkTTSResponse) GetResponses() []*TTSResponse {
+	if x != nil {
+		return x.Responses
+	}
+	return nil
+}
+
+// BulkItemResult is a single completed item of a streamed bulk fetch
+type BulkItemResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                                  // index of the item in the original request
+	Response      *TTSResponse           `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`                             // unset if the item failed
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // non-empty if the item failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkItemResult) Reset() {
+	*x = BulkItemResult{}
+	mi := &file_proto_tts_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkItemResult) ProtoMessage() {}
+
+func (x *BulkItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkItemResult.ProtoReflect.Descriptor instead.
+func (*BulkItemResult) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BulkItemResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BulkItemResult) GetResponse() *TTSResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *BulkItemResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// PlayResponse indicates success/failure of playback
+type PlayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	WasCached     bool                   `protobuf:"varint,3,opt,name=was_cached,json=wasCached,proto3" json:"was_cached,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayResponse) Reset() {
+	*x = PlayResponse{}
+	mi := &file_proto_tts_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayResponse) ProtoMessage() {}
+
+func (x *PlayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayResponse.ProtoReflect.Descriptor instead.
+func (*PlayResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PlayResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PlayResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PlayResponse) GetWasCached() bool {
+	if x != nil {
+		return x.WasCached
+	}
+	return false
+}
+
+// DeleteResponse indicates success/failure of deletion
+type DeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	CacheKey      string                 `protobuf:"bytes,3,opt,name=cache_key,json=cacheKey,proto3" json:"cache_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_proto_tts_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeleteResponse) GetCacheKey() string {
+	if x != nil {
+		return x.CacheKey
+	}
+	return ""
+}
+
+// NormalizationDiffRequest contains two texts to compare after normalization
+type NormalizationDiffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TextA         string                 `protobuf:"bytes,1,opt,name=text_a,json=textA,proto3" json:"text_a,omitempty"`
+	TextB         string                 `protobuf:"bytes,2,opt,name=text_b,json=textB,proto3" json:"text_b,omitempty"`
+	LanguageCode  string                 `protobuf:"bytes,3,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"` // language used to compute the cache keys
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NormalizationDiffRequest) Reset() {
+	*x = NormalizationDiffRequest{}
+	mi := &file_proto_tts_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NormalizationDiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NormalizationDiffRequest) ProtoMessage() {}
+
+func (x *NormalizationDiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NormalizationDiffRequest.ProtoReflect.Descriptor instead.
+func (*NormalizationDiffRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *NormalizationDiffRequest) GetTextA() string {
+	if x != nil {
+		return x.TextA
+	}
+	return ""
+}
+
+func (x *NormalizationDiffRequest) GetTextB() string {
+	if x != nil {
+		return x.TextB
+	}
+	return ""
+}
+
+func (x *NormalizationDiffRequest) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+// NormalizationDiffResponse shows the normalized forms and cache keys of both texts
+type NormalizationDiffResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NormalizedA    string                 `protobuf:"bytes,1,opt,name=normalized_a,json=normalizedA,proto3" json:"normalized_a,omitempty"`
+	NormalizedB    string                 `protobuf:"bytes,2,opt,name=normalized_b,json=normalizedB,proto3" json:"normalized_b,omitempty"`
+	KeyA           string                 `protobuf:"bytes,3,opt,name=key_a,json=keyA,proto3" json:"key_a,omitempty"`
+	KeyB           string                 `protobuf:"bytes,4,opt,name=key_b,json=keyB,proto3" json:"key_b,omitempty"`
+	KeysEqual      bool                   `protobuf:"varint,5,opt,name=keys_equal,json=keysEqual,proto3" json:"keys_equal,omitempty"`
+	FirstDiffIndex int32                  `protobuf:"varint,6,opt,name=first_diff_index,json=firstDiffIndex,proto3" json:"first_diff_index,omitempty"` // byte offset of the first difference, -1 if equal
+	FirstDiffRune  int32                  `protobuf:"varint,7,opt,name=first_diff_rune,json=firstDiffRune,proto3" json:"first_diff_rune,omitempty"`    // offset of the first difference in runes (code points), -1 if equal
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NormalizationDiffResponse) Reset() {
+	*x = NormalizationDiffResponse{}
+	mi := &file_proto_tts_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NormalizationDiffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NormalizationDiffResponse) ProtoMessage() {}
+
+func (x *NormalizationDiffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NormalizationDiffResponse.ProtoReflect.Descriptor instead.
+func (*NormalizationDiffResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *NormalizationDiffResponse) GetNormalizedA() string {
+	if x != nil {
+		return x.NormalizedA
+	}
+	return ""
+}
+
+func (x *NormalizationDiffResponse) GetNormalizedB() string {
+	if x != nil {
+		return x.NormalizedB
+	}
+	return ""
+}
+
+func (x *NormalizationDiffResponse) GetKeyA() string {
+	if x != nil {
+		return x.KeyA
+	}
+	return ""
+}
+
+func (x *NormalizationDiffResponse) GetKeyB() string {
+	if x != nil {
+		return x.KeyB
+	}
+	return ""
+}
+
+func (x *NormalizationDiffResponse) GetKeysEqual() bool {
+	if x != nil {
+		return x.KeysEqual
+	}
+	return false
+}
+
+func (x *NormalizationDiffResponse) GetFirstDiffIndex() int32 {
+	if x != nil {
+		return x.FirstDiffIndex
+	}
+	return 0
+}
+
+func (x *NormalizationDiffResponse) GetFirstDiffRune() int32 {
+	if x != nil {
+		return x.FirstDiffRune
+	}
+	return 0
+}
+
+// DiagnosticRequest requests a self-diagnostics run
+type DiagnosticRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiagnosticRequest) Reset() {
+	*x = DiagnosticRequest{}
+	mi := &file_proto_tts_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiagnosticRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagnosticRequest) ProtoMessage() {}
+
+func (x *DiagnosticRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagnosticRequest.ProtoReflect.Descriptor instead.
+func (*DiagnosticRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{17}
+}
+
+// DiagnosticCheck is the result of a single diagnostic check
+type DiagnosticCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "pass", "warn" or "fail"
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiagnosticCheck) Reset() {
+	*x = DiagnosticCheck{}
+	mi := &file_proto_tts_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiagnosticCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagnosticCheck) ProtoMessage() {}
+
+func (x *DiagnosticCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagnosticCheck.ProtoReflect.Descriptor instead.
+func (*DiagnosticCheck) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DiagnosticCheck) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DiagnosticCheck) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DiagnosticCheck) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *DeleteResponse) GetCacheKey() string {
+func (x *DiagnosticCheck) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// DiagnosticReport contains the results of all diagnostic checks
+type DiagnosticReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // worst status among all checks
+	Checks        []*DiagnosticCheck     `protobuf:"bytes,2,rep,name=checks,proto3" json:"checks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiagnosticReport) Reset() {
+	*x = DiagnosticReport{}
+	mi := &file_proto_tts_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiagnosticReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagnosticReport) ProtoMessage() {}
+
+func (x *DiagnosticReport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagnosticReport.ProtoReflect.Descriptor instead.
+func (*DiagnosticReport) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DiagnosticReport) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DiagnosticReport) GetChecks() []*DiagnosticCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+// WatchRequest selects which cache events to stream
+type WatchRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	FilterLanguageCode string                 `protobuf:"bytes,1,opt,name=filter_language_code,json=filterLanguageCode,proto3" json:"filter_language_code,omitempty"` // only events for this language (empty = all)
+	EventTypes         []string               `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`                           // "put" and/or "delete" (empty = all)
+	Namespace          string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`                                               // tenant whose entries' events are streamed (empty = the default namespace)
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_proto_tts_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WatchRequest) GetFilterLanguageCode() string {
+	if x != nil {
+		return x.FilterLanguageCode
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// CacheEvent describes a single change to the cache
+type CacheEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventType     string                 `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // "put" or "delete"
+	CacheKey      string                 `protobuf:"bytes,2,opt,name=cache_key,json=cacheKey,proto3" json:"cache_key,omitempty"`
+	LanguageCode  string                 `protobuf:"bytes,3,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"`
+	AudioSize     int64                  `protobuf:"varint,4,opt,name=audio_size,json=audioSize,proto3" json:"audio_size,omitempty"` // size of the audio in bytes (0 for deletes)
+	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                  // Unix timestamp of the change
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheEvent) Reset() {
+	*x = CacheEvent{}
+	mi := &file_proto_tts_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheEvent) ProtoMessage() {}
+
+func (x *CacheEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheEvent.ProtoReflect.Descriptor instead.
+func (*CacheEvent) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CacheEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *CacheEvent) GetCacheKey() string {
+	if x != nil {
+		return x.CacheKey
+	}
+	return ""
+}
+
+func (x *CacheEvent) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *CacheEvent) GetAudioSize() int64 {
+	if x != nil {
+		return x.AudioSize
+	}
+	return 0
+}
+
+func (x *CacheEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// CacheEntryInfo describes a cache entry without its audio
+type CacheEntryInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CacheKey      string                 `protobuf:"bytes,1,opt,name=cache_key,json=cacheKey,proto3" json:"cache_key,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	LanguageCode  string                 `protobuf:"bytes,3,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"`
+	AudioSize     int64                  `protobuf:"varint,4,opt,name=audio_size,json=audioSize,proto3" json:"audio_size,omitempty"` // stored (possibly compressed) size in bytes
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix timestamp
+	HitCount      int64                  `protobuf:"varint,6,opt,name=hit_count,json=hitCount,proto3" json:"hit_count,omitempty"`
+	LastAccessed  int64                  `protobuf:"varint,7,opt,name=last_accessed,json=lastAccessed,proto3" json:"last_accessed,omitempty"` // Unix timestamp
+	VoiceName     string                 `protobuf:"bytes,8,opt,name=voice_name,json=voiceName,proto3" json:"voice_name,omitempty"`           // voice the audio was synthesized with (empty if not recorded)
+	Namespace     string                 `protobuf:"bytes,9,opt,name=namespace,proto3" json:"namespace,omitempty"`                            // tenant the entry belongs to (empty = the default namespace)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheEntryInfo) Reset() {
+	*x = CacheEntryInfo{}
+	mi := &file_proto_tts_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheEntryInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheEntryInfo) ProtoMessage() {}
+
+func (x *CacheEntryInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheEntryInfo.ProtoReflect.Descriptor instead.
+func (*CacheEntryInfo) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CacheEntryInfo) GetCacheKey() string {
+	if x != nil {
+		return x.CacheKey
+	}
+	return ""
+}
+
+func (x *CacheEntryInfo) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *CacheEntryInfo) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *CacheEntryInfo) GetAudioSize() int64 {
+	if x != nil {
+		return x.AudioSize
+	}
+	return 0
+}
+
+func (x *CacheEntryInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *CacheEntryInfo) GetHitCount() int64 {
+	if x != nil {
+		return x.HitCount
+	}
+	return 0
+}
+
+func (x *CacheEntryInfo) GetLastAccessed() int64 {
+	if x != nil {
+		return x.LastAccessed
+	}
+	return 0
+}
+
+func (x *CacheEntryInfo) GetVoiceName() string {
+	if x != nil {
+		return x.VoiceName
+	}
+	return ""
+}
+
+func (x *CacheEntryInfo) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// ListCacheEntriesRequest selects a page of cache entries
+type ListCacheEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LanguageCode  string                 `protobuf:"bytes,1,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"` // only entries for this language (empty = all)
+	SinceUnix     int64                  `protobuf:"varint,2,opt,name=since_unix,json=sinceUnix,proto3" json:"since_unix,omitempty"`         // only entries created at or after this Unix timestamp (0 = all)
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`            // maximum entries to return (0 = 100)
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`          // next_page_token from the previous page (empty = first page)
+	TextPrefix    string                 `protobuf:"bytes,5,opt,name=text_prefix,json=textPrefix,proto3" json:"text_prefix,omitempty"`       // only entries whose text starts with this, case-sensitively (empty = all)
+	Namespace     string                 `protobuf:"bytes,6,opt,name=namespace,proto3" json:"namespace,omitempty"`                           // tenant whose entries are listed (empty = the default namespace)
+	TagFilter     string                 `protobuf:"bytes,7,opt,name=tag_filter,json=tagFilter,proto3" json:"tag_filter,omitempty"`          // only entries with this tag (empty = all)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCacheEntriesRequest) Reset() {
+	*x = ListCacheEntriesRequest{}
+	mi := &file_proto_tts_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCacheEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCacheEntriesRequest) ProtoMessage() {}
+
+func (x *ListCacheEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCacheEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCacheEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListCacheEntriesRequest) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *ListCacheEntriesRequest) GetSinceUnix() int64 {
+	if x != nil {
+		return x.SinceUnix
+	}
+	return 0
+}
+
+func (x *ListCacheEntriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCacheEntriesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListCacheEntriesRequest) GetTextPrefix() string {
+	if x != nil {
+		return x.TextPrefix
+	}
+	return ""
+}
+
+func (x *ListCacheEntriesRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListCacheEntriesRequest) GetTagFilter() string {
+	if x != nil {
+		return x.TagFilter
+	}
+	return ""
+}
+
+// ListCacheEntriesResponse contains a page of cache entries
+type ListCacheEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*CacheEntryInfo      `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty when there are no more entries
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCacheEntriesResponse) Reset() {
+	*x = ListCacheEntriesResponse{}
+	mi := &file_proto_tts_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCacheEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCacheEntriesResponse) ProtoMessage() {}
+
+func (x *ListCacheEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCacheEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCacheEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListCacheEntriesResponse) GetEntries() []*CacheEntryInfo {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListCacheEntriesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// GetCacheEntryRequest identifies a cache entry
+type GetCacheEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CacheKey      string                 `protobuf:"bytes,1,opt,name=cache_key,json=cacheKey,proto3" json:"cache_key,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"` // tenant the entry belongs to (empty = the default namespace)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCacheEntryRequest) Reset() {
+	*x = GetCacheEntryRequest{}
+	mi := &file_proto_tts_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCacheEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCacheEntryRequest) ProtoMessage() {}
+
+func (x *GetCacheEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCacheEntryRequest.ProtoReflect.Descriptor instead.
+func (*GetCacheEntryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetCacheEntryRequest) GetCacheKey() string {
+	if x != nil {
+		return x.CacheKey
+	}
+	return ""
+}
+
+func (x *GetCacheEntryRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// GetCacheEntryResponse contains a cache entry and its audio
+type GetCacheEntryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Entry         *CacheEntryInfo        `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	AudioData     []byte                 `protobuf:"bytes,3,opt,name=audio_data,json=audioData,proto3" json:"audio_data,omitempty"` // decompressed audio
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCacheEntryResponse) Reset() {
+	*x = GetCacheEntryResponse{}
+	mi := &file_proto_tts_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCacheEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCacheEntryResponse) ProtoMessage() {}
+
+func (x *GetCacheEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCacheEntryResponse.ProtoReflect.Descriptor instead.
+func (*GetCacheEntryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetCacheEntryResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetCacheEntryResponse) GetEntry() *CacheEntryInfo {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *GetCacheEntryResponse) GetAudioData() []byte {
+	if x != nil {
+		return x.AudioData
+	}
+	return nil
+}
+
+// CloneRequest selects the entries to copy from a source daemon
+type CloneRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	SourceAddress      string                 `protobuf:"bytes,1,opt,name=source_address,json=sourceAddress,proto3" json:"source_address,omitempty"`                  // gRPC address of the daemon to copy from
+	LanguageCodeFilter string                 `protobuf:"bytes,2,opt,name=language_code_filter,json=languageCodeFilter,proto3" json:"language_code_filter,omitempty"` // only copy entries for this language (empty = all)
+	SinceUnix          int64                  `protobuf:"varint,3,opt,name=since_unix,json=sinceUnix,proto3" json:"since_unix,omitempty"`                             // only copy entries created at or after this Unix timestamp (0 = all)
+	BatchSize          int32                  `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`                             // entries listed per page, and between progress messages (0 = 100)
+	Namespace          string                 `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`                                               // tenant whose entries are copied, on both daemons (empty = the default namespace)
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CloneRequest) Reset() {
+	*x = CloneRequest{}
+	mi := &file_proto_tts_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneRequest) ProtoMessage() {}
+
+func (x *CloneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneRequest.ProtoReflect.Descriptor instead.
+func (*CloneRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CloneRequest) GetSourceAddress() string {
+	if x != nil {
+		return x.SourceAddress
+	}
+	return ""
+}
+
+func (x *CloneRequest) GetLanguageCodeFilter() string {
+	if x != nil {
+		return x.LanguageCodeFilter
+	}
+	return ""
+}
+
+func (x *CloneRequest) GetSinceUnix() int64 {
+	if x != nil {
+		return x.SinceUnix
+	}
+	return 0
+}
+
+func (x *CloneRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *CloneRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// CloneProgress reports the running totals of a Clone
+type CloneProgress struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Copied          int64                  `protobuf:"varint,1,opt,name=copied,proto3" json:"copied,omitempty"`
+	Skipped         int64                  `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"` // entries already present locally
+	Failed          int64                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	CurrentLanguage string                 `protobuf:"bytes,4,opt,name=current_language,json=currentLanguage,proto3" json:"current_language,omitempty"` // language of the last entry processed
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CloneProgress) Reset() {
+	*x = CloneProgress{}
+	mi := &file_proto_tts_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneProgress) ProtoMessage() {}
+
+func (x *CloneProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneProgress.ProtoReflect.Descriptor instead.
+func (*CloneProgress) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CloneProgress) GetCopied() int64 {
+	if x != nil {
+		return x.Copied
+	}
+	return 0
+}
+
+func (x *CloneProgress) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *CloneProgress) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *CloneProgress) GetCurrentLanguage() string {
+	if x != nil {
+		return x.CurrentLanguage
+	}
+	return ""
+}
+
+// ExportCacheRequest selects the entries to export
+type ExportCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"` // tenant whose entries are exported (empty = the default namespace)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCacheRequest) Reset() {
+	*x = ExportCacheRequest{}
+	mi := &file_proto_tts_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCacheRequest) ProtoMessage() {}
+
+func (x *ExportCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCacheRequest.ProtoReflect.Descriptor instead.
+func (*ExportCacheRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ExportCacheRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// ExportChunk is the next part of an ExportCache archive
+type ExportChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // position of data in the archive
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportChunk) Reset() {
+	*x = ExportChunk{}
+	mi := &file_proto_tts_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportChunk) ProtoMessage() {}
+
+func (x *ExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportChunk.ProtoReflect.Descriptor instead.
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ExportChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// ImportChunk is the next part of an archive to import
+type ImportChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"` // tenant every entry of the archive belongs to, set in the first chunk (empty = the default namespace)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportChunk) Reset() {
+	*x = ImportChunk{}
+	mi := &file_proto_tts_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportChunk) ProtoMessage() {}
+
+func (x *ImportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportChunk.ProtoReflect.Descriptor instead.
+func (*ImportChunk) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ImportChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportChunk) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// ImportCacheResponse summarizes an import
+type ImportCacheResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Imported      int64                  `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	Skipped       int64                  `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"` // entries already cached
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCacheResponse) Reset() {
+	*x = ImportCacheResponse{}
+	mi := &file_proto_tts_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCacheResponse) ProtoMessage() {}
+
+func (x *ImportCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCacheResponse.ProtoReflect.Descriptor instead.
+func (*ImportCacheResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ImportCacheResponse) GetImported() int64 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportCacheResponse) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+// ResynthesizeRequest selects the entries to re-synthesize
+type ResynthesizeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LanguageCode  string                 `protobuf:"bytes,1,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"`     // required
+	BatchSize     int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`             // entries read from the cache per page (0 = 100)
+	RateLimitQps  float64                `protobuf:"fixed64,3,opt,name=rate_limit_qps,json=rateLimitQps,proto3" json:"rate_limit_qps,omitempty"` // Azure requests per second for this job (0 = azure.max_qps)
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`                               // tenant whose entries are re-synthesized (empty = the default namespace)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResynthesizeRequest) Reset() {
+	*x = ResynthesizeRequest{}
+	mi := &file_proto_tts_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResynthesizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResynthesizeRequest) ProtoMessage() {}
+
+func (x *ResynthesizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResynthesizeRequest.ProtoReflect.Descriptor instead.
+func (*ResynthesizeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ResynthesizeRequest) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *ResynthesizeRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *ResynthesizeRequest) GetRateLimitQps() float64 {
+	if x != nil {
+		return x.RateLimitQps
+	}
+	return 0
+}
+
+func (x *ResynthesizeRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// ResynthesizeProgress reports the entry just processed and the running totals
+type ResynthesizeProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // 1-based position of the entry
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // entries for the language when the job started
+	CacheKey      string                 `protobuf:"bytes,3,opt,name=cache_key,json=cacheKey,proto3" json:"cache_key,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"` // time spent synthesizing and storing the entry
+	Resynthesized int64                  `protobuf:"varint,5,opt,name=resynthesized,proto3" json:"resynthesized,omitempty"`
+	Failed        int64                  `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"` // why this entry failed, if it did
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResynthesizeProgress) Reset() {
+	*x = ResynthesizeProgress{}
+	mi := &file_proto_tts_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResynthesizeProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResynthesizeProgress) ProtoMessage() {}
+
+func (x *ResynthesizeProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResynthesizeProgress.ProtoReflect.Descriptor instead.
+func (*ResynthesizeProgress) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ResynthesizeProgress) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ResynthesizeProgress) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ResynthesizeProgress) GetCacheKey() string {
+	if x != nil {
+		return x.CacheKey
+	}
+	return ""
+}
+
+func (x *ResynthesizeProgress) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *ResynthesizeProgress) GetResynthesized() int64 {
+	if x != nil {
+		return x.Resynthesized
+	}
+	return 0
+}
+
+func (x *ResynthesizeProgress) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ResynthesizeProgress) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// StatsRequest has no parameters
+type StatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	mi := &file_proto_tts_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{35}
+}
+
+// DedupEvent records a synthesis shared by concurrent requests for the same text
+type DedupEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp       int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp when the synthesis finished
+	CacheKey        string                 `protobuf:"bytes,2,opt,name=cache_key,json=cacheKey,proto3" json:"cache_key,omitempty"`
+	WaiterCount     int32                  `protobuf:"varint,3,opt,name=waiter_count,json=waiterCount,proto3" json:"waiter_count,omitempty"` // requests that waited instead of calling Azure
+	SynthesisTimeMs int64                  `protobuf:"varint,4,opt,name=synthesis_time_ms,json=synthesisTimeMs,proto3" json:"synthesis_time_ms,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DedupEvent) Reset() {
+	*x = DedupEvent{}
+	mi := &file_proto_tts_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DedupEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DedupEvent) ProtoMessage() {}
+
+func (x *DedupEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DedupEvent.ProtoReflect.Descriptor instead.
+func (*DedupEvent) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DedupEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *DedupEvent) GetCacheKey() string {
+	if x != nil {
+		return x.CacheKey
+	}
+	return ""
+}
+
+func (x *DedupEvent) GetWaiterCount() int32 {
+	if x != nil {
+		return x.WaiterCount
+	}
+	return 0
+}
+
+func (x *DedupEvent) GetSynthesisTimeMs() int64 {
+	if x != nil {
+		return x.SynthesisTimeMs
+	}
+	return 0
+}
+
+// DedupStatsResponse summarizes request deduplication since the daemon started
+type DedupStatsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TotalDedupEvents   int64                  `protobuf:"varint,1,opt,name=total_dedup_events,json=totalDedupEvents,proto3" json:"total_dedup_events,omitempty"`
+	TotalWaitersSaved  int64                  `protobuf:"varint,2,opt,name=total_waiters_saved,json=totalWaitersSaved,proto3" json:"total_waiters_saved,omitempty"` // Azure calls saved
+	AvgSynthesisTimeMs float64                `protobuf:"fixed64,3,opt,name=avg_synthesis_time_ms,json=avgSynthesisTimeMs,proto3" json:"avg_synthesis_time_ms,omitempty"`
+	RecentEvents       []*DedupEvent          `protobuf:"bytes,4,rep,name=recent_events,json=recentEvents,proto3" json:"recent_events,omitempty"` // up to the last 1000 events, oldest first
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DedupStatsResponse) Reset() {
+	*x = DedupStatsResponse{}
+	mi := &file_proto_tts_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DedupStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DedupStatsResponse) ProtoMessage() {}
+
+func (x *DedupStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DedupStatsResponse.ProtoReflect.Descriptor instead.
+func (*DedupStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DedupStatsResponse) GetTotalDedupEvents() int64 {
+	if x != nil {
+		return x.TotalDedupEvents
+	}
+	return 0
+}
+
+func (x *DedupStatsResponse) GetTotalWaitersSaved() int64 {
+	if x != nil {
+		return x.TotalWaitersSaved
+	}
+	return 0
+}
+
+func (x *DedupStatsResponse) GetAvgSynthesisTimeMs() float64 {
+	if x != nil {
+		return x.AvgSynthesisTimeMs
+	}
+	return 0
+}
+
+func (x *DedupStatsResponse) GetRecentEvents() []*DedupEvent {
+	if x != nil {
+		return x.RecentEvents
+	}
+	return nil
+}
+
+// DeletePatternRequest selects cache entries by text pattern
+type DeletePatternRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TextPattern   string                 `protobuf:"bytes,1,opt,name=text_pattern,json=textPattern,proto3" json:"text_pattern,omitempty"`    // SQL LIKE pattern matched case-insensitively, e.g. "%old product%"
+	LanguageCode  string                 `protobuf:"bytes,2,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"` // only entries for this language (empty = all)
+	DryRun        bool                   `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                  // report what would be deleted without deleting
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`                           // tenant whose entries are deleted (empty = the default namespace)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePatternRequest) Reset() {
+	*x = DeletePatternRequest{}
+	mi := &file_proto_tts_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePatternRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePatternRequest) ProtoMessage() {}
+
+func (x *DeletePatternRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePatternRequest.ProtoReflect.Descriptor instead.
+func (*DeletePatternRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeletePatternRequest) GetTextPattern() string {
+	if x != nil {
+		return x.TextPattern
+	}
+	return ""
+}
+
+func (x *DeletePatternRequest) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *DeletePatternRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *DeletePatternRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// DeletePatternResponse summarizes a pattern delete
+type DeletePatternResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchedCount  int64                  `protobuf:"varint,1,opt,name=matched_count,json=matchedCount,proto3" json:"matched_count,omitempty"` // entries matching the pattern
+	DeletedCount  int64                  `protobuf:"varint,2,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"` // entries deleted (0 for a dry run)
+	FreedBytes    int64                  `protobuf:"varint,3,opt,name=freed_bytes,json=freedBytes,proto3" json:"freed_bytes,omitempty"`       // stored bytes freed (or that would be freed on a dry run)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePatternResponse) Reset() {
+	*x = DeletePatternResponse{}
+	mi := &file_proto_tts_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePatternResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePatternResponse) ProtoMessage() {}
+
+func (x *DeletePatternResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePatternResponse.ProtoReflect.Descriptor instead.
+func (*DeletePatternResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DeletePatternResponse) GetMatchedCount() int64 {
+	if x != nil {
+		return x.MatchedCount
+	}
+	return 0
+}
+
+func (x *DeletePatternResponse) GetDeletedCount() int64 {
+	if x != nil {
+		return x.DeletedCount
+	}
+	return 0
+}
+
+func (x *DeletePatternResponse) GetFreedBytes() int64 {
+	if x != nil {
+		return x.FreedBytes
+	}
+	return 0
+}
+
+// DeleteByTagRequest selects the tag whose entries are deleted
+type DeleteByTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"` // tenant whose entries are deleted (empty = the default namespace)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteByTagRequest) Reset() {
+	*x = DeleteByTagRequest{}
+	mi := &file_proto_tts_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteByTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteByTagRequest) ProtoMessage() {}
+
+func (x *DeleteByTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteByTagRequest.ProtoReflect.Descriptor instead.
+func (*DeleteByTagRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeleteByTagRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *DeleteByTagRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// DeleteByTagResponse summarizes a tag delete
+type DeleteByTagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeletedCount  int64                  `protobuf:"varint,1,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteByTagResponse) Reset() {
+	*x = DeleteByTagResponse{}
+	mi := &file_proto_tts_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteByTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteByTagResponse) ProtoMessage() {}
+
+func (x *DeleteByTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteByTagResponse.ProtoReflect.Descriptor instead.
+func (*DeleteByTagResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DeleteByTagResponse) GetDeletedCount() int64 {
+	if x != nil {
+		return x.DeletedCount
+	}
+	return 0
+}
+
+// DeleteByLanguageRequest selects the language to delete
+type DeleteByLanguageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LanguageCode  string                 `protobuf:"bytes,1,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"` // tenant whose entries are deleted (empty = the default namespace)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteByLanguageRequest) Reset() {
+	*x = DeleteByLanguageRequest{}
+	mi := &file_proto_tts_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteByLanguageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteByLanguageRequest) ProtoMessage() {}
+
+func (x *DeleteByLanguageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteByLanguageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteByLanguageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DeleteByLanguageRequest) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *DeleteByLanguageRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// DeleteByLanguageResponse summarizes a language delete
+type DeleteByLanguageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeletedCount  int64                  `protobuf:"varint,1,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"`
+	DeletedKeys   []string               `protobuf:"bytes,2,rep,name=deleted_keys,json=deletedKeys,proto3" json:"deleted_keys,omitempty"` // keys of the deleted entries, at most 1000 of them
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteByLanguageResponse) Reset() {
+	*x = DeleteByLanguageResponse{}
+	mi := &file_proto_tts_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteByLanguageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteByLanguageResponse) ProtoMessage() {}
+
+func (x *DeleteByLanguageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteByLanguageResponse.ProtoReflect.Descriptor instead.
+func (*DeleteByLanguageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DeleteByLanguageResponse) GetDeletedCount() int64 {
+	if x != nil {
+		return x.DeletedCount
+	}
+	return 0
+}
+
+func (x *DeleteByLanguageResponse) GetDeletedKeys() []string {
+	if x != nil {
+		return x.DeletedKeys
+	}
+	return nil
+}
+
+// VerifyIntegrityRequest selects the entries to check
+type VerifyIntegrityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"` // tenant whose entries are checked (empty = the default namespace)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyIntegrityRequest) Reset() {
+	*x = VerifyIntegrityRequest{}
+	mi := &file_proto_tts_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyIntegrityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyIntegrityRequest) ProtoMessage() {}
+
+func (x *VerifyIntegrityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyIntegrityRequest.ProtoReflect.Descriptor instead.
+func (*VerifyIntegrityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *VerifyIntegrityRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// CacheEntryRef identifies a cached text
+type CacheEntryRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	LanguageCode  string                 `protobuf:"bytes,2,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheEntryRef) Reset() {
+	*x = CacheEntryRef{}
+	mi := &file_proto_tts_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheEntryRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheEntryRef) ProtoMessage() {}
+
+func (x *CacheEntryRef) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheEntryRef.ProtoReflect.Descriptor instead.
+func (*CacheEntryRef) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CacheEntryRef) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *CacheEntryRef) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+// CollisionGroup lists every entry stored under the same cache key
+type CollisionGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CacheKey      string                 `protobuf:"bytes,1,opt,name=cache_key,json=cacheKey,proto3" json:"cache_key,omitempty"`
+	Entries       []*CacheEntryRef       `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollisionGroup) Reset() {
+	*x = CollisionGroup{}
+	mi := &file_proto_tts_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollisionGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollisionGroup) ProtoMessage() {}
+
+func (x *CollisionGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollisionGroup.ProtoReflect.Descriptor instead.
+func (*CollisionGroup) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CollisionGroup) GetCacheKey() string {
+	if x != nil {
+		return x.CacheKey
+	}
+	return ""
+}
+
+func (x *CollisionGroup) GetEntries() []*CacheEntryRef {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// KeyMismatch is an entry whose stored key doesn't match any key computed from its text
+type KeyMismatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CacheKey      string                 `protobuf:"bytes,1,opt,name=cache_key,json=cacheKey,proto3" json:"cache_key,omitempty"` // key stored in the database
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	LanguageCode  string                 `protobuf:"bytes,3,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"`
+	ExpectedKey   string                 `protobuf:"bytes,4,opt,name=expected_key,json=expectedKey,proto3" json:"expected_key,omitempty"` // key computed with default synthesis options
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyMismatch) Reset() {
+	*x = KeyMismatch{}
+	mi := &file_proto_tts_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyMismatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyMismatch) ProtoMessage() {}
+
+func (x *KeyMismatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyMismatch.ProtoReflect.Descriptor instead.
+func (*KeyMismatch) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *KeyMismatch) GetCacheKey() string {
+	if x != nil {
+		return x.CacheKey
+	}
+	return ""
+}
+
+func (x *KeyMismatch) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *KeyMismatch) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *KeyMismatch) GetExpectedKey() string {
+	if x != nil {
+		return x.ExpectedKey
+	}
+	return ""
+}
+
+// IntegrityReport contains the results of VerifyIntegrity
+type IntegrityReport struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Ok             bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"` // true if no collisions or mismatches were found
+	EntriesChecked int64                  `protobuf:"varint,2,opt,name=entries_checked,json=entriesChecked,proto3" json:"entries_checked,omitempty"`
+	Collisions     []*CollisionGroup      `protobuf:"bytes,3,rep,name=collisions,proto3" json:"collisions,omitempty"`
+	Mismatches     []*KeyMismatch         `protobuf:"bytes,4,rep,name=mismatches,proto3" json:"mismatches,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *IntegrityReport) Reset() {
+	*x = IntegrityReport{}
+	mi := &file_proto_tts_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntegrityReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntegrityReport) ProtoMessage() {}
+
+func (x *IntegrityReport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntegrityReport.ProtoReflect.Descriptor instead.
+func (*IntegrityReport) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *IntegrityReport) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *IntegrityReport) GetEntriesChecked() int64 {
+	if x != nil {
+		return x.EntriesChecked
+	}
+	return 0
+}
+
+func (x *IntegrityReport) GetCollisions() []*CollisionGroup {
+	if x != nil {
+		return x.Collisions
+	}
+	return nil
+}
+
+func (x *IntegrityReport) GetMismatches() []*KeyMismatch {
+	if x != nil {
+		return x.Mismatches
+	}
+	return nil
+}
+
+// VerifyCacheRequest selects whether corrupt entries are deleted
+type VerifyCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repair        bool                   `protobuf:"varint,1,opt,name=repair,proto3" json:"repair,omitempty"` // delete the corrupt entries found, so they are synthesized again
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyCacheRequest) Reset() {
+	*x = VerifyCacheRequest{}
+	mi := &file_proto_tts_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyCacheRequest) ProtoMessage() {}
+
+func (x *VerifyCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyCacheRequest.ProtoReflect.Descriptor instead.
+func (*VerifyCacheRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *VerifyCacheRequest) GetRepair() bool {
+	if x != nil {
+		return x.Repair
+	}
+	return false
+}
+
+// VerifyCacheResponse contains the results of VerifyCache. Only a random sample of entries is
+// decoded, so a healthy result doesn't rule out corrupt entries elsewhere.
+type VerifyCacheResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IsHealthy       bool                   `protobuf:"varint,1,opt,name=is_healthy,json=isHealthy,proto3" json:"is_healthy,omitempty"`                  // true if the integrity check passed and no corrupt entries were found
+	CorruptEntries  int64                  `protobuf:"varint,2,opt,name=corrupt_entries,json=corruptEntries,proto3" json:"corrupt_entries,omitempty"`   // corrupt entries found in the sample
+	CorruptKeys     []string               `protobuf:"bytes,3,rep,name=corrupt_keys,json=corruptKeys,proto3" json:"corrupt_keys,omitempty"`             // keys of the first corrupt entries found
+	IntegrityErrors []string               `protobuf:"bytes,4,rep,name=integrity_errors,json=integrityErrors,proto3" json:"integrity_errors,omitempty"` // problems reported by SQLite's integrity check
+	EntriesChecked  int64                  `protobuf:"varint,5,opt,name=entries_checked,json=entriesChecked,proto3" json:"entries_checked,omitempty"`   // entries decoded
+	RepairedCount   int64                  `protobuf:"varint,6,opt,name=repaired_count,json=repairedCount,proto3" json:"repaired_count,omitempty"`      // corrupt entries deleted (with repair)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VerifyCacheResponse) Reset() {
+	*x = VerifyCacheResponse{}
+	mi := &file_proto_tts_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyCacheResponse) ProtoMessage() {}
+
+func (x *VerifyCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyCacheResponse.ProtoReflect.Descriptor instead.
+func (*VerifyCacheResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *VerifyCacheResponse) GetIsHealthy() bool {
+	if x != nil {
+		return x.IsHealthy
+	}
+	return false
+}
+
+func (x *VerifyCacheResponse) GetCorruptEntries() int64 {
+	if x != nil {
+		return x.CorruptEntries
+	}
+	return 0
+}
+
+func (x *VerifyCacheResponse) GetCorruptKeys() []string {
+	if x != nil {
+		return x.CorruptKeys
+	}
+	return nil
+}
+
+func (x *VerifyCacheResponse) GetIntegrityErrors() []string {
+	if x != nil {
+		return x.IntegrityErrors
+	}
+	return nil
+}
+
+func (x *VerifyCacheResponse) GetEntriesChecked() int64 {
+	if x != nil {
+		return x.EntriesChecked
+	}
+	return 0
+}
+
+func (x *VerifyCacheResponse) GetRepairedCount() int64 {
+	if x != nil {
+		return x.RepairedCount
+	}
+	return 0
+}
+
+// NearDuplicatesRequest sets how similar texts must be to be grouped
+type NearDuplicatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Threshold     float64                `protobuf:"fixed64,1,opt,name=threshold,proto3" json:"threshold,omitempty"` // estimated Jaccard similarity of the texts' 3-grams, 0-1 (0 = 0.85)
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`   // tenant whose entries are compared (empty = the default namespace)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearDuplicatesRequest) Reset() {
+	*x = NearDuplicatesRequest{}
+	mi := &file_proto_tts_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearDuplicatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearDuplicatesRequest) ProtoMessage() {}
+
+func (x *NearDuplicatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearDuplicatesRequest.ProtoReflect.Descriptor instead.
+func (*NearDuplicatesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *NearDuplicatesRequest) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *NearDuplicatesRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// NearDuplicateGroup lists entries in the same language with nearly identical text
+type NearDuplicateGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*CacheEntryInfo      `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearDuplicateGroup) Reset() {
+	*x = NearDuplicateGroup{}
+	mi := &file_proto_tts_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearDuplicateGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearDuplicateGroup) ProtoMessage() {}
+
+func (x *NearDuplicateGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearDuplicateGroup.ProtoReflect.Descriptor instead.
+func (*NearDuplicateGroup) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *NearDuplicateGroup) GetEntries() []*CacheEntryInfo {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// NearDuplicatesResponse lists every group of near-duplicate entries
+type NearDuplicatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*NearDuplicateGroup  `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearDuplicatesResponse) Reset() {
+	*x = NearDuplicatesResponse{}
+	mi := &file_proto_tts_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearDuplicatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearDuplicatesResponse) ProtoMessage() {}
+
+func (x *NearDuplicatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearDuplicatesResponse.ProtoReflect.Descriptor instead.
+func (*NearDuplicatesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *NearDuplicatesResponse) GetGroups() []*NearDuplicateGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// PauseRequest pauses synthesis
+type PauseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PauseReason   string                 `protobuf:"bytes,1,opt,name=pause_reason,json=pauseReason,proto3" json:"pause_reason,omitempty"` // logged and shown by diagnostics
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseRequest) Reset() {
+	*x = PauseRequest{}
+	mi := &file_proto_tts_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseRequest) ProtoMessage() {}
+
+func (x *PauseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseRequest.ProtoReflect.Descriptor instead.
+func (*PauseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *PauseRequest) GetPauseReason() string {
+	if x != nil {
+		return x.PauseReason
+	}
+	return ""
+}
+
+// PauseResponse reports the previous state
+type PauseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WasPaused     bool                   `protobuf:"varint,1,opt,name=was_paused,json=wasPaused,proto3" json:"was_paused,omitempty"` // synthesis was already paused (only the reason was updated)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseResponse) Reset() {
+	*x = PauseResponse{}
+	mi := &file_proto_tts_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseResponse) ProtoMessage() {}
+
+func (x *PauseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseResponse.ProtoReflect.Descriptor instead.
+func (*PauseResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *PauseResponse) GetWasPaused() bool {
+	if x != nil {
+		return x.WasPaused
+	}
+	return false
+}
+
+// ResumeRequest has no parameters
+type ResumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeRequest) Reset() {
+	*x = ResumeRequest{}
+	mi := &file_proto_tts_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeRequest) ProtoMessage() {}
+
+func (x *ResumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeRequest.ProtoReflect.Descriptor instead.
+func (*ResumeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{56}
+}
+
+// ResumeResponse reports the previous state
+type ResumeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WasPaused     bool                   `protobuf:"varint,1,opt,name=was_paused,json=wasPaused,proto3" json:"was_paused,omitempty"`
+	PausedSeconds int64                  `protobuf:"varint,2,opt,name=paused_seconds,json=pausedSeconds,proto3" json:"paused_seconds,omitempty"` // how long synthesis was paused
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeResponse) Reset() {
+	*x = ResumeResponse{}
+	mi := &file_proto_tts_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeResponse) ProtoMessage() {}
+
+func (x *ResumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeResponse.ProtoReflect.Descriptor instead.
+func (*ResumeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ResumeResponse) GetWasPaused() bool {
+	if x != nil {
+		return x.WasPaused
+	}
+	return false
+}
+
+func (x *ResumeResponse) GetPausedSeconds() int64 {
+	if x != nil {
+		return x.PausedSeconds
+	}
+	return 0
+}
+
+// DrainRequest starts draining the daemon
+type DrainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DrainTimeoutS int32                  `protobuf:"varint,1,opt,name=drain_timeout_s,json=drainTimeoutS,proto3" json:"drain_timeout_s,omitempty"` // how long to wait for active requests before stopping (0 = 30)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DrainRequest) Reset() {
+	*x = DrainRequest{}
+	mi := &file_proto_tts_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainRequest) ProtoMessage() {}
+
+func (x *DrainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainRequest.ProtoReflect.Descriptor instead.
+func (*DrainRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *DrainRequest) GetDrainTimeoutS() int32 {
+	if x != nil {
+		return x.DrainTimeoutS
+	}
+	return 0
+}
+
+// DrainResponse reports the load when draining started
+type DrainResponse struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	ActiveRequestsAtDrainStart int32                  `protobuf:"varint,1,opt,name=active_requests_at_drain_start,json=activeRequestsAtDrainStart,proto3" json:"active_requests_at_drain_start,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *DrainResponse) Reset() {
+	*x = DrainResponse{}
+	mi := &file_proto_tts_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainResponse) ProtoMessage() {}
+
+func (x *DrainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainResponse.ProtoReflect.Descriptor instead.
+func (*DrainResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DrainResponse) GetActiveRequestsAtDrainStart() int32 {
+	if x != nil {
+		return x.ActiveRequestsAtDrainStart
+	}
+	return 0
+}
+
+// CompactionRequest has no parameters
+type CompactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompactionRequest) Reset() {
+	*x = CompactionRequest{}
+	mi := &file_proto_tts_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactionRequest) ProtoMessage() {}
+
+func (x *CompactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactionRequest.ProtoReflect.Descriptor instead.
+func (*CompactionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{60}
+}
+
+// CompactionResponse reports the database size before and after compaction
+type CompactionResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SizeBeforeBytes int64                  `protobuf:"varint,1,opt,name=size_before_bytes,json=sizeBeforeBytes,proto3" json:"size_before_bytes,omitempty"`
+	SizeAfterBytes  int64                  `protobuf:"varint,2,opt,name=size_after_bytes,json=sizeAfterBytes,proto3" json:"size_after_bytes,omitempty"`
+	DurationMs      int64                  `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CompactionResponse) Reset() {
+	*x = CompactionResponse{}
+	mi := &file_proto_tts_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactionResponse) ProtoMessage() {}
+
+func (x *CompactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactionResponse.ProtoReflect.Descriptor instead.
+func (*CompactionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *CompactionResponse) GetSizeBeforeBytes() int64 {
+	if x != nil {
+		return x.SizeBeforeBytes
+	}
+	return 0
+}
+
+func (x *CompactionResponse) GetSizeAfterBytes() int64 {
+	if x != nil {
+		return x.SizeAfterBytes
+	}
+	return 0
+}
+
+func (x *CompactionResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// HistoryRequest selects voice changes to list
+type HistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LanguageCode  string                 `protobuf:"bytes,1,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"` // only changes for this locale (empty = all)
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                  // most recent changes to return (0 = all)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
+	mi := &file_proto_tts_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryRequest) ProtoMessage() {}
+
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *HistoryRequest) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// VoiceChange records that the default voice for a locale changed
+type VoiceChange struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Locale          string                 `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	OldVoice        string                 `protobuf:"bytes,2,opt,name=old_voice,json=oldVoice,proto3" json:"old_voice,omitempty"`
+	NewVoice        string                 `protobuf:"bytes,3,opt,name=new_voice,json=newVoice,proto3" json:"new_voice,omitempty"`
+	DetectedAt      int64                  `protobuf:"varint,4,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`                  // Unix timestamp of the daemon start that noticed the change
+	OldVoiceEntries int64                  `protobuf:"varint,5,opt,name=old_voice_entries,json=oldVoiceEntries,proto3" json:"old_voice_entries,omitempty"` // cache entries still synthesized with old_voice
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VoiceChange) Reset() {
+	*x = VoiceChange{}
+	mi := &file_proto_tts_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoiceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoiceChange) ProtoMessage() {}
+
+func (x *VoiceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoiceChange.ProtoReflect.Descriptor instead.
+func (*VoiceChange) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *VoiceChange) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *VoiceChange) GetOldVoice() string {
+	if x != nil {
+		return x.OldVoice
+	}
+	return ""
+}
+
+func (x *VoiceChange) GetNewVoice() string {
+	if x != nil {
+		return x.NewVoice
+	}
+	return ""
+}
+
+func (x *VoiceChange) GetDetectedAt() int64 {
+	if x != nil {
+		return x.DetectedAt
+	}
+	return 0
+}
+
+func (x *VoiceChange) GetOldVoiceEntries() int64 {
+	if x != nil {
+		return x.OldVoiceEntries
+	}
+	return 0
+}
+
+// VoiceChangeHistoryResponse lists voice changes, newest first
+type VoiceChangeHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*VoiceChange         `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoiceChangeHistoryResponse) Reset() {
+	*x = VoiceChangeHistoryResponse{}
+	mi := &file_proto_tts_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoiceChangeHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoiceChangeHistoryResponse) ProtoMessage() {}
+
+func (x *VoiceChangeHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoiceChangeHistoryResponse.ProtoReflect.Descriptor instead.
+func (*VoiceChangeHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *VoiceChangeHistoryResponse) GetChanges() []*VoiceChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// RefreshRequest has no parameters
+type RefreshRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshRequest) Reset() {
+	*x = RefreshRequest{}
+	mi := &file_proto_tts_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshRequest) ProtoMessage() {}
+
+func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshRequest.ProtoReflect.Descriptor instead.
+func (*RefreshRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{65}
+}
+
+// RefreshResponse describes the reloaded voice list
+type RefreshResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VoiceCount    int32                  `protobuf:"varint,1,opt,name=voice_count,json=voiceCount,proto3" json:"voice_count,omitempty"`
+	LocaleCount   int32                  `protobuf:"varint,2,opt,name=locale_count,json=localeCount,proto3" json:"locale_count,omitempty"` // locales with a default voice
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshResponse) Reset() {
+	*x = RefreshResponse{}
+	mi := &file_proto_tts_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshResponse) ProtoMessage() {}
+
+func (x *RefreshResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshResponse.ProtoReflect.Descriptor instead.
+func (*RefreshResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *RefreshResponse) GetVoiceCount() int32 {
+	if x != nil {
+		return x.VoiceCount
+	}
+	return 0
+}
+
+func (x *RefreshResponse) GetLocaleCount() int32 {
+	if x != nil {
+		return x.LocaleCount
+	}
+	return 0
+}
+
+// ListLocalesRequest filters the locales to list
+type ListLocalesRequest struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	HasAzureVoiceFilter    bool                   `protobuf:"varint,1,opt,name=has_azure_voice_filter,json=hasAzureVoiceFilter,proto3" json:"has_azure_voice_filter,omitempty"`          // only locales Azure has a voice for
+	HasCachedContentFilter bool                   `protobuf:"varint,2,opt,name=has_cached_content_filter,json=hasCachedContentFilter,proto3" json:"has_cached_content_filter,omitempty"` // only locales with cached entries
+	Namespace              string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`                                                              // tenant whose entries are counted (empty = the default namespace)
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListLocalesRequest) Reset() {
+	*x = ListLocalesRequest{}
+	mi := &file_proto_tts_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocalesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocalesRequest) ProtoMessage() {}
+
+func (x *ListLocalesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocalesRequest.ProtoReflect.Descriptor instead.
+func (*ListLocalesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListLocalesRequest) GetHasAzureVoiceFilter() bool {
+	if x != nil {
+		return x.HasAzureVoiceFilter
+	}
+	return false
+}
+
+func (x *ListLocalesRequest) GetHasCachedContentFilter() bool {
+	if x != nil {
+		return x.HasCachedContentFilter
+	}
+	return false
+}
+
+func (x *ListLocalesRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// LocaleInfo describes one locale
+type LocaleInfo struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Locale           string                 `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	DisplayName      string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"` // English name, e.g. "Canadian French" (empty if unknown)
+	HasAzureVoice    bool                   `protobuf:"varint,3,opt,name=has_azure_voice,json=hasAzureVoice,proto3" json:"has_azure_voice,omitempty"`
+	CachedEntries    int64                  `protobuf:"varint,4,opt,name=cached_entries,json=cachedEntries,proto3" json:"cached_entries,omitempty"`
+	TotalCachedBytes int64                  `protobuf:"varint,5,opt,name=total_cached_bytes,json=totalCachedBytes,proto3" json:"total_cached_bytes,omitempty"` // stored (possibly compressed) size
+	DefaultVoice     string                 `protobuf:"bytes,6,opt,name=default_voice,json=defaultVoice,proto3" json:"default_voice,omitempty"`                // Azure's default voice for the locale (empty without one)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *LocaleInfo) Reset() {
+	*x = LocaleInfo{}
+	mi := &file_proto_tts_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocaleInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocaleInfo) ProtoMessage() {}
+
+func (x *LocaleInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocaleInfo.ProtoReflect.Descriptor instead.
+func (*LocaleInfo) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *LocaleInfo) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *LocaleInfo) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *LocaleInfo) GetHasAzureVoice() bool {
+	if x != nil {
+		return x.HasAzureVoice
+	}
+	return false
+}
+
+func (x *LocaleInfo) GetCachedEntries() int64 {
+	if x != nil {
+		return x.CachedEntries
+	}
+	return 0
+}
+
+func (x *LocaleInfo) GetTotalCachedBytes() int64 {
+	if x != nil {
+		return x.TotalCachedBytes
+	}
+	return 0
+}
+
+func (x *LocaleInfo) GetDefaultVoice() string {
+	if x != nil {
+		return x.DefaultVoice
+	}
+	return ""
+}
+
+// ListLocalesResponse lists locales sorted by locale
+type ListLocalesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locales       []*LocaleInfo          `protobuf:"bytes,1,rep,name=locales,proto3" json:"locales,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLocalesResponse) Reset() {
+	*x = ListLocalesResponse{}
+	mi := &file_proto_tts_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocalesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocalesResponse) ProtoMessage() {}
+
+func (x *ListLocalesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocalesResponse.ProtoReflect.Descriptor instead.
+func (*ListLocalesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ListLocalesResponse) GetLocales() []*LocaleInfo {
+	if x != nil {
+		return x.Locales
+	}
+	return nil
+}
+
+// GetCacheStatsRequest selects the entries the stats cover
+type GetCacheStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"` // tenant whose entries are counted (empty = the default namespace)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCacheStatsRequest) Reset() {
+	*x = GetCacheStatsRequest{}
+	mi := &file_proto_tts_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCacheStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCacheStatsRequest) ProtoMessage() {}
+
+func (x *GetCacheStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCacheStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetCacheStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *GetCacheStatsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// LanguageStats summarizes the cache entries for one language code
+type LanguageStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LanguageCode  string                 `protobuf:"bytes,1,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`       // stored (possibly compressed) size
+	OldestEntry   int64                  `protobuf:"varint,4,opt,name=oldest_entry,json=oldestEntry,proto3" json:"oldest_entry,omitempty"` // creation time of the oldest entry (Unix timestamp)
+	NewestEntry   int64                  `protobuf:"varint,5,opt,name=newest_entry,json=newestEntry,proto3" json:"newest_entry,omitempty"` // creation time of the newest entry (Unix timestamp)
+	HitRate_1M    float64                `protobuf:"fixed64,6,opt,name=hit_rate_1m,json=hitRate1m,proto3" json:"hit_rate_1m,omitempty"`    // share of the language's requests served from the cache (0-1)
+	HitRate_5M    float64                `protobuf:"fixed64,7,opt,name=hit_rate_5m,json=hitRate5m,proto3" json:"hit_rate_5m,omitempty"`
+	HitRateTotal  float64                `protobuf:"fixed64,8,opt,name=hit_rate_total,json=hitRateTotal,proto3" json:"hit_rate_total,omitempty"` // since the daemon started
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LanguageStats) Reset() {
+	*x = LanguageStats{}
+	mi := &file_proto_tts_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LanguageStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LanguageStats) ProtoMessage() {}
+
+func (x *LanguageStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LanguageStats.ProtoReflect.Descriptor instead.
+func (*LanguageStats) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *LanguageStats) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *LanguageStats) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *LanguageStats) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *LanguageStats) GetOldestEntry() int64 {
+	if x != nil {
+		return x.OldestEntry
+	}
+	return 0
+}
+
+func (x *LanguageStats) GetNewestEntry() int64 {
+	if x != nil {
+		return x.NewestEntry
+	}
+	return 0
+}
+
+func (x *LanguageStats) GetHitRate_1M() float64 {
+	if x != nil {
+		return x.HitRate_1M
+	}
+	return 0
+}
+
+func (x *LanguageStats) GetHitRate_5M() float64 {
+	if x != nil {
+		return x.HitRate_5M
+	}
+	return 0
+}
+
+func (x *LanguageStats) GetHitRateTotal() float64 {
+	if x != nil {
+		return x.HitRateTotal
+	}
+	return 0
+}
+
+// CacheStatsResponse describes a namespace's entries as a whole and for each language, sorted by
+// language code. Hit rates cover every namespace.
+type CacheStatsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TotalClips      int64                  `protobuf:"varint,1,opt,name=total_clips,json=totalClips,proto3" json:"total_clips,omitempty"`
+	TotalSizeBytes  int64                  `protobuf:"varint,2,opt,name=total_size_bytes,json=totalSizeBytes,proto3" json:"total_size_bytes,omitempty"`
+	MaxSizeMb       float64                `protobuf:"fixed64,3,opt,name=max_size_mb,json=maxSizeMb,proto3" json:"max_size_mb,omitempty"`        // database.max_size_mb, shared by every namespace (0 = unlimited)
+	UsagePercent    float64                `protobuf:"fixed64,4,opt,name=usage_percent,json=usagePercent,proto3" json:"usage_percent,omitempty"` // of max_size_mb used by the namespace (0 if unlimited)
+	ExpiredClips    int64                  `protobuf:"varint,5,opt,name=expired_clips,json=expiredClips,proto3" json:"expired_clips,omitempty"`  // entries past database.ttl_days awaiting cleanup
+	SynthesisPaused bool                   `protobuf:"varint,6,opt,name=synthesis_paused,json=synthesisPaused,proto3" json:"synthesis_paused,omitempty"`
+	PauseReason     string                 `protobuf:"bytes,7,opt,name=pause_reason,json=pauseReason,proto3" json:"pause_reason,omitempty"`
+	Languages       []*LanguageStats       `protobuf:"bytes,8,rep,name=languages,proto3" json:"languages,omitempty"`
+	HitRate_1M      float64                `protobuf:"fixed64,9,opt,name=hit_rate_1m,json=hitRate1m,proto3" json:"hit_rate_1m,omitempty"` // share of requests served from the cache (0-1)
+	HitRate_5M      float64                `protobuf:"fixed64,10,opt,name=hit_rate_5m,json=hitRate5m,proto3" json:"hit_rate_5m,omitempty"`
+	HitRateTotal    float64                `protobuf:"fixed64,11,opt,name=hit_rate_total,json=hitRateTotal,proto3" json:"hit_rate_total,omitempty"`    // since the daemon started
+	HitRate_1MNote  string                 `protobuf:"bytes,12,opt,name=hit_rate_1m_note,json=hitRate1mNote,proto3" json:"hit_rate_1m_note,omitempty"` // "insufficient data" (and hit_rate_1m 0) in the daemon's first minute
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CacheStatsResponse) Reset() {
+	*x = CacheStatsResponse{}
+	mi := &file_proto_tts_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheStatsResponse) ProtoMessage() {}
+
+func (x *CacheStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheStatsResponse.ProtoReflect.Descriptor instead.
+func (*CacheStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CacheStatsResponse) GetTotalClips() int64 {
+	if x != nil {
+		return x.TotalClips
+	}
+	return 0
+}
+
+func (x *CacheStatsResponse) GetTotalSizeBytes() int64 {
+	if x != nil {
+		return x.TotalSizeBytes
+	}
+	return 0
+}
+
+func (x *CacheStatsResponse) GetMaxSizeMb() float64 {
+	if x != nil {
+		return x.MaxSizeMb
+	}
+	return 0
+}
+
+func (x *CacheStatsResponse) GetUsagePercent() float64 {
+	if x != nil {
+		return x.UsagePercent
+	}
+	return 0
+}
+
+func (x *CacheStatsResponse) GetExpiredClips() int64 {
+	if x != nil {
+		return x.ExpiredClips
+	}
+	return 0
+}
+
+func (x *CacheStatsResponse) GetSynthesisPaused() bool {
+	if x != nil {
+		return x.SynthesisPaused
+	}
+	return false
+}
+
+func (x *CacheStatsResponse) GetPauseReason() string {
+	if x != nil {
+		return x.PauseReason
+	}
+	return ""
+}
+
+func (x *CacheStatsResponse) GetLanguages() []*LanguageStats {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *CacheStatsResponse) GetHitRate_1M() float64 {
+	if x != nil {
+		return x.HitRate_1M
+	}
+	return 0
+}
+
+func (x *CacheStatsResponse) GetHitRate_5M() float64 {
+	if x != nil {
+		return x.HitRate_5M
+	}
+	return 0
+}
+
+func (x *CacheStatsResponse) GetHitRateTotal() float64 {
+	if x != nil {
+		return x.HitRateTotal
+	}
+	return 0
+}
+
+func (x *CacheStatsResponse) GetHitRate_1MNote() string {
+	if x != nil {
+		return x.HitRate_1MNote
+	}
+	return ""
+}
+
+// ConsistencyRequest selects the languages to check
+type ConsistencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LanguageCode  string                 `protobuf:"bytes,1,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"` // only this language (empty = all)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsistencyRequest) Reset() {
+	*x = ConsistencyRequest{}
+	mi := &file_proto_tts_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsistencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsistencyRequest) ProtoMessage() {}
+
+func (x *ConsistencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsistencyRequest.ProtoReflect.Descriptor instead.
+func (*ConsistencyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ConsistencyRequest) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+// Inconsistency describes a language whose cached entries use more than the current voice
+type Inconsistency struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Locale          string                 `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	CurrentVoice    string                 `protobuf:"bytes,2,opt,name=current_voice,json=currentVoice,proto3" json:"current_voice,omitempty"`           // empty if there is no longer a voice for the language
+	CachedVoices    []string               `protobuf:"bytes,3,rep,name=cached_voices,json=cachedVoices,proto3" json:"cached_voices,omitempty"`           // every voice recorded for the language's entries
+	AffectedEntries int64                  `protobuf:"varint,4,opt,name=affected_entries,json=affectedEntries,proto3" json:"affected_entries,omitempty"` // entries not synthesized with current_voice
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Inconsistency) Reset() {
+	*x = Inconsistency{}
+	mi := &file_proto_tts_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Inconsistency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Inconsistency) ProtoMessage() {}
+
+func (x *Inconsistency) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Inconsistency.ProtoReflect.Descriptor instead.
+func (*Inconsistency) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *Inconsistency) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *Inconsistency) GetCurrentVoice() string {
+	if x != nil {
+		return x.CurrentVoice
+	}
+	return ""
+}
+
+func (x *Inconsistency) GetCachedVoices() []string {
+	if x != nil {
+		return x.CachedVoices
+	}
+	return nil
+}
+
+func (x *Inconsistency) GetAffectedEntries() int64 {
+	if x != nil {
+		return x.AffectedEntries
+	}
+	return 0
+}
+
+// ConsistencyResponse lists inconsistent languages, sorted by locale
+type ConsistencyResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Inconsistencies []*Inconsistency       `protobuf:"bytes,1,rep,name=inconsistencies,proto3" json:"inconsistencies,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ConsistencyResponse) Reset() {
+	*x = ConsistencyResponse{}
+	mi := &file_proto_tts_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsistencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsistencyResponse) ProtoMessage() {}
+
+func (x *ConsistencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsistencyResponse.ProtoReflect.Descriptor instead.
+func (*ConsistencyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ConsistencyResponse) GetInconsistencies() []*Inconsistency {
+	if x != nil {
+		return x.Inconsistencies
+	}
+	return nil
+}
+
+// HeatmapRequest selects the interval size and period of a cache heatmap
+type HeatmapRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	GranularityMinutes int32                  `protobuf:"varint,1,opt,name=granularity_minutes,json=granularityMinutes,proto3" json:"granularity_minutes,omitempty"` // interval size; must divide an hour (0 = 60)
+	DaysBack           int32                  `protobuf:"varint,2,opt,name=days_back,json=daysBack,proto3" json:"days_back,omitempty"`                               // only entries accessed in this many days (0 = 7)
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *HeatmapRequest) Reset() {
+	*x = HeatmapRequest{}
+	mi := &file_proto_tts_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeatmapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeatmapRequest) ProtoMessage() {}
+
+func (x *HeatmapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeatmapRequest.ProtoReflect.Descriptor instead.
+func (*HeatmapRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *HeatmapRequest) GetGranularityMinutes() int32 {
+	if x != nil {
+		return x.GranularityMinutes
+	}
+	return 0
+}
+
+func (x *HeatmapRequest) GetDaysBack() int32 {
+	if x != nil {
+		return x.DaysBack
+	}
+	return 0
+}
+
+// HeatmapBucket counts entries last accessed in one interval of the day
+type HeatmapBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HourOfDay     int32                  `protobuf:"varint,1,opt,name=hour_of_day,json=hourOfDay,proto3" json:"hour_of_day,omitempty"`             // UTC
+	MinuteOfHour  int32                  `protobuf:"varint,2,opt,name=minute_of_hour,json=minuteOfHour,proto3" json:"minute_of_hour,omitempty"`    // start of the interval
+	AccessCount   int64                  `protobuf:"varint,3,opt,name=access_count,json=accessCount,proto3" json:"access_count,omitempty"`         // entries last accessed in the interval
+	CacheHitCount int64                  `protobuf:"varint,4,opt,name=cache_hit_count,json=cacheHitCount,proto3" json:"cache_hit_count,omitempty"` // of those, entries served from the cache at least once
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeatmapBucket) Reset() {
+	*x = HeatmapBucket{}
+	mi := &file_proto_tts_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeatmapBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeatmapBucket) ProtoMessage() {}
+
+func (x *HeatmapBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeatmapBucket.ProtoReflect.Descriptor instead.
+func (*HeatmapBucket) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *HeatmapBucket) GetHourOfDay() int32 {
+	if x != nil {
+		return x.HourOfDay
+	}
+	return 0
+}
+
+func (x *HeatmapBucket) GetMinuteOfHour() int32 {
+	if x != nil {
+		return x.MinuteOfHour
+	}
+	return 0
+}
+
+func (x *HeatmapBucket) GetAccessCount() int64 {
+	if x != nil {
+		return x.AccessCount
+	}
+	return 0
+}
+
+func (x *HeatmapBucket) GetCacheHitCount() int64 {
+	if x != nil {
+		return x.CacheHitCount
+	}
+	return 0
+}
+
+// HeatmapResponse lists every interval of the day in order, including empty ones
+type HeatmapResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Buckets            []*HeatmapBucket       `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	GranularityMinutes int32                  `protobuf:"varint,2,opt,name=granularity_minutes,json=granularityMinutes,proto3" json:"granularity_minutes,omitempty"`
+	DaysBack           int32                  `protobuf:"varint,3,opt,name=days_back,json=daysBack,proto3" json:"days_back,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *HeatmapResponse) Reset() {
+	*x = HeatmapResponse{}
+	mi := &file_proto_tts_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeatmapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeatmapResponse) ProtoMessage() {}
+
+func (x *HeatmapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeatmapResponse.ProtoReflect.Descriptor instead.
+func (*HeatmapResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *HeatmapResponse) GetBuckets() []*HeatmapBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *HeatmapResponse) GetGranularityMinutes() int32 {
+	if x != nil {
+		return x.GranularityMinutes
+	}
+	return 0
+}
+
+func (x *HeatmapResponse) GetDaysBack() int32 {
+	if x != nil {
+		return x.DaysBack
+	}
+	return 0
+}
+
+// RLStatusRequest optionally waits for the rate limiter to have capacity before reporting
+type RLStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WaitForToken  bool                   `protobuf:"varint,1,opt,name=wait_for_token,json=waitForToken,proto3" json:"wait_for_token,omitempty"` // wait until a request can be made immediately (the token isn't taken)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RLStatusRequest) Reset() {
+	*x = RLStatusRequest{}
+	mi := &file_proto_tts_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RLStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RLStatusRequest) ProtoMessage() {}
+
+func (x *RLStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RLStatusRequest.ProtoReflect.Descriptor instead.
+func (*RLStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *RLStatusRequest) GetWaitForToken() bool {
+	if x != nil {
+		return x.WaitForToken
+	}
+	return false
+}
+
+// RLStatusResponse describes the Azure rate limiter
+type RLStatusResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	CurrentTokens        float64                `protobuf:"fixed64,1,opt,name=current_tokens,json=currentTokens,proto3" json:"current_tokens,omitempty"`
+	MaxTokens            float64                `protobuf:"fixed64,2,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"` // burst size
+	RatePerSecond        float64                `protobuf:"fixed64,3,opt,name=rate_per_second,json=ratePerSecond,proto3" json:"rate_per_second,omitempty"`
+	NextTokenAvailableMs int64                  `protobuf:"varint,4,opt,name=next_token_available_ms,json=nextTokenAvailableMs,proto3" json:"next_token_available_ms,omitempty"` // 0 if a request can be made immediately
+	DailyCharsUsed       int64                  `protobuf:"varint,5,opt,name=daily_chars_used,json=dailyCharsUsed,proto3" json:"daily_chars_used,omitempty"`                     // characters sent to Azure today (UTC) by every kind of synthesis
+	WaitedMs             int64                  `protobuf:"varint,6,opt,name=waited_ms,json=waitedMs,proto3" json:"waited_ms,omitempty"`                                         // how long wait_for_token waited
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RLStatusResponse) Reset() {
+	*x = RLStatusResponse{}
+	mi := &file_proto_tts_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RLStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RLStatusResponse) ProtoMessage() {}
+
+func (x *RLStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RLStatusResponse.ProtoReflect.Descriptor instead.
+func (*RLStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *RLStatusResponse) GetCurrentTokens() float64 {
+	if x != nil {
+		return x.CurrentTokens
+	}
+	return 0
+}
+
+func (x *RLStatusResponse) GetMaxTokens() float64 {
+	if x != nil {
+		return x.MaxTokens
+	}
+	return 0
+}
+
+func (x *RLStatusResponse) GetRatePerSecond() float64 {
+	if x != nil {
+		return x.RatePerSecond
+	}
+	return 0
+}
+
+func (x *RLStatusResponse) GetNextTokenAvailableMs() int64 {
+	if x != nil {
+		return x.NextTokenAvailableMs
+	}
+	return 0
+}
+
+func (x *RLStatusResponse) GetDailyCharsUsed() int64 {
+	if x != nil {
+		return x.DailyCharsUsed
+	}
+	return 0
+}
+
+func (x *RLStatusResponse) GetWaitedMs() int64 {
+	if x != nil {
+		return x.WaitedMs
+	}
+	return 0
+}
+
+// EnqueueRequest describes a synthesis job to run in the background
+type EnqueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	LanguageCode  string                 `protobuf:"bytes,2,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"`
+	Priority      int32                  `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"` // higher priority jobs run first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnqueueRequest) Reset() {
+	*x = EnqueueRequest{}
+	mi := &file_proto_tts_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnqueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnqueueRequest) ProtoMessage() {}
+
+func (x *EnqueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnqueueRequest.ProtoReflect.Descriptor instead.
+func (*EnqueueRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *EnqueueRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *EnqueueRequest) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *EnqueueRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+// EnqueueResponse identifies the queued job
+type EnqueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnqueueResponse) Reset() {
+	*x = EnqueueResponse{}
+	mi := &file_proto_tts_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnqueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnqueueResponse) ProtoMessage() {}
+
+func (x *EnqueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnqueueResponse.ProtoReflect.Descriptor instead.
+func (*EnqueueResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *EnqueueResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// JobStatusRequest selects a queued job
+type JobStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobStatusRequest) Reset() {
+	*x = JobStatusRequest{}
+	mi := &file_proto_tts_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobStatusRequest) ProtoMessage() {}
+
+func (x *JobStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobStatusRequest.ProtoReflect.Descriptor instead.
+func (*JobStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *JobStatusRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// JobStatus describes a queued synthesis job
+type JobStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "pending", "processing", "done" or "failed"
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	LanguageCode  string                 `protobuf:"bytes,4,opt,name=language_code,json=languageCode,proto3" json:"language_code,omitempty"`
+	Priority      int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`         // Unix timestamp
+	CompletedAt   int64                  `protobuf:"varint,7,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`   // Unix timestamp (0 until done or failed)
+	ErrorMessage  string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // set when status is "failed"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobStatus) Reset() {
+	*x = JobStatus{}
+	mi := &file_proto_tts_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobStatus) ProtoMessage() {}
+
+func (x *JobStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobStatus.ProtoReflect.Descriptor instead.
+func (*JobStatus) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *JobStatus) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *JobStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *JobStatus) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *JobStatus) GetLanguageCode() string {
+	if x != nil {
+		return x.LanguageCode
+	}
+	return ""
+}
+
+func (x *JobStatus) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *JobStatus) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *JobStatus) GetCompletedAt() int64 {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return 0
+}
+
+func (x *JobStatus) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// PriorityUpdate sets a new priority for one job
+type PriorityUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	NewPriority   int32                  `protobuf:"varint,2,opt,name=new_priority,json=newPriority,proto3" json:"new_priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriorityUpdate) Reset() {
+	*x = PriorityUpdate{}
+	mi := &file_proto_tts_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriorityUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriorityUpdate) ProtoMessage() {}
+
+func (x *PriorityUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriorityUpdate.ProtoReflect.Descriptor instead.
+func (*PriorityUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *PriorityUpdate) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *PriorityUpdate) GetNewPriority() int32 {
+	if x != nil {
+		return x.NewPriority
+	}
+	return 0
+}
+
+// ReorderRequest contains the priority changes to apply
+type ReorderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updates       []*PriorityUpdate      `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderRequest) Reset() {
+	*x = ReorderRequest{}
+	mi := &file_proto_tts_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderRequest) ProtoMessage() {}
+
+func (x *ReorderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderRequest.ProtoReflect.Descriptor instead.
+func (*ReorderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ReorderRequest) GetUpdates() []*PriorityUpdate {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+// ReorderResponse summarizes a reorder
+type ReorderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpdatedCount  int32                  `protobuf:"varint,1,opt,name=updated_count,json=updatedCount,proto3" json:"updated_count,omitempty"`
+	NotFoundIds   []string               `protobuf:"bytes,2,rep,name=not_found_ids,json=notFoundIds,proto3" json:"not_found_ids,omitempty"` // jobs that don't exist or are no longer pending
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderResponse) Reset() {
+	*x = ReorderResponse{}
+	mi := &file_proto_tts_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderResponse) ProtoMessage() {}
+
+func (x *ReorderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderResponse.ProtoReflect.Descriptor instead.
+func (*ReorderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ReorderResponse) GetUpdatedCount() int32 {
+	if x != nil {
+		return x.UpdatedCount
+	}
+	return 0
+}
+
+func (x *ReorderResponse) GetNotFoundIds() []string {
+	if x != nil {
+		return x.NotFoundIds
+	}
+	return nil
+}
+
+// MetricsRequest asks for the daemon's current metric values
+type MetricsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetricsRequest) Reset() {
+	*x = MetricsRequest{}
+	mi := &file_proto_tts_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricsRequest) ProtoMessage() {}
+
+func (x *MetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricsRequest.ProtoReflect.Descriptor instead.
+func (*MetricsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{88}
+}
+
+// LabelPair is one label of a metric
+type LabelPair struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabelPair) Reset() {
+	*x = LabelPair{}
+	mi := &file_proto_tts_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabelPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelPair) ProtoMessage() {}
+
+func (x *LabelPair) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelPair.ProtoReflect.Descriptor instead.
+func (*LabelPair) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *LabelPair) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LabelPair) GetValue() string {
 	if x != nil {
-		return x.CacheKey
+		return x.Value
+	}
+	return ""
+}
+
+// Quantile is one quantile of a summary
+type Quantile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quantile      float64                `protobuf:"fixed64,1,opt,name=quantile,proto3" json:"quantile,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quantile) Reset() {
+	*x = Quantile{}
+	mi := &file_proto_tts_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quantile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quantile) ProtoMessage() {}
+
+func (x *Quantile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quantile.ProtoReflect.Descriptor instead.
+func (*Quantile) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *Quantile) GetQuantile() float64 {
+	if x != nil {
+		return x.Quantile
+	}
+	return 0
+}
+
+func (x *Quantile) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// Bucket is one cumulative bucket of a histogram
+type Bucket struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UpperBound      float64                `protobuf:"fixed64,1,opt,name=upper_bound,json=upperBound,proto3" json:"upper_bound,omitempty"`
+	CumulativeCount uint64                 `protobuf:"varint,2,opt,name=cumulative_count,json=cumulativeCount,proto3" json:"cumulative_count,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Bucket) Reset() {
+	*x = Bucket{}
+	mi := &file_proto_tts_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bucket) ProtoMessage() {}
+
+func (x *Bucket) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bucket.ProtoReflect.Descriptor instead.
+func (*Bucket) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *Bucket) GetUpperBound() float64 {
+	if x != nil {
+		return x.UpperBound
+	}
+	return 0
+}
+
+func (x *Bucket) GetCumulativeCount() uint64 {
+	if x != nil {
+		return x.CumulativeCount
+	}
+	return 0
+}
+
+// Metric is one labelled series of a metric family
+type Metric struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Labels        []*LabelPair           `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`                               // counters, gauges and untyped metrics
+	TimestampMs   int64                  `protobuf:"varint,3,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"` // 0 = the time of the request
+	SampleCount   uint64                 `protobuf:"varint,4,opt,name=sample_count,json=sampleCount,proto3" json:"sample_count,omitempty"` // summaries and histograms
+	SampleSum     float64                `protobuf:"fixed64,5,opt,name=sample_sum,json=sampleSum,proto3" json:"sample_sum,omitempty"`      // summaries and histograms
+	Quantiles     []*Quantile            `protobuf:"bytes,6,rep,name=quantiles,proto3" json:"quantiles,omitempty"`                         // summaries
+	Buckets       []*Bucket              `protobuf:"bytes,7,rep,name=buckets,proto3" json:"buckets,omitempty"`                             // histograms
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metric) Reset() {
+	*x = Metric{}
+	mi := &file_proto_tts_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metric) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metric) ProtoMessage() {}
+
+func (x *Metric) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metric.ProtoReflect.Descriptor instead.
+func (*Metric) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *Metric) GetLabels() []*LabelPair {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Metric) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Metric) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *Metric) GetSampleCount() uint64 {
+	if x != nil {
+		return x.SampleCount
+	}
+	return 0
+}
+
+func (x *Metric) GetSampleSum() float64 {
+	if x != nil {
+		return x.SampleSum
+	}
+	return 0
+}
+
+func (x *Metric) GetQuantiles() []*Quantile {
+	if x != nil {
+		return x.Quantiles
+	}
+	return nil
+}
+
+func (x *Metric) GetBuckets() []*Bucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+// MetricFamily is a Prometheus metric and all its series
+type MetricFamily struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Help          string                 `protobuf:"bytes,2,opt,name=help,proto3" json:"help,omitempty"`
+	Type          MetricType             `protobuf:"varint,3,opt,name=type,proto3,enum=tts.MetricType" json:"type,omitempty"`
+	Metrics       []*Metric              `protobuf:"bytes,4,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetricFamily) Reset() {
+	*x = MetricFamily{}
+	mi := &file_proto_tts_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricFamily) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricFamily) ProtoMessage() {}
+
+func (x *MetricFamily) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricFamily.ProtoReflect.Descriptor instead.
+func (*MetricFamily) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *MetricFamily) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MetricFamily) GetHelp() string {
+	if x != nil {
+		return x.Help
 	}
 	return ""
 }
 
+func (x *MetricFamily) GetType() MetricType {
+	if x != nil {
+		return x.Type
+	}
+	return MetricType_COUNTER
+}
+
+func (x *MetricFamily) GetMetrics() []*Metric {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+// MetricsResponse lists every metric family, sorted by name
+type MetricsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Families      []*MetricFamily        `protobuf:"bytes,1,rep,name=families,proto3" json:"families,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetricsResponse) Reset() {
+	*x = MetricsResponse{}
+	mi := &file_proto_tts_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricsResponse) ProtoMessage() {}
+
+func (x *MetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tts_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricsResponse.ProtoReflect.Descriptor instead.
+func (*MetricsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tts_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *MetricsResponse) GetFamilies() []*MetricFamily {
+	if x != nil {
+		return x.Families
+	}
+	return nil
+}
+
 var File_proto_tts_proto protoreflect.FileDescriptor
 
 const file_proto_tts_proto_rawDesc = "" +
 	"\n" +
-	"\x0fproto/tts.proto\x12\x03tts\"j\n" +
+	"\x0fproto/tts.proto\x12\x03tts\"\xcc\x03\n" +
 	"\n" +
 	"TTSRequest\x12\x12\n" +
 	"\x04text\x18\x01 \x01(\tR\x04text\x12#\n" +
 	"\rlanguage_code\x18\x02 \x01(\tR\flanguageCode\x12#\n" +
-	"\rforce_refresh\x18\x03 \x01(\bR\fforceRefresh\"=\n" +
+	"\rforce_refresh\x18\x03 \x01(\bR\fforceRefresh\x12!\n" +
+	"\ftempo_factor\x18\x04 \x01(\x01R\vtempoFactor\x126\n" +
+	"\routput_format\x18\x05 \x01(\x0e2\x11.tts.OutputFormatR\foutputFormat\x12,\n" +
+	"\x12include_text_stats\x18\x06 \x01(\bR\x10includeTextStats\x12\x1f\n" +
+	"\vmp3_bitrate\x18\a \x01(\tR\n" +
+	"mp3Bitrate\x12+\n" +
+	"\x12mp3_sample_rate_hz\x18\b \x01(\x05R\x0fmp3SampleRateHz\x12\x1d\n" +
+	"\n" +
+	"voice_name\x18\t \x01(\tR\tvoiceName\x12\x17\n" +
+	"\ais_ssml\x18\n" +
+	" \x01(\bR\x06isSsml\x12\x1c\n" +
+	"\tnamespace\x18\v \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04tags\x18\f \x03(\tR\x04tags\x12\x1f\n" +
+	"\vttl_seconds\x18\r \x01(\x03R\n" +
+	"ttlSeconds\"Y\n" +
 	"\x0eBulkTTSRequest\x12+\n" +
-	"\brequests\x18\x01 \x03(\v2\x0f.tts.TTSRequestR\brequests\"\x80\x01\n" +
+	"\brequests\x18\x01 \x03(\v2\x0f.tts.TTSRequestR\brequests\x12\x1a\n" +
+	"\badaptive\x18\x02 \x01(\bR\badaptive\"a\n" +
+	"\x10WarmCacheRequest\x12+\n" +
+	"\brequests\x18\x01 \x03(\v2\x0f.tts.TTSRequestR\brequests\x12 \n" +
+	"\vconcurrency\x18\x02 \x01(\x05R\vconcurrency\"\xba\x01\n" +
+	"\x11WarmCacheProgress\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04done\x18\x02 \x01(\x03R\x04done\x12\x1f\n" +
+	"\vcached_hits\x18\x03 \x01(\x03R\n" +
+	"cachedHits\x12#\n" +
+	"\razure_fetches\x18\x04 \x01(\x03R\fazureFetches\x12\x16\n" +
+	"\x06errors\x18\x05 \x01(\x03R\x06errors\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x06 \x01(\tR\tlastError\"\xd4\x02\n" +
 	"\vTTSResponse\x12\x16\n" +
 	"\x06cached\x18\x01 \x01(\bR\x06cached\x12\x1d\n" +
 	"\n" +
 	"audio_data\x18\x02 \x01(\fR\taudioData\x12\x1b\n" +
 	"\tcache_key\x18\x03 \x01(\tR\bcacheKey\x12\x1d\n" +
 	"\n" +
-	"audio_size\x18\x04 \x01(\x03R\taudioSize\"A\n" +
+	"audio_size\x18\x04 \x01(\x03R\taudioSize\x12-\n" +
+	"\n" +
+	"text_stats\x18\x05 \x01(\v2\x0e.tts.TextStatsR\ttextStats\x122\n" +
+	"\x15voice_fallback_locale\x18\x06 \x01(\tR\x13voiceFallbackLocale\x12)\n" +
+	"\x10effective_locale\x18\a \x01(\tR\x0feffectiveLocale\x12(\n" +
+	"\x10from_stale_cache\x18\b \x01(\bR\x0efromStaleCache\x12\x1a\n" +
+	"\bfallback\x18\t \x01(\bR\bfallback\"\x91\x01\n" +
+	"\n" +
+	"AudioChunk\x12\x1d\n" +
+	"\n" +
+	"audio_data\x18\x01 \x01(\fR\taudioData\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x17\n" +
+	"\ais_last\x18\x03 \x01(\bR\x06isLast\x12\x16\n" +
+	"\x06cached\x18\x04 \x01(\bR\x06cached\x12\x1b\n" +
+	"\tcache_key\x18\x05 \x01(\tR\bcacheKey\"\xab\x01\n" +
+	"\tTextStats\x12\x1d\n" +
+	"\n" +
+	"char_count\x18\x01 \x01(\x05R\tcharCount\x12\x1d\n" +
+	"\n" +
+	"word_count\x18\x02 \x01(\x05R\twordCount\x12%\n" +
+	"\x0esentence_count\x18\x03 \x01(\x05R\rsentenceCount\x129\n" +
+	"\x19estimated_reading_time_ms\x18\x04 \x01(\x03R\x16estimatedReadingTimeMs\"S\n" +
+	"\x11EphemeralResponse\x12\x1d\n" +
+	"\n" +
+	"audio_data\x18\x01 \x01(\fR\taudioData\x12\x1f\n" +
+	"\vduration_ms\x18\x02 \x01(\x03R\n" +
+	"durationMs\"\x88\x01\n" +
+	"\x0fFallbackRequest\x12)\n" +
+	"\arequest\x18\x01 \x01(\v2\x0f.tts.TTSRequestR\arequest\x12%\n" +
+	"\x0fstale_ok_for_ms\x18\x02 \x01(\x03R\fstaleOkForMs\x12#\n" +
+	"\rfallback_text\x18\x03 \x01(\tR\ffallbackText\"a\n" +
+	"\x13FetchAndSaveRequest\x12)\n" +
+	"\arequest\x18\x01 \x01(\v2\x0f.tts.TTSRequestR\arequest\x12\x1f\n" +
+	"\voutput_path\x18\x02 \x01(\tR\n" +
+	"outputPath\"\x8b\x01\n" +
+	"\x14FetchAndSaveResponse\x12\x14\n" +
+	"\x05saved\x18\x01 \x01(\bR\x05saved\x12\x1f\n" +
+	"\voutput_path\x18\x02 \x01(\tR\n" +
+	"outputPath\x12\x1d\n" +
+	"\n" +
+	"audio_size\x18\x03 \x01(\x03R\taudioSize\x12\x1d\n" +
+	"\n" +
+	"was_cached\x18\x04 \x01(\bR\twasCached\"A\n" +
 	"\x0fBulkTTSResponse\x12.\n" +
-	"\tresponses\x18\x01 \x03(\v2\x10.tts.TTSResponseR\tresponses\"a\n" +
+	"\tresponses\x18\x01 \x03(\v2\x10.tts.TTSResponseR\tresponses\"y\n" +
+	"\x0eBulkItemResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12,\n" +
+	"\bresponse\x18\x02 \x01(\v2\x10.tts.TTSResponseR\bresponse\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"a\n" +
 	"\fPlayResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
@@ -392,14 +6072,412 @@ const file_proto_tts_proto_rawDesc = "" +
 	"\x0eDeleteResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
-	"\tcache_key\x18\x03 \x01(\tR\bcacheKey2\x90\x02\n" +
+	"\tcache_key\x18\x03 \x01(\tR\bcacheKey\"m\n" +
+	"\x18NormalizationDiffRequest\x12\x15\n" +
+	"\x06text_a\x18\x01 \x01(\tR\x05textA\x12\x15\n" +
+	"\x06text_b\x18\x02 \x01(\tR\x05textB\x12#\n" +
+	"\rlanguage_code\x18\x03 \x01(\tR\flanguageCode\"\xfc\x01\n" +
+	"\x19NormalizationDiffResponse\x12!\n" +
+	"\fnormalized_a\x18\x01 \x01(\tR\vnormalizedA\x12!\n" +
+	"\fnormalized_b\x18\x02 \x01(\tR\vnormalizedB\x12\x13\n" +
+	"\x05key_a\x18\x03 \x01(\tR\x04keyA\x12\x13\n" +
+	"\x05key_b\x18\x04 \x01(\tR\x04keyB\x12\x1d\n" +
+	"\n" +
+	"keys_equal\x18\x05 \x01(\bR\tkeysEqual\x12(\n" +
+	"\x10first_diff_index\x18\x06 \x01(\x05R\x0efirstDiffIndex\x12&\n" +
+	"\x0ffirst_diff_rune\x18\a \x01(\x05R\rfirstDiffRune\"\x13\n" +
+	"\x11DiagnosticRequest\"x\n" +
+	"\x0fDiagnosticCheck\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\"X\n" +
+	"\x10DiagnosticReport\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12,\n" +
+	"\x06checks\x18\x02 \x03(\v2\x14.tts.DiagnosticCheckR\x06checks\"\x7f\n" +
+	"\fWatchRequest\x120\n" +
+	"\x14filter_language_code\x18\x01 \x01(\tR\x12filterLanguageCode\x12\x1f\n" +
+	"\vevent_types\x18\x02 \x03(\tR\n" +
+	"eventTypes\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"\xaa\x01\n" +
+	"\n" +
+	"CacheEvent\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x01 \x01(\tR\teventType\x12\x1b\n" +
+	"\tcache_key\x18\x02 \x01(\tR\bcacheKey\x12#\n" +
+	"\rlanguage_code\x18\x03 \x01(\tR\flanguageCode\x12\x1d\n" +
+	"\n" +
+	"audio_size\x18\x04 \x01(\x03R\taudioSize\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\"\xa3\x02\n" +
+	"\x0eCacheEntryInfo\x12\x1b\n" +
+	"\tcache_key\x18\x01 \x01(\tR\bcacheKey\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12#\n" +
+	"\rlanguage_code\x18\x03 \x01(\tR\flanguageCode\x12\x1d\n" +
+	"\n" +
+	"audio_size\x18\x04 \x01(\x03R\taudioSize\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1b\n" +
+	"\thit_count\x18\x06 \x01(\x03R\bhitCount\x12#\n" +
+	"\rlast_accessed\x18\a \x01(\x03R\flastAccessed\x12\x1d\n" +
+	"\n" +
+	"voice_name\x18\b \x01(\tR\tvoiceName\x12\x1c\n" +
+	"\tnamespace\x18\t \x01(\tR\tnamespace\"\xf7\x01\n" +
+	"\x17ListCacheEntriesRequest\x12#\n" +
+	"\rlanguage_code\x18\x01 \x01(\tR\flanguageCode\x12\x1d\n" +
+	"\n" +
+	"since_unix\x18\x02 \x01(\x03R\tsinceUnix\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x1f\n" +
+	"\vtext_prefix\x18\x05 \x01(\tR\n" +
+	"textPrefix\x12\x1c\n" +
+	"\tnamespace\x18\x06 \x01(\tR\tnamespace\x12\x1d\n" +
+	"\n" +
+	"tag_filter\x18\a \x01(\tR\ttagFilter\"q\n" +
+	"\x18ListCacheEntriesResponse\x12-\n" +
+	"\aentries\x18\x01 \x03(\v2\x13.tts.CacheEntryInfoR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"Q\n" +
+	"\x14GetCacheEntryRequest\x12\x1b\n" +
+	"\tcache_key\x18\x01 \x01(\tR\bcacheKey\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"w\n" +
+	"\x15GetCacheEntryResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12)\n" +
+	"\x05entry\x18\x02 \x01(\v2\x13.tts.CacheEntryInfoR\x05entry\x12\x1d\n" +
+	"\n" +
+	"audio_data\x18\x03 \x01(\fR\taudioData\"\xc3\x01\n" +
+	"\fCloneRequest\x12%\n" +
+	"\x0esource_address\x18\x01 \x01(\tR\rsourceAddress\x120\n" +
+	"\x14language_code_filter\x18\x02 \x01(\tR\x12languageCodeFilter\x12\x1d\n" +
+	"\n" +
+	"since_unix\x18\x03 \x01(\x03R\tsinceUnix\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x04 \x01(\x05R\tbatchSize\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\"\x84\x01\n" +
+	"\rCloneProgress\x12\x16\n" +
+	"\x06copied\x18\x01 \x01(\x03R\x06copied\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x03R\askipped\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x03R\x06failed\x12)\n" +
+	"\x10current_language\x18\x04 \x01(\tR\x0fcurrentLanguage\"2\n" +
+	"\x12ExportCacheRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"9\n" +
+	"\vExportChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\"?\n" +
+	"\vImportChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"K\n" +
+	"\x13ImportCacheResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\x03R\bimported\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x03R\askipped\"\x9d\x01\n" +
+	"\x13ResynthesizeRequest\x12#\n" +
+	"\rlanguage_code\x18\x01 \x01(\tR\flanguageCode\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x12$\n" +
+	"\x0erate_limit_qps\x18\x03 \x01(\x01R\frateLimitQps\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\xd4\x01\n" +
+	"\x14ResynthesizeProgress\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x1b\n" +
+	"\tcache_key\x18\x03 \x01(\tR\bcacheKey\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\x12$\n" +
+	"\rresynthesized\x18\x05 \x01(\x03R\rresynthesized\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x03R\x06failed\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"\x0e\n" +
+	"\fStatsRequest\"\x96\x01\n" +
+	"\n" +
+	"DedupEvent\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x1b\n" +
+	"\tcache_key\x18\x02 \x01(\tR\bcacheKey\x12!\n" +
+	"\fwaiter_count\x18\x03 \x01(\x05R\vwaiterCount\x12*\n" +
+	"\x11synthesis_time_ms\x18\x04 \x01(\x03R\x0fsynthesisTimeMs\"\xdb\x01\n" +
+	"\x12DedupStatsResponse\x12,\n" +
+	"\x12total_dedup_events\x18\x01 \x01(\x03R\x10totalDedupEvents\x12.\n" +
+	"\x13total_waiters_saved\x18\x02 \x01(\x03R\x11totalWaitersSaved\x121\n" +
+	"\x15avg_synthesis_time_ms\x18\x03 \x01(\x01R\x12avgSynthesisTimeMs\x124\n" +
+	"\rrecent_events\x18\x04 \x03(\v2\x0f.tts.DedupEventR\frecentEvents\"\x95\x01\n" +
+	"\x14DeletePatternRequest\x12!\n" +
+	"\ftext_pattern\x18\x01 \x01(\tR\vtextPattern\x12#\n" +
+	"\rlanguage_code\x18\x02 \x01(\tR\flanguageCode\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\x82\x01\n" +
+	"\x15DeletePatternResponse\x12#\n" +
+	"\rmatched_count\x18\x01 \x01(\x03R\fmatchedCount\x12#\n" +
+	"\rdeleted_count\x18\x02 \x01(\x03R\fdeletedCount\x12\x1f\n" +
+	"\vfreed_bytes\x18\x03 \x01(\x03R\n" +
+	"freedBytes\"D\n" +
+	"\x12DeleteByTagRequest\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\":\n" +
+	"\x13DeleteByTagResponse\x12#\n" +
+	"\rdeleted_count\x18\x01 \x01(\x03R\fdeletedCount\"\\\n" +
+	"\x17DeleteByLanguageRequest\x12#\n" +
+	"\rlanguage_code\x18\x01 \x01(\tR\flanguageCode\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"b\n" +
+	"\x18DeleteByLanguageResponse\x12#\n" +
+	"\rdeleted_count\x18\x01 \x01(\x03R\fdeletedCount\x12!\n" +
+	"\fdeleted_keys\x18\x02 \x03(\tR\vdeletedKeys\"6\n" +
+	"\x16VerifyIntegrityRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"H\n" +
+	"\rCacheEntryRef\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12#\n" +
+	"\rlanguage_code\x18\x02 \x01(\tR\flanguageCode\"[\n" +
+	"\x0eCollisionGroup\x12\x1b\n" +
+	"\tcache_key\x18\x01 \x01(\tR\bcacheKey\x12,\n" +
+	"\aentries\x18\x02 \x03(\v2\x12.tts.CacheEntryRefR\aentries\"\x86\x01\n" +
+	"\vKeyMismatch\x12\x1b\n" +
+	"\tcache_key\x18\x01 \x01(\tR\bcacheKey\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12#\n" +
+	"\rlanguage_code\x18\x03 \x01(\tR\flanguageCode\x12!\n" +
+	"\fexpected_key\x18\x04 \x01(\tR\vexpectedKey\"\xb1\x01\n" +
+	"\x0fIntegrityReport\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12'\n" +
+	"\x0fentries_checked\x18\x02 \x01(\x03R\x0eentriesChecked\x123\n" +
+	"\n" +
+	"collisions\x18\x03 \x03(\v2\x13.tts.CollisionGroupR\n" +
+	"collisions\x120\n" +
+	"\n" +
+	"mismatches\x18\x04 \x03(\v2\x10.tts.KeyMismatchR\n" +
+	"mismatches\",\n" +
+	"\x12VerifyCacheRequest\x12\x16\n" +
+	"\x06repair\x18\x01 \x01(\bR\x06repair\"\xfb\x01\n" +
+	"\x13VerifyCacheResponse\x12\x1d\n" +
+	"\n" +
+	"is_healthy\x18\x01 \x01(\bR\tisHealthy\x12'\n" +
+	"\x0fcorrupt_entries\x18\x02 \x01(\x03R\x0ecorruptEntries\x12!\n" +
+	"\fcorrupt_keys\x18\x03 \x03(\tR\vcorruptKeys\x12)\n" +
+	"\x10integrity_errors\x18\x04 \x03(\tR\x0fintegrityErrors\x12'\n" +
+	"\x0fentries_checked\x18\x05 \x01(\x03R\x0eentriesChecked\x12%\n" +
+	"\x0erepaired_count\x18\x06 \x01(\x03R\rrepairedCount\"S\n" +
+	"\x15NearDuplicatesRequest\x12\x1c\n" +
+	"\tthreshold\x18\x01 \x01(\x01R\tthreshold\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"C\n" +
+	"\x12NearDuplicateGroup\x12-\n" +
+	"\aentries\x18\x01 \x03(\v2\x13.tts.CacheEntryInfoR\aentries\"I\n" +
+	"\x16NearDuplicatesResponse\x12/\n" +
+	"\x06groups\x18\x01 \x03(\v2\x17.tts.NearDuplicateGroupR\x06groups\"1\n" +
+	"\fPauseRequest\x12!\n" +
+	"\fpause_reason\x18\x01 \x01(\tR\vpauseReason\".\n" +
+	"\rPauseResponse\x12\x1d\n" +
+	"\n" +
+	"was_paused\x18\x01 \x01(\bR\twasPaused\"\x0f\n" +
+	"\rResumeRequest\"V\n" +
+	"\x0eResumeResponse\x12\x1d\n" +
+	"\n" +
+	"was_paused\x18\x01 \x01(\bR\twasPaused\x12%\n" +
+	"\x0epaused_seconds\x18\x02 \x01(\x03R\rpausedSeconds\"6\n" +
+	"\fDrainRequest\x12&\n" +
+	"\x0fdrain_timeout_s\x18\x01 \x01(\x05R\rdrainTimeoutS\"S\n" +
+	"\rDrainResponse\x12B\n" +
+	"\x1eactive_requests_at_drain_start\x18\x01 \x01(\x05R\x1aactiveRequestsAtDrainStart\"\x13\n" +
+	"\x11CompactionRequest\"\x8b\x01\n" +
+	"\x12CompactionResponse\x12*\n" +
+	"\x11size_before_bytes\x18\x01 \x01(\x03R\x0fsizeBeforeBytes\x12(\n" +
+	"\x10size_after_bytes\x18\x02 \x01(\x03R\x0esizeAfterBytes\x12\x1f\n" +
+	"\vduration_ms\x18\x03 \x01(\x03R\n" +
+	"durationMs\"K\n" +
+	"\x0eHistoryRequest\x12#\n" +
+	"\rlanguage_code\x18\x01 \x01(\tR\flanguageCode\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xac\x01\n" +
+	"\vVoiceChange\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\x12\x1b\n" +
+	"\told_voice\x18\x02 \x01(\tR\boldVoice\x12\x1b\n" +
+	"\tnew_voice\x18\x03 \x01(\tR\bnewVoice\x12\x1f\n" +
+	"\vdetected_at\x18\x04 \x01(\x03R\n" +
+	"detectedAt\x12*\n" +
+	"\x11old_voice_entries\x18\x05 \x01(\x03R\x0foldVoiceEntries\"H\n" +
+	"\x1aVoiceChangeHistoryResponse\x12*\n" +
+	"\achanges\x18\x01 \x03(\v2\x10.tts.VoiceChangeR\achanges\"\x10\n" +
+	"\x0eRefreshRequest\"U\n" +
+	"\x0fRefreshResponse\x12\x1f\n" +
+	"\vvoice_count\x18\x01 \x01(\x05R\n" +
+	"voiceCount\x12!\n" +
+	"\flocale_count\x18\x02 \x01(\x05R\vlocaleCount\"\xa2\x01\n" +
+	"\x12ListLocalesRequest\x123\n" +
+	"\x16has_azure_voice_filter\x18\x01 \x01(\bR\x13hasAzureVoiceFilter\x129\n" +
+	"\x19has_cached_content_filter\x18\x02 \x01(\bR\x16hasCachedContentFilter\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"\xe9\x01\n" +
+	"\n" +
+	"LocaleInfo\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12&\n" +
+	"\x0fhas_azure_voice\x18\x03 \x01(\bR\rhasAzureVoice\x12%\n" +
+	"\x0ecached_entries\x18\x04 \x01(\x03R\rcachedEntries\x12,\n" +
+	"\x12total_cached_bytes\x18\x05 \x01(\x03R\x10totalCachedBytes\x12#\n" +
+	"\rdefault_voice\x18\x06 \x01(\tR\fdefaultVoice\"@\n" +
+	"\x13ListLocalesResponse\x12)\n" +
+	"\alocales\x18\x01 \x03(\v2\x0f.tts.LocaleInfoR\alocales\"4\n" +
+	"\x14GetCacheStatsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\x95\x02\n" +
+	"\rLanguageStats\x12#\n" +
+	"\rlanguage_code\x18\x01 \x01(\tR\flanguageCode\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x12!\n" +
+	"\foldest_entry\x18\x04 \x01(\x03R\voldestEntry\x12!\n" +
+	"\fnewest_entry\x18\x05 \x01(\x03R\vnewestEntry\x12\x1e\n" +
+	"\vhit_rate_1m\x18\x06 \x01(\x01R\thitRate1m\x12\x1e\n" +
+	"\vhit_rate_5m\x18\a \x01(\x01R\thitRate5m\x12$\n" +
+	"\x0ehit_rate_total\x18\b \x01(\x01R\fhitRateTotal\"\xd8\x03\n" +
+	"\x12CacheStatsResponse\x12\x1f\n" +
+	"\vtotal_clips\x18\x01 \x01(\x03R\n" +
+	"totalClips\x12(\n" +
+	"\x10total_size_bytes\x18\x02 \x01(\x03R\x0etotalSizeBytes\x12\x1e\n" +
+	"\vmax_size_mb\x18\x03 \x01(\x01R\tmaxSizeMb\x12#\n" +
+	"\rusage_percent\x18\x04 \x01(\x01R\fusagePercent\x12#\n" +
+	"\rexpired_clips\x18\x05 \x01(\x03R\fexpiredClips\x12)\n" +
+	"\x10synthesis_paused\x18\x06 \x01(\bR\x0fsynthesisPaused\x12!\n" +
+	"\fpause_reason\x18\a \x01(\tR\vpauseReason\x120\n" +
+	"\tlanguages\x18\b \x03(\v2\x12.tts.LanguageStatsR\tlanguages\x12\x1e\n" +
+	"\vhit_rate_1m\x18\t \x01(\x01R\thitRate1m\x12\x1e\n" +
+	"\vhit_rate_5m\x18\n" +
+	" \x01(\x01R\thitRate5m\x12$\n" +
+	"\x0ehit_rate_total\x18\v \x01(\x01R\fhitRateTotal\x12'\n" +
+	"\x10hit_rate_1m_note\x18\f \x01(\tR\rhitRate1mNote\"9\n" +
+	"\x12ConsistencyRequest\x12#\n" +
+	"\rlanguage_code\x18\x01 \x01(\tR\flanguageCode\"\x9c\x01\n" +
+	"\rInconsistency\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\x12#\n" +
+	"\rcurrent_voice\x18\x02 \x01(\tR\fcurrentVoice\x12#\n" +
+	"\rcached_voices\x18\x03 \x03(\tR\fcachedVoices\x12)\n" +
+	"\x10affected_entries\x18\x04 \x01(\x03R\x0faffectedEntries\"S\n" +
+	"\x13ConsistencyResponse\x12<\n" +
+	"\x0finconsistencies\x18\x01 \x03(\v2\x12.tts.InconsistencyR\x0finconsistencies\"^\n" +
+	"\x0eHeatmapRequest\x12/\n" +
+	"\x13granularity_minutes\x18\x01 \x01(\x05R\x12granularityMinutes\x12\x1b\n" +
+	"\tdays_back\x18\x02 \x01(\x05R\bdaysBack\"\xa0\x01\n" +
+	"\rHeatmapBucket\x12\x1e\n" +
+	"\vhour_of_day\x18\x01 \x01(\x05R\thourOfDay\x12$\n" +
+	"\x0eminute_of_hour\x18\x02 \x01(\x05R\fminuteOfHour\x12!\n" +
+	"\faccess_count\x18\x03 \x01(\x03R\vaccessCount\x12&\n" +
+	"\x0fcache_hit_count\x18\x04 \x01(\x03R\rcacheHitCount\"\x8d\x01\n" +
+	"\x0fHeatmapResponse\x12,\n" +
+	"\abuckets\x18\x01 \x03(\v2\x12.tts.HeatmapBucketR\abuckets\x12/\n" +
+	"\x13granularity_minutes\x18\x02 \x01(\x05R\x12granularityMinutes\x12\x1b\n" +
+	"\tdays_back\x18\x03 \x01(\x05R\bdaysBack\"7\n" +
+	"\x0fRLStatusRequest\x12$\n" +
+	"\x0ewait_for_token\x18\x01 \x01(\bR\fwaitForToken\"\xfe\x01\n" +
+	"\x10RLStatusResponse\x12%\n" +
+	"\x0ecurrent_tokens\x18\x01 \x01(\x01R\rcurrentTokens\x12\x1d\n" +
+	"\n" +
+	"max_tokens\x18\x02 \x01(\x01R\tmaxTokens\x12&\n" +
+	"\x0frate_per_second\x18\x03 \x01(\x01R\rratePerSecond\x125\n" +
+	"\x17next_token_available_ms\x18\x04 \x01(\x03R\x14nextTokenAvailableMs\x12(\n" +
+	"\x10daily_chars_used\x18\x05 \x01(\x03R\x0edailyCharsUsed\x12\x1b\n" +
+	"\twaited_ms\x18\x06 \x01(\x03R\bwaitedMs\"e\n" +
+	"\x0eEnqueueRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12#\n" +
+	"\rlanguage_code\x18\x02 \x01(\tR\flanguageCode\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x05R\bpriority\"(\n" +
+	"\x0fEnqueueResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\")\n" +
+	"\x10JobStatusRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\xf6\x01\n" +
+	"\tJobStatus\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12#\n" +
+	"\rlanguage_code\x18\x04 \x01(\tR\flanguageCode\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12!\n" +
+	"\fcompleted_at\x18\a \x01(\x03R\vcompletedAt\x12#\n" +
+	"\rerror_message\x18\b \x01(\tR\ferrorMessage\"J\n" +
+	"\x0ePriorityUpdate\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12!\n" +
+	"\fnew_priority\x18\x02 \x01(\x05R\vnewPriority\"?\n" +
+	"\x0eReorderRequest\x12-\n" +
+	"\aupdates\x18\x01 \x03(\v2\x13.tts.PriorityUpdateR\aupdates\"Z\n" +
+	"\x0fReorderResponse\x12#\n" +
+	"\rupdated_count\x18\x01 \x01(\x05R\fupdatedCount\x12\"\n" +
+	"\rnot_found_ids\x18\x02 \x03(\tR\vnotFoundIds\"\x10\n" +
+	"\x0eMetricsRequest\"5\n" +
+	"\tLabelPair\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"<\n" +
+	"\bQuantile\x12\x1a\n" +
+	"\bquantile\x18\x01 \x01(\x01R\bquantile\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\"T\n" +
+	"\x06Bucket\x12\x1f\n" +
+	"\vupper_bound\x18\x01 \x01(\x01R\n" +
+	"upperBound\x12)\n" +
+	"\x10cumulative_count\x18\x02 \x01(\x04R\x0fcumulativeCount\"\xff\x01\n" +
+	"\x06Metric\x12&\n" +
+	"\x06labels\x18\x01 \x03(\v2\x0e.tts.LabelPairR\x06labels\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\x12!\n" +
+	"\ftimestamp_ms\x18\x03 \x01(\x03R\vtimestampMs\x12!\n" +
+	"\fsample_count\x18\x04 \x01(\x04R\vsampleCount\x12\x1d\n" +
+	"\n" +
+	"sample_sum\x18\x05 \x01(\x01R\tsampleSum\x12+\n" +
+	"\tquantiles\x18\x06 \x03(\v2\r.tts.QuantileR\tquantiles\x12%\n" +
+	"\abuckets\x18\a \x03(\v2\v.tts.BucketR\abuckets\"\x82\x01\n" +
+	"\fMetricFamily\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04help\x18\x02 \x01(\tR\x04help\x12#\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x0f.tts.MetricTypeR\x04type\x12%\n" +
+	"\ametrics\x18\x04 \x03(\v2\v.tts.MetricR\ametrics\"@\n" +
+	"\x0fMetricsResponse\x12-\n" +
+	"\bfamilies\x18\x01 \x03(\v2\x11.tts.MetricFamilyR\bfamilies*V\n" +
+	"\fOutputFormat\x12\a\n" +
+	"\x03MP3\x10\x00\x12\v\n" +
+	"\aWAV_16K\x10\x01\x12\f\n" +
+	"\bOPUS_24K\x10\x02\x12\x10\n" +
+	"\fOGG_OPUS_48K\x10\x03\x12\x10\n" +
+	"\fOGG_OPUS_24K\x10\x04*M\n" +
+	"\n" +
+	"MetricType\x12\v\n" +
+	"\aCOUNTER\x10\x00\x12\t\n" +
+	"\x05GAUGE\x10\x01\x12\v\n" +
+	"\aSUMMARY\x10\x02\x12\v\n" +
+	"\aUNTYPED\x10\x03\x12\r\n" +
+	"\tHISTOGRAM\x10\x042\xc7\x15\n" +
 	"\n" +
 	"TTSService\x12-\n" +
-	"\bFetchTTS\x12\x0f.tts.TTSRequest\x1a\x10.tts.TTSResponse\x129\n" +
-	"\fBulkFetchTTS\x12\x13.tts.BulkTTSRequest\x1a\x14.tts.BulkTTSResponse\x12-\n" +
-	"\aPlayTTS\x12\x0f.tts.TTSRequest\x1a\x11.tts.PlayResponse\x123\n" +
+	"\bFetchTTS\x12\x0f.tts.TTSRequest\x1a\x10.tts.TTSResponse\x12/\n" +
+	"\tStreamTTS\x12\x0f.tts.TTSRequest\x1a\x0f.tts.AudioChunk0\x01\x12;\n" +
+	"\x11FetchWithFallback\x12\x14.tts.FallbackRequest\x1a\x10.tts.TTSResponse\x12C\n" +
+	"\fFetchAndSave\x12\x18.tts.FetchAndSaveRequest\x1a\x19.tts.FetchAndSaveResponse\x129\n" +
+	"\fBulkFetchTTS\x12\x13.tts.BulkTTSRequest\x1a\x14.tts.BulkTTSResponse\x12@\n" +
+	"\x12StreamBulkFetchTTS\x12\x13.tts.BulkTTSRequest\x1a\x13.tts.BulkItemResult0\x01\x12<\n" +
+	"\tWarmCache\x12\x15.tts.WarmCacheRequest\x1a\x16.tts.WarmCacheProgress0\x01\x12=\n" +
+	"\x10EnqueueSynthesis\x12\x13.tts.EnqueueRequest\x1a\x14.tts.EnqueueResponse\x125\n" +
+	"\fGetJobStatus\x12\x15.tts.JobStatusRequest\x1a\x0e.tts.JobStatus\x129\n" +
+	"\fReorderQueue\x12\x13.tts.ReorderRequest\x1a\x14.tts.ReorderResponse\x12-\n" +
+	"\aPlayTTS\x12\x0f.tts.TTSRequest\x1a\x11.tts.PlayResponse\x12>\n" +
+	"\x13SynthesizeEphemeral\x12\x0f.tts.TTSRequest\x1a\x16.tts.EphemeralResponse\x123\n" +
 	"\x0eGetCachedAudio\x12\x0f.tts.TTSRequest\x1a\x10.tts.TTSResponse\x124\n" +
-	"\fDeleteCached\x12\x0f.tts.TTSRequest\x1a\x13.tts.DeleteResponseB!Z\x1fcom.biesnecker/tts-daemon/protob\x06proto3"
+	"\fDeleteCached\x12\x0f.tts.TTSRequest\x1a\x13.tts.DeleteResponse\x12F\n" +
+	"\rDeletePattern\x12\x19.tts.DeletePatternRequest\x1a\x1a.tts.DeletePatternResponse\x12O\n" +
+	"\x10DeleteByLanguage\x12\x1c.tts.DeleteByLanguageRequest\x1a\x1d.tts.DeleteByLanguageResponse\x12@\n" +
+	"\vDeleteByTag\x12\x17.tts.DeleteByTagRequest\x1a\x18.tts.DeleteByTagResponse\x12R\n" +
+	"\x11NormalizationDiff\x12\x1d.tts.NormalizationDiffRequest\x1a\x1e.tts.NormalizationDiffResponse\x12=\n" +
+	"\fSelfDiagnose\x12\x16.tts.DiagnosticRequest\x1a\x15.tts.DiagnosticReport\x122\n" +
+	"\n" +
+	"WatchCache\x12\x11.tts.WatchRequest\x1a\x0f.tts.CacheEvent0\x01\x12O\n" +
+	"\x10ListCacheEntries\x12\x1c.tts.ListCacheEntriesRequest\x1a\x1d.tts.ListCacheEntriesResponse\x12F\n" +
+	"\rGetCacheEntry\x12\x19.tts.GetCacheEntryRequest\x1a\x1a.tts.GetCacheEntryResponse\x12B\n" +
+	"\x10DeleteCacheEntry\x12\x19.tts.GetCacheEntryRequest\x1a\x13.tts.DeleteResponse\x120\n" +
+	"\x05Clone\x12\x11.tts.CloneRequest\x1a\x12.tts.CloneProgress0\x01\x12:\n" +
+	"\vExportCache\x12\x17.tts.ExportCacheRequest\x1a\x10.tts.ExportChunk0\x01\x12;\n" +
+	"\vImportCache\x12\x10.tts.ImportChunk\x1a\x18.tts.ImportCacheResponse(\x01\x12H\n" +
+	"\x0fResynthesizeAll\x12\x18.tts.ResynthesizeRequest\x1a\x19.tts.ResynthesizeProgress0\x01\x12;\n" +
+	"\rGetDedupStats\x12\x11.tts.StatsRequest\x1a\x17.tts.DedupStatsResponse\x12D\n" +
+	"\x0fVerifyIntegrity\x12\x1b.tts.VerifyIntegrityRequest\x1a\x14.tts.IntegrityReport\x12@\n" +
+	"\vVerifyCache\x12\x17.tts.VerifyCacheRequest\x1a\x18.tts.VerifyCacheResponse\x12M\n" +
+	"\x12FindNearDuplicates\x12\x1a.tts.NearDuplicatesRequest\x1a\x1b.tts.NearDuplicatesResponse\x127\n" +
+	"\x0ePauseSynthesis\x12\x11.tts.PauseRequest\x1a\x12.tts.PauseResponse\x12:\n" +
+	"\x0fResumeSynthesis\x12\x12.tts.ResumeRequest\x1a\x13.tts.ResumeResponse\x124\n" +
+	"\vSetDraining\x12\x11.tts.DrainRequest\x1a\x12.tts.DrainResponse\x12@\n" +
+	"\rRunCompaction\x12\x16.tts.CompactionRequest\x1a\x17.tts.CompactionResponse\x12M\n" +
+	"\x15GetVoiceChangeHistory\x12\x13.tts.HistoryRequest\x1a\x1f.tts.VoiceChangeHistoryResponse\x12=\n" +
+	"\x10RefreshVoiceList\x12\x13.tts.RefreshRequest\x1a\x14.tts.RefreshResponse\x12<\n" +
+	"\x0fGetCacheHeatmap\x12\x13.tts.HeatmapRequest\x1a\x14.tts.HeatmapResponse\x12A\n" +
+	"\x12GetRateLimitStatus\x12\x14.tts.RLStatusRequest\x1a\x15.tts.RLStatusResponse\x12J\n" +
+	"\x15CheckVoiceConsistency\x12\x17.tts.ConsistencyRequest\x1a\x18.tts.ConsistencyResponse\x12:\n" +
+	"\rExportMetrics\x12\x13.tts.MetricsRequest\x1a\x14.tts.MetricsResponse\x12@\n" +
+	"\vListLocales\x12\x17.tts.ListLocalesRequest\x1a\x18.tts.ListLocalesResponse\x12C\n" +
+	"\rGetCacheStats\x12\x19.tts.GetCacheStatsRequest\x1a\x17.tts.CacheStatsResponseB!Z\x1fcom.biesnecker/tts-daemon/protob\x06proto3"
 
 var (
 	file_proto_tts_proto_rawDescOnce sync.Once
@@ -413,33 +6491,228 @@ func file_proto_tts_proto_rawDescGZIP() []byte {
 	return file_proto_tts_proto_rawDescData
 }
 
-var file_proto_tts_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_tts_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_tts_proto_msgTypes = make([]protoimpl.MessageInfo, 95)
 var file_proto_tts_proto_goTypes = []any{
-	(*TTSRequest)(nil),      // 0: tts.TTSRequest
-	(*BulkTTSRequest)(nil),  // 1: tts.BulkTTSRequest
-	(*TTSResponse)(nil),     // 2: tts.TTSResponse
-	(*BulkTTSResponse)(nil), // 3: tts.BulkTTSResponse
-	(*PlayResponse)(nil),    // 4: tts.PlayResponse
-	(*DeleteResponse)(nil),  // 5: tts.DeleteResponse
+	(OutputFormat)(0),                  // 0: tts.OutputFormat
+	(MetricType)(0),                    // 1: tts.MetricType
+	(*TTSRequest)(nil),                 // 2: tts.TTSRequest
+	(*BulkTTSRequest)(nil),             // 3: tts.BulkTTSRequest
+	(*WarmCacheRequest)(nil),           // 4: tts.WarmCacheRequest
+	(*WarmCacheProgress)(nil),          // 5: tts.WarmCacheProgress
+	(*TTSResponse)(nil),                // 6: tts.TTSResponse
+	(*AudioChunk)(nil),                 // 7: tts.AudioChunk
+	(*TextStats)(nil),                  // 8: tts.TextStats
+	(*EphemeralResponse)(nil),          // 9: tts.EphemeralResponse
+	(*FallbackRequest)(nil),            // 10: tts.FallbackRequest
+	(*FetchAndSaveRequest)(nil),        // 11: tts.FetchAndSaveRequest
+	(*FetchAndSaveResponse)(nil),       // 12: tts.FetchAndSaveResponse
+	(*BulkTTSResponse)(nil),            // 13: tts.BulkTTSResponse
+	(*BulkItemResult)(nil),             // 14: tts.BulkItemResult
+	(*PlayResponse)(nil),               // 15: tts.PlayResponse
+	(*DeleteResponse)(nil),             // 16: tts.DeleteResponse
+	(*NormalizationDiffRequest)(nil),   // 17: tts.NormalizationDiffRequest
+	(*NormalizationDiffResponse)(nil),  // 18: tts.NormalizationDiffResponse
+	(*DiagnosticRequest)(nil),          // 19: tts.DiagnosticRequest
+	(*DiagnosticCheck)(nil),            // 20: tts.DiagnosticCheck
+	(*DiagnosticReport)(nil),           // 21: tts.DiagnosticReport
+	(*WatchRequest)(nil),               // 22: tts.WatchRequest
+	(*CacheEvent)(nil),                 // 23: tts.CacheEvent
+	(*CacheEntryInfo)(nil),             // 24: tts.CacheEntryInfo
+	(*ListCacheEntriesRequest)(nil),    // 25: tts.ListCacheEntriesRequest
+	(*ListCacheEntriesResponse)(nil),   // 26: tts.ListCacheEntriesResponse
+	(*GetCacheEntryRequest)(nil),       // 27: tts.GetCacheEntryRequest
+	(*GetCacheEntryResponse)(nil),      // 28: tts.GetCacheEntryResponse
+	(*CloneRequest)(nil),               // 29: tts.CloneRequest
+	(*CloneProgress)(nil),              // 30: tts.CloneProgress
+	(*ExportCacheRequest)(nil),         // 31: tts.ExportCacheRequest
+	(*ExportChunk)(nil),                // 32: tts.ExportChunk
+	(*ImportChunk)(nil),                // 33: tts.ImportChunk
+	(*ImportCacheResponse)(nil),        // 34: tts.ImportCacheResponse
+	(*ResynthesizeRequest)(nil),        // 35: tts.ResynthesizeRequest
+	(*ResynthesizeProgress)(nil),       // 36: tts.ResynthesizeProgress
+	(*StatsRequest)(nil),               // 37: tts.StatsRequest
+	(*DedupEvent)(nil),                 // 38: tts.DedupEvent
+	(*DedupStatsResponse)(nil),         // 39: tts.DedupStatsResponse
+	(*DeletePatternRequest)(nil),       // 40: tts.DeletePatternRequest
+	(*DeletePatternResponse)(nil),      // 41: tts.DeletePatternResponse
+	(*DeleteByTagRequest)(nil),         // 42: tts.DeleteByTagRequest
+	(*DeleteByTagResponse)(nil),        // 43: tts.DeleteByTagResponse
+	(*DeleteByLanguageRequest)(nil),    // 44: tts.DeleteByLanguageRequest
+	(*DeleteByLanguageResponse)(nil),   // 45: tts.DeleteByLanguageResponse
+	(*VerifyIntegrityRequest)(nil),     // 46: tts.VerifyIntegrityRequest
+	(*CacheEntryRef)(nil),              // 47: tts.CacheEntryRef
+	(*CollisionGroup)(nil),             // 48: tts.CollisionGroup
+	(*KeyMismatch)(nil),                // 49: tts.KeyMismatch
+	(*IntegrityReport)(nil),            // 50: tts.IntegrityReport
+	(*VerifyCacheRequest)(nil),         // 51: tts.VerifyCacheRequest
+	(*VerifyCacheResponse)(nil),        // 52: tts.VerifyCacheResponse
+	(*NearDuplicatesRequest)(nil),      // 53: tts.NearDuplicatesRequest
+	(*NearDuplicateGroup)(nil),         // 54: tts.NearDuplicateGroup
+	(*NearDuplicatesResponse)(nil),     // 55: tts.NearDuplicatesResponse
+	(*PauseRequest)(nil),               // 56: tts.PauseRequest
+	(*PauseResponse)(nil),              // 57: tts.PauseResponse
+	(*ResumeRequest)(nil),              // 58: tts.ResumeRequest
+	(*ResumeResponse)(nil),             // 59: tts.ResumeResponse
+	(*DrainRequest)(nil),               // 60: tts.DrainRequest
+	(*DrainResponse)(nil),              // 61: tts.DrainResponse
+	(*CompactionRequest)(nil),          // 62: tts.CompactionRequest
+	(*CompactionResponse)(nil),         // 63: tts.CompactionResponse
+	(*HistoryRequest)(nil),             // 64: tts.HistoryRequest
+	(*VoiceChange)(nil),                // 65: tts.VoiceChange
+	(*VoiceChangeHistoryResponse)(nil), // 66: tts.VoiceChangeHistoryResponse
+	(*RefreshRequest)(nil),             // 67: tts.RefreshRequest
+	(*RefreshResponse)(nil),            // 68: tts.RefreshResponse
+	(*ListLocalesRequest)(nil),         // 69: tts.ListLocalesRequest
+	(*LocaleInfo)(nil),                 // 70: tts.LocaleInfo
+	(*ListLocalesResponse)(nil),        // 71: tts.ListLocalesResponse
+	(*GetCacheStatsRequest)(nil),       // 72: tts.GetCacheStatsRequest
+	(*LanguageStats)(nil),              // 73: tts.LanguageStats
+	(*CacheStatsResponse)(nil),         // 74: tts.CacheStatsResponse
+	(*ConsistencyRequest)(nil),         // 75: tts.ConsistencyRequest
+	(*Inconsistency)(nil),              // 76: tts.Inconsistency
+	(*ConsistencyResponse)(nil),        // 77: tts.ConsistencyResponse
+	(*HeatmapRequest)(nil),             // 78: tts.HeatmapRequest
+	(*HeatmapBucket)(nil),              // 79: tts.HeatmapBucket
+	(*HeatmapResponse)(nil),            // 80: tts.HeatmapResponse
+	(*RLStatusRequest)(nil),            // 81: tts.RLStatusRequest
+	(*RLStatusResponse)(nil),           // 82: tts.RLStatusResponse
+	(*EnqueueRequest)(nil),             // 83: tts.EnqueueRequest
+	(*EnqueueResponse)(nil),            // 84: tts.EnqueueResponse
+	(*JobStatusRequest)(nil),           // 85: tts.JobStatusRequest
+	(*JobStatus)(nil),                  // 86: tts.JobStatus
+	(*PriorityUpdate)(nil),             // 87: tts.PriorityUpdate
+	(*ReorderRequest)(nil),             // 88: tts.ReorderRequest
+	(*ReorderResponse)(nil),            // 89: tts.ReorderResponse
+	(*MetricsRequest)(nil),             // 90: tts.MetricsRequest
+	(*LabelPair)(nil),                  // 91: tts.LabelPair
+	(*Quantile)(nil),                   // 92: tts.Quantile
+	(*Bucket)(nil),                     // 93: tts.Bucket
+	(*Metric)(nil),                     // 94: tts.Metric
+	(*MetricFamily)(nil),               // 95: tts.MetricFamily
+	(*MetricsResponse)(nil),            // 96: tts.MetricsResponse
 }
 var file_proto_tts_proto_depIdxs = []int32{
-	0, // 0: tts.BulkTTSRequest.requests:type_name -> tts.TTSRequest
-	2, // 1: tts.BulkTTSResponse.responses:type_name -> tts.TTSResponse
-	0, // 2: tts.TTSService.FetchTTS:input_type -> tts.TTSRequest
-	1, // 3: tts.TTSService.BulkFetchTTS:input_type -> tts.BulkTTSRequest
-	0, // 4: tts.TTSService.PlayTTS:input_type -> tts.TTSRequest
-	0, // 5: tts.TTSService.GetCachedAudio:input_type -> tts.TTSRequest
-	0, // 6: tts.TTSService.DeleteCached:input_type -> tts.TTSRequest
-	2, // 7: tts.TTSService.FetchTTS:output_type -> tts.TTSResponse
-	3, // 8: tts.TTSService.BulkFetchTTS:output_type -> tts.BulkTTSResponse
-	4, // 9: tts.TTSService.PlayTTS:output_type -> tts.PlayResponse
-	2, // 10: tts.TTSService.GetCachedAudio:output_type -> tts.TTSResponse
-	5, // 11: tts.TTSService.DeleteCached:output_type -> tts.DeleteResponse
-	7, // [7:12] is the sub-list for method output_type
-	2, // [2:7] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: tts.TTSRequest.output_format:type_name -> tts.OutputFormat
+	2,  // 1: tts.BulkTTSRequest.requests:type_name -> tts.TTSRequest
+	2,  // 2: tts.WarmCacheRequest.requests:type_name -> tts.TTSRequest
+	8,  // 3: tts.TTSResponse.text_stats:type_name -> tts.TextStats
+	2,  // 4: tts.FallbackRequest.request:type_name -> tts.TTSRequest
+	2,  // 5: tts.FetchAndSaveRequest.request:type_name -> tts.TTSRequest
+	6,  // 6: tts.BulkTTSResponse.responses:type_name -> tts.TTSResponse
+	6,  // 7: tts.BulkItemResult.response:type_name -> tts.TTSResponse
+	20, // 8: tts.DiagnosticReport.checks:type_name -> tts.DiagnosticCheck
+	24, // 9: tts.ListCacheEntriesResponse.entries:type_name -> tts.CacheEntryInfo
+	24, // 10: tts.GetCacheEntryResponse.entry:type_name -> tts.CacheEntryInfo
+	38, // 11: tts.DedupStatsResponse.recent_events:type_name -> tts.DedupEvent
+	47, // 12: tts.CollisionGroup.entries:type_name -> tts.CacheEntryRef
+	48, // 13: tts.IntegrityReport.collisions:type_name -> tts.CollisionGroup
+	49, // 14: tts.IntegrityReport.mismatches:type_name -> tts.KeyMismatch
+	24, // 15: tts.NearDuplicateGroup.entries:type_name -> tts.CacheEntryInfo
+	54, // 16: tts.NearDuplicatesResponse.groups:type_name -> tts.NearDuplicateGroup
+	65, // 17: tts.VoiceChangeHistoryResponse.changes:type_name -> tts.VoiceChange
+	70, // 18: tts.ListLocalesResponse.locales:type_name -> tts.LocaleInfo
+	73, // 19: tts.CacheStatsResponse.languages:type_name -> tts.LanguageStats
+	76, // 20: tts.ConsistencyResponse.inconsistencies:type_name -> tts.Inconsistency
+	79, // 21: tts.HeatmapResponse.buckets:type_name -> tts.HeatmapBucket
+	87, // 22: tts.ReorderRequest.updates:type_name -> tts.PriorityUpdate
+	91, // 23: tts.Metric.labels:type_name -> tts.LabelPair
+	92, // 24: tts.Metric.quantiles:type_name -> tts.Quantile
+	93, // 25: tts.Metric.buckets:type_name -> tts.Bucket
+	1,  // 26: tts.MetricFamily.type:type_name -> tts.MetricType
+	94, // 27: tts.MetricFamily.metrics:type_name -> tts.Metric
+	95, // 28: tts.MetricsResponse.families:type_name -> tts.MetricFamily
+	2,  // 29: tts.TTSService.FetchTTS:input_type -> tts.TTSRequest
+	2,  // 30: tts.TTSService.StreamTTS:input_type -> tts.TTSRequest
+	10, // 31: tts.TTSService.FetchWithFallback:input_type -> tts.FallbackRequest
+	11, // 32: tts.TTSService.FetchAndSave:input_type -> tts.FetchAndSaveRequest
+	3,  // 33: tts.TTSService.BulkFetchTTS:input_type -> tts.BulkTTSRequest
+	3,  // 34: tts.TTSService.StreamBulkFetchTTS:input_type -> tts.BulkTTSRequest
+	4,  // 35: tts.TTSService.WarmCache:input_type -> tts.WarmCacheRequest
+	83, // 36: tts.TTSService.EnqueueSynthesis:input_type -> tts.EnqueueRequest
+	85, // 37: tts.TTSService.GetJobStatus:input_type -> tts.JobStatusRequest
+	88, // 38: tts.TTSService.ReorderQueue:input_type -> tts.ReorderRequest
+	2,  // 39: tts.TTSService.PlayTTS:input_type -> tts.TTSRequest
+	2,  // 40: tts.TTSService.SynthesizeEphemeral:input_type -> tts.TTSRequest
+	2,  // 41: tts.TTSService.GetCachedAudio:input_type -> tts.TTSRequest
+	2,  // 42: tts.TTSService.DeleteCached:input_type -> tts.TTSRequest
+	40, // 43: tts.TTSService.DeletePattern:input_type -> tts.DeletePatternRequest
+	44, // 44: tts.TTSService.DeleteByLanguage:input_type -> tts.DeleteByLanguageRequest
+	42, // 45: tts.TTSService.DeleteByTag:input_type -> tts.DeleteByTagRequest
+	17, // 46: tts.TTSService.NormalizationDiff:input_type -> tts.NormalizationDiffRequest
+	19, // 47: tts.TTSService.SelfDiagnose:input_type -> tts.DiagnosticRequest
+	22, // 48: tts.TTSService.WatchCache:input_type -> tts.WatchRequest
+	25, // 49: tts.TTSService.ListCacheEntries:input_type -> tts.ListCacheEntriesRequest
+	27, // 50: tts.TTSService.GetCacheEntry:input_type -> tts.GetCacheEntryRequest
+	27, // 51: tts.TTSService.DeleteCacheEntry:input_type -> tts.GetCacheEntryRequest
+	29, // 52: tts.TTSService.Clone:input_type -> tts.CloneRequest
+	31, // 53: tts.TTSService.ExportCache:input_type -> tts.ExportCacheRequest
+	33, // 54: tts.TTSService.ImportCache:input_type -> tts.ImportChunk
+	35, // 55: tts.TTSService.ResynthesizeAll:input_type -> tts.ResynthesizeRequest
+	37, // 56: tts.TTSService.GetDedupStats:input_type -> tts.StatsRequest
+	46, // 57: tts.TTSService.VerifyIntegrity:input_type -> tts.VerifyIntegrityRequest
+	51, // 58: tts.TTSService.VerifyCache:input_type -> tts.VerifyCacheRequest
+	53, // 59: tts.TTSService.FindNearDuplicates:input_type -> tts.NearDuplicatesRequest
+	56, // 60: tts.TTSService.PauseSynthesis:input_type -> tts.PauseRequest
+	58, // 61: tts.TTSService.ResumeSynthesis:input_type -> tts.ResumeRequest
+	60, // 62: tts.TTSService.SetDraining:input_type -> tts.DrainRequest
+	62, // 63: tts.TTSService.RunCompaction:input_type -> tts.CompactionRequest
+	64, // 64: tts.TTSService.GetVoiceChangeHistory:input_type -> tts.HistoryRequest
+	67, // 65: tts.TTSService.RefreshVoiceList:input_type -> tts.RefreshRequest
+	78, // 66: tts.TTSService.GetCacheHeatmap:input_type -> tts.HeatmapRequest
+	81, // 67: tts.TTSService.GetRateLimitStatus:input_type -> tts.RLStatusRequest
+	75, // 68: tts.TTSService.CheckVoiceConsistency:input_type -> tts.ConsistencyRequest
+	90, // 69: tts.TTSService.ExportMetrics:input_type -> tts.MetricsRequest
+	69, // 70: tts.TTSService.ListLocales:input_type -> tts.ListLocalesRequest
+	72, // 71: tts.TTSService.GetCacheStats:input_type -> tts.GetCacheStatsRequest
+	6,  // 72: tts.TTSService.FetchTTS:output_type -> tts.TTSResponse
+	7,  // 73: tts.TTSService.StreamTTS:output_type -> tts.AudioChunk
+	6,  // 74: tts.TTSService.FetchWithFallback:output_type -> tts.TTSResponse
+	12, // 75: tts.TTSService.FetchAndSave:output_type -> tts.FetchAndSaveResponse
+	13, // 76: tts.TTSService.BulkFetchTTS:output_type -> tts.BulkTTSResponse
+	14, // 77: tts.TTSService.StreamBulkFetchTTS:output_type -> tts.BulkItemResult
+	5,  // 78: tts.TTSService.WarmCache:output_type -> tts.WarmCacheProgress
+	84, // 79: tts.TTSService.EnqueueSynthesis:output_type -> tts.EnqueueResponse
+	86, // 80: tts.TTSService.GetJobStatus:output_type -> tts.JobStatus
+	89, // 81: tts.TTSService.ReorderQueue:output_type -> tts.ReorderResponse
+	15, // 82: tts.TTSService.PlayTTS:output_type -> tts.PlayResponse
+	9,  // 83: tts.TTSService.SynthesizeEphemeral:output_type -> tts.EphemeralResponse
+	6,  // 84: tts.TTSService.GetCachedAudio:output_type -> tts.TTSResponse
+	16, // 85: tts.TTSService.DeleteCached:output_type -> tts.DeleteResponse
+	41, // 86: tts.TTSService.DeletePattern:output_type -> tts.DeletePatternResponse
+	45, // 87: tts.TTSService.DeleteByLanguage:output_type -> tts.DeleteByLanguageResponse
+	43, // 88: tts.TTSService.DeleteByTag:output_type -> tts.DeleteByTagResponse
+	18, // 89: tts.TTSService.NormalizationDiff:output_type -> tts.NormalizationDiffResponse
+	21, // 90: tts.TTSService.SelfDiagnose:output_type -> tts.DiagnosticReport
+	23, // 91: tts.TTSService.WatchCache:output_type -> tts.CacheEvent
+	26, // 92: tts.TTSService.ListCacheEntries:output_type -> tts.ListCacheEntriesResponse
+	28, // 93: tts.TTSService.GetCacheEntry:output_type -> tts.GetCacheEntryResponse
+	16, // 94: tts.TTSService.DeleteCacheEntry:output_type -> tts.DeleteResponse
+	30, // 95: tts.TTSService.Clone:output_type -> tts.CloneProgress
+	32, // 96: tts.TTSService.ExportCache:output_type -> tts.ExportChunk
+	34, // 97: tts.TTSService.ImportCache:output_type -> tts.ImportCacheResponse
+	36, // 98: tts.TTSService.ResynthesizeAll:output_type -> tts.ResynthesizeProgress
+	39, // 99: tts.TTSService.GetDedupStats:output_type -> tts.DedupStatsResponse
+	50, // 100: tts.TTSService.VerifyIntegrity:output_type -> tts.IntegrityReport
+	52, // 101: tts.TTSService.VerifyCache:output_type -> tts.VerifyCacheResponse
+	55, // 102: tts.TTSService.FindNearDuplicates:output_type -> tts.NearDuplicatesResponse
+	57, // 103: tts.TTSService.PauseSynthesis:output_type -> tts.PauseResponse
+	59, // 104: tts.TTSService.ResumeSynthesis:output_type -> tts.ResumeResponse
+	61, // 105: tts.TTSService.SetDraining:output_type -> tts.DrainResponse
+	63, // 106: tts.TTSService.RunCompaction:output_type -> tts.CompactionResponse
+	66, // 107: tts.TTSService.GetVoiceChangeHistory:output_type -> tts.VoiceChangeHistoryResponse
+	68, // 108: tts.TTSService.RefreshVoiceList:output_type -> tts.RefreshResponse
+	80, // 109: tts.TTSService.GetCacheHeatmap:output_type -> tts.HeatmapResponse
+	82, // 110: tts.TTSService.GetRateLimitStatus:output_type -> tts.RLStatusResponse
+	77, // 111: tts.TTSService.CheckVoiceConsistency:output_type -> tts.ConsistencyResponse
+	96, // 112: tts.TTSService.ExportMetrics:output_type -> tts.MetricsResponse
+	71, // 113: tts.TTSService.ListLocales:output_type -> tts.ListLocalesResponse
+	74, // 114: tts.TTSService.GetCacheStats:output_type -> tts.CacheStatsResponse
+	72, // [72:115] is the sub-list for method output_type
+	29, // [29:72] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_proto_tts_proto_init() }
@@ -452,13 +6725,14 @@ func file_proto_tts_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_tts_proto_rawDesc), len(file_proto_tts_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   95,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_tts_proto_goTypes,
 		DependencyIndexes: file_proto_tts_proto_depIdxs,
+		EnumInfos:         file_proto_tts_proto_enumTypes,
 		MessageInfos:      file_proto_tts_proto_msgTypes,
 	}.Build()
 	File_proto_tts_proto = out.File
@@ -19,11 +19,49 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TTSService_FetchTTS_FullMethodName       = "/tts.TTSService/FetchTTS"
-	TTSService_BulkFetchTTS_FullMethodName   = "/tts.TTSService/BulkFetchTTS"
-	TTSService_PlayTTS_FullMethodName        = "/tts.TTSService/PlayTTS"
-	TTSService_GetCachedAudio_FullMethodName = "/tts.TTSService/GetCachedAudio"
-	TTSService_DeleteCached_FullMethodName   = "/tts.TTSService/DeleteCached"
+	TTSService_FetchTTS_FullMethodName              = "/tts.TTSService/FetchTTS"
+	TTSService_StreamTTS_FullMethodName             = "/tts.TTSService/StreamTTS"
+	TTSService_FetchWithFallback_FullMethodName     = "/tts.TTSService/FetchWithFallback"
+	TTSService_FetchAndSave_FullMethodName          = "/tts.TTSService/FetchAndSave"
+	TTSService_BulkFetchTTS_FullMethodName          = "/tts.TTSService/BulkFetchTTS"
+	TTSService_StreamBulkFetchTTS_FullMethodName    = "/tts.TTSService/StreamBulkFetchTTS"
+	TTSService_WarmCache_FullMethodName             = "/tts.TTSService/WarmCache"
+	TTSService_EnqueueSynthesis_FullMethodName      = "/tts.TTSService/EnqueueSynthesis"
+	TTSService_GetJobStatus_FullMethodName          = "/tts.TTSService/GetJobStatus"
+	TTSService_ReorderQueue_FullMethodName          = "/tts.TTSService/ReorderQueue"
+	TTSService_PlayTTS_FullMethodName               = "/tts.TTSService/PlayTTS"
+	TTSService_SynthesizeEphemeral_FullMethodName   = "/tts.TTSService/SynthesizeEphemeral"
+	TTSService_GetCachedAudio_FullMethodName        = "/tts.TTSService/GetCachedAudio"
+	TTSService_DeleteCached_FullMethodName          = "/tts.TTSService/DeleteCached"
+	TTSService_DeletePattern_FullMethodName         = "/tts.TTSService/DeletePattern"
+	TTSService_DeleteByLanguage_FullMethodName      = "/tts.TTSService/DeleteByLanguage"
+	TTSService_DeleteByTag_FullMethodName           = "/tts.TTSService/DeleteByTag"
+	TTSService_NormalizationDiff_FullMethodName     = "/tts.TTSService/NormalizationDiff"
+	TTSService_SelfDiagnose_FullMethodName          = "/tts.TTSService/SelfDiagnose"
+	TTSService_WatchCache_FullMethodName            = "/tts.TTSService/WatchCache"
+	TTSService_ListCacheEntries_FullMethodName      = "/tts.TTSService/ListCacheEntries"
+	TTSService_GetCacheEntry_FullMethodName         = "/tts.TTSService/GetCacheEntry"
+	TTSService_DeleteCacheEntry_FullMethodName      = "/tts.TTSService/DeleteCacheEntry"
+	TTSService_Clone_FullMethodName                 = "/tts.TTSService/Clone"
+	TTSService_ExportCache_FullMethodName           = "/tts.TTSService/ExportCache"
+	TTSService_ImportCache_FullMethodName           = "/tts.TTSService/ImportCache"
+	TTSService_ResynthesizeAll_FullMethodName       = "/tts.TTSService/ResynthesizeAll"
+	TTSService_GetDedupStats_FullMethodName         = "/tts.TTSService/GetDedupStats"
+	TTSService_VerifyIntegrity_FullMethodName       = "/tts.TTSService/VerifyIntegrity"
+	TTSService_VerifyCache_FullMethodName           = "/tts.TTSService/VerifyCache"
+	TTSService_FindNearDuplicates_FullMethodName    = "/tts.TTSService/FindNearDuplicates"
+	TTSService_PauseSynthesis_FullMethodName        = "/tts.TTSService/PauseSynthesis"
+	TTSService_ResumeSynthesis_FullMethodName       = "/tts.TTSService/ResumeSynthesis"
+	TTSService_SetDraining_FullMethodName           = "/tts.TTSService/SetDraining"
+	TTSService_RunCompaction_FullMethodName         = "/tts.TTSService/RunCompaction"
+	TTSService_GetVoiceChangeHistory_FullMethodName = "/tts.TTSService/GetVoiceChangeHistory"
+	TTSService_RefreshVoiceList_FullMethodName      = "/tts.TTSService/RefreshVoiceList"
+	TTSService_GetCacheHeatmap_FullMethodName       = "/tts.TTSService/GetCacheHeatmap"
+	TTSService_GetRateLimitStatus_FullMethodName    = "/tts.TTSService/GetRateLimitStatus"
+	TTSService_CheckVoiceConsistency_FullMethodName = "/tts.TTSService/CheckVoiceConsistency"
+	TTSService_ExportMetrics_FullMethodName         = "/tts.TTSService/ExportMetrics"
+	TTSService_ListLocales_FullMethodName           = "/tts.TTSService/ListLocales"
+	TTSService_GetCacheStats_FullMethodName         = "/tts.TTSService/GetCacheStats"
 )
 
 // TTSServiceClient is the client API for TTSService service.
@@ -34,14 +72,107 @@ const (
 type TTSServiceClient interface {
 	// FetchTTS fetches and caches audio for the given text
 	FetchTTS(ctx context.Context, in *TTSRequest, opts ...grpc.CallOption) (*TTSResponse, error)
+	// StreamTTS fetches and caches audio like FetchTTS, streaming it back in chunks so that long
+	// texts aren't limited by the maximum message size
+	StreamTTS(ctx context.Context, in *TTSRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AudioChunk], error)
+	// FetchWithFallback returns cached audio right away, and otherwise waits a limited time for
+	// synthesis before returning the cached audio of a fallback text instead
+	FetchWithFallback(ctx context.Context, in *FallbackRequest, opts ...grpc.CallOption) (*TTSResponse, error)
+	// FetchAndSave fetches and caches audio, then writes it to a file on the daemon's machine
+	FetchAndSave(ctx context.Context, in *FetchAndSaveRequest, opts ...grpc.CallOption) (*FetchAndSaveResponse, error)
 	// BulkFetchTTS fetches and caches audio for multiple texts concurrently
 	BulkFetchTTS(ctx context.Context, in *BulkTTSRequest, opts ...grpc.CallOption) (*BulkTTSResponse, error)
+	// StreamBulkFetchTTS fetches multiple texts concurrently, streaming each result as it completes
+	StreamBulkFetchTTS(ctx context.Context, in *BulkTTSRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BulkItemResult], error)
+	// WarmCache fetches and caches multiple texts without returning their audio, streaming the
+	// running totals as each one completes
+	WarmCache(ctx context.Context, in *WarmCacheRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WarmCacheProgress], error)
+	// EnqueueSynthesis queues text for background synthesis and returns immediately with a job ID
+	EnqueueSynthesis(ctx context.Context, in *EnqueueRequest, opts ...grpc.CallOption) (*EnqueueResponse, error)
+	// GetJobStatus reports the progress of a queued synthesis job
+	GetJobStatus(ctx context.Context, in *JobStatusRequest, opts ...grpc.CallOption) (*JobStatus, error)
+	// ReorderQueue changes the priority of pending synthesis jobs
+	ReorderQueue(ctx context.Context, in *ReorderRequest, opts ...grpc.CallOption) (*ReorderResponse, error)
 	// PlayTTS fetches (if needed), caches, and plays audio for the given text
 	PlayTTS(ctx context.Context, in *TTSRequest, opts ...grpc.CallOption) (*PlayResponse, error)
+	// SynthesizeEphemeral synthesizes audio directly from Azure without reading or storing the cache
+	SynthesizeEphemeral(ctx context.Context, in *TTSRequest, opts ...grpc.CallOption) (*EphemeralResponse, error)
 	// GetCachedAudio retrieves audio from cache without fetching
 	GetCachedAudio(ctx context.Context, in *TTSRequest, opts ...grpc.CallOption) (*TTSResponse, error)
 	// DeleteCached removes audio from cache
 	DeleteCached(ctx context.Context, in *TTSRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// DeletePattern removes (or previews removing) every cache entry whose text matches a LIKE pattern
+	DeletePattern(ctx context.Context, in *DeletePatternRequest, opts ...grpc.CallOption) (*DeletePatternResponse, error)
+	// DeleteByLanguage removes every cache entry for a language, e.g. after its voice was updated
+	DeleteByLanguage(ctx context.Context, in *DeleteByLanguageRequest, opts ...grpc.CallOption) (*DeleteByLanguageResponse, error)
+	// DeleteByTag removes every cache entry tagged with a tag (see TTSRequest.tags)
+	DeleteByTag(ctx context.Context, in *DeleteByTagRequest, opts ...grpc.CallOption) (*DeleteByTagResponse, error)
+	// NormalizationDiff shows how two texts are normalized and whether they share a cache key
+	NormalizationDiff(ctx context.Context, in *NormalizationDiffRequest, opts ...grpc.CallOption) (*NormalizationDiffResponse, error)
+	// SelfDiagnose runs health checks against the daemon's subsystems
+	SelfDiagnose(ctx context.Context, in *DiagnosticRequest, opts ...grpc.CallOption) (*DiagnosticReport, error)
+	// WatchCache streams cache changes (puts and deletes) as they happen
+	WatchCache(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CacheEvent], error)
+	// ListCacheEntries pages through cache entries (without audio) in cache key order
+	ListCacheEntries(ctx context.Context, in *ListCacheEntriesRequest, opts ...grpc.CallOption) (*ListCacheEntriesResponse, error)
+	// GetCacheEntry returns a single cache entry, including its audio, by cache key
+	GetCacheEntry(ctx context.Context, in *GetCacheEntryRequest, opts ...grpc.CallOption) (*GetCacheEntryResponse, error)
+	// DeleteCacheEntry deletes the entry stored under a cache key, whatever options it was made with
+	DeleteCacheEntry(ctx context.Context, in *GetCacheEntryRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Clone copies cache entries from another daemon into this one, streaming progress
+	Clone(ctx context.Context, in *CloneRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CloneProgress], error)
+	// ExportCache streams the whole cache as a zip archive of each entry's audio and a JSON file
+	// with its metadata, for seeding other daemons
+	ExportCache(ctx context.Context, in *ExportCacheRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error)
+	// ImportCache adds the entries of an ExportCache archive sent in chunks, keeping entries that
+	// are already cached
+	ImportCache(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportChunk, ImportCacheResponse], error)
+	// ResynthesizeAll re-synthesizes every cache entry for a language, e.g. after a voice model
+	// update, streaming progress after each entry
+	ResynthesizeAll(ctx context.Context, in *ResynthesizeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ResynthesizeProgress], error)
+	// GetDedupStats reports how many Azure calls deduplication of concurrent requests has saved
+	GetDedupStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*DedupStatsResponse, error)
+	// VerifyIntegrity checks that every cache key is unique and matches the key computed from its text
+	VerifyIntegrity(ctx context.Context, in *VerifyIntegrityRequest, opts ...grpc.CallOption) (*IntegrityReport, error)
+	// VerifyCache checks the database file for corruption, e.g. after a power outage, optionally
+	// deleting the corrupt entries it finds
+	VerifyCache(ctx context.Context, in *VerifyCacheRequest, opts ...grpc.CallOption) (*VerifyCacheResponse, error)
+	// FindNearDuplicates groups cache entries whose texts are nearly identical, such as the same
+	// sentence with different punctuation
+	FindNearDuplicates(ctx context.Context, in *NearDuplicatesRequest, opts ...grpc.CallOption) (*NearDuplicatesResponse, error)
+	// PauseSynthesis stops requests from reaching Azure (cached audio is still served), e.g. during
+	// an Azure maintenance window
+	PauseSynthesis(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error)
+	// ResumeSynthesis lets requests reach Azure again after PauseSynthesis
+	ResumeSynthesis(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error)
+	// SetDraining stops the daemon from accepting connections and makes the readiness probe fail,
+	// then stops it once the requests in progress finish (or the drain timeout passes)
+	SetDraining(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainResponse, error)
+	// RunCompaction compacts the cache database with VACUUM now, instead of waiting for
+	// database.compact_schedule
+	RunCompaction(ctx context.Context, in *CompactionRequest, opts ...grpc.CallOption) (*CompactionResponse, error)
+	// GetVoiceChangeHistory lists detected changes to Azure's default voice for each locale
+	GetVoiceChangeHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*VoiceChangeHistoryResponse, error)
+	// RefreshVoiceList reloads the voices Azure offers, which otherwise happens every
+	// azure.voice_cache_refresh_interval_h hours
+	RefreshVoiceList(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResponse, error)
+	// GetCacheHeatmap reports at what times of day (UTC) cache entries were last accessed, e.g. to
+	// schedule maintenance for quiet hours
+	GetCacheHeatmap(ctx context.Context, in *HeatmapRequest, opts ...grpc.CallOption) (*HeatmapResponse, error)
+	// GetRateLimitStatus reports the capacity left in the Azure rate limiter, e.g. for scripts that
+	// check before submitting a large batch
+	GetRateLimitStatus(ctx context.Context, in *RLStatusRequest, opts ...grpc.CallOption) (*RLStatusResponse, error)
+	// CheckVoiceConsistency finds languages with cache entries synthesized by a voice other than
+	// the one that would be used now, e.g. after a voice mapping changed
+	CheckVoiceConsistency(ctx context.Context, in *ConsistencyRequest, opts ...grpc.CallOption) (*ConsistencyResponse, error)
+	// ExportMetrics returns the daemon's Prometheus metrics, for monitoring agents that can reach
+	// the gRPC port but not an HTTP endpoint
+	ExportMetrics(ctx context.Context, in *MetricsRequest, opts ...grpc.CallOption) (*MetricsResponse, error)
+	// ListLocales lists the locales Azure has voices for and the language codes in the cache, with
+	// the cached entries for each
+	ListLocales(ctx context.Context, in *ListLocalesRequest, opts ...grpc.CallOption) (*ListLocalesResponse, error)
+	// GetCacheStats returns the size of the cache, overall and for each language
+	GetCacheStats(ctx context.Context, in *GetCacheStatsRequest, opts ...grpc.CallOption) (*CacheStatsResponse, error)
 }
 
 type tTSServiceClient struct {
@@ -62,6 +193,45 @@ func (c *tTSServiceClient) FetchTTS(ctx context.Context, in *TTSRequest, opts ..
 	return out, nil
 }
 
+func (c *tTSServiceClient) StreamTTS(ctx context.Context, in *TTSRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AudioChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TTSService_ServiceDesc.Streams[0], TTSService_StreamTTS_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TTSRequest, AudioChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_StreamTTSClient = grpc.ServerStreamingClient[AudioChunk]
+
+func (c *tTSServiceClient) FetchWithFallback(ctx context.Context, in *FallbackRequest, opts ...grpc.CallOption) (*TTSResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TTSResponse)
+	err := c.cc.Invoke(ctx, TTSService_FetchWithFallback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) FetchAndSave(ctx context.Context, in *FetchAndSaveRequest, opts ...grpc.CallOption) (*FetchAndSaveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FetchAndSaveResponse)
+	err := c.cc.Invoke(ctx, TTSService_FetchAndSave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tTSServiceClient) BulkFetchTTS(ctx context.Context, in *BulkTTSRequest, opts ...grpc.CallOption) (*BulkTTSResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(BulkTTSResponse)
@@ -69,187 +239,1425 @@ func (c *tTSServiceClient) BulkFetchTTS(ctx context.Context, in *BulkTTSRequest,
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *tTSServiceClient) StreamBulkFetchTTS(ctx context.Context, in *BulkTTSRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BulkItemResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TTSService_ServiceDesc.Streams[1], TTSService_StreamBulkFetchTTS_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BulkTTSRequest, BulkItemResult]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_StreamBulkFetchTTSClient = grpc.ServerStreamingClient[BulkItemResult]
+
+func (c *tTSServiceClient) WarmCache(ctx context.Context, in *WarmCacheRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WarmCacheProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TTSService_ServiceDesc.Streams[2], TTSService_WarmCache_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WarmCacheRequest, WarmCacheProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_WarmCacheClient = grpc.ServerStreamingClient[WarmCacheProgress]
+
+func (c *tTSServiceClient) EnqueueSynthesis(ctx context.Context, in *EnqueueRequest, opts ...grpc.CallOption) (*EnqueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnqueueResponse)
+	err := c.cc.Invoke(ctx, TTSService_EnqueueSynthesis_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) GetJobStatus(ctx context.Context, in *JobStatusRequest, opts ...grpc.CallOption) (*JobStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JobStatus)
+	err := c.cc.Invoke(ctx, TTSService_GetJobStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) ReorderQueue(ctx context.Context, in *ReorderRequest, opts ...grpc.CallOption) (*ReorderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReorderResponse)
+	err := c.cc.Invoke(ctx, TTSService_ReorderQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) PlayTTS(ctx context.Context, in *TTSRequest, opts ...grpc.CallOption) (*PlayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlayResponse)
+	err := c.cc.Invoke(ctx, TTSService_PlayTTS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) SynthesizeEphemeral(ctx context.Context, in *TTSRequest, opts ...grpc.CallOption) (*EphemeralResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EphemeralResponse)
+	err := c.cc.Invoke(ctx, TTSService_SynthesizeEphemeral_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) GetCachedAudio(ctx context.Context, in *TTSRequest, opts ...grpc.CallOption) (*TTSResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TTSResponse)
+	err := c.cc.Invoke(ctx, TTSService_GetCachedAudio_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) DeleteCached(ctx context.Context, in *TTSRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, TTSService_DeleteCached_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) DeletePattern(ctx context.Context, in *DeletePatternRequest, opts ...grpc.CallOption) (*DeletePatternResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePatternResponse)
+	err := c.cc.Invoke(ctx, TTSService_DeletePattern_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) DeleteByLanguage(ctx context.Context, in *DeleteByLanguageRequest, opts ...grpc.CallOption) (*DeleteByLanguageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteByLanguageResponse)
+	err := c.cc.Invoke(ctx, TTSService_DeleteByLanguage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) DeleteByTag(ctx context.Context, in *DeleteByTagRequest, opts ...grpc.CallOption) (*DeleteByTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteByTagResponse)
+	err := c.cc.Invoke(ctx, TTSService_DeleteByTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) NormalizationDiff(ctx context.Context, in *NormalizationDiffRequest, opts ...grpc.CallOption) (*NormalizationDiffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NormalizationDiffResponse)
+	err := c.cc.Invoke(ctx, TTSService_NormalizationDiff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) SelfDiagnose(ctx context.Context, in *DiagnosticRequest, opts ...grpc.CallOption) (*DiagnosticReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiagnosticReport)
+	err := c.cc.Invoke(ctx, TTSService_SelfDiagnose_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) WatchCache(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CacheEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TTSService_ServiceDesc.Streams[3], TTSService_WatchCache_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, CacheEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_WatchCacheClient = grpc.ServerStreamingClient[CacheEvent]
+
+func (c *tTSServiceClient) ListCacheEntries(ctx context.Context, in *ListCacheEntriesRequest, opts ...grpc.CallOption) (*ListCacheEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCacheEntriesResponse)
+	err := c.cc.Invoke(ctx, TTSService_ListCacheEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) GetCacheEntry(ctx context.Context, in *GetCacheEntryRequest, opts ...grpc.CallOption) (*GetCacheEntryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCacheEntryResponse)
+	err := c.cc.Invoke(ctx, TTSService_GetCacheEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) DeleteCacheEntry(ctx context.Context, in *GetCacheEntryRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, TTSService_DeleteCacheEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) Clone(ctx context.Context, in *CloneRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CloneProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TTSService_ServiceDesc.Streams[4], TTSService_Clone_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CloneRequest, CloneProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_CloneClient = grpc.ServerStreamingClient[CloneProgress]
+
+func (c *tTSServiceClient) ExportCache(ctx context.Context, in *ExportCacheRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TTSService_ServiceDesc.Streams[5], TTSService_ExportCache_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportCacheRequest, ExportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_ExportCacheClient = grpc.ServerStreamingClient[ExportChunk]
+
+func (c *tTSServiceClient) ImportCache(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportChunk, ImportCacheResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TTSService_ServiceDesc.Streams[6], TTSService_ImportCache_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportChunk, ImportCacheResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_ImportCacheClient = grpc.ClientStreamingClient[ImportChunk, ImportCacheResponse]
+
+func (c *tTSServiceClient) ResynthesizeAll(ctx context.Context, in *ResynthesizeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ResynthesizeProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TTSService_ServiceDesc.Streams[7], TTSService_ResynthesizeAll_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ResynthesizeRequest, ResynthesizeProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_ResynthesizeAllClient = grpc.ServerStreamingClient[ResynthesizeProgress]
+
+func (c *tTSServiceClient) GetDedupStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*DedupStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DedupStatsResponse)
+	err := c.cc.Invoke(ctx, TTSService_GetDedupStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) VerifyIntegrity(ctx context.Context, in *VerifyIntegrityRequest, opts ...grpc.CallOption) (*IntegrityReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IntegrityReport)
+	err := c.cc.Invoke(ctx, TTSService_VerifyIntegrity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) VerifyCache(ctx context.Context, in *VerifyCacheRequest, opts ...grpc.CallOption) (*VerifyCacheResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyCacheResponse)
+	err := c.cc.Invoke(ctx, TTSService_VerifyCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) FindNearDuplicates(ctx context.Context, in *NearDuplicatesRequest, opts ...grpc.CallOption) (*NearDuplicatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NearDuplicatesResponse)
+	err := c.cc.Invoke(ctx, TTSService_FindNearDuplicates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) PauseSynthesis(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseResponse)
+	err := c.cc.Invoke(ctx, TTSService_PauseSynthesis_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) ResumeSynthesis(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeResponse)
+	err := c.cc.Invoke(ctx, TTSService_ResumeSynthesis_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) SetDraining(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DrainResponse)
+	err := c.cc.Invoke(ctx, TTSService_SetDraining_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) RunCompaction(ctx context.Context, in *CompactionRequest, opts ...grpc.CallOption) (*CompactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompactionResponse)
+	err := c.cc.Invoke(ctx, TTSService_RunCompaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) GetVoiceChangeHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*VoiceChangeHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoiceChangeHistoryResponse)
+	err := c.cc.Invoke(ctx, TTSService_GetVoiceChangeHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) RefreshVoiceList(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshResponse)
+	err := c.cc.Invoke(ctx, TTSService_RefreshVoiceList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) GetCacheHeatmap(ctx context.Context, in *HeatmapRequest, opts ...grpc.CallOption) (*HeatmapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeatmapResponse)
+	err := c.cc.Invoke(ctx, TTSService_GetCacheHeatmap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) GetRateLimitStatus(ctx context.Context, in *RLStatusRequest, opts ...grpc.CallOption) (*RLStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RLStatusResponse)
+	err := c.cc.Invoke(ctx, TTSService_GetRateLimitStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) CheckVoiceConsistency(ctx context.Context, in *ConsistencyRequest, opts ...grpc.CallOption) (*ConsistencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConsistencyResponse)
+	err := c.cc.Invoke(ctx, TTSService_CheckVoiceConsistency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) ExportMetrics(ctx context.Context, in *MetricsRequest, opts ...grpc.CallOption) (*MetricsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MetricsResponse)
+	err := c.cc.Invoke(ctx, TTSService_ExportMetrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) ListLocales(ctx context.Context, in *ListLocalesRequest, opts ...grpc.CallOption) (*ListLocalesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLocalesResponse)
+	err := c.cc.Invoke(ctx, TTSService_ListLocales_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tTSServiceClient) GetCacheStats(ctx context.Context, in *GetCacheStatsRequest, opts ...grpc.CallOption) (*CacheStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CacheStatsResponse)
+	err := c.cc.Invoke(ctx, TTSService_GetCacheStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TTSServiceServer is the server API for TTSService service.
+// All implementations must embed UnimplementedTTSServiceServer
+// for forward compatibility.
+//
+// TTSService handles text-to-speech requests
+type TTSServiceServer interface {
+	// FetchTTS fetches and caches audio for the given text
+	FetchTTS(context.Context, *TTSRequest) (*TTSResponse, error)
+	// StreamTTS fetches and caches audio like FetchTTS, streaming it back in chunks so that long
+	// texts aren't limited by the maximum message size
+	StreamTTS(*TTSRequest, grpc.ServerStreamingServer[AudioChunk]) error
+	// FetchWithFallback returns cached audio right away, and otherwise waits a limited time for
+	// synthesis before returning the cached audio of a fallback text instead
+	FetchWithFallback(context.Context, *FallbackRequest) (*TTSResponse, error)
+	// FetchAndSave fetches and caches audio, then writes it to a file on the daemon's machine
+	FetchAndSave(context.Context, *FetchAndSaveRequest) (*FetchAndSaveResponse, error)
+	// BulkFetchTTS fetches and caches audio for multiple texts concurrently
+	BulkFetchTTS(context.Context, *BulkTTSRequest) (*BulkTTSResponse, error)
+	// StreamBulkFetchTTS fetches multiple texts concurrently, streaming each result as it completes
+	StreamBulkFetchTTS(*BulkTTSRequest, grpc.ServerStreamingServer[BulkItemResult]) error
+	// WarmCache fetches and caches multiple texts without returning their audio, streaming the
+	// running totals as each one completes
+	WarmCache(*WarmCacheRequest, grpc.ServerStreamingServer[WarmCacheProgress]) error
+	// EnqueueSynthesis queues text for background synthesis and returns immediately with a job ID
+	EnqueueSynthesis(context.Context, *EnqueueRequest) (*EnqueueResponse, error)
+	// GetJobStatus reports the progress of a queued synthesis job
+	GetJobStatus(context.Context, *JobStatusRequest) (*JobStatus, error)
+	// ReorderQueue changes the priority of pending synthesis jobs
+	ReorderQueue(context.Context, *ReorderRequest) (*ReorderResponse, error)
+	// PlayTTS fetches (if needed), caches, and plays audio for the given text
+	PlayTTS(context.Context, *TTSRequest) (*PlayResponse, error)
+	// SynthesizeEphemeral synthesizes audio directly from Azure without reading or storing the cache
+	SynthesizeEphemeral(context.Context, *TTSRequest) (*EphemeralResponse, error)
+	// GetCachedAudio retrieves audio from cache without fetching
+	GetCachedAudio(context.Context, *TTSRequest) (*TTSResponse, error)
+	// DeleteCached removes audio from cache
+	DeleteCached(context.Context, *TTSRequest) (*DeleteResponse, error)
+	// DeletePattern removes (or previews removing) every cache entry whose text matches a LIKE pattern
+	DeletePattern(context.Context, *DeletePatternRequest) (*DeletePatternResponse, error)
+	// DeleteByLanguage removes every cache entry for a language, e.g. after its voice was updated
+	DeleteByLanguage(context.Context, *DeleteByLanguageRequest) (*DeleteByLanguageResponse, error)
+	// DeleteByTag removes every cache entry tagged with a tag (see TTSRequest.tags)
+	DeleteByTag(context.Context, *DeleteByTagRequest) (*DeleteByTagResponse, error)
+	// NormalizationDiff shows how two texts are normalized and whether they share a cache key
+	NormalizationDiff(context.Context, *NormalizationDiffRequest) (*NormalizationDiffResponse, error)
+	// SelfDiagnose runs health checks against the daemon's subsystems
+	SelfDiagnose(context.Context, *DiagnosticRequest) (*DiagnosticReport, error)
+	// WatchCache streams cache changes (puts and deletes) as they happen
+	WatchCache(*WatchRequest, grpc.ServerStreamingServer[CacheEvent]) error
+	// ListCacheEntries pages through cache entries (without audio) in cache key order
+	ListCacheEntries(context.Context, *ListCacheEntriesRequest) (*ListCacheEntriesResponse, error)
+	// GetCacheEntry returns a single cache entry, including its audio, by cache key
+	GetCacheEntry(context.Context, *GetCacheEntryRequest) (*GetCacheEntryResponse, error)
+	// DeleteCacheEntry deletes the entry stored under a cache key, whatever options it was made with
+	DeleteCacheEntry(context.Context, *GetCacheEntryRequest) (*DeleteResponse, error)
+	// Clone copies cache entries from another daemon into this one, streaming progress
+	Clone(*CloneRequest, grpc.ServerStreamingServer[CloneProgress]) error
+	// ExportCache streams the whole cache as a zip archive of each entry's audio and a JSON file
+	// with its metadata, for seeding other daemons
+	ExportCache(*ExportCacheRequest, grpc.ServerStreamingServer[ExportChunk]) error
+	// ImportCache adds the entries of an ExportCache archive sent in chunks, keeping entries that
+	// are already cached
+	ImportCache(grpc.ClientStreamingServer[ImportChunk, ImportCacheResponse]) error
+	// ResynthesizeAll re-synthesizes every cache entry for a language, e.g. after a voice model
+	// update, streaming progress after each entry
+	ResynthesizeAll(*ResynthesizeRequest, grpc.ServerStreamingServer[ResynthesizeProgress]) error
+	// GetDedupStats reports how many Azure calls deduplication of concurrent requests has saved
+	GetDedupStats(context.Context, *StatsRequest) (*DedupStatsResponse, error)
+	// VerifyIntegrity checks that every cache key is unique and matches the key computed from its text
+	VerifyIntegrity(context.Context, *VerifyIntegrityRequest) (*IntegrityReport, error)
+	// VerifyCache checks the database file for corruption, e.g. after a power outage, optionally
+	// deleting the corrupt entries it finds
+	VerifyCache(context.Context, *VerifyCacheRequest) (*VerifyCacheResponse, error)
+	// FindNearDuplicates groups cache entries whose texts are nearly identical, such as the same
+	// sentence with different punctuation
+	FindNearDuplicates(context.Context, *NearDuplicatesRequest) (*NearDuplicatesResponse, error)
+	// PauseSynthesis stops requests from reaching Azure (cached audio is still served), e.g. during
+	// an Azure maintenance window
+	PauseSynthesis(context.Context, *PauseRequest) (*PauseResponse, error)
+	// ResumeSynthesis lets requests reach Azure again after PauseSynthesis
+	ResumeSynthesis(context.Context, *ResumeRequest) (*ResumeResponse, error)
+	// SetDraining stops the daemon from accepting connections and makes the readiness probe fail,
+	// then stops it once the requests in progress finish (or the drain timeout passes)
+	SetDraining(context.Context, *DrainRequest) (*DrainResponse, error)
+	// RunCompaction compacts the cache database with VACUUM now, instead of waiting for
+	// database.compact_schedule
+	RunCompaction(context.Context, *CompactionRequest) (*CompactionResponse, error)
+	// GetVoiceChangeHistory lists detected changes to Azure's default voice for each locale
+	GetVoiceChangeHistory(context.Context, *HistoryRequest) (*VoiceChangeHistoryResponse, error)
+	// RefreshVoiceList reloads the voices Azure offers, which otherwise happens every
+	// azure.voice_cache_refresh_interval_h hours
+	RefreshVoiceList(context.Context, *RefreshRequest) (*RefreshResponse, error)
+	// GetCacheHeatmap reports at what times of day (UTC) cache entries were last accessed, e.g. to
+	// schedule maintenance for quiet hours
+	GetCacheHeatmap(context.Context, *HeatmapRequest) (*HeatmapResponse, error)
+	// GetRateLimitStatus reports the capacity left in the Azure rate limiter, e.g. for scripts that
+	// check before submitting a large batch
+	GetRateLimitStatus(context.Context, *RLStatusRequest) (*RLStatusResponse, error)
+	// CheckVoiceConsistency finds languages with cache entries synthesized by a voice other than
+	// the one that would be used now, e.g. after a voice mapping changed
+	CheckVoiceConsistency(context.Context, *ConsistencyRequest) (*ConsistencyResponse, error)
+	// ExportMetrics returns the daemon's Prometheus metrics, for monitoring agents that can reach
+	// the gRPC port but not an HTTP endpoint
+	ExportMetrics(context.Context, *MetricsRequest) (*MetricsResponse, error)
+	// ListLocales lists the locales Azure has voices for and the language codes in the cache, with
+	// the cached entries for each
+	ListLocales(context.Context, *ListLocalesRequest) (*ListLocalesResponse, error)
+	// GetCacheStats returns the size of the cache, overall and for each language
+	GetCacheStats(context.Context, *GetCacheStatsRequest) (*CacheStatsResponse, error)
+	mustEmbedUnimplementedTTSServiceServer()
+}
+
+// UnimplementedTTSServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTTSServiceServer struct{}
+
+func (UnimplementedTTSServiceServer) FetchTTS(context.Context, *TTSRequest) (*TTSResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchTTS not implemented")
+}
+func (UnimplementedTTSServiceServer) StreamTTS(*TTSRequest, grpc.ServerStreamingServer[AudioChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTTS not implemented")
+}
+func (UnimplementedTTSServiceServer) FetchWithFallback(context.Context, *FallbackRequest) (*TTSResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchWithFallback not implemented")
+}
+func (UnimplementedTTSServiceServer) FetchAndSave(context.Context, *FetchAndSaveRequest) (*FetchAndSaveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchAndSave not implemented")
+}
+func (UnimplementedTTSServiceServer) BulkFetchTTS(context.Context, *BulkTTSRequest) (*BulkTTSResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkFetchTTS not implemented")
+}
+func (UnimplementedTTSServiceServer) StreamBulkFetchTTS(*BulkTTSRequest, grpc.ServerStreamingServer[BulkItemResult]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBulkFetchTTS not implemented")
+}
+func (UnimplementedTTSServiceServer) WarmCache(*WarmCacheRequest, grpc.ServerStreamingServer[WarmCacheProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method WarmCache not implemented")
+}
+func (UnimplementedTTSServiceServer) EnqueueSynthesis(context.Context, *EnqueueRequest) (*EnqueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnqueueSynthesis not implemented")
+}
+func (UnimplementedTTSServiceServer) GetJobStatus(context.Context, *JobStatusRequest) (*JobStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobStatus not implemented")
+}
+func (UnimplementedTTSServiceServer) ReorderQueue(context.Context, *ReorderRequest) (*ReorderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderQueue not implemented")
+}
+func (UnimplementedTTSServiceServer) PlayTTS(context.Context, *TTSRequest) (*PlayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlayTTS not implemented")
+}
+func (UnimplementedTTSServiceServer) SynthesizeEphemeral(context.Context, *TTSRequest) (*EphemeralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SynthesizeEphemeral not implemented")
+}
+func (UnimplementedTTSServiceServer) GetCachedAudio(context.Context, *TTSRequest) (*TTSResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCachedAudio not implemented")
+}
+func (UnimplementedTTSServiceServer) DeleteCached(context.Context, *TTSRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCached not implemented")
+}
+func (UnimplementedTTSServiceServer) DeletePattern(context.Context, *DeletePatternRequest) (*DeletePatternResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePattern not implemented")
+}
+func (UnimplementedTTSServiceServer) DeleteByLanguage(context.Context, *DeleteByLanguageRequest) (*DeleteByLanguageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteByLanguage not implemented")
+}
+func (UnimplementedTTSServiceServer) DeleteByTag(context.Context, *DeleteByTagRequest) (*DeleteByTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteByTag not implemented")
+}
+func (UnimplementedTTSServiceServer) NormalizationDiff(context.Context, *NormalizationDiffRequest) (*NormalizationDiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormalizationDiff not implemented")
+}
+func (UnimplementedTTSServiceServer) SelfDiagnose(context.Context, *DiagnosticRequest) (*DiagnosticReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelfDiagnose not implemented")
+}
+func (UnimplementedTTSServiceServer) WatchCache(*WatchRequest, grpc.ServerStreamingServer[CacheEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCache not implemented")
+}
+func (UnimplementedTTSServiceServer) ListCacheEntries(context.Context, *ListCacheEntriesRequest) (*ListCacheEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCacheEntries not implemented")
+}
+func (UnimplementedTTSServiceServer) GetCacheEntry(context.Context, *GetCacheEntryRequest) (*GetCacheEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCacheEntry not implemented")
+}
+func (UnimplementedTTSServiceServer) DeleteCacheEntry(context.Context, *GetCacheEntryRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCacheEntry not implemented")
+}
+func (UnimplementedTTSServiceServer) Clone(*CloneRequest, grpc.ServerStreamingServer[CloneProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method Clone not implemented")
+}
+func (UnimplementedTTSServiceServer) ExportCache(*ExportCacheRequest, grpc.ServerStreamingServer[ExportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportCache not implemented")
+}
+func (UnimplementedTTSServiceServer) ImportCache(grpc.ClientStreamingServer[ImportChunk, ImportCacheResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportCache not implemented")
+}
+func (UnimplementedTTSServiceServer) ResynthesizeAll(*ResynthesizeRequest, grpc.ServerStreamingServer[ResynthesizeProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method ResynthesizeAll not implemented")
+}
+func (UnimplementedTTSServiceServer) GetDedupStats(context.Context, *StatsRequest) (*DedupStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDedupStats not implemented")
+}
+func (UnimplementedTTSServiceServer) VerifyIntegrity(context.Context, *VerifyIntegrityRequest) (*IntegrityReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyIntegrity not implemented")
+}
+func (UnimplementedTTSServiceServer) VerifyCache(context.Context, *VerifyCacheRequest) (*VerifyCacheResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyCache not implemented")
+}
+func (UnimplementedTTSServiceServer) FindNearDuplicates(context.Context, *NearDuplicatesRequest) (*NearDuplicatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindNearDuplicates not implemented")
+}
+func (UnimplementedTTSServiceServer) PauseSynthesis(context.Context, *PauseRequest) (*PauseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseSynthesis not implemented")
+}
+func (UnimplementedTTSServiceServer) ResumeSynthesis(context.Context, *ResumeRequest) (*ResumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeSynthesis not implemented")
+}
+func (UnimplementedTTSServiceServer) SetDraining(context.Context, *DrainRequest) (*DrainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDraining not implemented")
+}
+func (UnimplementedTTSServiceServer) RunCompaction(context.Context, *CompactionRequest) (*CompactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunCompaction not implemented")
+}
+func (UnimplementedTTSServiceServer) GetVoiceChangeHistory(context.Context, *HistoryRequest) (*VoiceChangeHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVoiceChangeHistory not implemented")
+}
+func (UnimplementedTTSServiceServer) RefreshVoiceList(context.Context, *RefreshRequest) (*RefreshResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshVoiceList not implemented")
+}
+func (UnimplementedTTSServiceServer) GetCacheHeatmap(context.Context, *HeatmapRequest) (*HeatmapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCacheHeatmap not implemented")
+}
+func (UnimplementedTTSServiceServer) GetRateLimitStatus(context.Context, *RLStatusRequest) (*RLStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRateLimitStatus not implemented")
+}
+func (UnimplementedTTSServiceServer) CheckVoiceConsistency(context.Context, *ConsistencyRequest) (*ConsistencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckVoiceConsistency not implemented")
+}
+func (UnimplementedTTSServiceServer) ExportMetrics(context.Context, *MetricsRequest) (*MetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMetrics not implemented")
+}
+func (UnimplementedTTSServiceServer) ListLocales(context.Context, *ListLocalesRequest) (*ListLocalesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLocales not implemented")
+}
+func (UnimplementedTTSServiceServer) GetCacheStats(context.Context, *GetCacheStatsRequest) (*CacheStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCacheStats not implemented")
+}
+func (UnimplementedTTSServiceServer) mustEmbedUnimplementedTTSServiceServer() {}
+func (UnimplementedTTSServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeTTSServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TTSServiceServer will
+// result in compilation errors.
+type UnsafeTTSServiceServer interface {
+	mustEmbedUnimplementedTTSServiceServer()
+}
+
+func RegisterTTSServiceServer(s grpc.ServiceRegistrar, srv TTSServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTTSServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TTSService_ServiceDesc, srv)
+}
+
+func _TTSService_FetchTTS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TTSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).FetchTTS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_FetchTTS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).FetchTTS(ctx, req.(*TTSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_StreamTTS_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TTSRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TTSServiceServer).StreamTTS(m, &grpc.GenericServerStream[TTSRequest, AudioChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_StreamTTSServer = grpc.ServerStreamingServer[AudioChunk]
+
+func _TTSService_FetchWithFallback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FallbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).FetchWithFallback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_FetchWithFallback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).FetchWithFallback(ctx, req.(*FallbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_FetchAndSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchAndSaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).FetchAndSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_FetchAndSave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).FetchAndSave(ctx, req.(*FetchAndSaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_BulkFetchTTS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkTTSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).BulkFetchTTS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_BulkFetchTTS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).BulkFetchTTS(ctx, req.(*BulkTTSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_StreamBulkFetchTTS_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BulkTTSRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TTSServiceServer).StreamBulkFetchTTS(m, &grpc.GenericServerStream[BulkTTSRequest, BulkItemResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_StreamBulkFetchTTSServer = grpc.ServerStreamingServer[BulkItemResult]
+
+func _TTSService_WarmCache_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WarmCacheRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TTSServiceServer).WarmCache(m, &grpc.GenericServerStream[WarmCacheRequest, WarmCacheProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_WarmCacheServer = grpc.ServerStreamingServer[WarmCacheProgress]
+
+func _TTSService_EnqueueSynthesis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).EnqueueSynthesis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_EnqueueSynthesis_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).EnqueueSynthesis(ctx, req.(*EnqueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_GetJobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).GetJobStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_GetJobStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).GetJobStatus(ctx, req.(*JobStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_ReorderQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).ReorderQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_ReorderQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).ReorderQueue(ctx, req.(*ReorderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_PlayTTS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TTSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).PlayTTS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_PlayTTS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).PlayTTS(ctx, req.(*TTSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_SynthesizeEphemeral_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TTSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).SynthesizeEphemeral(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_SynthesizeEphemeral_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).SynthesizeEphemeral(ctx, req.(*TTSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_GetCachedAudio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TTSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).GetCachedAudio(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_GetCachedAudio_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).GetCachedAudio(ctx, req.(*TTSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_DeleteCached_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TTSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).DeleteCached(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_DeleteCached_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).DeleteCached(ctx, req.(*TTSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_DeletePattern_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePatternRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).DeletePattern(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_DeletePattern_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).DeletePattern(ctx, req.(*DeletePatternRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_DeleteByLanguage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteByLanguageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).DeleteByLanguage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_DeleteByLanguage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).DeleteByLanguage(ctx, req.(*DeleteByLanguageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_DeleteByTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteByTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).DeleteByTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_DeleteByTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).DeleteByTag(ctx, req.(*DeleteByTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_NormalizationDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NormalizationDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).NormalizationDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_NormalizationDiff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).NormalizationDiff(ctx, req.(*NormalizationDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_SelfDiagnose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiagnosticRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).SelfDiagnose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_SelfDiagnose_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).SelfDiagnose(ctx, req.(*DiagnosticRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_WatchCache_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TTSServiceServer).WatchCache(m, &grpc.GenericServerStream[WatchRequest, CacheEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_WatchCacheServer = grpc.ServerStreamingServer[CacheEvent]
+
+func _TTSService_ListCacheEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCacheEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).ListCacheEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_ListCacheEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).ListCacheEntries(ctx, req.(*ListCacheEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_GetCacheEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCacheEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).GetCacheEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_GetCacheEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).GetCacheEntry(ctx, req.(*GetCacheEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_DeleteCacheEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCacheEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).DeleteCacheEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_DeleteCacheEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).DeleteCacheEntry(ctx, req.(*GetCacheEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_Clone_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CloneRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TTSServiceServer).Clone(m, &grpc.GenericServerStream[CloneRequest, CloneProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_CloneServer = grpc.ServerStreamingServer[CloneProgress]
+
+func _TTSService_ExportCache_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportCacheRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TTSServiceServer).ExportCache(m, &grpc.GenericServerStream[ExportCacheRequest, ExportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_ExportCacheServer = grpc.ServerStreamingServer[ExportChunk]
+
+func _TTSService_ImportCache_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TTSServiceServer).ImportCache(&grpc.GenericServerStream[ImportChunk, ImportCacheResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_ImportCacheServer = grpc.ClientStreamingServer[ImportChunk, ImportCacheResponse]
+
+func _TTSService_ResynthesizeAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ResynthesizeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TTSServiceServer).ResynthesizeAll(m, &grpc.GenericServerStream[ResynthesizeRequest, ResynthesizeProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TTSService_ResynthesizeAllServer = grpc.ServerStreamingServer[ResynthesizeProgress]
+
+func _TTSService_GetDedupStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).GetDedupStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_GetDedupStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).GetDedupStats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_VerifyIntegrity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyIntegrityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).VerifyIntegrity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_VerifyIntegrity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).VerifyIntegrity(ctx, req.(*VerifyIntegrityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TTSService_VerifyCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).VerifyCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_VerifyCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).VerifyCache(ctx, req.(*VerifyCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *tTSServiceClient) PlayTTS(ctx context.Context, in *TTSRequest, opts ...grpc.CallOption) (*PlayResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(PlayResponse)
-	err := c.cc.Invoke(ctx, TTSService_PlayTTS_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _TTSService_FindNearDuplicates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NearDuplicatesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(TTSServiceServer).FindNearDuplicates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_FindNearDuplicates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).FindNearDuplicates(ctx, req.(*NearDuplicatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *tTSServiceClient) GetCachedAudio(ctx context.Context, in *TTSRequest, opts ...grpc.CallOption) (*TTSResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(TTSResponse)
-	err := c.cc.Invoke(ctx, TTSService_GetCachedAudio_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _TTSService_PauseSynthesis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(TTSServiceServer).PauseSynthesis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_PauseSynthesis_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).PauseSynthesis(ctx, req.(*PauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *tTSServiceClient) DeleteCached(ctx context.Context, in *TTSRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DeleteResponse)
-	err := c.cc.Invoke(ctx, TTSService_DeleteCached_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _TTSService_ResumeSynthesis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(TTSServiceServer).ResumeSynthesis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_ResumeSynthesis_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).ResumeSynthesis(ctx, req.(*ResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// TTSServiceServer is the server API for TTSService service.
-// All implementations must embed UnimplementedTTSServiceServer
-// for forward compatibility.
-//
-// TTSService handles text-to-speech requests
-type TTSServiceServer interface {
-	// FetchTTS fetches and caches audio for the given text
-	FetchTTS(context.Context, *TTSRequest) (*TTSResponse, error)
-	// BulkFetchTTS fetches and caches audio for multiple texts concurrently
-	BulkFetchTTS(context.Context, *BulkTTSRequest) (*BulkTTSResponse, error)
-	// PlayTTS fetches (if needed), caches, and plays audio for the given text
-	PlayTTS(context.Context, *TTSRequest) (*PlayResponse, error)
-	// GetCachedAudio retrieves audio from cache without fetching
-	GetCachedAudio(context.Context, *TTSRequest) (*TTSResponse, error)
-	// DeleteCached removes audio from cache
-	DeleteCached(context.Context, *TTSRequest) (*DeleteResponse, error)
-	mustEmbedUnimplementedTTSServiceServer()
+func _TTSService_SetDraining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).SetDraining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_SetDraining_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).SetDraining(ctx, req.(*DrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedTTSServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedTTSServiceServer struct{}
-
-func (UnimplementedTTSServiceServer) FetchTTS(context.Context, *TTSRequest) (*TTSResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FetchTTS not implemented")
-}
-func (UnimplementedTTSServiceServer) BulkFetchTTS(context.Context, *BulkTTSRequest) (*BulkTTSResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BulkFetchTTS not implemented")
-}
-func (UnimplementedTTSServiceServer) PlayTTS(context.Context, *TTSRequest) (*PlayResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PlayTTS not implemented")
-}
-func (UnimplementedTTSServiceServer) GetCachedAudio(context.Context, *TTSRequest) (*TTSResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetCachedAudio not implemented")
+func _TTSService_RunCompaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).RunCompaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_RunCompaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).RunCompaction(ctx, req.(*CompactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedTTSServiceServer) DeleteCached(context.Context, *TTSRequest) (*DeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteCached not implemented")
+
+func _TTSService_GetVoiceChangeHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).GetVoiceChangeHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_GetVoiceChangeHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).GetVoiceChangeHistory(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedTTSServiceServer) mustEmbedUnimplementedTTSServiceServer() {}
-func (UnimplementedTTSServiceServer) testEmbeddedByValue()                    {}
 
-// UnsafeTTSServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to TTSServiceServer will
-// result in compilation errors.
-type UnsafeTTSServiceServer interface {
-	mustEmbedUnimplementedTTSServiceServer()
+func _TTSService_RefreshVoiceList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TTSServiceServer).RefreshVoiceList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_RefreshVoiceList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).RefreshVoiceList(ctx, req.(*RefreshRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterTTSServiceServer(s grpc.ServiceRegistrar, srv TTSServiceServer) {
-	// If the following call pancis, it indicates UnimplementedTTSServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _TTSService_GetCacheHeatmap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeatmapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&TTSService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(TTSServiceServer).GetCacheHeatmap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TTSService_GetCacheHeatmap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TTSServiceServer).GetCacheHeatmap(ctx, req.(*HeatmapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _TTSService_FetchTTS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TTSRequest)
+func _TTSService_GetRateLimitStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RLStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TTSServiceServer).FetchTTS(ctx, in)
+		return srv.(TTSServiceServer).GetRateLimitStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TTSService_FetchTTS_FullMethodName,
+		FullMethod: TTSService_GetRateLimitStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TTSServiceServer).FetchTTS(ctx, req.(*TTSRequest))
+		return srv.(TTSServiceServer).GetRateLimitStatus(ctx, req.(*RLStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TTSService_BulkFetchTTS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BulkTTSRequest)
+func _TTSService_CheckVoiceConsistency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsistencyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TTSServiceServer).BulkFetchTTS(ctx, in)
+		return srv.(TTSServiceServer).CheckVoiceConsistency(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TTSService_BulkFetchTTS_FullMethodName,
+		FullMethod: TTSService_CheckVoiceConsistency_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TTSServiceServer).BulkFetchTTS(ctx, req.(*BulkTTSRequest))
+		return srv.(TTSServiceServer).CheckVoiceConsistency(ctx, req.(*ConsistencyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TTSService_PlayTTS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TTSRequest)
+func _TTSService_ExportMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetricsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TTSServiceServer).PlayTTS(ctx, in)
+		return srv.(TTSServiceServer).ExportMetrics(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TTSService_PlayTTS_FullMethodName,
+		FullMethod: TTSService_ExportMetrics_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TTSServiceServer).PlayTTS(ctx, req.(*TTSRequest))
+		return srv.(TTSServiceServer).ExportMetrics(ctx, req.(*MetricsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TTSService_GetCachedAudio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TTSRequest)
+func _TTSService_ListLocales_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLocalesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TTSServiceServer).GetCachedAudio(ctx, in)
+		return srv.(TTSServiceServer).ListLocales(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TTSService_GetCachedAudio_FullMethodName,
+		FullMethod: TTSService_ListLocales_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TTSServiceServer).GetCachedAudio(ctx, req.(*TTSRequest))
+		return srv.(TTSServiceServer).ListLocales(ctx, req.(*ListLocalesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TTSService_DeleteCached_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TTSRequest)
+func _TTSService_GetCacheStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCacheStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TTSServiceServer).DeleteCached(ctx, in)
+		return srv.(TTSServiceServer).GetCacheStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TTSService_DeleteCached_FullMethodName,
+		FullMethod: TTSService_GetCacheStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TTSServiceServer).DeleteCached(ctx, req.(*TTSRequest))
+		return srv.(TTSServiceServer).GetCacheStats(ctx, req.(*GetCacheStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -265,14 +1673,38 @@ var TTSService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FetchTTS",
 			Handler:    _TTSService_FetchTTS_Handler,
 		},
+		{
+			MethodName: "FetchWithFallback",
+			Handler:    _TTSService_FetchWithFallback_Handler,
+		},
+		{
+			MethodName: "FetchAndSave",
+			Handler:    _TTSService_FetchAndSave_Handler,
+		},
 		{
 			MethodName: "BulkFetchTTS",
 			Handler:    _TTSService_BulkFetchTTS_Handler,
 		},
+		{
+			MethodName: "EnqueueSynthesis",
+			Handler:    _TTSService_EnqueueSynthesis_Handler,
+		},
+		{
+			MethodName: "GetJobStatus",
+			Handler:    _TTSService_GetJobStatus_Handler,
+		},
+		{
+			MethodName: "ReorderQueue",
+			Handler:    _TTSService_ReorderQueue_Handler,
+		},
 		{
 			MethodName: "PlayTTS",
 			Handler:    _TTSService_PlayTTS_Handler,
 		},
+		{
+			MethodName: "SynthesizeEphemeral",
+			Handler:    _TTSService_SynthesizeEphemeral_Handler,
+		},
 		{
 			MethodName: "GetCachedAudio",
 			Handler:    _TTSService_GetCachedAudio_Handler,
@@ -281,7 +1713,144 @@ var TTSService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCached",
 			Handler:    _TTSService_DeleteCached_Handler,
 		},
+		{
+			MethodName: "DeletePattern",
+			Handler:    _TTSService_DeletePattern_Handler,
+		},
+		{
+			MethodName: "DeleteByLanguage",
+			Handler:    _TTSService_DeleteByLanguage_Handler,
+		},
+		{
+			MethodName: "DeleteByTag",
+			Handler:    _TTSService_DeleteByTag_Handler,
+		},
+		{
+			MethodName: "NormalizationDiff",
+			Handler:    _TTSService_NormalizationDiff_Handler,
+		},
+		{
+			MethodName: "SelfDiagnose",
+			Handler:    _TTSService_SelfDiagnose_Handler,
+		},
+		{
+			MethodName: "ListCacheEntries",
+			Handler:    _TTSService_ListCacheEntries_Handler,
+		},
+		{
+			MethodName: "GetCacheEntry",
+			Handler:    _TTSService_GetCacheEntry_Handler,
+		},
+		{
+			MethodName: "DeleteCacheEntry",
+			Handler:    _TTSService_DeleteCacheEntry_Handler,
+		},
+		{
+			MethodName: "GetDedupStats",
+			Handler:    _TTSService_GetDedupStats_Handler,
+		},
+		{
+			MethodName: "VerifyIntegrity",
+			Handler:    _TTSService_VerifyIntegrity_Handler,
+		},
+		{
+			MethodName: "VerifyCache",
+			Handler:    _TTSService_VerifyCache_Handler,
+		},
+		{
+			MethodName: "FindNearDuplicates",
+			Handler:    _TTSService_FindNearDuplicates_Handler,
+		},
+		{
+			MethodName: "PauseSynthesis",
+			Handler:    _TTSService_PauseSynthesis_Handler,
+		},
+		{
+			MethodName: "ResumeSynthesis",
+			Handler:    _TTSService_ResumeSynthesis_Handler,
+		},
+		{
+			MethodName: "SetDraining",
+			Handler:    _TTSService_SetDraining_Handler,
+		},
+		{
+			MethodName: "RunCompaction",
+			Handler:    _TTSService_RunCompaction_Handler,
+		},
+		{
+			MethodName: "GetVoiceChangeHistory",
+			Handler:    _TTSService_GetVoiceChangeHistory_Handler,
+		},
+		{
+			MethodName: "RefreshVoiceList",
+			Handler:    _TTSService_RefreshVoiceList_Handler,
+		},
+		{
+			MethodName: "GetCacheHeatmap",
+			Handler:    _TTSService_GetCacheHeatmap_Handler,
+		},
+		{
+			MethodName: "GetRateLimitStatus",
+			Handler:    _TTSService_GetRateLimitStatus_Handler,
+		},
+		{
+			MethodName: "CheckVoiceConsistency",
+			Handler:    _TTSService_CheckVoiceConsistency_Handler,
+		},
+		{
+			MethodName: "ExportMetrics",
+			Handler:    _TTSService_ExportMetrics_Handler,
+		},
+		{
+			MethodName: "ListLocales",
+			Handler:    _TTSService_ListLocales_Handler,
+		},
+		{
+			MethodName: "GetCacheStats",
+			Handler:    _TTSService_GetCacheStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTTS",
+			Handler:       _TTSService_StreamTTS_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamBulkFetchTTS",
+			Handler:       _TTSService_StreamBulkFetchTTS_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WarmCache",
+			Handler:       _TTSService_WarmCache_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchCache",
+			Handler:       _TTSService_WatchCache_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Clone",
+			Handler:       _TTSService_Clone_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportCache",
+			Handler:       _TTSService_ExportCache_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportCache",
+			Handler:       _TTSService_ImportCache_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ResynthesizeAll",
+			Handler:       _TTSService_ResynthesizeAll_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/tts.proto",
 }